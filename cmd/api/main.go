@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"os"
 
 	config "github.com/jeffleon1/consumption-ms/internal/configuration"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
 	"github.com/jeffleon1/consumption-ms/pkg/application"
 	"github.com/jeffleon1/consumption-ms/pkg/infraestructure"
 	repositories "github.com/jeffleon1/consumption-ms/pkg/repository"
@@ -30,19 +33,210 @@ func main() {
 		logrus.Fatalf("Fatal Error: the database could not connect %s", err.Error())
 		os.Exit(1)
 	}
-	powerConsumptionMySQLRepository := repositories.NewMySQLPowerConsumptionRepository(db)
+	err = db.Use(repositories.NewDomainErrorsPlugin())
+	if err != nil {
+		logrus.Fatalf("Fatal Error: the database plugins could not be registered %s", err.Error())
+		os.Exit(1)
+	}
+	liveConsumptionBroker := application.NewLiveConsumptionBroker()
+	powerConsumptionMySQLRepository := application.NewLiveConsumptionRepository(repositories.NewMySQLPowerConsumptionRepository(db), liveConsumptionBroker)
 	err = powerConsumptionMySQLRepository.ModelMigration()
 	if err != nil {
 		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
 		os.Exit(1)
 	}
+	meterGroupRepository := repositories.NewMeterGroupMySQLRepository(db)
+	err = meterGroupRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	meterSettingRepository := repositories.NewMeterSettingMySQLRepository(db)
+	err = meterSettingRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	qualityRuleRepository := repositories.NewQualityRuleMySQLRepository(db)
+	err = qualityRuleRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	quarantineRepository := repositories.NewQuarantineMySQLRepository(db)
+	err = quarantineRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	importRepository := repositories.NewImportMySQLRepository(db)
+	err = importRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	outboxRepository := repositories.NewOutboxMySQLRepository(db)
+	err = outboxRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	webhookRepository := repositories.NewWebhookMySQLRepository(db)
+	err = webhookRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
+	alertRepository := repositories.NewAlertMySQLRepository(db)
+	err = alertRepository.ModelMigration()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: It was not possible to migrate the model %s", err.Error())
+		os.Exit(1)
+	}
 	powerConsumptionCSVRepository := repositories.NewCSVConsumptionRepository()
-	powerConsumptionService := application.NewPowerConsumptionService(powerConsumptionMySQLRepository, powerConsumptionCSVRepository)
+	powerConsumptionService := application.NewPowerConsumptionService(powerConsumptionMySQLRepository, powerConsumptionCSVRepository, meterGroupRepository, meterSettingRepository, qualityRuleRepository, quarantineRepository, importRepository)
 	powerConsumptionHandler := infraestructure.NewPowerConsumptionHandler(powerConsumptionService)
 	powerConsumptionRoutes := infraestructure.NewRoutes(powerConsumptionHandler)
+	meterGroupService := application.NewMeterGroupService(meterGroupRepository)
+	meterGroupHandler := infraestructure.NewMeterGroupHandler(meterGroupService)
+	meterGroupRoutes := infraestructure.NewMeterGroupRoutes(meterGroupHandler)
+	meterSettingService := application.NewMeterSettingService(meterSettingRepository)
+	meterSettingHandler := infraestructure.NewMeterSettingHandler(meterSettingService)
+	meterSettingRoutes := infraestructure.NewMeterSettingRoutes(meterSettingHandler)
+	qualityRuleService := application.NewQualityRuleService(qualityRuleRepository)
+	qualityRuleHandler := infraestructure.NewQualityRuleHandler(qualityRuleService)
+	qualityRuleRoutes := infraestructure.NewQualityRuleRoutes(qualityRuleHandler)
+	quarantineService := application.NewQuarantineService(quarantineRepository, powerConsumptionMySQLRepository, powerConsumptionCSVRepository, powerConsumptionService)
+	quarantineHandler := infraestructure.NewQuarantineHandler(quarantineService)
+	quarantineRoutes := infraestructure.NewQuarantineRoutes(quarantineHandler)
+	importService := application.NewImportService(importRepository, powerConsumptionMySQLRepository)
+	importHandler := infraestructure.NewImportHandler(importService)
+	importRoutes := infraestructure.NewImportRoutes(importHandler)
+
+	if config.Config.INGEST.WATCH_DIR != "" {
+		var sources []infraestructure.RemoteFileSource
+		if config.Config.INGEST.SFTP_ADDR != "" {
+			sftpConnect, err := infraestructure.NewSFTPConnect(config.Config.INGEST.SFTP_ADDR, config.Config.INGEST.SFTP_USER, config.Config.INGEST.SFTP_PASSWORD, config.Config.INGEST.SFTP_HOST_KEY)
+			if err != nil {
+				logrus.Fatalf("Fatal Error: the sftp connection is not valid %s", err.Error())
+				os.Exit(1)
+			}
+			sources = append(sources, infraestructure.NewSFTPFileSource(sftpConnect, config.Config.INGEST.SFTP_DIR))
+		}
+		ingestionWorker := infraestructure.NewIngestionWorker(powerConsumptionService, config.Config.INGEST.WATCH_DIR, config.Config.INGEST.POLL_INTERVAL, config.Config.INGEST.UNIT, sources...)
+		go func() {
+			if err := ingestionWorker.Run(context.Background()); err != nil {
+				logrus.Fatalf("Fatal Error: the ingestion worker could not start %s", err.Error())
+			}
+		}()
+	}
+
+	if config.Config.MQTT.BROKER != "" {
+		liveReadingService, err := application.NewLiveReadingService(powerConsumptionMySQLRepository, powerConsumptionCSVRepository, quarantineRepository, application.LiveReadingFormat{
+			TopicPattern:  config.Config.MQTT.TOPIC,
+			PayloadFormat: config.Config.MQTT.PAYLOAD_FORMAT,
+			Unit:          config.Config.MQTT.UNIT,
+			BatchSize:     config.Config.MQTT.BATCH_SIZE,
+		})
+		if err != nil {
+			logrus.Fatalf("Fatal Error: the mqtt format is not valid %s", err.Error())
+			os.Exit(1)
+		}
+		mqttOptions := infraestructure.NewMQTTClientOptions(config.Config.MQTT.BROKER, config.Config.MQTT.CLIENT_ID, config.Config.MQTT.USER, config.Config.MQTT.PASSWORD)
+		mqttSubscriber := infraestructure.NewMQTTSubscriber(mqttOptions, liveReadingService, byte(config.Config.MQTT.QOS), config.Config.MQTT.FLUSH_INTERVAL)
+		go func() {
+			if err := mqttSubscriber.Run(context.Background()); err != nil {
+				logrus.Fatalf("Fatal Error: the mqtt subscriber could not start %s", err.Error())
+			}
+		}()
+	}
+
+	var kafkaConsumerLag infraestructure.ConsumerLag
+	if len(config.Config.KAFKA.BROKERS) > 0 && config.Config.KAFKA.BROKERS[0] != "" {
+		var avroSchema []byte
+		if config.Config.KAFKA.AVRO_SCHEMA_FILE != "" {
+			avroSchema, err = os.ReadFile(config.Config.KAFKA.AVRO_SCHEMA_FILE)
+			if err != nil {
+				logrus.Fatalf("Fatal Error: the avro schema could not be read %s", err.Error())
+				os.Exit(1)
+			}
+		}
+		kafkaReadingService, err := application.NewLiveReadingService(powerConsumptionMySQLRepository, powerConsumptionCSVRepository, quarantineRepository, application.LiveReadingFormat{
+			TopicPattern:  config.Config.KAFKA.TOPIC,
+			PayloadFormat: config.Config.KAFKA.PAYLOAD_FORMAT,
+			Unit:          config.Config.KAFKA.UNIT,
+			BatchSize:     config.Config.KAFKA.BATCH_SIZE,
+			AvroSchema:    string(avroSchema),
+		})
+		if err != nil {
+			logrus.Fatalf("Fatal Error: the kafka format is not valid %s", err.Error())
+			os.Exit(1)
+		}
+		kafkaReader := infraestructure.NewKafkaReader(config.Config.KAFKA.BROKERS, config.Config.KAFKA.GROUP_ID, config.Config.KAFKA.TOPIC)
+		kafkaConsumer := infraestructure.NewKafkaConsumer(kafkaReader, kafkaReadingService, config.Config.KAFKA.FLUSH_INTERVAL)
+		kafkaConsumerLag = kafkaConsumer
+		go kafkaConsumer.Run(context.Background())
+	}
+	webhookService := application.NewWebhookService(webhookRepository, infraestructure.NewHTTPWebhookSender(config.Config.WEBHOOK.TIMEOUT))
+	webhookHandler := infraestructure.NewWebhookHandler(webhookService)
+	webhookRoutes := infraestructure.NewWebhookRoutes(webhookHandler)
+	webhookDispatcher := infraestructure.NewWebhookDispatcher(webhookService, config.Config.WEBHOOK.DELIVERY_INTERVAL)
+	go webhookDispatcher.Run(context.Background())
+	alertService := application.NewAlertService(alertRepository, meterGroupRepository, powerConsumptionService)
+	alertHandler := infraestructure.NewAlertHandler(alertService)
+	alertRoutes := infraestructure.NewAlertRoutes(alertHandler)
+	alertScheduler := infraestructure.NewAlertScheduler(alertService, config.Config.ALERT.EVALUATION_INTERVAL)
+	go alertScheduler.Run(context.Background())
+	eventSinks := []application.EventSink{alertService, webhookService}
+	if config.Config.OUTBOX.WEBHOOK_URL != "" {
+		eventSinks = append(eventSinks, infraestructure.NewWebhookEventSink(config.Config.OUTBOX.WEBHOOK_URL))
+	}
+	if config.Config.OUTBOX.KAFKA_TOPIC != "" && len(config.Config.KAFKA.BROKERS) > 0 && config.Config.KAFKA.BROKERS[0] != "" {
+		eventSinks = append(eventSinks, infraestructure.NewKafkaEventSink(infraestructure.NewKafkaWriter(config.Config.KAFKA.BROKERS, config.Config.OUTBOX.KAFKA_TOPIC)))
+	}
+	outboxService := application.NewOutboxService(outboxRepository, eventSinks...)
+	outboxRelay := infraestructure.NewOutboxRelay(outboxService, config.Config.OUTBOX.POLL_INTERVAL)
+	go outboxRelay.Run(context.Background())
+	liveConsumptionService := application.NewLiveConsumptionService(liveConsumptionBroker, powerConsumptionService)
+	liveConsumptionHandler := infraestructure.NewLiveConsumptionHandler(liveConsumptionService, constants.LiveConsumptionHeartbeat)
+	liveConsumptionRoutes := infraestructure.NewLiveConsumptionRoutes(liveConsumptionHandler)
+	graphQLSchema, err := infraestructure.NewConsumptionGraphQLSchema()
+	if err != nil {
+		logrus.Fatalf("Fatal Error: building the graphql schema %s", err.Error())
+		os.Exit(1)
+	}
+	graphQLHandler := infraestructure.NewGraphQLHandler(graphQLSchema, func() application.ConsumptionLoader {
+		return application.NewConsumptionLoader(powerConsumptionMySQLRepository, meterSettingRepository, powerConsumptionService)
+	})
+	graphQLRoutes := infraestructure.NewGraphQLRoutes(graphQLHandler)
+	healthHandler := infraestructure.NewHealthHandler(kafkaConsumerLag)
+	healthRoutes := infraestructure.NewHealthRoutes(healthHandler)
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Config.APP.GRPC_PORT))
+	if err != nil {
+		logrus.Fatalf("Fatal Error: the grpc port could not be opened %s", err.Error())
+		os.Exit(1)
+	}
+	grpcServer := infraestructure.NewGRPCServer(infraestructure.NewConsumptionGRPCServer(powerConsumptionService))
+	go func() {
+		logrus.Infof("the grpc server listens on the port %s", config.Config.APP.GRPC_PORT)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logrus.Fatalf("Fatal Error: the grpc server stopped %s", err.Error())
+		}
+	}()
 
 	r := infraestructure.NewRouter(infraestructure.RoutesGroup{
 		PowerConsumption: powerConsumptionRoutes,
+		LiveConsumption:  liveConsumptionRoutes,
+		MeterGroup:       meterGroupRoutes,
+		MeterSetting:     meterSettingRoutes,
+		QualityRule:      qualityRuleRoutes,
+		Quarantine:       quarantineRoutes,
+		Import:           importRoutes,
+		Webhook:          webhookRoutes,
+		Alert:            alertRoutes,
+		GraphQL:          graphQLRoutes,
+		Health:           healthRoutes,
 		Swagger:          infraestructure.NewSwaggerDocsRoutes(),
 	})
 
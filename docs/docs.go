@@ -25,6 +25,140 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/alert-rules": {
+            "get": {
+                "description": "Get all the alert rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get all the alert rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an alert rule of a meter or a group, the metric could be active_energy, reactive_energy, capacitive_reactive or solar, the period kind daily, calendar_weekly or monthly and the comparison greater_than, less_than or projected_greater_than to be told when the period is on track to exceed the threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Create an alert rule",
+                "parameters": [
+                    {
+                        "description": "alert rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.AlertRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/alert-rules/{id}": {
+            "delete": {
+                "description": "Delete an alert rule, its triggered alerts are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Delete an alert rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "alert rule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts": {
+            "get": {
+                "description": "Get the triggered alerts, a rule triggers at most one alert by period, the newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get the triggered alerts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/consumption": {
             "get": {
                 "description": "Get the user consumption information in a window time divided monthly, weekly or daily",
@@ -55,8 +189,182 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "kind period",
+                        "description": "kind period monthly, weekly, daily, calendar_weekly, interval or billing_cycle, required if interval is blank",
                         "name": "kind_period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meter ids, required if group_id is blank",
+                        "name": "meter_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meter group id, required if meter_ids is blank",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "compare with previous_period, previous_year or custom",
+                        "name": "compare_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start date of the custom window to compare",
+                        "name": "compare_start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end date of the custom window to compare",
+                        "name": "compare_end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "aggregations by period separated by comma max, min, mean, count or p95",
+                        "name": "aggregations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day that begins the calendar_weekly periods, default monday",
+                        "name": "week_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "length of the interval periods anchored at the start date like 2h or 3d, up to 3660 days",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day that starts the billing_cycle periods, default the billing cycle day of every meter",
+                        "name": "billing_cycle_day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "unit of the values Wh, kWh or MWh, default kWh",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/consumption/analytics": {
+            "get": {
+                "description": "Get the mean consumption by hour of the day split by weekday and weekend and the load factor by month",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consumption"
+                ],
+                "summary": "Get the average daily load profile and the monthly load factor of the meters in a window time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "start date",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "end date",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meter ids",
+                        "name": "meter_ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "length of the demand window used for the peak demand, default 15m",
+                        "name": "demand_window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/consumption/demand": {
+            "get": {
+                "description": "Convert the active energy of the readings in average power by demand window and find the monthly peak",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consumption"
+                ],
+                "summary": "Get the peak demand (kW) by demand window and by month of the meters in a window time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "start date",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "end date",
+                        "name": "end_date",
                         "in": "query",
                         "required": true
                     },
@@ -66,6 +374,18 @@ const docTemplate = `{
                         "name": "meter_ids",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "length of the demand window, default 15m",
+                        "name": "demand_window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "fixed or rolling, default fixed",
+                        "name": "window_type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -104,6 +424,891 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unit of the values in the file Wh, kWh or MWh, default the unit of every meter",
+                        "name": "unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who uploads the file",
+                        "name": "uploader",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "import the file even if it was already imported",
+                        "name": "force",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/consumption/live": {
+            "get": {
+                "description": "Server-Sent Events stream, the aggregate event is sent when the client connects and after every reading event with the updated consumption of the current period of every meter, a heartbeat comment is sent when there are not new readings",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Power Consumption"
+                ],
+                "summary": "Stream the live consumption of some meters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "meter ids separated by comma",
+                        "name": "meter_ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "current period of the aggregates daily, calendar_weekly or monthly, default daily",
+                        "name": "kind_period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.LiveAggregateSerializer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Execute a graphql query, the clients select the meters, the fields, the period kind and the window of every series in only one request, e.g. { meters(ids: [1, 2]) { id settings { unit } consumption(startDate: \"2023-06-01\", endDate: \"2023-06-30\", periodKind: \"weekly\") { period active } } }. The response follows the graphql spec with data and errors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "Execute a graphql query",
+                "parameters": [
+                    {
+                        "description": "graphql query",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "description": "Get all the meter groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meter Groups"
+                ],
+                "summary": "Get all the meter groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a meter group, a named set of meters that could be nested in another group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meter Groups"
+                ],
+                "summary": "Create a meter group",
+                "parameters": [
+                    {
+                        "description": "meter group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.MeterGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "description": "Get a meter group with all the meters in it and in its nested groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meter Groups"
+                ],
+                "summary": "Get a meter group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "meter group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get the status of the service and the lag of the kafka consumer when it is enabled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Get the health of the service",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "get": {
+                "description": "Get all the imported files with their checksum, uploader, unit, status and row counts, the newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Get the imports",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}/readings": {
+            "get": {
+                "description": "Get an import and all the readings that came from its file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Get the readings of an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "import id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}/rollback": {
+            "post": {
+                "description": "Delete all the readings and the quarantined readings of an import, the import is kept as rolled back",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Roll back an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "import id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/meters/{id}/settings": {
+            "get": {
+                "description": "Get the settings of a meter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meter Settings"
+                ],
+                "summary": "Get the settings of a meter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "meter id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Save the settings of a meter like the day that starts its billing cycles or if it reports register values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meter Settings"
+                ],
+                "summary": "Save the settings of a meter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "meter id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "meter settings",
+                        "name": "setting",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.MeterSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quality-rules": {
+            "get": {
+                "description": "Get all the data quality rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quality Rules"
+                ],
+                "summary": "Get all the data quality rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a data quality rule that is checked in every import, the kind could be min, max, max_delta, date_range or meter_exists and the action reject, quarantine or flag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quality Rules"
+                ],
+                "summary": "Create a data quality rule",
+                "parameters": [
+                    {
+                        "description": "quality rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.QualityRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quality-rules/{id}": {
+            "delete": {
+                "description": "Delete a data quality rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quality Rules"
+                ],
+                "summary": "Delete a data quality rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "quality rule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quarantine": {
+            "get": {
+                "description": "Get all the readings that did not pass the data quality rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quarantine"
+                ],
+                "summary": "Get the quarantined readings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quarantine/{id}": {
+            "put": {
+                "description": "Replace the csv line of a quarantined reading, the line has the same columns as the imported file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quarantine"
+                ],
+                "summary": "Edit a quarantined reading",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "quarantined reading id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fixed csv line",
+                        "name": "reading",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.UpdateQuarantinedReadingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quarantine/{id}/release": {
+            "post": {
+                "description": "Save a reviewed reading in the user_consumption database without checking the rules and remove it from the quarantine",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quarantine"
+                ],
+                "summary": "Release a quarantined reading",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "quarantined reading id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quarantine/{id}/resubmit": {
+            "post": {
+                "description": "Send the csv line of a quarantined reading through the import again checking the data quality rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quarantine"
+                ],
+                "summary": "Re-submit a quarantined reading",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "quarantined reading id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Get all the webhook subscriptions without their secrets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get all the webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribe a url to the import_completed, anomaly_detected or threshold_exceeded events of some meters or of all the meters when the meters are blank, the payloads are signed with HMAC SHA256 in the X-Webhook-Signature header over the X-Webhook-Timestamp header, a dot and the body, the secret is only returned here",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Subscribe a url to the events of the service",
+                "parameters": [
+                    {
+                        "description": "webhook subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "Delete a webhook subscription, its pending deliveries are not sent anymore",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Activate or deactivate a webhook subscription, an inactive subscription does not receive new events and its pending deliveries are not sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Activate or deactivate a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook subscription status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.WebhookSubscriptionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/infraestructure.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Get the deliveries of a webhook subscription with their status, attempts and last error, the newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get the delivery log of a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -124,10 +1329,341 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "application.AggregationSerializer": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "exported": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_capacitive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_inductive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "application.ComparisonSerializer": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "delta": {
+                    "$ref": "#/definitions/application.EnergyDelta"
+                },
+                "delta_percentage": {
+                    "$ref": "#/definitions/application.EnergyPercentageDelta"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "exported": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "period": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reactive_capacitive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_inductive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "application.EnergyDelta": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "exported": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_capacitive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_inductive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "application.EnergyPercentageDelta": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "exported": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_capacitive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_inductive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "infraestructure.AlertRuleRequest": {
+            "type": "object",
+            "required": [
+                "comparison",
+                "period_kind"
+            ],
+            "properties": {
+                "comparison": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "meter_id": {
+                    "type": "integer"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "period_kind": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "infraestructure.GraphQLRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "infraestructure.LiveAggregateSerializer": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "aggregations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/application.AggregationSerializer"
+                    }
+                },
+                "comparison": {
+                    "$ref": "#/definitions/application.ComparisonSerializer"
+                },
+                "exported": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "kind_period": {
+                    "type": "string"
+                },
+                "meter_id": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reactive_capacitive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_inductive": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "reactive_unit": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "infraestructure.MeterGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "meter_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "infraestructure.MeterSettingRequest": {
+            "type": "object",
+            "properties": {
+                "billing_cycle_day": {
+                    "type": "integer"
+                },
+                "cumulative": {
+                    "type": "boolean"
+                },
+                "register_max": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "infraestructure.QualityRuleRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "kind"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "meter_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
         "infraestructure.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {},
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
                 "error": {},
                 "msg": {
                     "type": "string"
@@ -136,6 +1672,55 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "infraestructure.UpdateQuarantinedReadingRequest": {
+            "type": "object",
+            "required": [
+                "raw_line"
+            ],
+            "properties": {
+                "raw_line": {
+                    "type": "string"
+                }
+            }
+        },
+        "infraestructure.WebhookSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meter_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "infraestructure.WebhookSubscriptionStatusRequest": {
+            "type": "object",
+            "required": [
+                "active"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                }
+            }
         }
     }
 }`
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
@@ -28,6 +29,12 @@ func init() {
 type config struct {
 	APP
 	DB
+	INGEST
+	MQTT
+	KAFKA
+	OUTBOX
+	WEBHOOK
+	ALERT
 }
 
 type DB struct {
@@ -41,10 +48,61 @@ type DB struct {
 }
 
 type APP struct {
-	PORT string `env:"APP_PORT" envDefault:"8080"`
+	PORT      string `env:"APP_PORT" envDefault:"8080"`
+	GRPC_PORT string `env:"GRPC_PORT" envDefault:"9090"`
+}
+
+type MQTT struct {
+	BROKER         string        `env:"MQTT_BROKER" envDefault:""`
+	CLIENT_ID      string        `env:"MQTT_CLIENT_ID" envDefault:"consumption-ms"`
+	USER           string        `env:"MQTT_USER" envDefault:""`
+	PASSWORD       string        `env:"MQTT_PASSWORD" envDefault:""`
+	TOPIC          string        `env:"MQTT_TOPIC" envDefault:"meters/{meter_id}/readings"`
+	PAYLOAD_FORMAT string        `env:"MQTT_PAYLOAD_FORMAT" envDefault:"json"`
+	QOS            int           `env:"MQTT_QOS" envDefault:"1"`
+	UNIT           string        `env:"MQTT_UNIT" envDefault:""`
+	BATCH_SIZE     int           `env:"MQTT_BATCH_SIZE" envDefault:"500"`
+	FLUSH_INTERVAL time.Duration `env:"MQTT_FLUSH_INTERVAL" envDefault:"5s"`
+}
+
+type KAFKA struct {
+	BROKERS          []string      `env:"KAFKA_BROKERS" envSeparator:"," envDefault:""`
+	GROUP_ID         string        `env:"KAFKA_GROUP_ID" envDefault:"consumption-ms"`
+	TOPIC            string        `env:"KAFKA_TOPIC" envDefault:"readings"`
+	PAYLOAD_FORMAT   string        `env:"KAFKA_PAYLOAD_FORMAT" envDefault:"json"`
+	AVRO_SCHEMA_FILE string        `env:"KAFKA_AVRO_SCHEMA_FILE" envDefault:""`
+	UNIT             string        `env:"KAFKA_UNIT" envDefault:""`
+	BATCH_SIZE       int           `env:"KAFKA_BATCH_SIZE" envDefault:"500"`
+	FLUSH_INTERVAL   time.Duration `env:"KAFKA_FLUSH_INTERVAL" envDefault:"5s"`
+}
+
+type OUTBOX struct {
+	WEBHOOK_URL   string        `env:"OUTBOX_WEBHOOK_URL" envDefault:""`
+	KAFKA_TOPIC   string        `env:"OUTBOX_KAFKA_TOPIC" envDefault:""`
+	POLL_INTERVAL time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"5s"`
+}
+
+type ALERT struct {
+	EVALUATION_INTERVAL time.Duration `env:"ALERT_EVALUATION_INTERVAL" envDefault:"15m"`
+}
+
+type WEBHOOK struct {
+	DELIVERY_INTERVAL time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"10s"`
+	TIMEOUT           time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+}
+
+type INGEST struct {
+	WATCH_DIR     string        `env:"INGEST_WATCH_DIR" envDefault:""`
+	POLL_INTERVAL time.Duration `env:"INGEST_POLL_INTERVAL" envDefault:"1m"`
+	UNIT          string        `env:"INGEST_UNIT" envDefault:""`
+	SFTP_ADDR     string        `env:"INGEST_SFTP_ADDR" envDefault:""`
+	SFTP_USER     string        `env:"INGEST_SFTP_USER" envDefault:""`
+	SFTP_PASSWORD string        `env:"INGEST_SFTP_PASSWORD" envDefault:""`
+	SFTP_DIR      string        `env:"INGEST_SFTP_DIR" envDefault:"."`
+	SFTP_HOST_KEY string        `env:"INGEST_SFTP_HOST_KEY" envDefault:""`
 }
 
 func (c *config) DatabaseInit() (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?charset=utf8mb4&parseTime=True&loc=UTC", c.DB.USER, c.DB.PASSWORD, c.DB.HOST, c.DB.DBNAME)
-	return gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	return gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 }
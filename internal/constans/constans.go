@@ -1,10 +1,122 @@
 package constants
 
+import "time"
+
+const (
+	DateFormatWeeklyAndDailyPeriod string  = "Jan 2"
+	DateFormatMonthlyPeriod        string  = "Jan 2006"
+	DateFormatDateTimeWithTZ       string  = "2006-01-02 15:04:05+00"
+	DateFormatDate                 string  = "2006-01-02"
+	PeriodKindMonthly              string  = "monthly"
+	PeriodKindWeekly               string  = "weekly"
+	PeriodKindDaily                string  = "daily"
+	PeriodKindCalendarWeekly       string  = "calendar_weekly"
+	PeriodKindInterval             string  = "interval"
+	PeriodKindBillingCycle         string  = "billing_cycle"
+	CompareToPreviousPeriod        string  = "previous_period"
+	CompareToPreviousYear          string  = "previous_year"
+	CompareToCustom                string  = "custom"
+	AggregationMax                 string  = "max"
+	AggregationMin                 string  = "min"
+	AggregationMean                string  = "mean"
+	AggregationCount               string  = "count"
+	AggregationP95                 string  = "p95"
+	DemandWindowTypeFixed          string  = "fixed"
+	DemandWindowTypeRolling        string  = "rolling"
+	DefaultDemandWindow            string  = "15m"
+	DateFormatDemandTimestamp      string  = "2006-01-02 15:04"
+	DateFormatHourOfDay            string  = "15:04"
+	MaxIntervalGroups              int     = 10000
+	MaxWindowDaysDaily             int     = 366
+	MaxWindowDaysWeekly            int     = 1830
+	MaxWindowDaysMonthly           int     = 3660
+	RegisterRolloverThreshold      float64 = 0.9
+	QualityRuleMin                 string  = "min"
+	QualityRuleMax                 string  = "max"
+	QualityRuleMaxDelta            string  = "max_delta"
+	QualityRuleDateRange           string  = "date_range"
+	QualityRuleMeterExists         string  = "meter_exists"
+	QualityRuleParse               string  = "parse"
+	QualityActionReject            string  = "reject"
+	QualityActionQuarantine        string  = "quarantine"
+	QualityActionFlag              string  = "flag"
+	FieldActiveEnergy              string  = "active_energy"
+	FieldReactiveEnergy            string  = "reactive_energy"
+	FieldCapacitiveReactive        string  = "capacitive_reactive"
+	FieldSolar                     string  = "solar"
+	ImportStatusInProgress         string  = "in_progress"
+	ImportStatusCompleted          string  = "completed"
+	ImportStatusFailed             string  = "failed"
+	ImportStatusRolledBack         string  = "rolled_back"
+	IngestProcessedDir             string  = "processed"
+	IngestFailedDir                string  = "failed"
+	IngestUploader                 string  = "ingestion-worker"
+	IngestFileExtension            string  = ".csv"
+	LivePayloadJSON                string  = "json"
+	LivePayloadCSV                 string  = "csv"
+	LivePayloadAvro                string  = "avro"
+	LiveTopicMeterID               string  = "{meter_id}"
+	LiveBatchSize                  int     = 500
+	EventReadingsImported          string  = "readings_imported"
+	OutboxBatchSize                int     = 100
+	OutboxMaxAttempts              int     = 8
+	EventImportCompleted           string  = "import_completed"
+	EventAnomalyDetected           string  = "anomaly_detected"
+	EventThresholdExceeded         string  = "threshold_exceeded"
+	WebhookStatusPending           string  = "pending"
+	WebhookStatusDelivered         string  = "delivered"
+	WebhookStatusFailed            string  = "failed"
+	WebhookMaxAttempts             int     = 8
+	WebhookBatchSize               int     = 100
+	WebhookSecretSize              int     = 32
+	WebhookEventHeader             string  = "X-Webhook-Event"
+	WebhookDeliveryHeader          string  = "X-Webhook-Delivery"
+	WebhookTimestampHeader         string  = "X-Webhook-Timestamp"
+	WebhookSignatureHeader         string  = "X-Webhook-Signature"
+	AlertComparisonGreaterThan     string  = "greater_than"
+	AlertComparisonLessThan        string  = "less_than"
+	AlertComparisonProjected       string  = "projected_greater_than"
+	GRPCUploader                   string  = "grpc"
+	GRPCIngestBatchSize            int     = 4000
+	LiveConsumptionBufferSize      int     = 64
+	LiveEventReading               string  = "reading"
+	LiveEventAggregate             string  = "aggregate"
+	LiveEventError                 string  = "error"
+	ErrorCodeInvalidRequest        string  = "invalid_request"
+	ErrorCodeInvalidBody           string  = "invalid_body"
+	ErrorCodeMissingParam          string  = "missing_param"
+	ErrorCodeInvalidParam          string  = "invalid_param"
+	ErrorCodeInvalidDate           string  = "invalid_date"
+	ErrorCodeInvalidDateRange      string  = "invalid_date_range"
+	ErrorCodeInvalidMeterID        string  = "invalid_meter_id"
+	ErrorCodeInvalidKindPeriod     string  = "invalid_kind_period"
+	ErrorCodeDuplicateMeterID      string  = "duplicate_meter_id"
+	ErrorCodeWindowTooLarge        string  = "window_too_large"
+	ErrorCodeNotFound              string  = "not_found"
+	ErrorCodeMeterSettingNotFound  string  = "meter_setting_not_found"
+	ErrorCodeMeterGroupNotFound    string  = "meter_group_not_found"
+	ErrorCodeDuplicated            string  = "duplicated"
+	ErrorCodeDuplicateImport       string  = "duplicate_import"
+	ErrorCodeImportRolledBack      string  = "import_rolled_back"
+	ErrorCodeDatabaseUnavailable   string  = "database_unavailable"
+	ErrorCodeInternal              string  = "internal_error"
+)
+
+const IngestFileMinAge time.Duration = 10 * time.Second
+
+const (
+	WebhookRetryBaseDelay time.Duration = 30 * time.Second
+	WebhookRetryMaxDelay  time.Duration = time.Hour
+)
+
+const (
+	OutboxRetryBaseDelay time.Duration = 10 * time.Second
+	OutboxRetryMaxDelay  time.Duration = time.Hour
+)
+
+const LiveConsumptionHeartbeat time.Duration = 15 * time.Second
+
 const (
-	DateFormatWeeklyAndDailyPeriod string = "Jan 2"
-	DateFormatMonthlyPeriod        string = "Jan 2006"
-	DateFormatDateTimeWithTZ       string = "2006-01-02 15:04:05+00"
-	PeriodKindMonthly              string = "monthly"
-	PeriodKindWeekly               string = "weekly"
-	PeriodKindDaily                string = "daily"
+	KafkaRetryBaseDelay time.Duration = time.Second
+	KafkaRetryMaxDelay  time.Duration = time.Minute
 )
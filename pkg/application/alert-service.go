@@ -0,0 +1,463 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . AlertService
+type AlertService interface {
+	CreateAlertRule(rule domain.AlertRule) (*domain.AlertRule, error)
+	GetAlertRules() ([]domain.AlertRule, error)
+	DeleteAlertRule(ruleID string) error
+	GetAlerts() ([]domain.Alert, error)
+	EvaluateAlertRules() (int, error)
+	Name() string
+	Publish(envelope EventEnvelope) error
+}
+
+type AlertServiceImpl struct {
+	alertRepository         domain.AlertRepository
+	meterGroupRepository    domain.MeterGroupRepository
+	powerConsumptionService PowerConsumptionService
+	now                     func() time.Time
+}
+
+func NewAlertService(alertRepository domain.AlertRepository, meterGroupRepository domain.MeterGroupRepository, powerConsumptionService PowerConsumptionService) AlertService {
+	return &AlertServiceImpl{
+		alertRepository:         alertRepository,
+		meterGroupRepository:    meterGroupRepository,
+		powerConsumptionService: powerConsumptionService,
+		now:                     time.Now,
+	}
+}
+
+// CreateAlertRule: check and create an alert rule
+//
+// Parameters:
+// rule: the meter or the group, the metric, the period kind, the threshold and the comparison of the rule
+//
+// Returns:
+// return the created rule or an error if the rule is not valid
+func (a *AlertServiceImpl) CreateAlertRule(rule domain.AlertRule) (*domain.AlertRule, error) {
+	rule, err := ChekingAlertRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	if rule.GroupID != nil {
+		if _, err := a.meterGroupRepository.GetMeterGroupByID(*rule.GroupID); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.alertRepository.CreateAlertRule(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetAlertRules: get all the alert rules
+//
+// Returns:
+// return all the alert rules
+func (a *AlertServiceImpl) GetAlertRules() ([]domain.AlertRule, error) {
+	return a.alertRepository.GetAlertRules()
+}
+
+// DeleteAlertRule: delete an alert rule
+//
+// Parameters:
+// ruleID: the id of the rule
+//
+// Returns:
+// return an error if the rule could not be deleted
+func (a *AlertServiceImpl) DeleteAlertRule(ruleID string) error {
+	numberRuleID, err := domain.StrToInt(ruleID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int ruleID %s", err.Error())
+		return domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the rule id is not a number %s", ruleID))
+	}
+	return a.alertRepository.DeleteAlertRule(uint(numberRuleID))
+}
+
+// GetAlerts: get the triggered alerts
+//
+// Returns:
+// return all the alerts, the newest first
+func (a *AlertServiceImpl) GetAlerts() ([]domain.Alert, error) {
+	return a.alertRepository.GetAlerts()
+}
+
+// EvaluateAlertRules: evaluate all the alert rules in the current period and in the period of yesterday to check
+// the periods that just finished, it is called on a schedule
+//
+// Returns:
+// return the number of new alerts or an error if the rules could not be read
+func (a *AlertServiceImpl) EvaluateAlertRules() (int, error) {
+	rules, err := a.alertRepository.GetAlertRules()
+	if err != nil {
+		return 0, err
+	}
+	today := a.now().UTC().Truncate(24 * time.Hour)
+	triggered := 0
+	for _, rule := range rules {
+		alerts, err := a.evaluateAlertRule(rule, []time.Time{today.AddDate(0, 0, -1), today})
+		if err != nil {
+			logrus.Errorf("Error: evaluating the alert rule %d %s", rule.ID, err.Error())
+			continue
+		}
+		triggered += alerts
+	}
+	return triggered, nil
+}
+
+func (a *AlertServiceImpl) Name() string {
+	return "alerts"
+}
+
+// Publish: evaluate the alert rules of the meters of the imported readings only in the periods with imported
+// readings, the other events are ignored and a rule that could not be evaluated does not stop the publication of the
+// events
+//
+// Parameters:
+// envelope: the event of the outbox
+//
+// Returns:
+// return an error if the event or the rules could not be read
+func (a *AlertServiceImpl) Publish(envelope EventEnvelope) error {
+	if envelope.Kind != constants.EventReadingsImported {
+		return nil
+	}
+	var event domain.ReadingsImportedEvent
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
+		return fmt.Errorf("Error: reading the event %d %s", envelope.ID, err.Error())
+	}
+	days, err := readingsImportedDays(event)
+	if err != nil {
+		return fmt.Errorf("Error: reading the days of the event %d %s", envelope.ID, err.Error())
+	}
+	rules, err := a.alertRepository.GetAlertRules()
+	if err != nil {
+		return err
+	}
+	importedMeterIDs := make(map[int]bool)
+	for _, meterID := range event.MeterIDs {
+		importedMeterIDs[meterID] = true
+	}
+	for _, rule := range rules {
+		applies, err := a.alertRuleApplies(rule, importedMeterIDs)
+		if err != nil {
+			logrus.Errorf("Error: resolving the meters of the alert rule %d %s", rule.ID, err.Error())
+			continue
+		}
+		if !applies {
+			continue
+		}
+		if _, err := a.evaluateAlertRule(rule, days); err != nil {
+			logrus.Errorf("Error: evaluating the alert rule %d %s", rule.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+// readingsImportedDays: get the days with readings of an event, the events written before the days were in the
+// event only have the start and the end date so every day between them is returned
+func readingsImportedDays(event domain.ReadingsImportedEvent) ([]time.Time, error) {
+	var days []time.Time
+	if len(event.Days) == 0 {
+		endDay := event.EndDate.UTC().Truncate(24 * time.Hour)
+		for day := event.StartDate.UTC().Truncate(24 * time.Hour); !day.After(endDay); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+		return days, nil
+	}
+	for _, eventDay := range event.Days {
+		day, err := time.Parse(constants.DateFormatDate, eventDay)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// alertRuleApplies: check if a rule is of one of the meters or of a group with one of the meters
+func (a *AlertServiceImpl) alertRuleApplies(rule domain.AlertRule, meterIDs map[int]bool) (bool, error) {
+	if rule.MeterID != nil {
+		return meterIDs[*rule.MeterID], nil
+	}
+	_, groupMeterIDs, err := ResolveMeterGroup(a.meterGroupRepository, *rule.GroupID)
+	if err != nil {
+		return false, err
+	}
+	for _, meterID := range groupMeterIDs {
+		if meterIDs[meterID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateAlertRule: evaluate a rule in the periods of the days, the periods that do not start yet or where the rule
+// already triggered an alert are skipped and every new alert is written with its threshold exceeded event so the
+// sinks and the webhooks receive it
+//
+// Parameters:
+// rule: the alert rule
+// days: the days to evaluate
+//
+// Returns:
+// return the number of new alerts
+func (a *AlertServiceImpl) evaluateAlertRule(rule domain.AlertRule, days []time.Time) (int, error) {
+	now := a.now().UTC()
+	periodStarts := AlertPeriodStarts(rule.PeriodKind, days, now)
+	if len(periodStarts) == 0 {
+		return 0, nil
+	}
+	alertPeriodStarts, err := a.alertRepository.GetAlertPeriodStarts(rule.ID, periodStarts[0], periodStarts[len(periodStarts)-1])
+	if err != nil {
+		return 0, err
+	}
+	triggeredPeriods := make(map[int64]bool)
+	for _, alertPeriodStart := range alertPeriodStarts {
+		triggeredPeriods[alertPeriodStart.Unix()] = true
+	}
+	triggered := 0
+	for _, periodStart := range periodStarts {
+		if triggeredPeriods[periodStart.Unix()] {
+			continue
+		}
+		periodEnd := AlertPeriodEnd(rule.PeriodKind, periodStart)
+		value, err := a.alertRuleValue(rule, periodStart, periodEnd)
+		if err != nil {
+			return triggered, err
+		}
+		projected := ProjectAlertValue(value, periodStart, periodEnd, now)
+		if !AlertTriggered(rule, value, projected, periodEnd, now) {
+			continue
+		}
+		alert := domain.Alert{
+			RuleID:      rule.ID,
+			MeterID:     rule.MeterID,
+			GroupID:     rule.GroupID,
+			Metric:      rule.Metric,
+			PeriodKind:  rule.PeriodKind,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			Value:       value,
+			Projected:   projected,
+			Threshold:   rule.Threshold,
+			Comparison:  rule.Comparison,
+		}
+		created, err := a.alertRepository.CreateAlert(&alert)
+		if err != nil {
+			return triggered, err
+		}
+		if !created {
+			continue
+		}
+		triggered++
+		logrus.Warnf("the alert rule %d was triggered in the period %s with %f", rule.ID, domain.TimeTostr(periodStart, constants.DateFormatDate), value)
+	}
+	return triggered, nil
+}
+
+// alertRuleValue: get the consumption of the meter or the group of a rule in a period with the filters of the
+// consumption queries
+//
+// Parameters:
+// rule: the alert rule
+// periodStart: the first day of the period
+// periodEnd: the last day of the period
+//
+// Returns:
+// return the sum of the metric of the rule in the period
+func (a *AlertServiceImpl) alertRuleValue(rule domain.AlertRule, periodStart, periodEnd time.Time) (float64, error) {
+	startDate := domain.TimeTostr(periodStart, constants.DateFormatDate)
+	endDate := domain.TimeTostr(periodEnd, constants.DateFormatDate)
+	var serializer Serializer
+	if rule.MeterID != nil {
+		serializers, err := a.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(strconv.Itoa(*rule.MeterID), startDate, endDate, rule.PeriodKind, domain.ConsumptionQueryOptions{})
+		if err != nil {
+			return 0, err
+		}
+		if len(serializers) == 0 {
+			return 0, nil
+		}
+		serializer = serializers[0]
+	} else {
+		groupSerializer, err := a.powerConsumptionService.GetConsumptionByGroupAndWindowTime(strconv.FormatUint(uint64(*rule.GroupID), 10), startDate, endDate, rule.PeriodKind, domain.ConsumptionQueryOptions{})
+		if err != nil {
+			return 0, err
+		}
+		serializer = groupSerializer.Total
+	}
+	var values []float64
+	switch rule.Metric {
+	case constants.FieldActiveEnergy:
+		values = serializer.Active
+	case constants.FieldReactiveEnergy:
+		values = serializer.ReactiveInductive
+	case constants.FieldCapacitiveReactive:
+		values = serializer.ReactiveCapacitive
+	case constants.FieldSolar:
+		values = serializer.Exported
+	}
+	value := 0.0
+	for _, periodValue := range values {
+		value += periodValue
+	}
+	return value, nil
+}
+
+// AlertPeriodStart: the first day of the period of a date
+//
+// Parameters:
+// periodKind: daily, calendar_weekly or monthly
+// date: the date
+//
+// Returns:
+// return the first day of the period, the weeks start on monday
+func AlertPeriodStart(periodKind string, date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch periodKind {
+	case constants.PeriodKindCalendarWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case constants.PeriodKindMonthly:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// AlertPeriodStarts: the first day of the periods of the days without repeating them, in date order
+//
+// Parameters:
+// periodKind: daily, calendar_weekly or monthly
+// days: the days
+// now: the current time, the periods that do not start yet are left out
+//
+// Returns:
+// return the first day of every period
+func AlertPeriodStarts(periodKind string, days []time.Time, now time.Time) []time.Time {
+	var periodStarts []time.Time
+	seenPeriodStarts := make(map[int64]bool)
+	for _, day := range days {
+		periodStart := AlertPeriodStart(periodKind, day)
+		if seenPeriodStarts[periodStart.Unix()] || !periodStart.Before(now) {
+			continue
+		}
+		seenPeriodStarts[periodStart.Unix()] = true
+		periodStarts = append(periodStarts, periodStart)
+	}
+	sort.Slice(periodStarts, func(i, j int) bool {
+		return periodStarts[i].Before(periodStarts[j])
+	})
+	return periodStarts
+}
+
+// AlertPeriodEnd: the last day of the period that starts in a date
+//
+// Parameters:
+// periodKind: daily, calendar_weekly or monthly
+// periodStart: the first day of the period
+//
+// Returns:
+// return the last day of the period
+func AlertPeriodEnd(periodKind string, periodStart time.Time) time.Time {
+	switch periodKind {
+	case constants.PeriodKindCalendarWeekly:
+		return periodStart.AddDate(0, 0, 6)
+	case constants.PeriodKindMonthly:
+		return periodStart.AddDate(0, 1, -1)
+	}
+	return periodStart
+}
+
+// ProjectAlertValue: project the consumption of a period that is not finished to the whole period with the average
+// consumption of the elapsed time
+//
+// Parameters:
+// value: the consumption until now
+// periodStart: the first day of the period
+// periodEnd: the last day of the period
+// now: the current time
+//
+// Returns:
+// return the projected consumption or the consumption when the period is finished
+func ProjectAlertValue(value float64, periodStart, periodEnd, now time.Time) float64 {
+	periodFinish := periodEnd.AddDate(0, 0, 1)
+	if !now.Before(periodFinish) || !now.After(periodStart) {
+		return value
+	}
+	return value * float64(periodFinish.Sub(periodStart)) / float64(now.Sub(periodStart))
+}
+
+// AlertTriggered: check if a rule is triggered, less than is only checked in finished periods because the
+// consumption of a period only grows
+//
+// Parameters:
+// rule: the alert rule
+// value: the consumption of the period
+// projected: the projected consumption of the period
+// periodEnd: the last day of the period
+// now: the current time
+//
+// Returns:
+// return true if the rule is triggered
+func AlertTriggered(rule domain.AlertRule, value, projected float64, periodEnd, now time.Time) bool {
+	switch rule.Comparison {
+	case constants.AlertComparisonGreaterThan:
+		return value > rule.Threshold
+	case constants.AlertComparisonProjected:
+		return projected > rule.Threshold
+	case constants.AlertComparisonLessThan:
+		return !now.Before(periodEnd.AddDate(0, 0, 1)) && value < rule.Threshold
+	}
+	return false
+}
+
+// ChekingAlertRule: this function check the target, the metric, the period kind and the comparison of an alert rule
+//
+// Parameters:
+// rule: the rule to check
+//
+// Returns:
+// return the rule with the metric, the period kind and the comparison in lower case or an error if the rule is not
+// valid
+func ChekingAlertRule(rule domain.AlertRule) (domain.AlertRule, error) {
+	rule.Metric = strings.ToLower(strings.Trim(rule.Metric, " "))
+	rule.PeriodKind = strings.ToLower(strings.Trim(rule.PeriodKind, " "))
+	rule.Comparison = strings.ToLower(strings.Trim(rule.Comparison, " "))
+	if (rule.MeterID == nil) == (rule.GroupID == nil) {
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "meter_id", "Error: the alert rule must have a meter or a group")
+	}
+	if rule.Metric == "" {
+		rule.Metric = constants.FieldActiveEnergy
+	}
+	switch rule.Metric {
+	case constants.FieldActiveEnergy, constants.FieldReactiveEnergy, constants.FieldCapacitiveReactive, constants.FieldSolar:
+	default:
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "metric", fmt.Sprintf("Error: metric not allowed %s", rule.Metric))
+	}
+	switch rule.PeriodKind {
+	case constants.PeriodKindDaily, constants.PeriodKindCalendarWeekly, constants.PeriodKindMonthly:
+	default:
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidKindPeriod, "period_kind", fmt.Sprintf("Error: period kind not allowed %s", rule.PeriodKind))
+	}
+	switch rule.Comparison {
+	case constants.AlertComparisonGreaterThan, constants.AlertComparisonLessThan, constants.AlertComparisonProjected:
+	default:
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "comparison", fmt.Sprintf("Error: comparison not allowed %s", rule.Comparison))
+	}
+	if rule.Threshold < 0 {
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "threshold", fmt.Sprintf("Error: threshold not allowed %f", rule.Threshold))
+	}
+	return rule, nil
+}
@@ -0,0 +1,186 @@
+package application
+
+import (
+	"encoding/json"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("AlertService", func() {
+	var (
+		mockAlertRepo      *domainfakes.FakeAlertRepository
+		mockMySQLRepo      *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterGroupRepo *domainfakes.FakeMeterGroupRepository
+		service            *AlertServiceImpl
+		meterID            = 1
+	)
+
+	BeforeEach(func() {
+		mockAlertRepo = &domainfakes.FakeAlertRepository{}
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		powerConsumptionService := NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, mockMeterGroupRepo, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		service = NewAlertService(mockAlertRepo, mockMeterGroupRepo, powerConsumptionService).(*AlertServiceImpl)
+		service.now = func() time.Time { return time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC) }
+		mockAlertRepo.CreateAlertReturns(true, nil)
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 30, Date: time.Date(2023, 8, 10, 1, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 40, Date: time.Date(2023, 8, 10, 2, 0, 0, 0, time.UTC)},
+		}, nil)
+	})
+
+	Context("CreateAlertRule", func() {
+		It("should reject rules without a target or with an unknown comparison", func() {
+			_, err := service.CreateAlertRule(domain.AlertRule{PeriodKind: "daily", Comparison: "greater_than"})
+			Expect(err).ToNot(BeNil())
+			_, err = service.CreateAlertRule(domain.AlertRule{MeterID: &meterID, PeriodKind: "daily", Comparison: "equal"})
+			Expect(err).ToNot(BeNil())
+			Expect(mockAlertRepo.CreateAlertRuleCallCount()).To(Equal(0))
+		})
+
+		It("should use the active energy by default", func() {
+			rule, err := service.CreateAlertRule(domain.AlertRule{MeterID: &meterID, PeriodKind: "Daily", Threshold: 50, Comparison: "greater_than"})
+			Expect(err).To(BeNil())
+			Expect(rule.Metric).To(Equal(constants.FieldActiveEnergy))
+			Expect(rule.PeriodKind).To(Equal(constants.PeriodKindDaily))
+		})
+	})
+
+	Context("EvaluateAlertRules", func() {
+		It("should trigger and notify an alert when the daily consumption exceeds the threshold", func() {
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 3}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindDaily, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+			}, nil)
+			mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeStub = func(startDate, endDate time.Time, meterID int) ([]domain.UserConsumption, error) {
+				if startDate.Day() != 10 {
+					return nil, nil
+				}
+				return []domain.UserConsumption{
+					{MeterID: 1, ActiveEnergy: 30, Date: time.Date(2023, 8, 10, 1, 0, 0, 0, time.UTC)},
+					{MeterID: 1, ActiveEnergy: 40, Date: time.Date(2023, 8, 10, 2, 0, 0, 0, time.UTC)},
+				}, nil
+			}
+
+			triggered, err := service.EvaluateAlertRules()
+
+			Expect(err).To(BeNil())
+			Expect(triggered).To(Equal(1))
+			alert := mockAlertRepo.CreateAlertArgsForCall(0)
+			Expect(alert.RuleID).To(Equal(uint(3)))
+			Expect(alert.PeriodStart).To(Equal(time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)))
+			Expect(alert.Value).To(Equal(70.0))
+		})
+
+		It("should not count an alert that was already triggered in the period", func() {
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 3}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindMonthly, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+			}, nil)
+			mockAlertRepo.CreateAlertReturns(false, nil)
+
+			triggered, err := service.EvaluateAlertRules()
+
+			Expect(err).To(BeNil())
+			Expect(triggered).To(Equal(0))
+			Expect(mockAlertRepo.CreateAlertCallCount()).To(Equal(1))
+		})
+
+		It("should trigger a monthly budget that is on track to be exceeded", func() {
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 4}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindMonthly, Threshold: 200, Comparison: constants.AlertComparisonProjected},
+			}, nil)
+
+			triggered, err := service.EvaluateAlertRules()
+
+			Expect(err).To(BeNil())
+			Expect(triggered).To(Equal(1))
+			alert := mockAlertRepo.CreateAlertArgsForCall(0)
+			Expect(alert.Value).To(Equal(70.0))
+			Expect(alert.Projected).To(BeNumerically(">", 200))
+			Expect(alert.PeriodEnd).To(Equal(time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should skip the periods where the rule already triggered an alert", func() {
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 3}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindDaily, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+			}, nil)
+			mockAlertRepo.GetAlertPeriodStartsReturns([]time.Time{time.Date(2023, 8, 9, 0, 0, 0, 0, time.UTC), time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)}, nil)
+
+			triggered, err := service.EvaluateAlertRules()
+
+			Expect(err).To(BeNil())
+			Expect(triggered).To(Equal(0))
+			ruleID, startDate, endDate := mockAlertRepo.GetAlertPeriodStartsArgsForCall(0)
+			Expect(ruleID).To(Equal(uint(3)))
+			Expect(startDate).To(Equal(time.Date(2023, 8, 9, 0, 0, 0, 0, time.UTC)))
+			Expect(endDate).To(Equal(time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)))
+			Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+			Expect(mockAlertRepo.CreateAlertCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("Publish", func() {
+		It("should evaluate the rules of the imported meters in the periods of the readings", func() {
+			otherMeterID := 2
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 3}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindDaily, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+				{Model: gorm.Model{ID: 5}, MeterID: &otherMeterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindDaily, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+			}, nil)
+			data, _ := json.Marshal(domain.ReadingsImportedEvent{MeterIDs: []int{1}, StartDate: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2023, 8, 1, 23, 0, 0, 0, time.UTC)})
+
+			err := service.Publish(EventEnvelope{ID: 1, Kind: constants.EventReadingsImported, Data: data})
+
+			Expect(err).To(BeNil())
+			Expect(mockAlertRepo.CreateAlertCallCount()).To(Equal(1))
+			alert := mockAlertRepo.CreateAlertArgsForCall(0)
+			Expect(alert.RuleID).To(Equal(uint(3)))
+			Expect(alert.PeriodStart).To(Equal(time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should only evaluate the periods with imported readings", func() {
+			mockAlertRepo.GetAlertRulesReturns([]domain.AlertRule{
+				{Model: gorm.Model{ID: 3}, MeterID: &meterID, Metric: constants.FieldActiveEnergy, PeriodKind: constants.PeriodKindDaily, Threshold: 50, Comparison: constants.AlertComparisonGreaterThan},
+			}, nil)
+			mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeStub = func(startDate, endDate time.Time, meterID int) ([]domain.UserConsumption, error) {
+				if startDate.Month() != time.August {
+					return nil, nil
+				}
+				return []domain.UserConsumption{
+					{MeterID: 1, ActiveEnergy: 70, Date: time.Date(2023, 8, 10, 1, 0, 0, 0, time.UTC)},
+				}, nil
+			}
+			data, _ := json.Marshal(domain.ReadingsImportedEvent{MeterIDs: []int{1}, StartDate: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2023, 8, 10, 2, 0, 0, 0, time.UTC), Days: []string{"2023-01-02", "2023-08-10"}})
+
+			err := service.Publish(EventEnvelope{ID: 1, Kind: constants.EventReadingsImported, Data: data})
+
+			Expect(err).To(BeNil())
+			Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(2))
+			Expect(mockAlertRepo.CreateAlertCallCount()).To(Equal(1))
+			Expect(mockAlertRepo.CreateAlertArgsForCall(0).PeriodStart).To(Equal(time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should ignore the other events", func() {
+			err := service.Publish(EventEnvelope{ID: 1, Kind: constants.EventImportCompleted, Data: json.RawMessage(`{}`)})
+			Expect(err).To(BeNil())
+			Expect(mockAlertRepo.GetAlertRulesCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("AlertTriggered", func() {
+		It("should only check less than in finished periods", func() {
+			rule := domain.AlertRule{Threshold: 10, Comparison: constants.AlertComparisonLessThan}
+			now := time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC)
+			Expect(AlertTriggered(rule, 5, 5, time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC), now)).To(BeFalse())
+			Expect(AlertTriggered(rule, 5, 5, time.Date(2023, 8, 9, 0, 0, 0, 0, time.UTC), now)).To(BeTrue())
+		})
+
+		It("should start the calendar weeks on monday", func() {
+			Expect(AlertPeriodStart(constants.PeriodKindCalendarWeekly, time.Date(2023, 8, 13, 0, 0, 0, 0, time.UTC))).To(Equal(time.Date(2023, 8, 7, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+})
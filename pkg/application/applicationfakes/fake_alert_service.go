@@ -0,0 +1,540 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeAlertService struct {
+	CreateAlertRuleStub        func(domain.AlertRule) (*domain.AlertRule, error)
+	createAlertRuleMutex       sync.RWMutex
+	createAlertRuleArgsForCall []struct {
+		arg1 domain.AlertRule
+	}
+	createAlertRuleReturns struct {
+		result1 *domain.AlertRule
+		result2 error
+	}
+	createAlertRuleReturnsOnCall map[int]struct {
+		result1 *domain.AlertRule
+		result2 error
+	}
+	DeleteAlertRuleStub        func(string) error
+	deleteAlertRuleMutex       sync.RWMutex
+	deleteAlertRuleArgsForCall []struct {
+		arg1 string
+	}
+	deleteAlertRuleReturns struct {
+		result1 error
+	}
+	deleteAlertRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EvaluateAlertRulesStub        func() (int, error)
+	evaluateAlertRulesMutex       sync.RWMutex
+	evaluateAlertRulesArgsForCall []struct {
+	}
+	evaluateAlertRulesReturns struct {
+		result1 int
+		result2 error
+	}
+	evaluateAlertRulesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	GetAlertRulesStub        func() ([]domain.AlertRule, error)
+	getAlertRulesMutex       sync.RWMutex
+	getAlertRulesArgsForCall []struct {
+	}
+	getAlertRulesReturns struct {
+		result1 []domain.AlertRule
+		result2 error
+	}
+	getAlertRulesReturnsOnCall map[int]struct {
+		result1 []domain.AlertRule
+		result2 error
+	}
+	GetAlertsStub        func() ([]domain.Alert, error)
+	getAlertsMutex       sync.RWMutex
+	getAlertsArgsForCall []struct {
+	}
+	getAlertsReturns struct {
+		result1 []domain.Alert
+		result2 error
+	}
+	getAlertsReturnsOnCall map[int]struct {
+		result1 []domain.Alert
+		result2 error
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PublishStub        func(application.EventEnvelope) error
+	publishMutex       sync.RWMutex
+	publishArgsForCall []struct {
+		arg1 application.EventEnvelope
+	}
+	publishReturns struct {
+		result1 error
+	}
+	publishReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAlertService) CreateAlertRule(arg1 domain.AlertRule) (*domain.AlertRule, error) {
+	fake.createAlertRuleMutex.Lock()
+	ret, specificReturn := fake.createAlertRuleReturnsOnCall[len(fake.createAlertRuleArgsForCall)]
+	fake.createAlertRuleArgsForCall = append(fake.createAlertRuleArgsForCall, struct {
+		arg1 domain.AlertRule
+	}{arg1})
+	stub := fake.CreateAlertRuleStub
+	fakeReturns := fake.createAlertRuleReturns
+	fake.recordInvocation("CreateAlertRule", []interface{}{arg1})
+	fake.createAlertRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertService) CreateAlertRuleCallCount() int {
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	return len(fake.createAlertRuleArgsForCall)
+}
+
+func (fake *FakeAlertService) CreateAlertRuleCalls(stub func(domain.AlertRule) (*domain.AlertRule, error)) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = stub
+}
+
+func (fake *FakeAlertService) CreateAlertRuleArgsForCall(i int) domain.AlertRule {
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	argsForCall := fake.createAlertRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertService) CreateAlertRuleReturns(result1 *domain.AlertRule, result2 error) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = nil
+	fake.createAlertRuleReturns = struct {
+		result1 *domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) CreateAlertRuleReturnsOnCall(i int, result1 *domain.AlertRule, result2 error) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = nil
+	if fake.createAlertRuleReturnsOnCall == nil {
+		fake.createAlertRuleReturnsOnCall = make(map[int]struct {
+			result1 *domain.AlertRule
+			result2 error
+		})
+	}
+	fake.createAlertRuleReturnsOnCall[i] = struct {
+		result1 *domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) DeleteAlertRule(arg1 string) error {
+	fake.deleteAlertRuleMutex.Lock()
+	ret, specificReturn := fake.deleteAlertRuleReturnsOnCall[len(fake.deleteAlertRuleArgsForCall)]
+	fake.deleteAlertRuleArgsForCall = append(fake.deleteAlertRuleArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteAlertRuleStub
+	fakeReturns := fake.deleteAlertRuleReturns
+	fake.recordInvocation("DeleteAlertRule", []interface{}{arg1})
+	fake.deleteAlertRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertService) DeleteAlertRuleCallCount() int {
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	return len(fake.deleteAlertRuleArgsForCall)
+}
+
+func (fake *FakeAlertService) DeleteAlertRuleCalls(stub func(string) error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = stub
+}
+
+func (fake *FakeAlertService) DeleteAlertRuleArgsForCall(i int) string {
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	argsForCall := fake.deleteAlertRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertService) DeleteAlertRuleReturns(result1 error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = nil
+	fake.deleteAlertRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertService) DeleteAlertRuleReturnsOnCall(i int, result1 error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = nil
+	if fake.deleteAlertRuleReturnsOnCall == nil {
+		fake.deleteAlertRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAlertRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertService) EvaluateAlertRules() (int, error) {
+	fake.evaluateAlertRulesMutex.Lock()
+	ret, specificReturn := fake.evaluateAlertRulesReturnsOnCall[len(fake.evaluateAlertRulesArgsForCall)]
+	fake.evaluateAlertRulesArgsForCall = append(fake.evaluateAlertRulesArgsForCall, struct {
+	}{})
+	stub := fake.EvaluateAlertRulesStub
+	fakeReturns := fake.evaluateAlertRulesReturns
+	fake.recordInvocation("EvaluateAlertRules", []interface{}{})
+	fake.evaluateAlertRulesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertService) EvaluateAlertRulesCallCount() int {
+	fake.evaluateAlertRulesMutex.RLock()
+	defer fake.evaluateAlertRulesMutex.RUnlock()
+	return len(fake.evaluateAlertRulesArgsForCall)
+}
+
+func (fake *FakeAlertService) EvaluateAlertRulesCalls(stub func() (int, error)) {
+	fake.evaluateAlertRulesMutex.Lock()
+	defer fake.evaluateAlertRulesMutex.Unlock()
+	fake.EvaluateAlertRulesStub = stub
+}
+
+func (fake *FakeAlertService) EvaluateAlertRulesReturns(result1 int, result2 error) {
+	fake.evaluateAlertRulesMutex.Lock()
+	defer fake.evaluateAlertRulesMutex.Unlock()
+	fake.EvaluateAlertRulesStub = nil
+	fake.evaluateAlertRulesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) EvaluateAlertRulesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.evaluateAlertRulesMutex.Lock()
+	defer fake.evaluateAlertRulesMutex.Unlock()
+	fake.EvaluateAlertRulesStub = nil
+	if fake.evaluateAlertRulesReturnsOnCall == nil {
+		fake.evaluateAlertRulesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.evaluateAlertRulesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) GetAlertRules() ([]domain.AlertRule, error) {
+	fake.getAlertRulesMutex.Lock()
+	ret, specificReturn := fake.getAlertRulesReturnsOnCall[len(fake.getAlertRulesArgsForCall)]
+	fake.getAlertRulesArgsForCall = append(fake.getAlertRulesArgsForCall, struct {
+	}{})
+	stub := fake.GetAlertRulesStub
+	fakeReturns := fake.getAlertRulesReturns
+	fake.recordInvocation("GetAlertRules", []interface{}{})
+	fake.getAlertRulesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertService) GetAlertRulesCallCount() int {
+	fake.getAlertRulesMutex.RLock()
+	defer fake.getAlertRulesMutex.RUnlock()
+	return len(fake.getAlertRulesArgsForCall)
+}
+
+func (fake *FakeAlertService) GetAlertRulesCalls(stub func() ([]domain.AlertRule, error)) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = stub
+}
+
+func (fake *FakeAlertService) GetAlertRulesReturns(result1 []domain.AlertRule, result2 error) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = nil
+	fake.getAlertRulesReturns = struct {
+		result1 []domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) GetAlertRulesReturnsOnCall(i int, result1 []domain.AlertRule, result2 error) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = nil
+	if fake.getAlertRulesReturnsOnCall == nil {
+		fake.getAlertRulesReturnsOnCall = make(map[int]struct {
+			result1 []domain.AlertRule
+			result2 error
+		})
+	}
+	fake.getAlertRulesReturnsOnCall[i] = struct {
+		result1 []domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) GetAlerts() ([]domain.Alert, error) {
+	fake.getAlertsMutex.Lock()
+	ret, specificReturn := fake.getAlertsReturnsOnCall[len(fake.getAlertsArgsForCall)]
+	fake.getAlertsArgsForCall = append(fake.getAlertsArgsForCall, struct {
+	}{})
+	stub := fake.GetAlertsStub
+	fakeReturns := fake.getAlertsReturns
+	fake.recordInvocation("GetAlerts", []interface{}{})
+	fake.getAlertsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertService) GetAlertsCallCount() int {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	return len(fake.getAlertsArgsForCall)
+}
+
+func (fake *FakeAlertService) GetAlertsCalls(stub func() ([]domain.Alert, error)) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = stub
+}
+
+func (fake *FakeAlertService) GetAlertsReturns(result1 []domain.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	fake.getAlertsReturns = struct {
+		result1 []domain.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) GetAlertsReturnsOnCall(i int, result1 []domain.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	if fake.getAlertsReturnsOnCall == nil {
+		fake.getAlertsReturnsOnCall = make(map[int]struct {
+			result1 []domain.Alert
+			result2 error
+		})
+	}
+	fake.getAlertsReturnsOnCall[i] = struct {
+		result1 []domain.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertService) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertService) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeAlertService) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeAlertService) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAlertService) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAlertService) Publish(arg1 application.EventEnvelope) error {
+	fake.publishMutex.Lock()
+	ret, specificReturn := fake.publishReturnsOnCall[len(fake.publishArgsForCall)]
+	fake.publishArgsForCall = append(fake.publishArgsForCall, struct {
+		arg1 application.EventEnvelope
+	}{arg1})
+	stub := fake.PublishStub
+	fakeReturns := fake.publishReturns
+	fake.recordInvocation("Publish", []interface{}{arg1})
+	fake.publishMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertService) PublishCallCount() int {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	return len(fake.publishArgsForCall)
+}
+
+func (fake *FakeAlertService) PublishCalls(stub func(application.EventEnvelope) error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = stub
+}
+
+func (fake *FakeAlertService) PublishArgsForCall(i int) application.EventEnvelope {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	argsForCall := fake.publishArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertService) PublishReturns(result1 error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = nil
+	fake.publishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertService) PublishReturnsOnCall(i int, result1 error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = nil
+	if fake.publishReturnsOnCall == nil {
+		fake.publishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	fake.evaluateAlertRulesMutex.RLock()
+	defer fake.evaluateAlertRulesMutex.RUnlock()
+	fake.getAlertRulesMutex.RLock()
+	defer fake.getAlertRulesMutex.RUnlock()
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAlertService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.AlertService = new(FakeAlertService)
@@ -0,0 +1,260 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeConsumptionLoader struct {
+	LoadConsumptionStub        func(application.ConsumptionKey) func() (*application.Serializer, error)
+	loadConsumptionMutex       sync.RWMutex
+	loadConsumptionArgsForCall []struct {
+		arg1 application.ConsumptionKey
+	}
+	loadConsumptionReturns struct {
+		result1 func() (*application.Serializer, error)
+	}
+	loadConsumptionReturnsOnCall map[int]struct {
+		result1 func() (*application.Serializer, error)
+	}
+	LoadMeterSettingStub        func(int) func() (*domain.MeterSetting, error)
+	loadMeterSettingMutex       sync.RWMutex
+	loadMeterSettingArgsForCall []struct {
+		arg1 int
+	}
+	loadMeterSettingReturns struct {
+		result1 func() (*domain.MeterSetting, error)
+	}
+	loadMeterSettingReturnsOnCall map[int]struct {
+		result1 func() (*domain.MeterSetting, error)
+	}
+	LoadReadingsStub        func(application.ReadingsKey) func() ([]domain.UserConsumption, error)
+	loadReadingsMutex       sync.RWMutex
+	loadReadingsArgsForCall []struct {
+		arg1 application.ReadingsKey
+	}
+	loadReadingsReturns struct {
+		result1 func() ([]domain.UserConsumption, error)
+	}
+	loadReadingsReturnsOnCall map[int]struct {
+		result1 func() ([]domain.UserConsumption, error)
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumption(arg1 application.ConsumptionKey) func() (*application.Serializer, error) {
+	fake.loadConsumptionMutex.Lock()
+	ret, specificReturn := fake.loadConsumptionReturnsOnCall[len(fake.loadConsumptionArgsForCall)]
+	fake.loadConsumptionArgsForCall = append(fake.loadConsumptionArgsForCall, struct {
+		arg1 application.ConsumptionKey
+	}{arg1})
+	stub := fake.LoadConsumptionStub
+	fakeReturns := fake.loadConsumptionReturns
+	fake.recordInvocation("LoadConsumption", []interface{}{arg1})
+	fake.loadConsumptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumptionCallCount() int {
+	fake.loadConsumptionMutex.RLock()
+	defer fake.loadConsumptionMutex.RUnlock()
+	return len(fake.loadConsumptionArgsForCall)
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumptionCalls(stub func(application.ConsumptionKey) func() (*application.Serializer, error)) {
+	fake.loadConsumptionMutex.Lock()
+	defer fake.loadConsumptionMutex.Unlock()
+	fake.LoadConsumptionStub = stub
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumptionArgsForCall(i int) application.ConsumptionKey {
+	fake.loadConsumptionMutex.RLock()
+	defer fake.loadConsumptionMutex.RUnlock()
+	argsForCall := fake.loadConsumptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumptionReturns(result1 func() (*application.Serializer, error)) {
+	fake.loadConsumptionMutex.Lock()
+	defer fake.loadConsumptionMutex.Unlock()
+	fake.LoadConsumptionStub = nil
+	fake.loadConsumptionReturns = struct {
+		result1 func() (*application.Serializer, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) LoadConsumptionReturnsOnCall(i int, result1 func() (*application.Serializer, error)) {
+	fake.loadConsumptionMutex.Lock()
+	defer fake.loadConsumptionMutex.Unlock()
+	fake.LoadConsumptionStub = nil
+	if fake.loadConsumptionReturnsOnCall == nil {
+		fake.loadConsumptionReturnsOnCall = make(map[int]struct {
+			result1 func() (*application.Serializer, error)
+		})
+	}
+	fake.loadConsumptionReturnsOnCall[i] = struct {
+		result1 func() (*application.Serializer, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSetting(arg1 int) func() (*domain.MeterSetting, error) {
+	fake.loadMeterSettingMutex.Lock()
+	ret, specificReturn := fake.loadMeterSettingReturnsOnCall[len(fake.loadMeterSettingArgsForCall)]
+	fake.loadMeterSettingArgsForCall = append(fake.loadMeterSettingArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.LoadMeterSettingStub
+	fakeReturns := fake.loadMeterSettingReturns
+	fake.recordInvocation("LoadMeterSetting", []interface{}{arg1})
+	fake.loadMeterSettingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSettingCallCount() int {
+	fake.loadMeterSettingMutex.RLock()
+	defer fake.loadMeterSettingMutex.RUnlock()
+	return len(fake.loadMeterSettingArgsForCall)
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSettingCalls(stub func(int) func() (*domain.MeterSetting, error)) {
+	fake.loadMeterSettingMutex.Lock()
+	defer fake.loadMeterSettingMutex.Unlock()
+	fake.LoadMeterSettingStub = stub
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSettingArgsForCall(i int) int {
+	fake.loadMeterSettingMutex.RLock()
+	defer fake.loadMeterSettingMutex.RUnlock()
+	argsForCall := fake.loadMeterSettingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSettingReturns(result1 func() (*domain.MeterSetting, error)) {
+	fake.loadMeterSettingMutex.Lock()
+	defer fake.loadMeterSettingMutex.Unlock()
+	fake.LoadMeterSettingStub = nil
+	fake.loadMeterSettingReturns = struct {
+		result1 func() (*domain.MeterSetting, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) LoadMeterSettingReturnsOnCall(i int, result1 func() (*domain.MeterSetting, error)) {
+	fake.loadMeterSettingMutex.Lock()
+	defer fake.loadMeterSettingMutex.Unlock()
+	fake.LoadMeterSettingStub = nil
+	if fake.loadMeterSettingReturnsOnCall == nil {
+		fake.loadMeterSettingReturnsOnCall = make(map[int]struct {
+			result1 func() (*domain.MeterSetting, error)
+		})
+	}
+	fake.loadMeterSettingReturnsOnCall[i] = struct {
+		result1 func() (*domain.MeterSetting, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) LoadReadings(arg1 application.ReadingsKey) func() ([]domain.UserConsumption, error) {
+	fake.loadReadingsMutex.Lock()
+	ret, specificReturn := fake.loadReadingsReturnsOnCall[len(fake.loadReadingsArgsForCall)]
+	fake.loadReadingsArgsForCall = append(fake.loadReadingsArgsForCall, struct {
+		arg1 application.ReadingsKey
+	}{arg1})
+	stub := fake.LoadReadingsStub
+	fakeReturns := fake.loadReadingsReturns
+	fake.recordInvocation("LoadReadings", []interface{}{arg1})
+	fake.loadReadingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeConsumptionLoader) LoadReadingsCallCount() int {
+	fake.loadReadingsMutex.RLock()
+	defer fake.loadReadingsMutex.RUnlock()
+	return len(fake.loadReadingsArgsForCall)
+}
+
+func (fake *FakeConsumptionLoader) LoadReadingsCalls(stub func(application.ReadingsKey) func() ([]domain.UserConsumption, error)) {
+	fake.loadReadingsMutex.Lock()
+	defer fake.loadReadingsMutex.Unlock()
+	fake.LoadReadingsStub = stub
+}
+
+func (fake *FakeConsumptionLoader) LoadReadingsArgsForCall(i int) application.ReadingsKey {
+	fake.loadReadingsMutex.RLock()
+	defer fake.loadReadingsMutex.RUnlock()
+	argsForCall := fake.loadReadingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeConsumptionLoader) LoadReadingsReturns(result1 func() ([]domain.UserConsumption, error)) {
+	fake.loadReadingsMutex.Lock()
+	defer fake.loadReadingsMutex.Unlock()
+	fake.LoadReadingsStub = nil
+	fake.loadReadingsReturns = struct {
+		result1 func() ([]domain.UserConsumption, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) LoadReadingsReturnsOnCall(i int, result1 func() ([]domain.UserConsumption, error)) {
+	fake.loadReadingsMutex.Lock()
+	defer fake.loadReadingsMutex.Unlock()
+	fake.LoadReadingsStub = nil
+	if fake.loadReadingsReturnsOnCall == nil {
+		fake.loadReadingsReturnsOnCall = make(map[int]struct {
+			result1 func() ([]domain.UserConsumption, error)
+		})
+	}
+	fake.loadReadingsReturnsOnCall[i] = struct {
+		result1 func() ([]domain.UserConsumption, error)
+	}{result1}
+}
+
+func (fake *FakeConsumptionLoader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.loadConsumptionMutex.RLock()
+	defer fake.loadConsumptionMutex.RUnlock()
+	fake.loadMeterSettingMutex.RLock()
+	defer fake.loadMeterSettingMutex.RUnlock()
+	fake.loadReadingsMutex.RLock()
+	defer fake.loadReadingsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeConsumptionLoader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.ConsumptionLoader = new(FakeConsumptionLoader)
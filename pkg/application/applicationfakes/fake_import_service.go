@@ -0,0 +1,271 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeImportService struct {
+	GetImportReadingsStub        func(string) (*domain.Import, []domain.UserConsumption, error)
+	getImportReadingsMutex       sync.RWMutex
+	getImportReadingsArgsForCall []struct {
+		arg1 string
+	}
+	getImportReadingsReturns struct {
+		result1 *domain.Import
+		result2 []domain.UserConsumption
+		result3 error
+	}
+	getImportReadingsReturnsOnCall map[int]struct {
+		result1 *domain.Import
+		result2 []domain.UserConsumption
+		result3 error
+	}
+	GetImportsStub        func() ([]domain.Import, error)
+	getImportsMutex       sync.RWMutex
+	getImportsArgsForCall []struct {
+	}
+	getImportsReturns struct {
+		result1 []domain.Import
+		result2 error
+	}
+	getImportsReturnsOnCall map[int]struct {
+		result1 []domain.Import
+		result2 error
+	}
+	RollbackImportStub        func(string) (*domain.Import, error)
+	rollbackImportMutex       sync.RWMutex
+	rollbackImportArgsForCall []struct {
+		arg1 string
+	}
+	rollbackImportReturns struct {
+		result1 *domain.Import
+		result2 error
+	}
+	rollbackImportReturnsOnCall map[int]struct {
+		result1 *domain.Import
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImportService) GetImportReadings(arg1 string) (*domain.Import, []domain.UserConsumption, error) {
+	fake.getImportReadingsMutex.Lock()
+	ret, specificReturn := fake.getImportReadingsReturnsOnCall[len(fake.getImportReadingsArgsForCall)]
+	fake.getImportReadingsArgsForCall = append(fake.getImportReadingsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetImportReadingsStub
+	fakeReturns := fake.getImportReadingsReturns
+	fake.recordInvocation("GetImportReadings", []interface{}{arg1})
+	fake.getImportReadingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImportService) GetImportReadingsCallCount() int {
+	fake.getImportReadingsMutex.RLock()
+	defer fake.getImportReadingsMutex.RUnlock()
+	return len(fake.getImportReadingsArgsForCall)
+}
+
+func (fake *FakeImportService) GetImportReadingsCalls(stub func(string) (*domain.Import, []domain.UserConsumption, error)) {
+	fake.getImportReadingsMutex.Lock()
+	defer fake.getImportReadingsMutex.Unlock()
+	fake.GetImportReadingsStub = stub
+}
+
+func (fake *FakeImportService) GetImportReadingsArgsForCall(i int) string {
+	fake.getImportReadingsMutex.RLock()
+	defer fake.getImportReadingsMutex.RUnlock()
+	argsForCall := fake.getImportReadingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportService) GetImportReadingsReturns(result1 *domain.Import, result2 []domain.UserConsumption, result3 error) {
+	fake.getImportReadingsMutex.Lock()
+	defer fake.getImportReadingsMutex.Unlock()
+	fake.GetImportReadingsStub = nil
+	fake.getImportReadingsReturns = struct {
+		result1 *domain.Import
+		result2 []domain.UserConsumption
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImportService) GetImportReadingsReturnsOnCall(i int, result1 *domain.Import, result2 []domain.UserConsumption, result3 error) {
+	fake.getImportReadingsMutex.Lock()
+	defer fake.getImportReadingsMutex.Unlock()
+	fake.GetImportReadingsStub = nil
+	if fake.getImportReadingsReturnsOnCall == nil {
+		fake.getImportReadingsReturnsOnCall = make(map[int]struct {
+			result1 *domain.Import
+			result2 []domain.UserConsumption
+			result3 error
+		})
+	}
+	fake.getImportReadingsReturnsOnCall[i] = struct {
+		result1 *domain.Import
+		result2 []domain.UserConsumption
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImportService) GetImports() ([]domain.Import, error) {
+	fake.getImportsMutex.Lock()
+	ret, specificReturn := fake.getImportsReturnsOnCall[len(fake.getImportsArgsForCall)]
+	fake.getImportsArgsForCall = append(fake.getImportsArgsForCall, struct {
+	}{})
+	stub := fake.GetImportsStub
+	fakeReturns := fake.getImportsReturns
+	fake.recordInvocation("GetImports", []interface{}{})
+	fake.getImportsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportService) GetImportsCallCount() int {
+	fake.getImportsMutex.RLock()
+	defer fake.getImportsMutex.RUnlock()
+	return len(fake.getImportsArgsForCall)
+}
+
+func (fake *FakeImportService) GetImportsCalls(stub func() ([]domain.Import, error)) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = stub
+}
+
+func (fake *FakeImportService) GetImportsReturns(result1 []domain.Import, result2 error) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = nil
+	fake.getImportsReturns = struct {
+		result1 []domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportService) GetImportsReturnsOnCall(i int, result1 []domain.Import, result2 error) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = nil
+	if fake.getImportsReturnsOnCall == nil {
+		fake.getImportsReturnsOnCall = make(map[int]struct {
+			result1 []domain.Import
+			result2 error
+		})
+	}
+	fake.getImportsReturnsOnCall[i] = struct {
+		result1 []domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportService) RollbackImport(arg1 string) (*domain.Import, error) {
+	fake.rollbackImportMutex.Lock()
+	ret, specificReturn := fake.rollbackImportReturnsOnCall[len(fake.rollbackImportArgsForCall)]
+	fake.rollbackImportArgsForCall = append(fake.rollbackImportArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RollbackImportStub
+	fakeReturns := fake.rollbackImportReturns
+	fake.recordInvocation("RollbackImport", []interface{}{arg1})
+	fake.rollbackImportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportService) RollbackImportCallCount() int {
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	return len(fake.rollbackImportArgsForCall)
+}
+
+func (fake *FakeImportService) RollbackImportCalls(stub func(string) (*domain.Import, error)) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = stub
+}
+
+func (fake *FakeImportService) RollbackImportArgsForCall(i int) string {
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	argsForCall := fake.rollbackImportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportService) RollbackImportReturns(result1 *domain.Import, result2 error) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = nil
+	fake.rollbackImportReturns = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportService) RollbackImportReturnsOnCall(i int, result1 *domain.Import, result2 error) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = nil
+	if fake.rollbackImportReturnsOnCall == nil {
+		fake.rollbackImportReturnsOnCall = make(map[int]struct {
+			result1 *domain.Import
+			result2 error
+		})
+	}
+	fake.rollbackImportReturnsOnCall[i] = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getImportReadingsMutex.RLock()
+	defer fake.getImportReadingsMutex.RUnlock()
+	fake.getImportsMutex.RLock()
+	defer fake.getImportsMutex.RUnlock()
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImportService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.ImportService = new(FakeImportService)
@@ -0,0 +1,200 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeLiveConsumptionBroker struct {
+	PublishReadingsStub        func([]*domain.UserConsumption)
+	publishReadingsMutex       sync.RWMutex
+	publishReadingsArgsForCall []struct {
+		arg1 []*domain.UserConsumption
+	}
+	SubscribeStub        func([]int) *application.LiveConsumptionSubscription
+	subscribeMutex       sync.RWMutex
+	subscribeArgsForCall []struct {
+		arg1 []int
+	}
+	subscribeReturns struct {
+		result1 *application.LiveConsumptionSubscription
+	}
+	subscribeReturnsOnCall map[int]struct {
+		result1 *application.LiveConsumptionSubscription
+	}
+	UnsubscribeStub        func(*application.LiveConsumptionSubscription)
+	unsubscribeMutex       sync.RWMutex
+	unsubscribeArgsForCall []struct {
+		arg1 *application.LiveConsumptionSubscription
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLiveConsumptionBroker) PublishReadings(arg1 []*domain.UserConsumption) {
+	var arg1Copy []*domain.UserConsumption
+	if arg1 != nil {
+		arg1Copy = make([]*domain.UserConsumption, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.publishReadingsMutex.Lock()
+	fake.publishReadingsArgsForCall = append(fake.publishReadingsArgsForCall, struct {
+		arg1 []*domain.UserConsumption
+	}{arg1Copy})
+	stub := fake.PublishReadingsStub
+	fake.recordInvocation("PublishReadings", []interface{}{arg1Copy})
+	fake.publishReadingsMutex.Unlock()
+	if stub != nil {
+		fake.PublishReadingsStub(arg1)
+	}
+}
+
+func (fake *FakeLiveConsumptionBroker) PublishReadingsCallCount() int {
+	fake.publishReadingsMutex.RLock()
+	defer fake.publishReadingsMutex.RUnlock()
+	return len(fake.publishReadingsArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionBroker) PublishReadingsCalls(stub func([]*domain.UserConsumption)) {
+	fake.publishReadingsMutex.Lock()
+	defer fake.publishReadingsMutex.Unlock()
+	fake.PublishReadingsStub = stub
+}
+
+func (fake *FakeLiveConsumptionBroker) PublishReadingsArgsForCall(i int) []*domain.UserConsumption {
+	fake.publishReadingsMutex.RLock()
+	defer fake.publishReadingsMutex.RUnlock()
+	argsForCall := fake.publishReadingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLiveConsumptionBroker) Subscribe(arg1 []int) *application.LiveConsumptionSubscription {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.subscribeMutex.Lock()
+	ret, specificReturn := fake.subscribeReturnsOnCall[len(fake.subscribeArgsForCall)]
+	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct {
+		arg1 []int
+	}{arg1Copy})
+	stub := fake.SubscribeStub
+	fakeReturns := fake.subscribeReturns
+	fake.recordInvocation("Subscribe", []interface{}{arg1Copy})
+	fake.subscribeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLiveConsumptionBroker) SubscribeCallCount() int {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return len(fake.subscribeArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionBroker) SubscribeCalls(stub func([]int) *application.LiveConsumptionSubscription) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = stub
+}
+
+func (fake *FakeLiveConsumptionBroker) SubscribeArgsForCall(i int) []int {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	argsForCall := fake.subscribeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLiveConsumptionBroker) SubscribeReturns(result1 *application.LiveConsumptionSubscription) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	fake.subscribeReturns = struct {
+		result1 *application.LiveConsumptionSubscription
+	}{result1}
+}
+
+func (fake *FakeLiveConsumptionBroker) SubscribeReturnsOnCall(i int, result1 *application.LiveConsumptionSubscription) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	if fake.subscribeReturnsOnCall == nil {
+		fake.subscribeReturnsOnCall = make(map[int]struct {
+			result1 *application.LiveConsumptionSubscription
+		})
+	}
+	fake.subscribeReturnsOnCall[i] = struct {
+		result1 *application.LiveConsumptionSubscription
+	}{result1}
+}
+
+func (fake *FakeLiveConsumptionBroker) Unsubscribe(arg1 *application.LiveConsumptionSubscription) {
+	fake.unsubscribeMutex.Lock()
+	fake.unsubscribeArgsForCall = append(fake.unsubscribeArgsForCall, struct {
+		arg1 *application.LiveConsumptionSubscription
+	}{arg1})
+	stub := fake.UnsubscribeStub
+	fake.recordInvocation("Unsubscribe", []interface{}{arg1})
+	fake.unsubscribeMutex.Unlock()
+	if stub != nil {
+		fake.UnsubscribeStub(arg1)
+	}
+}
+
+func (fake *FakeLiveConsumptionBroker) UnsubscribeCallCount() int {
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	return len(fake.unsubscribeArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionBroker) UnsubscribeCalls(stub func(*application.LiveConsumptionSubscription)) {
+	fake.unsubscribeMutex.Lock()
+	defer fake.unsubscribeMutex.Unlock()
+	fake.UnsubscribeStub = stub
+}
+
+func (fake *FakeLiveConsumptionBroker) UnsubscribeArgsForCall(i int) *application.LiveConsumptionSubscription {
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	argsForCall := fake.unsubscribeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLiveConsumptionBroker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.publishReadingsMutex.RLock()
+	defer fake.publishReadingsMutex.RUnlock()
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLiveConsumptionBroker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.LiveConsumptionBroker = new(FakeLiveConsumptionBroker)
@@ -0,0 +1,236 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+)
+
+type FakeLiveConsumptionService struct {
+	GetCurrentPeriodConsumptionStub        func(*application.LiveConsumptionSubscription) ([]application.Serializer, error)
+	getCurrentPeriodConsumptionMutex       sync.RWMutex
+	getCurrentPeriodConsumptionArgsForCall []struct {
+		arg1 *application.LiveConsumptionSubscription
+	}
+	getCurrentPeriodConsumptionReturns struct {
+		result1 []application.Serializer
+		result2 error
+	}
+	getCurrentPeriodConsumptionReturnsOnCall map[int]struct {
+		result1 []application.Serializer
+		result2 error
+	}
+	SubscribeStub        func(string, string) (*application.LiveConsumptionSubscription, error)
+	subscribeMutex       sync.RWMutex
+	subscribeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	subscribeReturns struct {
+		result1 *application.LiveConsumptionSubscription
+		result2 error
+	}
+	subscribeReturnsOnCall map[int]struct {
+		result1 *application.LiveConsumptionSubscription
+		result2 error
+	}
+	UnsubscribeStub        func(*application.LiveConsumptionSubscription)
+	unsubscribeMutex       sync.RWMutex
+	unsubscribeArgsForCall []struct {
+		arg1 *application.LiveConsumptionSubscription
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumption(arg1 *application.LiveConsumptionSubscription) ([]application.Serializer, error) {
+	fake.getCurrentPeriodConsumptionMutex.Lock()
+	ret, specificReturn := fake.getCurrentPeriodConsumptionReturnsOnCall[len(fake.getCurrentPeriodConsumptionArgsForCall)]
+	fake.getCurrentPeriodConsumptionArgsForCall = append(fake.getCurrentPeriodConsumptionArgsForCall, struct {
+		arg1 *application.LiveConsumptionSubscription
+	}{arg1})
+	stub := fake.GetCurrentPeriodConsumptionStub
+	fakeReturns := fake.getCurrentPeriodConsumptionReturns
+	fake.recordInvocation("GetCurrentPeriodConsumption", []interface{}{arg1})
+	fake.getCurrentPeriodConsumptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumptionCallCount() int {
+	fake.getCurrentPeriodConsumptionMutex.RLock()
+	defer fake.getCurrentPeriodConsumptionMutex.RUnlock()
+	return len(fake.getCurrentPeriodConsumptionArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumptionCalls(stub func(*application.LiveConsumptionSubscription) ([]application.Serializer, error)) {
+	fake.getCurrentPeriodConsumptionMutex.Lock()
+	defer fake.getCurrentPeriodConsumptionMutex.Unlock()
+	fake.GetCurrentPeriodConsumptionStub = stub
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumptionArgsForCall(i int) *application.LiveConsumptionSubscription {
+	fake.getCurrentPeriodConsumptionMutex.RLock()
+	defer fake.getCurrentPeriodConsumptionMutex.RUnlock()
+	argsForCall := fake.getCurrentPeriodConsumptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumptionReturns(result1 []application.Serializer, result2 error) {
+	fake.getCurrentPeriodConsumptionMutex.Lock()
+	defer fake.getCurrentPeriodConsumptionMutex.Unlock()
+	fake.GetCurrentPeriodConsumptionStub = nil
+	fake.getCurrentPeriodConsumptionReturns = struct {
+		result1 []application.Serializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLiveConsumptionService) GetCurrentPeriodConsumptionReturnsOnCall(i int, result1 []application.Serializer, result2 error) {
+	fake.getCurrentPeriodConsumptionMutex.Lock()
+	defer fake.getCurrentPeriodConsumptionMutex.Unlock()
+	fake.GetCurrentPeriodConsumptionStub = nil
+	if fake.getCurrentPeriodConsumptionReturnsOnCall == nil {
+		fake.getCurrentPeriodConsumptionReturnsOnCall = make(map[int]struct {
+			result1 []application.Serializer
+			result2 error
+		})
+	}
+	fake.getCurrentPeriodConsumptionReturnsOnCall[i] = struct {
+		result1 []application.Serializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLiveConsumptionService) Subscribe(arg1 string, arg2 string) (*application.LiveConsumptionSubscription, error) {
+	fake.subscribeMutex.Lock()
+	ret, specificReturn := fake.subscribeReturnsOnCall[len(fake.subscribeArgsForCall)]
+	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SubscribeStub
+	fakeReturns := fake.subscribeReturns
+	fake.recordInvocation("Subscribe", []interface{}{arg1, arg2})
+	fake.subscribeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLiveConsumptionService) SubscribeCallCount() int {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return len(fake.subscribeArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionService) SubscribeCalls(stub func(string, string) (*application.LiveConsumptionSubscription, error)) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = stub
+}
+
+func (fake *FakeLiveConsumptionService) SubscribeArgsForCall(i int) (string, string) {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	argsForCall := fake.subscribeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLiveConsumptionService) SubscribeReturns(result1 *application.LiveConsumptionSubscription, result2 error) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	fake.subscribeReturns = struct {
+		result1 *application.LiveConsumptionSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLiveConsumptionService) SubscribeReturnsOnCall(i int, result1 *application.LiveConsumptionSubscription, result2 error) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	if fake.subscribeReturnsOnCall == nil {
+		fake.subscribeReturnsOnCall = make(map[int]struct {
+			result1 *application.LiveConsumptionSubscription
+			result2 error
+		})
+	}
+	fake.subscribeReturnsOnCall[i] = struct {
+		result1 *application.LiveConsumptionSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLiveConsumptionService) Unsubscribe(arg1 *application.LiveConsumptionSubscription) {
+	fake.unsubscribeMutex.Lock()
+	fake.unsubscribeArgsForCall = append(fake.unsubscribeArgsForCall, struct {
+		arg1 *application.LiveConsumptionSubscription
+	}{arg1})
+	stub := fake.UnsubscribeStub
+	fake.recordInvocation("Unsubscribe", []interface{}{arg1})
+	fake.unsubscribeMutex.Unlock()
+	if stub != nil {
+		fake.UnsubscribeStub(arg1)
+	}
+}
+
+func (fake *FakeLiveConsumptionService) UnsubscribeCallCount() int {
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	return len(fake.unsubscribeArgsForCall)
+}
+
+func (fake *FakeLiveConsumptionService) UnsubscribeCalls(stub func(*application.LiveConsumptionSubscription)) {
+	fake.unsubscribeMutex.Lock()
+	defer fake.unsubscribeMutex.Unlock()
+	fake.UnsubscribeStub = stub
+}
+
+func (fake *FakeLiveConsumptionService) UnsubscribeArgsForCall(i int) *application.LiveConsumptionSubscription {
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	argsForCall := fake.unsubscribeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLiveConsumptionService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCurrentPeriodConsumptionMutex.RLock()
+	defer fake.getCurrentPeriodConsumptionMutex.RUnlock()
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	fake.unsubscribeMutex.RLock()
+	defer fake.unsubscribeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLiveConsumptionService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.LiveConsumptionService = new(FakeLiveConsumptionService)
@@ -0,0 +1,250 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+)
+
+type FakeLiveReadingService struct {
+	FlushStub        func() error
+	flushMutex       sync.RWMutex
+	flushArgsForCall []struct {
+	}
+	flushReturns struct {
+		result1 error
+	}
+	flushReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HandleMessageStub        func(string, []byte, func()) error
+	handleMessageMutex       sync.RWMutex
+	handleMessageArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 func()
+	}
+	handleMessageReturns struct {
+		result1 error
+	}
+	handleMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SubscriptionTopicStub        func() string
+	subscriptionTopicMutex       sync.RWMutex
+	subscriptionTopicArgsForCall []struct {
+	}
+	subscriptionTopicReturns struct {
+		result1 string
+	}
+	subscriptionTopicReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLiveReadingService) Flush() error {
+	fake.flushMutex.Lock()
+	ret, specificReturn := fake.flushReturnsOnCall[len(fake.flushArgsForCall)]
+	fake.flushArgsForCall = append(fake.flushArgsForCall, struct {
+	}{})
+	stub := fake.FlushStub
+	fakeReturns := fake.flushReturns
+	fake.recordInvocation("Flush", []interface{}{})
+	fake.flushMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLiveReadingService) FlushCallCount() int {
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	return len(fake.flushArgsForCall)
+}
+
+func (fake *FakeLiveReadingService) FlushCalls(stub func() error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = stub
+}
+
+func (fake *FakeLiveReadingService) FlushReturns(result1 error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = nil
+	fake.flushReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) FlushReturnsOnCall(i int, result1 error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = nil
+	if fake.flushReturnsOnCall == nil {
+		fake.flushReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.flushReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) HandleMessage(arg1 string, arg2 []byte, arg3 func()) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.handleMessageMutex.Lock()
+	ret, specificReturn := fake.handleMessageReturnsOnCall[len(fake.handleMessageArgsForCall)]
+	fake.handleMessageArgsForCall = append(fake.handleMessageArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 func()
+	}{arg1, arg2Copy, arg3})
+	stub := fake.HandleMessageStub
+	fakeReturns := fake.handleMessageReturns
+	fake.recordInvocation("HandleMessage", []interface{}{arg1, arg2Copy, arg3})
+	fake.handleMessageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLiveReadingService) HandleMessageCallCount() int {
+	fake.handleMessageMutex.RLock()
+	defer fake.handleMessageMutex.RUnlock()
+	return len(fake.handleMessageArgsForCall)
+}
+
+func (fake *FakeLiveReadingService) HandleMessageCalls(stub func(string, []byte, func()) error) {
+	fake.handleMessageMutex.Lock()
+	defer fake.handleMessageMutex.Unlock()
+	fake.HandleMessageStub = stub
+}
+
+func (fake *FakeLiveReadingService) HandleMessageArgsForCall(i int) (string, []byte, func()) {
+	fake.handleMessageMutex.RLock()
+	defer fake.handleMessageMutex.RUnlock()
+	argsForCall := fake.handleMessageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLiveReadingService) HandleMessageReturns(result1 error) {
+	fake.handleMessageMutex.Lock()
+	defer fake.handleMessageMutex.Unlock()
+	fake.HandleMessageStub = nil
+	fake.handleMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) HandleMessageReturnsOnCall(i int, result1 error) {
+	fake.handleMessageMutex.Lock()
+	defer fake.handleMessageMutex.Unlock()
+	fake.HandleMessageStub = nil
+	if fake.handleMessageReturnsOnCall == nil {
+		fake.handleMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.handleMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) SubscriptionTopic() string {
+	fake.subscriptionTopicMutex.Lock()
+	ret, specificReturn := fake.subscriptionTopicReturnsOnCall[len(fake.subscriptionTopicArgsForCall)]
+	fake.subscriptionTopicArgsForCall = append(fake.subscriptionTopicArgsForCall, struct {
+	}{})
+	stub := fake.SubscriptionTopicStub
+	fakeReturns := fake.subscriptionTopicReturns
+	fake.recordInvocation("SubscriptionTopic", []interface{}{})
+	fake.subscriptionTopicMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLiveReadingService) SubscriptionTopicCallCount() int {
+	fake.subscriptionTopicMutex.RLock()
+	defer fake.subscriptionTopicMutex.RUnlock()
+	return len(fake.subscriptionTopicArgsForCall)
+}
+
+func (fake *FakeLiveReadingService) SubscriptionTopicCalls(stub func() string) {
+	fake.subscriptionTopicMutex.Lock()
+	defer fake.subscriptionTopicMutex.Unlock()
+	fake.SubscriptionTopicStub = stub
+}
+
+func (fake *FakeLiveReadingService) SubscriptionTopicReturns(result1 string) {
+	fake.subscriptionTopicMutex.Lock()
+	defer fake.subscriptionTopicMutex.Unlock()
+	fake.SubscriptionTopicStub = nil
+	fake.subscriptionTopicReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) SubscriptionTopicReturnsOnCall(i int, result1 string) {
+	fake.subscriptionTopicMutex.Lock()
+	defer fake.subscriptionTopicMutex.Unlock()
+	fake.SubscriptionTopicStub = nil
+	if fake.subscriptionTopicReturnsOnCall == nil {
+		fake.subscriptionTopicReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.subscriptionTopicReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeLiveReadingService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	fake.handleMessageMutex.RLock()
+	defer fake.handleMessageMutex.RUnlock()
+	fake.subscriptionTopicMutex.RLock()
+	defer fake.subscriptionTopicMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLiveReadingService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.LiveReadingService = new(FakeLiveReadingService)
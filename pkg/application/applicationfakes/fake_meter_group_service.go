@@ -0,0 +1,280 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeMeterGroupService struct {
+	CreateMeterGroupStub        func(string, *uint, []int) (*domain.MeterGroup, error)
+	createMeterGroupMutex       sync.RWMutex
+	createMeterGroupArgsForCall []struct {
+		arg1 string
+		arg2 *uint
+		arg3 []int
+	}
+	createMeterGroupReturns struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}
+	createMeterGroupReturnsOnCall map[int]struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}
+	GetMeterGroupByIDStub        func(string) (*domain.MeterGroup, []int, error)
+	getMeterGroupByIDMutex       sync.RWMutex
+	getMeterGroupByIDArgsForCall []struct {
+		arg1 string
+	}
+	getMeterGroupByIDReturns struct {
+		result1 *domain.MeterGroup
+		result2 []int
+		result3 error
+	}
+	getMeterGroupByIDReturnsOnCall map[int]struct {
+		result1 *domain.MeterGroup
+		result2 []int
+		result3 error
+	}
+	GetMeterGroupsStub        func() ([]domain.MeterGroup, error)
+	getMeterGroupsMutex       sync.RWMutex
+	getMeterGroupsArgsForCall []struct {
+	}
+	getMeterGroupsReturns struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	getMeterGroupsReturnsOnCall map[int]struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroup(arg1 string, arg2 *uint, arg3 []int) (*domain.MeterGroup, error) {
+	var arg3Copy []int
+	if arg3 != nil {
+		arg3Copy = make([]int, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.createMeterGroupMutex.Lock()
+	ret, specificReturn := fake.createMeterGroupReturnsOnCall[len(fake.createMeterGroupArgsForCall)]
+	fake.createMeterGroupArgsForCall = append(fake.createMeterGroupArgsForCall, struct {
+		arg1 string
+		arg2 *uint
+		arg3 []int
+	}{arg1, arg2, arg3Copy})
+	stub := fake.CreateMeterGroupStub
+	fakeReturns := fake.createMeterGroupReturns
+	fake.recordInvocation("CreateMeterGroup", []interface{}{arg1, arg2, arg3Copy})
+	fake.createMeterGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroupCallCount() int {
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	return len(fake.createMeterGroupArgsForCall)
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroupCalls(stub func(string, *uint, []int) (*domain.MeterGroup, error)) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = stub
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroupArgsForCall(i int) (string, *uint, []int) {
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	argsForCall := fake.createMeterGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroupReturns(result1 *domain.MeterGroup, result2 error) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = nil
+	fake.createMeterGroupReturns = struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupService) CreateMeterGroupReturnsOnCall(i int, result1 *domain.MeterGroup, result2 error) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = nil
+	if fake.createMeterGroupReturnsOnCall == nil {
+		fake.createMeterGroupReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterGroup
+			result2 error
+		})
+	}
+	fake.createMeterGroupReturnsOnCall[i] = struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByID(arg1 string) (*domain.MeterGroup, []int, error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	ret, specificReturn := fake.getMeterGroupByIDReturnsOnCall[len(fake.getMeterGroupByIDArgsForCall)]
+	fake.getMeterGroupByIDArgsForCall = append(fake.getMeterGroupByIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetMeterGroupByIDStub
+	fakeReturns := fake.getMeterGroupByIDReturns
+	fake.recordInvocation("GetMeterGroupByID", []interface{}{arg1})
+	fake.getMeterGroupByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByIDCallCount() int {
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	return len(fake.getMeterGroupByIDArgsForCall)
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByIDCalls(stub func(string) (*domain.MeterGroup, []int, error)) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = stub
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByIDArgsForCall(i int) string {
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	argsForCall := fake.getMeterGroupByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByIDReturns(result1 *domain.MeterGroup, result2 []int, result3 error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = nil
+	fake.getMeterGroupByIDReturns = struct {
+		result1 *domain.MeterGroup
+		result2 []int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupByIDReturnsOnCall(i int, result1 *domain.MeterGroup, result2 []int, result3 error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = nil
+	if fake.getMeterGroupByIDReturnsOnCall == nil {
+		fake.getMeterGroupByIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterGroup
+			result2 []int
+			result3 error
+		})
+	}
+	fake.getMeterGroupByIDReturnsOnCall[i] = struct {
+		result1 *domain.MeterGroup
+		result2 []int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroups() ([]domain.MeterGroup, error) {
+	fake.getMeterGroupsMutex.Lock()
+	ret, specificReturn := fake.getMeterGroupsReturnsOnCall[len(fake.getMeterGroupsArgsForCall)]
+	fake.getMeterGroupsArgsForCall = append(fake.getMeterGroupsArgsForCall, struct {
+	}{})
+	stub := fake.GetMeterGroupsStub
+	fakeReturns := fake.getMeterGroupsReturns
+	fake.recordInvocation("GetMeterGroups", []interface{}{})
+	fake.getMeterGroupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupsCallCount() int {
+	fake.getMeterGroupsMutex.RLock()
+	defer fake.getMeterGroupsMutex.RUnlock()
+	return len(fake.getMeterGroupsArgsForCall)
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupsCalls(stub func() ([]domain.MeterGroup, error)) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = stub
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupsReturns(result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = nil
+	fake.getMeterGroupsReturns = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupService) GetMeterGroupsReturnsOnCall(i int, result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = nil
+	if fake.getMeterGroupsReturnsOnCall == nil {
+		fake.getMeterGroupsReturnsOnCall = make(map[int]struct {
+			result1 []domain.MeterGroup
+			result2 error
+		})
+	}
+	fake.getMeterGroupsReturnsOnCall[i] = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	fake.getMeterGroupsMutex.RLock()
+	defer fake.getMeterGroupsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMeterGroupService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.MeterGroupService = new(FakeMeterGroupService)
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeMeterSettingService struct {
+	GetMeterSettingByMeterIDStub        func(string) (*domain.MeterSetting, error)
+	getMeterSettingByMeterIDMutex       sync.RWMutex
+	getMeterSettingByMeterIDArgsForCall []struct {
+		arg1 string
+	}
+	getMeterSettingByMeterIDReturns struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	getMeterSettingByMeterIDReturnsOnCall map[int]struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	SaveMeterSettingStub        func(string, domain.MeterSetting) (*domain.MeterSetting, error)
+	saveMeterSettingMutex       sync.RWMutex
+	saveMeterSettingArgsForCall []struct {
+		arg1 string
+		arg2 domain.MeterSetting
+	}
+	saveMeterSettingReturns struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	saveMeterSettingReturnsOnCall map[int]struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterID(arg1 string) (*domain.MeterSetting, error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	ret, specificReturn := fake.getMeterSettingByMeterIDReturnsOnCall[len(fake.getMeterSettingByMeterIDArgsForCall)]
+	fake.getMeterSettingByMeterIDArgsForCall = append(fake.getMeterSettingByMeterIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetMeterSettingByMeterIDStub
+	fakeReturns := fake.getMeterSettingByMeterIDReturns
+	fake.recordInvocation("GetMeterSettingByMeterID", []interface{}{arg1})
+	fake.getMeterSettingByMeterIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterIDCallCount() int {
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	return len(fake.getMeterSettingByMeterIDArgsForCall)
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterIDCalls(stub func(string) (*domain.MeterSetting, error)) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = stub
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterIDArgsForCall(i int) string {
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	argsForCall := fake.getMeterSettingByMeterIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterIDReturns(result1 *domain.MeterSetting, result2 error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = nil
+	fake.getMeterSettingByMeterIDReturns = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingService) GetMeterSettingByMeterIDReturnsOnCall(i int, result1 *domain.MeterSetting, result2 error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = nil
+	if fake.getMeterSettingByMeterIDReturnsOnCall == nil {
+		fake.getMeterSettingByMeterIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterSetting
+			result2 error
+		})
+	}
+	fake.getMeterSettingByMeterIDReturnsOnCall[i] = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSetting(arg1 string, arg2 domain.MeterSetting) (*domain.MeterSetting, error) {
+	fake.saveMeterSettingMutex.Lock()
+	ret, specificReturn := fake.saveMeterSettingReturnsOnCall[len(fake.saveMeterSettingArgsForCall)]
+	fake.saveMeterSettingArgsForCall = append(fake.saveMeterSettingArgsForCall, struct {
+		arg1 string
+		arg2 domain.MeterSetting
+	}{arg1, arg2})
+	stub := fake.SaveMeterSettingStub
+	fakeReturns := fake.saveMeterSettingReturns
+	fake.recordInvocation("SaveMeterSetting", []interface{}{arg1, arg2})
+	fake.saveMeterSettingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSettingCallCount() int {
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	return len(fake.saveMeterSettingArgsForCall)
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSettingCalls(stub func(string, domain.MeterSetting) (*domain.MeterSetting, error)) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = stub
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSettingArgsForCall(i int) (string, domain.MeterSetting) {
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	argsForCall := fake.saveMeterSettingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSettingReturns(result1 *domain.MeterSetting, result2 error) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = nil
+	fake.saveMeterSettingReturns = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingService) SaveMeterSettingReturnsOnCall(i int, result1 *domain.MeterSetting, result2 error) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = nil
+	if fake.saveMeterSettingReturnsOnCall == nil {
+		fake.saveMeterSettingReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterSetting
+			result2 error
+		})
+	}
+	fake.saveMeterSettingReturnsOnCall[i] = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMeterSettingService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.MeterSettingService = new(FakeMeterSettingService)
@@ -0,0 +1,107 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+)
+
+type FakeOutboxService struct {
+	PublishPendingEventsStub        func() (int, error)
+	publishPendingEventsMutex       sync.RWMutex
+	publishPendingEventsArgsForCall []struct {
+	}
+	publishPendingEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	publishPendingEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOutboxService) PublishPendingEvents() (int, error) {
+	fake.publishPendingEventsMutex.Lock()
+	ret, specificReturn := fake.publishPendingEventsReturnsOnCall[len(fake.publishPendingEventsArgsForCall)]
+	fake.publishPendingEventsArgsForCall = append(fake.publishPendingEventsArgsForCall, struct {
+	}{})
+	stub := fake.PublishPendingEventsStub
+	fakeReturns := fake.publishPendingEventsReturns
+	fake.recordInvocation("PublishPendingEvents", []interface{}{})
+	fake.publishPendingEventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOutboxService) PublishPendingEventsCallCount() int {
+	fake.publishPendingEventsMutex.RLock()
+	defer fake.publishPendingEventsMutex.RUnlock()
+	return len(fake.publishPendingEventsArgsForCall)
+}
+
+func (fake *FakeOutboxService) PublishPendingEventsCalls(stub func() (int, error)) {
+	fake.publishPendingEventsMutex.Lock()
+	defer fake.publishPendingEventsMutex.Unlock()
+	fake.PublishPendingEventsStub = stub
+}
+
+func (fake *FakeOutboxService) PublishPendingEventsReturns(result1 int, result2 error) {
+	fake.publishPendingEventsMutex.Lock()
+	defer fake.publishPendingEventsMutex.Unlock()
+	fake.PublishPendingEventsStub = nil
+	fake.publishPendingEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxService) PublishPendingEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.publishPendingEventsMutex.Lock()
+	defer fake.publishPendingEventsMutex.Unlock()
+	fake.PublishPendingEventsStub = nil
+	if fake.publishPendingEventsReturnsOnCall == nil {
+		fake.publishPendingEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.publishPendingEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.publishPendingEventsMutex.RLock()
+	defer fake.publishPendingEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeOutboxService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.OutboxService = new(FakeOutboxService)
@@ -39,13 +39,47 @@ type FakePowerConsumptionService struct {
 		result1 string
 		result2 error
 	}
-	GetConsumptionByMeterIDAndWindowTimeStub        func(string, string, string, string) ([]application.Serializer, error)
+	GetAnalyticsByMeterIDAndWindowTimeStub        func(string, string, string, string) ([]application.AnalyticsSerializer, error)
+	getAnalyticsByMeterIDAndWindowTimeMutex       sync.RWMutex
+	getAnalyticsByMeterIDAndWindowTimeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	getAnalyticsByMeterIDAndWindowTimeReturns struct {
+		result1 []application.AnalyticsSerializer
+		result2 error
+	}
+	getAnalyticsByMeterIDAndWindowTimeReturnsOnCall map[int]struct {
+		result1 []application.AnalyticsSerializer
+		result2 error
+	}
+	GetConsumptionByGroupAndWindowTimeStub        func(string, string, string, string, domain.ConsumptionQueryOptions) (*application.GroupSerializer, error)
+	getConsumptionByGroupAndWindowTimeMutex       sync.RWMutex
+	getConsumptionByGroupAndWindowTimeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 domain.ConsumptionQueryOptions
+	}
+	getConsumptionByGroupAndWindowTimeReturns struct {
+		result1 *application.GroupSerializer
+		result2 error
+	}
+	getConsumptionByGroupAndWindowTimeReturnsOnCall map[int]struct {
+		result1 *application.GroupSerializer
+		result2 error
+	}
+	GetConsumptionByMeterIDAndWindowTimeStub        func(string, string, string, string, domain.ConsumptionQueryOptions) ([]application.Serializer, error)
 	getConsumptionByMeterIDAndWindowTimeMutex       sync.RWMutex
 	getConsumptionByMeterIDAndWindowTimeArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
 		arg4 string
+		arg5 domain.ConsumptionQueryOptions
 	}
 	getConsumptionByMeterIDAndWindowTimeReturns struct {
 		result1 []application.Serializer
@@ -55,16 +89,83 @@ type FakePowerConsumptionService struct {
 		result1 []application.Serializer
 		result2 error
 	}
-	ImportCsvToDatabaseStub        func(*multipart.File) error
+	GetConsumptionByMeterIDsInBatchStub        func(string, string, string, string, domain.ConsumptionQueryOptions) ([]application.Serializer, map[int]error, error)
+	getConsumptionByMeterIDsInBatchMutex       sync.RWMutex
+	getConsumptionByMeterIDsInBatchArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 domain.ConsumptionQueryOptions
+	}
+	getConsumptionByMeterIDsInBatchReturns struct {
+		result1 []application.Serializer
+		result2 map[int]error
+		result3 error
+	}
+	getConsumptionByMeterIDsInBatchReturnsOnCall map[int]struct {
+		result1 []application.Serializer
+		result2 map[int]error
+		result3 error
+	}
+	GetPeakDemandByMeterIDAndWindowTimeStub        func(string, string, string, string, string) ([]application.DemandSerializer, error)
+	getPeakDemandByMeterIDAndWindowTimeMutex       sync.RWMutex
+	getPeakDemandByMeterIDAndWindowTimeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	getPeakDemandByMeterIDAndWindowTimeReturns struct {
+		result1 []application.DemandSerializer
+		result2 error
+	}
+	getPeakDemandByMeterIDAndWindowTimeReturnsOnCall map[int]struct {
+		result1 []application.DemandSerializer
+		result2 error
+	}
+	ImportCSVRecordsStub        func([]*domain.CSVUserConsumption, domain.ImportOptions) (*application.ImportSummary, error)
+	importCSVRecordsMutex       sync.RWMutex
+	importCSVRecordsArgsForCall []struct {
+		arg1 []*domain.CSVUserConsumption
+		arg2 domain.ImportOptions
+	}
+	importCSVRecordsReturns struct {
+		result1 *application.ImportSummary
+		result2 error
+	}
+	importCSVRecordsReturnsOnCall map[int]struct {
+		result1 *application.ImportSummary
+		result2 error
+	}
+	ImportCsvToDatabaseStub        func(*multipart.File, domain.ImportOptions) (*application.ImportSummary, error)
 	importCsvToDatabaseMutex       sync.RWMutex
 	importCsvToDatabaseArgsForCall []struct {
 		arg1 *multipart.File
+		arg2 domain.ImportOptions
 	}
 	importCsvToDatabaseReturns struct {
-		result1 error
+		result1 *application.ImportSummary
+		result2 error
 	}
 	importCsvToDatabaseReturnsOnCall map[int]struct {
-		result1 error
+		result1 *application.ImportSummary
+		result2 error
+	}
+	IngestCSVRecordsStub        func([]*domain.CSVUserConsumption, domain.ImportOptions) (*application.ImportSummary, error)
+	ingestCSVRecordsMutex       sync.RWMutex
+	ingestCSVRecordsArgsForCall []struct {
+		arg1 []*domain.CSVUserConsumption
+		arg2 domain.ImportOptions
+	}
+	ingestCSVRecordsReturns struct {
+		result1 *application.ImportSummary
+		result2 error
+	}
+	ingestCSVRecordsReturnsOnCall map[int]struct {
+		result1 *application.ImportSummary
+		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
@@ -201,7 +302,142 @@ func (fake *FakePowerConsumptionService) ChekingKindPeriodReturnsOnCall(i int, r
 	}{result1, result2}
 }
 
-func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTime(arg1 string, arg2 string, arg3 string, arg4 string) ([]application.Serializer, error) {
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTime(arg1 string, arg2 string, arg3 string, arg4 string) ([]application.AnalyticsSerializer, error) {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.Lock()
+	ret, specificReturn := fake.getAnalyticsByMeterIDAndWindowTimeReturnsOnCall[len(fake.getAnalyticsByMeterIDAndWindowTimeArgsForCall)]
+	fake.getAnalyticsByMeterIDAndWindowTimeArgsForCall = append(fake.getAnalyticsByMeterIDAndWindowTimeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetAnalyticsByMeterIDAndWindowTimeStub
+	fakeReturns := fake.getAnalyticsByMeterIDAndWindowTimeReturns
+	fake.recordInvocation("GetAnalyticsByMeterIDAndWindowTime", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTimeCallCount() int {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.RUnlock()
+	return len(fake.getAnalyticsByMeterIDAndWindowTimeArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTimeCalls(stub func(string, string, string, string) ([]application.AnalyticsSerializer, error)) {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetAnalyticsByMeterIDAndWindowTimeStub = stub
+}
+
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTimeArgsForCall(i int) (string, string, string, string) {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.RUnlock()
+	argsForCall := fake.getAnalyticsByMeterIDAndWindowTimeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTimeReturns(result1 []application.AnalyticsSerializer, result2 error) {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetAnalyticsByMeterIDAndWindowTimeStub = nil
+	fake.getAnalyticsByMeterIDAndWindowTimeReturns = struct {
+		result1 []application.AnalyticsSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) GetAnalyticsByMeterIDAndWindowTimeReturnsOnCall(i int, result1 []application.AnalyticsSerializer, result2 error) {
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetAnalyticsByMeterIDAndWindowTimeStub = nil
+	if fake.getAnalyticsByMeterIDAndWindowTimeReturnsOnCall == nil {
+		fake.getAnalyticsByMeterIDAndWindowTimeReturnsOnCall = make(map[int]struct {
+			result1 []application.AnalyticsSerializer
+			result2 error
+		})
+	}
+	fake.getAnalyticsByMeterIDAndWindowTimeReturnsOnCall[i] = struct {
+		result1 []application.AnalyticsSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTime(arg1 string, arg2 string, arg3 string, arg4 string, arg5 domain.ConsumptionQueryOptions) (*application.GroupSerializer, error) {
+	fake.getConsumptionByGroupAndWindowTimeMutex.Lock()
+	ret, specificReturn := fake.getConsumptionByGroupAndWindowTimeReturnsOnCall[len(fake.getConsumptionByGroupAndWindowTimeArgsForCall)]
+	fake.getConsumptionByGroupAndWindowTimeArgsForCall = append(fake.getConsumptionByGroupAndWindowTimeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 domain.ConsumptionQueryOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetConsumptionByGroupAndWindowTimeStub
+	fakeReturns := fake.getConsumptionByGroupAndWindowTimeReturns
+	fake.recordInvocation("GetConsumptionByGroupAndWindowTime", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getConsumptionByGroupAndWindowTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTimeCallCount() int {
+	fake.getConsumptionByGroupAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.RUnlock()
+	return len(fake.getConsumptionByGroupAndWindowTimeArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTimeCalls(stub func(string, string, string, string, domain.ConsumptionQueryOptions) (*application.GroupSerializer, error)) {
+	fake.getConsumptionByGroupAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByGroupAndWindowTimeStub = stub
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTimeArgsForCall(i int) (string, string, string, string, domain.ConsumptionQueryOptions) {
+	fake.getConsumptionByGroupAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.RUnlock()
+	argsForCall := fake.getConsumptionByGroupAndWindowTimeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTimeReturns(result1 *application.GroupSerializer, result2 error) {
+	fake.getConsumptionByGroupAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByGroupAndWindowTimeStub = nil
+	fake.getConsumptionByGroupAndWindowTimeReturns = struct {
+		result1 *application.GroupSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByGroupAndWindowTimeReturnsOnCall(i int, result1 *application.GroupSerializer, result2 error) {
+	fake.getConsumptionByGroupAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByGroupAndWindowTimeStub = nil
+	if fake.getConsumptionByGroupAndWindowTimeReturnsOnCall == nil {
+		fake.getConsumptionByGroupAndWindowTimeReturnsOnCall = make(map[int]struct {
+			result1 *application.GroupSerializer
+			result2 error
+		})
+	}
+	fake.getConsumptionByGroupAndWindowTimeReturnsOnCall[i] = struct {
+		result1 *application.GroupSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTime(arg1 string, arg2 string, arg3 string, arg4 string, arg5 domain.ConsumptionQueryOptions) ([]application.Serializer, error) {
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.Lock()
 	ret, specificReturn := fake.getConsumptionByMeterIDAndWindowTimeReturnsOnCall[len(fake.getConsumptionByMeterIDAndWindowTimeArgsForCall)]
 	fake.getConsumptionByMeterIDAndWindowTimeArgsForCall = append(fake.getConsumptionByMeterIDAndWindowTimeArgsForCall, struct {
@@ -209,13 +445,14 @@ func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTime(ar
 		arg2 string
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 domain.ConsumptionQueryOptions
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.GetConsumptionByMeterIDAndWindowTimeStub
 	fakeReturns := fake.getConsumptionByMeterIDAndWindowTimeReturns
-	fake.recordInvocation("GetConsumptionByMeterIDAndWindowTime", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetConsumptionByMeterIDAndWindowTime", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -229,17 +466,17 @@ func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeCal
 	return len(fake.getConsumptionByMeterIDAndWindowTimeArgsForCall)
 }
 
-func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeCalls(stub func(string, string, string, string) ([]application.Serializer, error)) {
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeCalls(stub func(string, string, string, string, domain.ConsumptionQueryOptions) ([]application.Serializer, error)) {
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.Lock()
 	defer fake.getConsumptionByMeterIDAndWindowTimeMutex.Unlock()
 	fake.GetConsumptionByMeterIDAndWindowTimeStub = stub
 }
 
-func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeArgsForCall(i int) (string, string, string, string) {
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeArgsForCall(i int) (string, string, string, string, domain.ConsumptionQueryOptions) {
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.RLock()
 	defer fake.getConsumptionByMeterIDAndWindowTimeMutex.RUnlock()
 	argsForCall := fake.getConsumptionByMeterIDAndWindowTimeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeReturns(result1 []application.Serializer, result2 error) {
@@ -268,23 +505,233 @@ func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDAndWindowTimeRet
 	}{result1, result2}
 }
 
-func (fake *FakePowerConsumptionService) ImportCsvToDatabase(arg1 *multipart.File) error {
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatch(arg1 string, arg2 string, arg3 string, arg4 string, arg5 domain.ConsumptionQueryOptions) ([]application.Serializer, map[int]error, error) {
+	fake.getConsumptionByMeterIDsInBatchMutex.Lock()
+	ret, specificReturn := fake.getConsumptionByMeterIDsInBatchReturnsOnCall[len(fake.getConsumptionByMeterIDsInBatchArgsForCall)]
+	fake.getConsumptionByMeterIDsInBatchArgsForCall = append(fake.getConsumptionByMeterIDsInBatchArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 domain.ConsumptionQueryOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetConsumptionByMeterIDsInBatchStub
+	fakeReturns := fake.getConsumptionByMeterIDsInBatchReturns
+	fake.recordInvocation("GetConsumptionByMeterIDsInBatch", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getConsumptionByMeterIDsInBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatchCallCount() int {
+	fake.getConsumptionByMeterIDsInBatchMutex.RLock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.RUnlock()
+	return len(fake.getConsumptionByMeterIDsInBatchArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatchCalls(stub func(string, string, string, string, domain.ConsumptionQueryOptions) ([]application.Serializer, map[int]error, error)) {
+	fake.getConsumptionByMeterIDsInBatchMutex.Lock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.Unlock()
+	fake.GetConsumptionByMeterIDsInBatchStub = stub
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatchArgsForCall(i int) (string, string, string, string, domain.ConsumptionQueryOptions) {
+	fake.getConsumptionByMeterIDsInBatchMutex.RLock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.RUnlock()
+	argsForCall := fake.getConsumptionByMeterIDsInBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatchReturns(result1 []application.Serializer, result2 map[int]error, result3 error) {
+	fake.getConsumptionByMeterIDsInBatchMutex.Lock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.Unlock()
+	fake.GetConsumptionByMeterIDsInBatchStub = nil
+	fake.getConsumptionByMeterIDsInBatchReturns = struct {
+		result1 []application.Serializer
+		result2 map[int]error
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePowerConsumptionService) GetConsumptionByMeterIDsInBatchReturnsOnCall(i int, result1 []application.Serializer, result2 map[int]error, result3 error) {
+	fake.getConsumptionByMeterIDsInBatchMutex.Lock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.Unlock()
+	fake.GetConsumptionByMeterIDsInBatchStub = nil
+	if fake.getConsumptionByMeterIDsInBatchReturnsOnCall == nil {
+		fake.getConsumptionByMeterIDsInBatchReturnsOnCall = make(map[int]struct {
+			result1 []application.Serializer
+			result2 map[int]error
+			result3 error
+		})
+	}
+	fake.getConsumptionByMeterIDsInBatchReturnsOnCall[i] = struct {
+		result1 []application.Serializer
+		result2 map[int]error
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTime(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) ([]application.DemandSerializer, error) {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.Lock()
+	ret, specificReturn := fake.getPeakDemandByMeterIDAndWindowTimeReturnsOnCall[len(fake.getPeakDemandByMeterIDAndWindowTimeArgsForCall)]
+	fake.getPeakDemandByMeterIDAndWindowTimeArgsForCall = append(fake.getPeakDemandByMeterIDAndWindowTimeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetPeakDemandByMeterIDAndWindowTimeStub
+	fakeReturns := fake.getPeakDemandByMeterIDAndWindowTimeReturns
+	fake.recordInvocation("GetPeakDemandByMeterIDAndWindowTime", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTimeCallCount() int {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.RUnlock()
+	return len(fake.getPeakDemandByMeterIDAndWindowTimeArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTimeCalls(stub func(string, string, string, string, string) ([]application.DemandSerializer, error)) {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetPeakDemandByMeterIDAndWindowTimeStub = stub
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTimeArgsForCall(i int) (string, string, string, string, string) {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.RUnlock()
+	argsForCall := fake.getPeakDemandByMeterIDAndWindowTimeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTimeReturns(result1 []application.DemandSerializer, result2 error) {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetPeakDemandByMeterIDAndWindowTimeStub = nil
+	fake.getPeakDemandByMeterIDAndWindowTimeReturns = struct {
+		result1 []application.DemandSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) GetPeakDemandByMeterIDAndWindowTimeReturnsOnCall(i int, result1 []application.DemandSerializer, result2 error) {
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.Lock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.Unlock()
+	fake.GetPeakDemandByMeterIDAndWindowTimeStub = nil
+	if fake.getPeakDemandByMeterIDAndWindowTimeReturnsOnCall == nil {
+		fake.getPeakDemandByMeterIDAndWindowTimeReturnsOnCall = make(map[int]struct {
+			result1 []application.DemandSerializer
+			result2 error
+		})
+	}
+	fake.getPeakDemandByMeterIDAndWindowTimeReturnsOnCall[i] = struct {
+		result1 []application.DemandSerializer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecords(arg1 []*domain.CSVUserConsumption, arg2 domain.ImportOptions) (*application.ImportSummary, error) {
+	var arg1Copy []*domain.CSVUserConsumption
+	if arg1 != nil {
+		arg1Copy = make([]*domain.CSVUserConsumption, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.importCSVRecordsMutex.Lock()
+	ret, specificReturn := fake.importCSVRecordsReturnsOnCall[len(fake.importCSVRecordsArgsForCall)]
+	fake.importCSVRecordsArgsForCall = append(fake.importCSVRecordsArgsForCall, struct {
+		arg1 []*domain.CSVUserConsumption
+		arg2 domain.ImportOptions
+	}{arg1Copy, arg2})
+	stub := fake.ImportCSVRecordsStub
+	fakeReturns := fake.importCSVRecordsReturns
+	fake.recordInvocation("ImportCSVRecords", []interface{}{arg1Copy, arg2})
+	fake.importCSVRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecordsCallCount() int {
+	fake.importCSVRecordsMutex.RLock()
+	defer fake.importCSVRecordsMutex.RUnlock()
+	return len(fake.importCSVRecordsArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecordsCalls(stub func([]*domain.CSVUserConsumption, domain.ImportOptions) (*application.ImportSummary, error)) {
+	fake.importCSVRecordsMutex.Lock()
+	defer fake.importCSVRecordsMutex.Unlock()
+	fake.ImportCSVRecordsStub = stub
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecordsArgsForCall(i int) ([]*domain.CSVUserConsumption, domain.ImportOptions) {
+	fake.importCSVRecordsMutex.RLock()
+	defer fake.importCSVRecordsMutex.RUnlock()
+	argsForCall := fake.importCSVRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecordsReturns(result1 *application.ImportSummary, result2 error) {
+	fake.importCSVRecordsMutex.Lock()
+	defer fake.importCSVRecordsMutex.Unlock()
+	fake.ImportCSVRecordsStub = nil
+	fake.importCSVRecordsReturns = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) ImportCSVRecordsReturnsOnCall(i int, result1 *application.ImportSummary, result2 error) {
+	fake.importCSVRecordsMutex.Lock()
+	defer fake.importCSVRecordsMutex.Unlock()
+	fake.ImportCSVRecordsStub = nil
+	if fake.importCSVRecordsReturnsOnCall == nil {
+		fake.importCSVRecordsReturnsOnCall = make(map[int]struct {
+			result1 *application.ImportSummary
+			result2 error
+		})
+	}
+	fake.importCSVRecordsReturnsOnCall[i] = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) ImportCsvToDatabase(arg1 *multipart.File, arg2 domain.ImportOptions) (*application.ImportSummary, error) {
 	fake.importCsvToDatabaseMutex.Lock()
 	ret, specificReturn := fake.importCsvToDatabaseReturnsOnCall[len(fake.importCsvToDatabaseArgsForCall)]
 	fake.importCsvToDatabaseArgsForCall = append(fake.importCsvToDatabaseArgsForCall, struct {
 		arg1 *multipart.File
-	}{arg1})
+		arg2 domain.ImportOptions
+	}{arg1, arg2})
 	stub := fake.ImportCsvToDatabaseStub
 	fakeReturns := fake.importCsvToDatabaseReturns
-	fake.recordInvocation("ImportCsvToDatabase", []interface{}{arg1})
+	fake.recordInvocation("ImportCsvToDatabase", []interface{}{arg1, arg2})
 	fake.importCsvToDatabaseMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakePowerConsumptionService) ImportCsvToDatabaseCallCount() int {
@@ -293,40 +740,113 @@ func (fake *FakePowerConsumptionService) ImportCsvToDatabaseCallCount() int {
 	return len(fake.importCsvToDatabaseArgsForCall)
 }
 
-func (fake *FakePowerConsumptionService) ImportCsvToDatabaseCalls(stub func(*multipart.File) error) {
+func (fake *FakePowerConsumptionService) ImportCsvToDatabaseCalls(stub func(*multipart.File, domain.ImportOptions) (*application.ImportSummary, error)) {
 	fake.importCsvToDatabaseMutex.Lock()
 	defer fake.importCsvToDatabaseMutex.Unlock()
 	fake.ImportCsvToDatabaseStub = stub
 }
 
-func (fake *FakePowerConsumptionService) ImportCsvToDatabaseArgsForCall(i int) *multipart.File {
+func (fake *FakePowerConsumptionService) ImportCsvToDatabaseArgsForCall(i int) (*multipart.File, domain.ImportOptions) {
 	fake.importCsvToDatabaseMutex.RLock()
 	defer fake.importCsvToDatabaseMutex.RUnlock()
 	argsForCall := fake.importCsvToDatabaseArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakePowerConsumptionService) ImportCsvToDatabaseReturns(result1 error) {
+func (fake *FakePowerConsumptionService) ImportCsvToDatabaseReturns(result1 *application.ImportSummary, result2 error) {
 	fake.importCsvToDatabaseMutex.Lock()
 	defer fake.importCsvToDatabaseMutex.Unlock()
 	fake.ImportCsvToDatabaseStub = nil
 	fake.importCsvToDatabaseReturns = struct {
-		result1 error
-	}{result1}
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePowerConsumptionService) ImportCsvToDatabaseReturnsOnCall(i int, result1 error) {
+func (fake *FakePowerConsumptionService) ImportCsvToDatabaseReturnsOnCall(i int, result1 *application.ImportSummary, result2 error) {
 	fake.importCsvToDatabaseMutex.Lock()
 	defer fake.importCsvToDatabaseMutex.Unlock()
 	fake.ImportCsvToDatabaseStub = nil
 	if fake.importCsvToDatabaseReturnsOnCall == nil {
 		fake.importCsvToDatabaseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 *application.ImportSummary
+			result2 error
 		})
 	}
 	fake.importCsvToDatabaseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecords(arg1 []*domain.CSVUserConsumption, arg2 domain.ImportOptions) (*application.ImportSummary, error) {
+	var arg1Copy []*domain.CSVUserConsumption
+	if arg1 != nil {
+		arg1Copy = make([]*domain.CSVUserConsumption, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.ingestCSVRecordsMutex.Lock()
+	ret, specificReturn := fake.ingestCSVRecordsReturnsOnCall[len(fake.ingestCSVRecordsArgsForCall)]
+	fake.ingestCSVRecordsArgsForCall = append(fake.ingestCSVRecordsArgsForCall, struct {
+		arg1 []*domain.CSVUserConsumption
+		arg2 domain.ImportOptions
+	}{arg1Copy, arg2})
+	stub := fake.IngestCSVRecordsStub
+	fakeReturns := fake.ingestCSVRecordsReturns
+	fake.recordInvocation("IngestCSVRecords", []interface{}{arg1Copy, arg2})
+	fake.ingestCSVRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecordsCallCount() int {
+	fake.ingestCSVRecordsMutex.RLock()
+	defer fake.ingestCSVRecordsMutex.RUnlock()
+	return len(fake.ingestCSVRecordsArgsForCall)
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecordsCalls(stub func([]*domain.CSVUserConsumption, domain.ImportOptions) (*application.ImportSummary, error)) {
+	fake.ingestCSVRecordsMutex.Lock()
+	defer fake.ingestCSVRecordsMutex.Unlock()
+	fake.IngestCSVRecordsStub = stub
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecordsArgsForCall(i int) ([]*domain.CSVUserConsumption, domain.ImportOptions) {
+	fake.ingestCSVRecordsMutex.RLock()
+	defer fake.ingestCSVRecordsMutex.RUnlock()
+	argsForCall := fake.ingestCSVRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecordsReturns(result1 *application.ImportSummary, result2 error) {
+	fake.ingestCSVRecordsMutex.Lock()
+	defer fake.ingestCSVRecordsMutex.Unlock()
+	fake.IngestCSVRecordsStub = nil
+	fake.ingestCSVRecordsReturns = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePowerConsumptionService) IngestCSVRecordsReturnsOnCall(i int, result1 *application.ImportSummary, result2 error) {
+	fake.ingestCSVRecordsMutex.Lock()
+	defer fake.ingestCSVRecordsMutex.Unlock()
+	fake.IngestCSVRecordsStub = nil
+	if fake.ingestCSVRecordsReturnsOnCall == nil {
+		fake.ingestCSVRecordsReturnsOnCall = make(map[int]struct {
+			result1 *application.ImportSummary
+			result2 error
+		})
+	}
+	fake.ingestCSVRecordsReturnsOnCall[i] = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePowerConsumptionService) Invocations() map[string][][]interface{} {
@@ -336,10 +856,22 @@ func (fake *FakePowerConsumptionService) Invocations() map[string][][]interface{
 	defer fake.checkingQueryParamConstrainsMutex.RUnlock()
 	fake.chekingKindPeriodMutex.RLock()
 	defer fake.chekingKindPeriodMutex.RUnlock()
+	fake.getAnalyticsByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getAnalyticsByMeterIDAndWindowTimeMutex.RUnlock()
+	fake.getConsumptionByGroupAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByGroupAndWindowTimeMutex.RUnlock()
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.RLock()
 	defer fake.getConsumptionByMeterIDAndWindowTimeMutex.RUnlock()
+	fake.getConsumptionByMeterIDsInBatchMutex.RLock()
+	defer fake.getConsumptionByMeterIDsInBatchMutex.RUnlock()
+	fake.getPeakDemandByMeterIDAndWindowTimeMutex.RLock()
+	defer fake.getPeakDemandByMeterIDAndWindowTimeMutex.RUnlock()
+	fake.importCSVRecordsMutex.RLock()
+	defer fake.importCSVRecordsMutex.RUnlock()
 	fake.importCsvToDatabaseMutex.RLock()
 	defer fake.importCsvToDatabaseMutex.RUnlock()
+	fake.ingestCSVRecordsMutex.RLock()
+	defer fake.ingestCSVRecordsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
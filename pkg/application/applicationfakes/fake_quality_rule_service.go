@@ -0,0 +1,261 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeQualityRuleService struct {
+	CreateQualityRuleStub        func(domain.QualityRule) (*domain.QualityRule, error)
+	createQualityRuleMutex       sync.RWMutex
+	createQualityRuleArgsForCall []struct {
+		arg1 domain.QualityRule
+	}
+	createQualityRuleReturns struct {
+		result1 *domain.QualityRule
+		result2 error
+	}
+	createQualityRuleReturnsOnCall map[int]struct {
+		result1 *domain.QualityRule
+		result2 error
+	}
+	DeleteQualityRuleStub        func(string) error
+	deleteQualityRuleMutex       sync.RWMutex
+	deleteQualityRuleArgsForCall []struct {
+		arg1 string
+	}
+	deleteQualityRuleReturns struct {
+		result1 error
+	}
+	deleteQualityRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetQualityRulesStub        func() ([]domain.QualityRule, error)
+	getQualityRulesMutex       sync.RWMutex
+	getQualityRulesArgsForCall []struct {
+	}
+	getQualityRulesReturns struct {
+		result1 []domain.QualityRule
+		result2 error
+	}
+	getQualityRulesReturnsOnCall map[int]struct {
+		result1 []domain.QualityRule
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRule(arg1 domain.QualityRule) (*domain.QualityRule, error) {
+	fake.createQualityRuleMutex.Lock()
+	ret, specificReturn := fake.createQualityRuleReturnsOnCall[len(fake.createQualityRuleArgsForCall)]
+	fake.createQualityRuleArgsForCall = append(fake.createQualityRuleArgsForCall, struct {
+		arg1 domain.QualityRule
+	}{arg1})
+	stub := fake.CreateQualityRuleStub
+	fakeReturns := fake.createQualityRuleReturns
+	fake.recordInvocation("CreateQualityRule", []interface{}{arg1})
+	fake.createQualityRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRuleCallCount() int {
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	return len(fake.createQualityRuleArgsForCall)
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRuleCalls(stub func(domain.QualityRule) (*domain.QualityRule, error)) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = stub
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRuleArgsForCall(i int) domain.QualityRule {
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	argsForCall := fake.createQualityRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRuleReturns(result1 *domain.QualityRule, result2 error) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = nil
+	fake.createQualityRuleReturns = struct {
+		result1 *domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleService) CreateQualityRuleReturnsOnCall(i int, result1 *domain.QualityRule, result2 error) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = nil
+	if fake.createQualityRuleReturnsOnCall == nil {
+		fake.createQualityRuleReturnsOnCall = make(map[int]struct {
+			result1 *domain.QualityRule
+			result2 error
+		})
+	}
+	fake.createQualityRuleReturnsOnCall[i] = struct {
+		result1 *domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRule(arg1 string) error {
+	fake.deleteQualityRuleMutex.Lock()
+	ret, specificReturn := fake.deleteQualityRuleReturnsOnCall[len(fake.deleteQualityRuleArgsForCall)]
+	fake.deleteQualityRuleArgsForCall = append(fake.deleteQualityRuleArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteQualityRuleStub
+	fakeReturns := fake.deleteQualityRuleReturns
+	fake.recordInvocation("DeleteQualityRule", []interface{}{arg1})
+	fake.deleteQualityRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRuleCallCount() int {
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	return len(fake.deleteQualityRuleArgsForCall)
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRuleCalls(stub func(string) error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = stub
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRuleArgsForCall(i int) string {
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	argsForCall := fake.deleteQualityRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRuleReturns(result1 error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = nil
+	fake.deleteQualityRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleService) DeleteQualityRuleReturnsOnCall(i int, result1 error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = nil
+	if fake.deleteQualityRuleReturnsOnCall == nil {
+		fake.deleteQualityRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteQualityRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleService) GetQualityRules() ([]domain.QualityRule, error) {
+	fake.getQualityRulesMutex.Lock()
+	ret, specificReturn := fake.getQualityRulesReturnsOnCall[len(fake.getQualityRulesArgsForCall)]
+	fake.getQualityRulesArgsForCall = append(fake.getQualityRulesArgsForCall, struct {
+	}{})
+	stub := fake.GetQualityRulesStub
+	fakeReturns := fake.getQualityRulesReturns
+	fake.recordInvocation("GetQualityRules", []interface{}{})
+	fake.getQualityRulesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQualityRuleService) GetQualityRulesCallCount() int {
+	fake.getQualityRulesMutex.RLock()
+	defer fake.getQualityRulesMutex.RUnlock()
+	return len(fake.getQualityRulesArgsForCall)
+}
+
+func (fake *FakeQualityRuleService) GetQualityRulesCalls(stub func() ([]domain.QualityRule, error)) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = stub
+}
+
+func (fake *FakeQualityRuleService) GetQualityRulesReturns(result1 []domain.QualityRule, result2 error) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = nil
+	fake.getQualityRulesReturns = struct {
+		result1 []domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleService) GetQualityRulesReturnsOnCall(i int, result1 []domain.QualityRule, result2 error) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = nil
+	if fake.getQualityRulesReturnsOnCall == nil {
+		fake.getQualityRulesReturnsOnCall = make(map[int]struct {
+			result1 []domain.QualityRule
+			result2 error
+		})
+	}
+	fake.getQualityRulesReturnsOnCall[i] = struct {
+		result1 []domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	fake.getQualityRulesMutex.RLock()
+	defer fake.getQualityRulesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQualityRuleService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.QualityRuleService = new(FakeQualityRuleService)
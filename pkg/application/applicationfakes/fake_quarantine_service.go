@@ -0,0 +1,347 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeQuarantineService struct {
+	GetQuarantinedReadingsStub        func() ([]domain.QuarantinedReading, error)
+	getQuarantinedReadingsMutex       sync.RWMutex
+	getQuarantinedReadingsArgsForCall []struct {
+	}
+	getQuarantinedReadingsReturns struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}
+	getQuarantinedReadingsReturnsOnCall map[int]struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}
+	ReleaseQuarantinedReadingStub        func(string) (*domain.UserConsumption, error)
+	releaseQuarantinedReadingMutex       sync.RWMutex
+	releaseQuarantinedReadingArgsForCall []struct {
+		arg1 string
+	}
+	releaseQuarantinedReadingReturns struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}
+	releaseQuarantinedReadingReturnsOnCall map[int]struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}
+	ResubmitQuarantinedReadingStub        func(string) (*application.ImportSummary, error)
+	resubmitQuarantinedReadingMutex       sync.RWMutex
+	resubmitQuarantinedReadingArgsForCall []struct {
+		arg1 string
+	}
+	resubmitQuarantinedReadingReturns struct {
+		result1 *application.ImportSummary
+		result2 error
+	}
+	resubmitQuarantinedReadingReturnsOnCall map[int]struct {
+		result1 *application.ImportSummary
+		result2 error
+	}
+	UpdateQuarantinedReadingStub        func(string, string) (*domain.QuarantinedReading, error)
+	updateQuarantinedReadingMutex       sync.RWMutex
+	updateQuarantinedReadingArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateQuarantinedReadingReturns struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}
+	updateQuarantinedReadingReturnsOnCall map[int]struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQuarantineService) GetQuarantinedReadings() ([]domain.QuarantinedReading, error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	ret, specificReturn := fake.getQuarantinedReadingsReturnsOnCall[len(fake.getQuarantinedReadingsArgsForCall)]
+	fake.getQuarantinedReadingsArgsForCall = append(fake.getQuarantinedReadingsArgsForCall, struct {
+	}{})
+	stub := fake.GetQuarantinedReadingsStub
+	fakeReturns := fake.getQuarantinedReadingsReturns
+	fake.recordInvocation("GetQuarantinedReadings", []interface{}{})
+	fake.getQuarantinedReadingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineService) GetQuarantinedReadingsCallCount() int {
+	fake.getQuarantinedReadingsMutex.RLock()
+	defer fake.getQuarantinedReadingsMutex.RUnlock()
+	return len(fake.getQuarantinedReadingsArgsForCall)
+}
+
+func (fake *FakeQuarantineService) GetQuarantinedReadingsCalls(stub func() ([]domain.QuarantinedReading, error)) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = stub
+}
+
+func (fake *FakeQuarantineService) GetQuarantinedReadingsReturns(result1 []domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = nil
+	fake.getQuarantinedReadingsReturns = struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) GetQuarantinedReadingsReturnsOnCall(i int, result1 []domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = nil
+	if fake.getQuarantinedReadingsReturnsOnCall == nil {
+		fake.getQuarantinedReadingsReturnsOnCall = make(map[int]struct {
+			result1 []domain.QuarantinedReading
+			result2 error
+		})
+	}
+	fake.getQuarantinedReadingsReturnsOnCall[i] = struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReading(arg1 string) (*domain.UserConsumption, error) {
+	fake.releaseQuarantinedReadingMutex.Lock()
+	ret, specificReturn := fake.releaseQuarantinedReadingReturnsOnCall[len(fake.releaseQuarantinedReadingArgsForCall)]
+	fake.releaseQuarantinedReadingArgsForCall = append(fake.releaseQuarantinedReadingArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleaseQuarantinedReadingStub
+	fakeReturns := fake.releaseQuarantinedReadingReturns
+	fake.recordInvocation("ReleaseQuarantinedReading", []interface{}{arg1})
+	fake.releaseQuarantinedReadingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReadingCallCount() int {
+	fake.releaseQuarantinedReadingMutex.RLock()
+	defer fake.releaseQuarantinedReadingMutex.RUnlock()
+	return len(fake.releaseQuarantinedReadingArgsForCall)
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReadingCalls(stub func(string) (*domain.UserConsumption, error)) {
+	fake.releaseQuarantinedReadingMutex.Lock()
+	defer fake.releaseQuarantinedReadingMutex.Unlock()
+	fake.ReleaseQuarantinedReadingStub = stub
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReadingArgsForCall(i int) string {
+	fake.releaseQuarantinedReadingMutex.RLock()
+	defer fake.releaseQuarantinedReadingMutex.RUnlock()
+	argsForCall := fake.releaseQuarantinedReadingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReadingReturns(result1 *domain.UserConsumption, result2 error) {
+	fake.releaseQuarantinedReadingMutex.Lock()
+	defer fake.releaseQuarantinedReadingMutex.Unlock()
+	fake.ReleaseQuarantinedReadingStub = nil
+	fake.releaseQuarantinedReadingReturns = struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) ReleaseQuarantinedReadingReturnsOnCall(i int, result1 *domain.UserConsumption, result2 error) {
+	fake.releaseQuarantinedReadingMutex.Lock()
+	defer fake.releaseQuarantinedReadingMutex.Unlock()
+	fake.ReleaseQuarantinedReadingStub = nil
+	if fake.releaseQuarantinedReadingReturnsOnCall == nil {
+		fake.releaseQuarantinedReadingReturnsOnCall = make(map[int]struct {
+			result1 *domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.releaseQuarantinedReadingReturnsOnCall[i] = struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReading(arg1 string) (*application.ImportSummary, error) {
+	fake.resubmitQuarantinedReadingMutex.Lock()
+	ret, specificReturn := fake.resubmitQuarantinedReadingReturnsOnCall[len(fake.resubmitQuarantinedReadingArgsForCall)]
+	fake.resubmitQuarantinedReadingArgsForCall = append(fake.resubmitQuarantinedReadingArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ResubmitQuarantinedReadingStub
+	fakeReturns := fake.resubmitQuarantinedReadingReturns
+	fake.recordInvocation("ResubmitQuarantinedReading", []interface{}{arg1})
+	fake.resubmitQuarantinedReadingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReadingCallCount() int {
+	fake.resubmitQuarantinedReadingMutex.RLock()
+	defer fake.resubmitQuarantinedReadingMutex.RUnlock()
+	return len(fake.resubmitQuarantinedReadingArgsForCall)
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReadingCalls(stub func(string) (*application.ImportSummary, error)) {
+	fake.resubmitQuarantinedReadingMutex.Lock()
+	defer fake.resubmitQuarantinedReadingMutex.Unlock()
+	fake.ResubmitQuarantinedReadingStub = stub
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReadingArgsForCall(i int) string {
+	fake.resubmitQuarantinedReadingMutex.RLock()
+	defer fake.resubmitQuarantinedReadingMutex.RUnlock()
+	argsForCall := fake.resubmitQuarantinedReadingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReadingReturns(result1 *application.ImportSummary, result2 error) {
+	fake.resubmitQuarantinedReadingMutex.Lock()
+	defer fake.resubmitQuarantinedReadingMutex.Unlock()
+	fake.ResubmitQuarantinedReadingStub = nil
+	fake.resubmitQuarantinedReadingReturns = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) ResubmitQuarantinedReadingReturnsOnCall(i int, result1 *application.ImportSummary, result2 error) {
+	fake.resubmitQuarantinedReadingMutex.Lock()
+	defer fake.resubmitQuarantinedReadingMutex.Unlock()
+	fake.ResubmitQuarantinedReadingStub = nil
+	if fake.resubmitQuarantinedReadingReturnsOnCall == nil {
+		fake.resubmitQuarantinedReadingReturnsOnCall = make(map[int]struct {
+			result1 *application.ImportSummary
+			result2 error
+		})
+	}
+	fake.resubmitQuarantinedReadingReturnsOnCall[i] = struct {
+		result1 *application.ImportSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReading(arg1 string, arg2 string) (*domain.QuarantinedReading, error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	ret, specificReturn := fake.updateQuarantinedReadingReturnsOnCall[len(fake.updateQuarantinedReadingArgsForCall)]
+	fake.updateQuarantinedReadingArgsForCall = append(fake.updateQuarantinedReadingArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UpdateQuarantinedReadingStub
+	fakeReturns := fake.updateQuarantinedReadingReturns
+	fake.recordInvocation("UpdateQuarantinedReading", []interface{}{arg1, arg2})
+	fake.updateQuarantinedReadingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReadingCallCount() int {
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	return len(fake.updateQuarantinedReadingArgsForCall)
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReadingCalls(stub func(string, string) (*domain.QuarantinedReading, error)) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = stub
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReadingArgsForCall(i int) (string, string) {
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	argsForCall := fake.updateQuarantinedReadingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReadingReturns(result1 *domain.QuarantinedReading, result2 error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = nil
+	fake.updateQuarantinedReadingReturns = struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) UpdateQuarantinedReadingReturnsOnCall(i int, result1 *domain.QuarantinedReading, result2 error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = nil
+	if fake.updateQuarantinedReadingReturnsOnCall == nil {
+		fake.updateQuarantinedReadingReturnsOnCall = make(map[int]struct {
+			result1 *domain.QuarantinedReading
+			result2 error
+		})
+	}
+	fake.updateQuarantinedReadingReturnsOnCall[i] = struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getQuarantinedReadingsMutex.RLock()
+	defer fake.getQuarantinedReadingsMutex.RUnlock()
+	fake.releaseQuarantinedReadingMutex.RLock()
+	defer fake.releaseQuarantinedReadingMutex.RUnlock()
+	fake.resubmitQuarantinedReadingMutex.RLock()
+	defer fake.resubmitQuarantinedReadingMutex.RUnlock()
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQuarantineService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.QuarantineService = new(FakeQuarantineService)
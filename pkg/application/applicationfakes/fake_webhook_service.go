@@ -0,0 +1,630 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeWebhookService struct {
+	CreateWebhookSubscriptionStub        func(domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	createWebhookSubscriptionMutex       sync.RWMutex
+	createWebhookSubscriptionArgsForCall []struct {
+		arg1 domain.WebhookSubscription
+	}
+	createWebhookSubscriptionReturns struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	createWebhookSubscriptionReturnsOnCall map[int]struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	DeleteWebhookSubscriptionStub        func(string) error
+	deleteWebhookSubscriptionMutex       sync.RWMutex
+	deleteWebhookSubscriptionArgsForCall []struct {
+		arg1 string
+	}
+	deleteWebhookSubscriptionReturns struct {
+		result1 error
+	}
+	deleteWebhookSubscriptionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeliverDueWebhooksStub        func() (int, error)
+	deliverDueWebhooksMutex       sync.RWMutex
+	deliverDueWebhooksArgsForCall []struct {
+	}
+	deliverDueWebhooksReturns struct {
+		result1 int
+		result2 error
+	}
+	deliverDueWebhooksReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	GetWebhookDeliveriesStub        func(string) ([]domain.WebhookDelivery, error)
+	getWebhookDeliveriesMutex       sync.RWMutex
+	getWebhookDeliveriesArgsForCall []struct {
+		arg1 string
+	}
+	getWebhookDeliveriesReturns struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	getWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	GetWebhookSubscriptionsStub        func() ([]domain.WebhookSubscription, error)
+	getWebhookSubscriptionsMutex       sync.RWMutex
+	getWebhookSubscriptionsArgsForCall []struct {
+	}
+	getWebhookSubscriptionsReturns struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}
+	getWebhookSubscriptionsReturnsOnCall map[int]struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PublishStub        func(application.EventEnvelope) error
+	publishMutex       sync.RWMutex
+	publishArgsForCall []struct {
+		arg1 application.EventEnvelope
+	}
+	publishReturns struct {
+		result1 error
+	}
+	publishReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateWebhookSubscriptionActiveStub        func(string, bool) (*domain.WebhookSubscription, error)
+	updateWebhookSubscriptionActiveMutex       sync.RWMutex
+	updateWebhookSubscriptionActiveArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	updateWebhookSubscriptionActiveReturns struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	updateWebhookSubscriptionActiveReturnsOnCall map[int]struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscription(arg1 domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	ret, specificReturn := fake.createWebhookSubscriptionReturnsOnCall[len(fake.createWebhookSubscriptionArgsForCall)]
+	fake.createWebhookSubscriptionArgsForCall = append(fake.createWebhookSubscriptionArgsForCall, struct {
+		arg1 domain.WebhookSubscription
+	}{arg1})
+	stub := fake.CreateWebhookSubscriptionStub
+	fakeReturns := fake.createWebhookSubscriptionReturns
+	fake.recordInvocation("CreateWebhookSubscription", []interface{}{arg1})
+	fake.createWebhookSubscriptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscriptionCallCount() int {
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	return len(fake.createWebhookSubscriptionArgsForCall)
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscriptionCalls(stub func(domain.WebhookSubscription) (*domain.WebhookSubscription, error)) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = stub
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscriptionArgsForCall(i int) domain.WebhookSubscription {
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	argsForCall := fake.createWebhookSubscriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscriptionReturns(result1 *domain.WebhookSubscription, result2 error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = nil
+	fake.createWebhookSubscriptionReturns = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) CreateWebhookSubscriptionReturnsOnCall(i int, result1 *domain.WebhookSubscription, result2 error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = nil
+	if fake.createWebhookSubscriptionReturnsOnCall == nil {
+		fake.createWebhookSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 *domain.WebhookSubscription
+			result2 error
+		})
+	}
+	fake.createWebhookSubscriptionReturnsOnCall[i] = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscription(arg1 string) error {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookSubscriptionReturnsOnCall[len(fake.deleteWebhookSubscriptionArgsForCall)]
+	fake.deleteWebhookSubscriptionArgsForCall = append(fake.deleteWebhookSubscriptionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteWebhookSubscriptionStub
+	fakeReturns := fake.deleteWebhookSubscriptionReturns
+	fake.recordInvocation("DeleteWebhookSubscription", []interface{}{arg1})
+	fake.deleteWebhookSubscriptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscriptionCallCount() int {
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	return len(fake.deleteWebhookSubscriptionArgsForCall)
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscriptionCalls(stub func(string) error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = stub
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscriptionArgsForCall(i int) string {
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	argsForCall := fake.deleteWebhookSubscriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscriptionReturns(result1 error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = nil
+	fake.deleteWebhookSubscriptionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookService) DeleteWebhookSubscriptionReturnsOnCall(i int, result1 error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = nil
+	if fake.deleteWebhookSubscriptionReturnsOnCall == nil {
+		fake.deleteWebhookSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteWebhookSubscriptionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookService) DeliverDueWebhooks() (int, error) {
+	fake.deliverDueWebhooksMutex.Lock()
+	ret, specificReturn := fake.deliverDueWebhooksReturnsOnCall[len(fake.deliverDueWebhooksArgsForCall)]
+	fake.deliverDueWebhooksArgsForCall = append(fake.deliverDueWebhooksArgsForCall, struct {
+	}{})
+	stub := fake.DeliverDueWebhooksStub
+	fakeReturns := fake.deliverDueWebhooksReturns
+	fake.recordInvocation("DeliverDueWebhooks", []interface{}{})
+	fake.deliverDueWebhooksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookService) DeliverDueWebhooksCallCount() int {
+	fake.deliverDueWebhooksMutex.RLock()
+	defer fake.deliverDueWebhooksMutex.RUnlock()
+	return len(fake.deliverDueWebhooksArgsForCall)
+}
+
+func (fake *FakeWebhookService) DeliverDueWebhooksCalls(stub func() (int, error)) {
+	fake.deliverDueWebhooksMutex.Lock()
+	defer fake.deliverDueWebhooksMutex.Unlock()
+	fake.DeliverDueWebhooksStub = stub
+}
+
+func (fake *FakeWebhookService) DeliverDueWebhooksReturns(result1 int, result2 error) {
+	fake.deliverDueWebhooksMutex.Lock()
+	defer fake.deliverDueWebhooksMutex.Unlock()
+	fake.DeliverDueWebhooksStub = nil
+	fake.deliverDueWebhooksReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) DeliverDueWebhooksReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deliverDueWebhooksMutex.Lock()
+	defer fake.deliverDueWebhooksMutex.Unlock()
+	fake.DeliverDueWebhooksStub = nil
+	if fake.deliverDueWebhooksReturnsOnCall == nil {
+		fake.deliverDueWebhooksReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deliverDueWebhooksReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveries(arg1 string) ([]domain.WebhookDelivery, error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.getWebhookDeliveriesReturnsOnCall[len(fake.getWebhookDeliveriesArgsForCall)]
+	fake.getWebhookDeliveriesArgsForCall = append(fake.getWebhookDeliveriesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetWebhookDeliveriesStub
+	fakeReturns := fake.getWebhookDeliveriesReturns
+	fake.recordInvocation("GetWebhookDeliveries", []interface{}{arg1})
+	fake.getWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveriesCallCount() int {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveriesCalls(stub func(string) ([]domain.WebhookDelivery, error)) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveriesArgsForCall(i int) string {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveriesReturns(result1 []domain.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = nil
+	fake.getWebhookDeliveriesReturns = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) GetWebhookDeliveriesReturnsOnCall(i int, result1 []domain.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = nil
+	if fake.getWebhookDeliveriesReturnsOnCall == nil {
+		fake.getWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []domain.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.getWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) GetWebhookSubscriptions() ([]domain.WebhookSubscription, error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	ret, specificReturn := fake.getWebhookSubscriptionsReturnsOnCall[len(fake.getWebhookSubscriptionsArgsForCall)]
+	fake.getWebhookSubscriptionsArgsForCall = append(fake.getWebhookSubscriptionsArgsForCall, struct {
+	}{})
+	stub := fake.GetWebhookSubscriptionsStub
+	fakeReturns := fake.getWebhookSubscriptionsReturns
+	fake.recordInvocation("GetWebhookSubscriptions", []interface{}{})
+	fake.getWebhookSubscriptionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookService) GetWebhookSubscriptionsCallCount() int {
+	fake.getWebhookSubscriptionsMutex.RLock()
+	defer fake.getWebhookSubscriptionsMutex.RUnlock()
+	return len(fake.getWebhookSubscriptionsArgsForCall)
+}
+
+func (fake *FakeWebhookService) GetWebhookSubscriptionsCalls(stub func() ([]domain.WebhookSubscription, error)) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = stub
+}
+
+func (fake *FakeWebhookService) GetWebhookSubscriptionsReturns(result1 []domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = nil
+	fake.getWebhookSubscriptionsReturns = struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) GetWebhookSubscriptionsReturnsOnCall(i int, result1 []domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = nil
+	if fake.getWebhookSubscriptionsReturnsOnCall == nil {
+		fake.getWebhookSubscriptionsReturnsOnCall = make(map[int]struct {
+			result1 []domain.WebhookSubscription
+			result2 error
+		})
+	}
+	fake.getWebhookSubscriptionsReturnsOnCall[i] = struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookService) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeWebhookService) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeWebhookService) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWebhookService) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWebhookService) Publish(arg1 application.EventEnvelope) error {
+	fake.publishMutex.Lock()
+	ret, specificReturn := fake.publishReturnsOnCall[len(fake.publishArgsForCall)]
+	fake.publishArgsForCall = append(fake.publishArgsForCall, struct {
+		arg1 application.EventEnvelope
+	}{arg1})
+	stub := fake.PublishStub
+	fakeReturns := fake.publishReturns
+	fake.recordInvocation("Publish", []interface{}{arg1})
+	fake.publishMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookService) PublishCallCount() int {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	return len(fake.publishArgsForCall)
+}
+
+func (fake *FakeWebhookService) PublishCalls(stub func(application.EventEnvelope) error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = stub
+}
+
+func (fake *FakeWebhookService) PublishArgsForCall(i int) application.EventEnvelope {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	argsForCall := fake.publishArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookService) PublishReturns(result1 error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = nil
+	fake.publishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookService) PublishReturnsOnCall(i int, result1 error) {
+	fake.publishMutex.Lock()
+	defer fake.publishMutex.Unlock()
+	fake.PublishStub = nil
+	if fake.publishReturnsOnCall == nil {
+		fake.publishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActive(arg1 string, arg2 bool) (*domain.WebhookSubscription, error) {
+	fake.updateWebhookSubscriptionActiveMutex.Lock()
+	ret, specificReturn := fake.updateWebhookSubscriptionActiveReturnsOnCall[len(fake.updateWebhookSubscriptionActiveArgsForCall)]
+	fake.updateWebhookSubscriptionActiveArgsForCall = append(fake.updateWebhookSubscriptionActiveArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.UpdateWebhookSubscriptionActiveStub
+	fakeReturns := fake.updateWebhookSubscriptionActiveReturns
+	fake.recordInvocation("UpdateWebhookSubscriptionActive", []interface{}{arg1, arg2})
+	fake.updateWebhookSubscriptionActiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActiveCallCount() int {
+	fake.updateWebhookSubscriptionActiveMutex.RLock()
+	defer fake.updateWebhookSubscriptionActiveMutex.RUnlock()
+	return len(fake.updateWebhookSubscriptionActiveArgsForCall)
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActiveCalls(stub func(string, bool) (*domain.WebhookSubscription, error)) {
+	fake.updateWebhookSubscriptionActiveMutex.Lock()
+	defer fake.updateWebhookSubscriptionActiveMutex.Unlock()
+	fake.UpdateWebhookSubscriptionActiveStub = stub
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActiveArgsForCall(i int) (string, bool) {
+	fake.updateWebhookSubscriptionActiveMutex.RLock()
+	defer fake.updateWebhookSubscriptionActiveMutex.RUnlock()
+	argsForCall := fake.updateWebhookSubscriptionActiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActiveReturns(result1 *domain.WebhookSubscription, result2 error) {
+	fake.updateWebhookSubscriptionActiveMutex.Lock()
+	defer fake.updateWebhookSubscriptionActiveMutex.Unlock()
+	fake.UpdateWebhookSubscriptionActiveStub = nil
+	fake.updateWebhookSubscriptionActiveReturns = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) UpdateWebhookSubscriptionActiveReturnsOnCall(i int, result1 *domain.WebhookSubscription, result2 error) {
+	fake.updateWebhookSubscriptionActiveMutex.Lock()
+	defer fake.updateWebhookSubscriptionActiveMutex.Unlock()
+	fake.UpdateWebhookSubscriptionActiveStub = nil
+	if fake.updateWebhookSubscriptionActiveReturnsOnCall == nil {
+		fake.updateWebhookSubscriptionActiveReturnsOnCall = make(map[int]struct {
+			result1 *domain.WebhookSubscription
+			result2 error
+		})
+	}
+	fake.updateWebhookSubscriptionActiveReturnsOnCall[i] = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	fake.deliverDueWebhooksMutex.RLock()
+	defer fake.deliverDueWebhooksMutex.RUnlock()
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	fake.getWebhookSubscriptionsMutex.RLock()
+	defer fake.getWebhookSubscriptionsMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	fake.updateWebhookSubscriptionActiveMutex.RLock()
+	defer fake.updateWebhookSubscriptionActiveMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ application.WebhookService = new(FakeWebhookService)
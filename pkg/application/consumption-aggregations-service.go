@@ -0,0 +1,144 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type Reducer func(values []float64) float64
+
+type EnergyAggregation struct {
+	ActiveEnergy       float64
+	ReactiveEnergy     float64
+	CapacitiveReactive float64
+	Exported           float64
+}
+
+type AggregationSerializer struct {
+	Active             []float64 `json:"active"`
+	ReactiveInductive  []float64 `json:"reactive_inductive"`
+	ReactiveCapacitive []float64 `json:"reactive_capacitive"`
+	Exported           []float64 `json:"exported"`
+}
+
+var reducers = map[string]Reducer{
+	constants.AggregationMax:   maxReducer,
+	constants.AggregationMin:   minReducer,
+	constants.AggregationMean:  meanReducer,
+	constants.AggregationCount: countReducer,
+	constants.AggregationP95: func(values []float64) float64 {
+		return percentileReducer(values, 95)
+	},
+}
+
+// ChekingAggregations: this function check if all the aggregations are allowed
+//
+// Parameters:
+// aggregations: the aggregations separated by comma example "max,p95"
+//
+// Returns:
+// return the aggregations without duplicates or an error if one of them is not allowed
+func ChekingAggregations(aggregations string) ([]string, error) {
+	var checkedAggregations []string
+	seenAggregations := make(map[string]bool)
+	for _, aggregation := range strings.Split(aggregations, ",") {
+		trimAndLowerCaseAggregation := strings.Trim(strings.ToLower(aggregation), " ")
+		if trimAndLowerCaseAggregation == "" || seenAggregations[trimAndLowerCaseAggregation] {
+			continue
+		}
+		if _, ok := reducers[trimAndLowerCaseAggregation]; !ok {
+			return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "aggregations", fmt.Sprintf("Error: aggregation not allowed %s", trimAndLowerCaseAggregation))
+		}
+		seenAggregations[trimAndLowerCaseAggregation] = true
+		checkedAggregations = append(checkedAggregations, trimAndLowerCaseAggregation)
+	}
+	return checkedAggregations, nil
+}
+
+// ReduceAggregations: run the selected reducers over the records of every group division
+//
+// Parameters:
+// groupConsumptions: has the information matched between groups and information
+// aggregations: the reducers to run
+func ReduceAggregations(groupConsumptions []*ConsumptionEnergy, aggregations []string) {
+	if len(aggregations) == 0 {
+		return
+	}
+	for _, groupConsumption := range groupConsumptions {
+		var active, reactive, capacitive, exported []float64
+		for _, group := range groupConsumption.Data {
+			active = append(active, group.ActiveEnergy)
+			reactive = append(reactive, group.ReactiveEnergy)
+			capacitive = append(capacitive, group.CapacitiveReactive)
+			exported = append(exported, group.Solar)
+		}
+		groupConsumption.Aggregations = make(map[string]EnergyAggregation, len(aggregations))
+		for _, aggregation := range aggregations {
+			reducer := reducers[aggregation]
+			groupConsumption.Aggregations[aggregation] = EnergyAggregation{
+				ActiveEnergy:       reducer(active),
+				ReactiveEnergy:     reducer(reactive),
+				CapacitiveReactive: reducer(capacitive),
+				Exported:           reducer(exported),
+			}
+		}
+	}
+	logrus.Info("Reduce aggregations is done")
+}
+
+func maxReducer(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	result := values[0]
+	for _, value := range values[1:] {
+		result = math.Max(result, value)
+	}
+	return result
+}
+
+func minReducer(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	result := values[0]
+	for _, value := range values[1:] {
+		result = math.Min(result, value)
+	}
+	return result
+}
+
+func meanReducer(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func countReducer(values []float64) float64 {
+	return float64(len(values))
+}
+
+// percentileReducer: compute the percentile with linear interpolation between the closest ranks
+func percentileReducer(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sortedValues := append([]float64(nil), values...)
+	sort.Float64s(sortedValues)
+	rank := percentile / 100 * float64(len(sortedValues)-1)
+	lowerRank := int(math.Floor(rank))
+	upperRank := int(math.Ceil(rank))
+	weight := rank - float64(lowerRank)
+	return sortedValues[lowerRank]*(1-weight) + sortedValues[upperRank]*weight
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChekingAggregations", func() {
+	It("should return the aggregations without duplicates", func() {
+		aggregations, err := ChekingAggregations(" MAX,p95,max,,count")
+		Expect(err).To(BeNil())
+		Expect(aggregations).To(Equal([]string{"max", "p95", "count"}))
+	})
+
+	It("should return an error for an unknown aggregation", func() {
+		aggregations, err := ChekingAggregations("max,median")
+		Expect(err).To(HaveOccurred())
+		Expect(aggregations).To(BeNil())
+	})
+})
+
+var _ = Describe("ReduceAggregations", func() {
+	var groupConsumptions []*ConsumptionEnergy
+
+	BeforeEach(func() {
+		groupConsumptions = []*ConsumptionEnergy{
+			{
+				Data: []domain.UserConsumption{
+					{ActiveEnergy: 10, ReactiveEnergy: 1, Solar: 4},
+					{ActiveEnergy: 30, ReactiveEnergy: 3, Solar: 0},
+					{ActiveEnergy: 20, ReactiveEnergy: 2, Solar: 2},
+				},
+			},
+		}
+	})
+
+	It("should run every selected reducer by group division", func() {
+		ReduceAggregations(groupConsumptions, []string{"max", "min", "mean", "count", "p95"})
+
+		aggregations := groupConsumptions[0].Aggregations
+		Expect(aggregations["max"].ActiveEnergy).To(Equal(float64(30)))
+		Expect(aggregations["max"].Exported).To(Equal(float64(4)))
+		Expect(aggregations["min"].ActiveEnergy).To(Equal(float64(10)))
+		Expect(aggregations["mean"].ReactiveEnergy).To(Equal(float64(2)))
+		Expect(aggregations["count"].ActiveEnergy).To(Equal(float64(3)))
+		Expect(aggregations["p95"].ActiveEnergy).To(BeNumerically("~", 29))
+	})
+
+	It("should not compute aggregations when none is selected", func() {
+		ReduceAggregations(groupConsumptions, nil)
+		Expect(groupConsumptions[0].Aggregations).To(BeNil())
+	})
+})
+
+var _ = Describe("GetConsumptionByMeterIDAndWindowTime with aggregations", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo   *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+	})
+
+	It("should return the aggregated series next to the sums", func() {
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 5, Date: time.Date(2023, 1, 2, 1, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 7, Date: time.Date(2023, 1, 2, 2, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 1, Date: time.Date(2023, 1, 3, 1, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-01-02", "2023-01-03", "daily", domain.ConsumptionQueryOptions{Aggregations: "max,count"})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Active).To(Equal([]float64{12, 1}))
+		Expect(result[0].Aggregations["max"].Active).To(Equal([]float64{7, 1}))
+		Expect(result[0].Aggregations["count"].Active).To(Equal([]float64{2, 1}))
+	})
+
+	It("should return an error when an aggregation is not allowed", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-01-02", "2023-01-03", "daily", domain.ConsumptionQueryOptions{Aggregations: "median"})
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
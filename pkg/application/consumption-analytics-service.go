@@ -0,0 +1,158 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type AnalyticsSerializer struct {
+	MeterID     int                   `json:"meter_id"`
+	LoadProfile LoadProfileSerializer `json:"load_profile"`
+	LoadFactor  LoadFactorSerializer  `json:"load_factor"`
+}
+
+type LoadProfileSerializer struct {
+	Period  []string  `json:"period"`
+	Weekday []float64 `json:"weekday"`
+	Weekend []float64 `json:"weekend"`
+}
+
+type LoadFactorSerializer struct {
+	Period        []string  `json:"period"`
+	Energy        []float64 `json:"energy"`
+	AverageDemand []float64 `json:"average_demand"`
+	PeakDemand    []float64 `json:"peak_demand"`
+	LoadFactor    []float64 `json:"load_factor"`
+}
+
+// GetLoadProfile: compute the average daily load profile, the mean of the active energy consumed in every hour of
+// the day split by weekdays and weekends
+//
+// Parameters:
+// data: the readings of the meter
+//
+// Returns:
+// return the mean consumption by hour of the day for weekdays and weekends
+func GetLoadProfile(data []domain.UserConsumption) LoadProfileSerializer {
+	profile := LoadProfileSerializer{
+		Period:  make([]string, 24),
+		Weekday: make([]float64, 24),
+		Weekend: make([]float64, 24),
+	}
+	for hour := 0; hour < 24; hour++ {
+		profile.Period[hour] = domain.TimeTostr(time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC), constants.DateFormatHourOfDay)
+	}
+
+	energyByHour := make(map[time.Time]float64)
+	for _, reading := range data {
+		energyByHour[reading.Date.Truncate(time.Hour)] += reading.ActiveEnergy
+	}
+	var weekdayDays, weekendDays [24]int
+	for hour, energy := range energyByHour {
+		if isWeekend(hour) {
+			profile.Weekend[hour.Hour()] += energy
+			weekendDays[hour.Hour()]++
+			continue
+		}
+		profile.Weekday[hour.Hour()] += energy
+		weekdayDays[hour.Hour()]++
+	}
+	for hour := 0; hour < 24; hour++ {
+		if weekdayDays[hour] > 0 {
+			profile.Weekday[hour] /= float64(weekdayDays[hour])
+		}
+		if weekendDays[hour] > 0 {
+			profile.Weekend[hour] /= float64(weekendDays[hour])
+		}
+	}
+	return profile
+}
+
+// GetLoadFactor: compute the load factor of every month in the window, the average demand divided by the peak demand
+//
+// Parameters:
+// data: the readings of the meter
+// startDate: the start of the window
+// endDate: the end of the window
+// windowLength: the length of the demand window used to find the peak demand
+//
+// Returns:
+// return the energy, average demand, peak demand and load factor of every month in the window
+func GetLoadFactor(data []domain.UserConsumption, startDate, endDate time.Time, windowLength time.Duration) LoadFactorSerializer {
+	loadFactor := LoadFactorSerializer{
+		Period:        []string{},
+		Energy:        []float64{},
+		AverageDemand: []float64{},
+		PeakDemand:    []float64{},
+		LoadFactor:    []float64{},
+	}
+	sortedData := append([]domain.UserConsumption(nil), data...)
+	sort.Slice(sortedData, func(i, j int) bool {
+		return sortedData[i].Date.Before(sortedData[j].Date)
+	})
+	readingInterval := InferReadingInterval(sortedData, windowLength)
+	if readingInterval > windowLength {
+		windowLength = readingInterval
+	}
+	peaks := MonthlyPeakDemand(CalculateDemandWindows(sortedData, windowLength, constants.DemandWindowTypeFixed))
+
+	for month := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location()); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+		monthStart, monthEnd := month, month.AddDate(0, 1, 0)
+		if monthStart.Before(startDate) {
+			monthStart = startDate
+		}
+		if monthEnd.After(endDate) {
+			monthEnd = endDate.Add(time.Second)
+		}
+		var energy, peakDemand float64
+		for _, reading := range sortedData {
+			if sameMonth(reading.Date, month) {
+				energy += reading.ActiveEnergy
+			}
+		}
+		for _, peak := range peaks {
+			if sameMonth(peak.StartDate, month) {
+				peakDemand = peak.Demand
+			}
+		}
+		averageDemand := energy / monthEnd.Sub(monthStart).Hours()
+		var factor float64
+		if peakDemand > 0 {
+			factor = averageDemand / peakDemand
+		}
+		loadFactor.Period = append(loadFactor.Period, domain.TimeTostr(month, constants.DateFormatMonthlyPeriod))
+		loadFactor.Energy = append(loadFactor.Energy, energy)
+		loadFactor.AverageDemand = append(loadFactor.AverageDemand, averageDemand)
+		loadFactor.PeakDemand = append(loadFactor.PeakDemand, peakDemand)
+		loadFactor.LoadFactor = append(loadFactor.LoadFactor, factor)
+	}
+	return loadFactor
+}
+
+// GetAnalyticsData: compute the load profile and the load factor of a meter
+//
+// Parameters:
+// meterID: the meter of the readings
+// data: the readings of the meter
+// queryParams: the query params checked
+// windowLength: the length of the demand window used to find the peak demand
+//
+// Returns:
+// return the analytics of the meter ready to serialize
+func GetAnalyticsData(meterID int, data []domain.UserConsumption, queryParams *domain.UserConsumptionQueryParams, windowLength time.Duration) AnalyticsSerializer {
+	analytics := AnalyticsSerializer{
+		MeterID:     meterID,
+		LoadProfile: GetLoadProfile(data),
+		LoadFactor:  GetLoadFactor(data, queryParams.StartDate, queryParams.EndDate, windowLength),
+	}
+	logrus.Infof("Analytics of the meter %d is done", meterID)
+	return analytics
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
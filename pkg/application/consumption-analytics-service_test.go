@@ -0,0 +1,87 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetLoadProfile", func() {
+	It("should average the consumption by hour of the day split by weekday and weekend", func() {
+		data := []domain.UserConsumption{
+			// Monday and Tuesday at 08:00, two readings by hour on Monday
+			{ActiveEnergy: 2, Date: time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC)},
+			{ActiveEnergy: 2, Date: time.Date(2023, 1, 2, 8, 30, 0, 0, time.UTC)},
+			{ActiveEnergy: 6, Date: time.Date(2023, 1, 3, 8, 0, 0, 0, time.UTC)},
+			// Saturday at 20:00
+			{ActiveEnergy: 9, Date: time.Date(2023, 1, 7, 20, 0, 0, 0, time.UTC)},
+		}
+
+		profile := GetLoadProfile(data)
+
+		Expect(profile.Period).To(HaveLen(24))
+		Expect(profile.Period[8]).To(Equal("08:00"))
+		Expect(profile.Weekday[8]).To(Equal(float64(5)))
+		Expect(profile.Weekend[8]).To(Equal(float64(0)))
+		Expect(profile.Weekend[20]).To(Equal(float64(9)))
+	})
+})
+
+var _ = Describe("GetLoadFactor", func() {
+	It("should divide the average demand by the peak demand for every month of the window", func() {
+		startDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2023, 2, 28, 23, 59, 59, 0, time.UTC)
+		var data []domain.UserConsumption
+		for hour := 0; hour < 31*24; hour++ {
+			energy := float64(1)
+			if hour == 10 {
+				energy = 4
+			}
+			data = append(data, domain.UserConsumption{ActiveEnergy: energy, Date: startDate.Add(time.Duration(hour) * time.Hour)})
+		}
+
+		loadFactor := GetLoadFactor(data, startDate, endDate, 15*time.Minute)
+
+		Expect(loadFactor.Period).To(Equal([]string{"Jan 2023", "Feb 2023"}))
+		Expect(loadFactor.Energy).To(Equal([]float64{747, 0}))
+		Expect(loadFactor.PeakDemand).To(Equal([]float64{4, 0}))
+		Expect(loadFactor.AverageDemand[0]).To(BeNumerically("~", 747.0/744.0))
+		Expect(loadFactor.LoadFactor[0]).To(BeNumerically("~", 747.0/744.0/4))
+		Expect(loadFactor.LoadFactor[1]).To(Equal(float64(0)))
+	})
+})
+
+var _ = Describe("GetAnalyticsByMeterIDAndWindowTime", func() {
+	var (
+		mockMySQLRepo *domainfakes.FakeMySQLPowerConsumptionRepository
+		service       PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+	})
+
+	It("should return the analytics of every meter", func() {
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{ActiveEnergy: 3, Date: time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		result, err := service.GetAnalyticsByMeterIDAndWindowTime("1,2", "2023-01-01", "2023-01-31", "")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].LoadProfile.Weekday[8]).To(Equal(float64(3)))
+		Expect(result[1].LoadFactor.Period).To(Equal([]string{"Jan 2023"}))
+	})
+
+	It("should return an error when the query params are not valid", func() {
+		result, err := service.GetAnalyticsByMeterIDAndWindowTime("1", "2023-02-01", "2023-01-01", "")
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type BillingCycleFilter struct {
+	Filter
+	CycleDay int
+}
+
+// ChekingBillingCycleDay: this function check the day of the month that starts the billing cycles, the days that do
+// not exist in a month are moved to the last day of the month
+//
+// Parameters:
+// cycleDay: the day of the month
+//
+// Returns:
+// return an error if the day is not valid
+func ChekingBillingCycleDay(cycleDay int) error {
+	if cycleDay < 1 || cycleDay > 31 {
+		return domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", fmt.Sprintf("Error: billing cycle day not allowed %d", cycleDay))
+	}
+	return nil
+}
+
+// ChekingBillingCycleOption: this function check the billing cycle day of the request
+//
+// Parameters:
+// billingCycleDay: the day of the month, blank to use the day of every meter
+//
+// Returns:
+// return the day or 0 when the day of every meter must be used
+func ChekingBillingCycleOption(billingCycleDay string) (int, error) {
+	if billingCycleDay == "" {
+		return 0, nil
+	}
+	cycleDay, err := domain.StrToInt(billingCycleDay)
+	if err != nil {
+		return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", fmt.Sprintf("Error: billing cycle day not allowed %s", billingCycleDay))
+	}
+	if err := ChekingBillingCycleDay(cycleDay); err != nil {
+		return 0, err
+	}
+	return cycleDay, nil
+}
+
+// ResolveBillingCycleDay: find the billing cycle day of the meters, all the meters must have the same day
+//
+// Parameters:
+// meterSettingRepository: the repository to get the settings of the meters
+// meterIDs: the meters
+//
+// Returns:
+// return the billing cycle day or an error if a meter does not have it or the meters have different days
+func ResolveBillingCycleDay(meterSettingRepository domain.MeterSettingRepository, meterIDs []int) (int, error) {
+	cycleDay := 0
+	for _, meterID := range meterIDs {
+		setting, err := meterSettingRepository.GetMeterSettingByMeterID(meterID)
+		if err != nil {
+			return 0, err
+		}
+		if setting == nil || setting.BillingCycleDay == 0 {
+			logrus.Errorf("Error: the meter %d does not have a billing cycle day", meterID)
+			return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", fmt.Sprintf("Error: the meter %d does not have a billing cycle day", meterID))
+		}
+		if cycleDay != 0 && cycleDay != setting.BillingCycleDay {
+			logrus.Errorf("Error: the meters have different billing cycle days %d %d", cycleDay, setting.BillingCycleDay)
+			return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", fmt.Sprintf("Error: the meters have different billing cycle days %d %d", cycleDay, setting.BillingCycleDay))
+		}
+		cycleDay = setting.BillingCycleDay
+	}
+	return cycleDay, nil
+}
+
+// WindowGroupDivision: do the group division for a billing cycle filter, the cycles go from the cycle day of a
+// month to the day before the cycle day of the next month
+//
+// Returns:
+// return the cycles that intersect the window
+func (b *BillingCycleFilter) WindowGroupDivision() []TimeGroupDivision {
+	return b.cyclesBetween(b.StartDate, b.EndDate)
+}
+
+// GroupDivision: do the group division for a billing cycle filter, the cycles that intersect the month
+//
+// Parameters:
+// month
+// year
+//
+// Returns:
+// return the time group division for a billing cycle filter
+func (b *BillingCycleFilter) GroupDivision(month, year int) []TimeGroupDivision {
+	if month < 1 || month > 12 {
+		return []TimeGroupDivision{}
+	}
+	initialDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	lastDate := initialDate.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	return b.cyclesBetween(initialDate, lastDate)
+}
+
+// GroupsSerializedToString: serialize the cycle with its first and last day
+// example "2023-05-17" and "2023-06-16" --> "2023-05-17 - 2023-06-16"
+//
+// Parameters:
+// startDate
+// endDate
+//
+// Returns:
+// return the cycle in a correct way "2023-05-17 - 2023-06-16"
+func (b *BillingCycleFilter) GroupsSerializedToString(startDate time.Time, endDate time.Time) string {
+	if startDate.After(endDate) {
+		return ""
+	}
+	return fmt.Sprintf("%s - %s", startDate.Format(constants.DateFormatDate), endDate.Format(constants.DateFormatDate))
+}
+
+func (b *BillingCycleFilter) cyclesBetween(startDate, endDate time.Time) []TimeGroupDivision {
+	var cycleGroups []TimeGroupDivision
+	if ChekingBillingCycleDay(b.CycleDay) != nil {
+		return cycleGroups
+	}
+	initialDate := b.cycleStart(startDate.Year(), startDate.Month(), startDate.Location())
+	if initialDate.After(startDate) {
+		initialDate = b.cycleStart(startDate.Year(), startDate.Month()-1, startDate.Location())
+	}
+	for !initialDate.After(endDate) {
+		nextDate := b.cycleStart(initialDate.Year(), initialDate.Month()+1, initialDate.Location())
+		cycleGroups = append(cycleGroups, TimeGroupDivision{
+			InitDate:   initialDate,
+			FinishDate: nextDate.Add(-time.Nanosecond),
+		})
+		initialDate = nextDate
+	}
+	return cycleGroups
+}
+
+func (b *BillingCycleFilter) cycleStart(year int, month time.Month, location *time.Location) time.Time {
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, location)
+	day := b.CycleDay
+	if lastDay := b.daysInMonth(int(firstDay.Month()), firstDay.Year()); day > lastDay {
+		day = lastDay
+	}
+	return firstDay.AddDate(0, 0, day-1)
+}
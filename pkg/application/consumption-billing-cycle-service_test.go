@@ -0,0 +1,131 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Billing cycle filter", func() {
+	var (
+		billingCycleFilter *BillingCycleFilter
+	)
+
+	BeforeEach(func() {
+		billingCycleFilter = &BillingCycleFilter{Filter{
+			StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2023, 7, 31, 23, 59, 59, 0, time.UTC),
+		}, 17}
+	})
+
+	Context("WindowGroupDivision", func() {
+		It("should begin with the cycle that contains the start date", func() {
+			result := billingCycleFilter.WindowGroupDivision()
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].InitDate).To(Equal(time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)))
+			Expect(result[0].FinishDate).To(Equal(time.Date(2023, 6, 17, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)))
+			Expect(result[2].InitDate).To(Equal(time.Date(2023, 7, 17, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should move the cycle day to the last day of the short months", func() {
+			billingCycleFilter.CycleDay = 31
+			result := billingCycleFilter.GroupDivision(2, 2023)
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].InitDate).To(Equal(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)))
+			Expect(result[1].InitDate).To(Equal(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)))
+			Expect(result[1].FinishDate).To(Equal(time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)))
+		})
+
+		It("should handle an invalid month correctly", func() {
+			result := billingCycleFilter.GroupDivision(13, 2023)
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Context("GroupsSerializedToString", func() {
+		It("should serialize the first and the last day of the cycle", func() {
+			startDate := time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)
+			result := billingCycleFilter.GroupsSerializedToString(startDate, startDate.AddDate(0, 1, 0).Add(-time.Nanosecond))
+			Expect(result).To(Equal("2023-05-17 - 2023-06-16"))
+		})
+	})
+})
+
+var _ = Describe("ResolveBillingCycleDay", func() {
+	var (
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+	)
+
+	BeforeEach(func() {
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+	})
+
+	It("should return the day shared by the meters", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDReturns(&domain.MeterSetting{BillingCycleDay: 17}, nil)
+		cycleDay, err := ResolveBillingCycleDay(mockMeterSettingRepo, []int{1, 2})
+		Expect(err).To(BeNil())
+		Expect(cycleDay).To(Equal(17))
+	})
+
+	It("should return an error when a meter does not have settings", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDReturns(nil, nil)
+		_, err := ResolveBillingCycleDay(mockMeterSettingRepo, []int{1})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error when the meters have different days", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDStub = func(meterID int) (*domain.MeterSetting, error) {
+			return &domain.MeterSetting{MeterID: meterID, BillingCycleDay: meterID}, nil
+		}
+		_, err := ResolveBillingCycleDay(mockMeterSettingRepo, []int{1, 2})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("GetConsumptionByMeterIDAndWindowTime with billing cycles", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 1, Date: time.Date(2023, 6, 16, 12, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 2, Date: time.Date(2023, 6, 17, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+	})
+
+	It("should use the billing cycle day of the meter", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDReturns(&domain.MeterSetting{MeterID: 1, BillingCycleDay: 17}, nil)
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "billing_cycle", domain.ConsumptionQueryOptions{})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Period).To(Equal([]string{"2023-05-17 - 2023-06-16", "2023-06-17 - 2023-07-16"}))
+		Expect(result[0].Active).To(Equal([]float64{1, 2}))
+	})
+
+	It("should prefer the billing cycle day of the request", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDReturns(&domain.MeterSetting{MeterID: 1, BillingCycleDay: 17}, nil)
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "billing_cycle", domain.ConsumptionQueryOptions{BillingCycleDay: "1"})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Period).To(Equal([]string{"2023-06-01 - 2023-06-30"}))
+		Expect(result[0].Active).To(Equal([]float64{3}))
+	})
+
+	It("should return an error when the meter does not have a billing cycle day", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "billing_cycle", domain.ConsumptionQueryOptions{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
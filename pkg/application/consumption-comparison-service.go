@@ -0,0 +1,183 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type ComparisonSerializer struct {
+	StartDate          string                `json:"start_date"`
+	EndDate            string                `json:"end_date"`
+	Period             []string              `json:"period"`
+	Active             []float64             `json:"active"`
+	ReactiveInductive  []float64             `json:"reactive_inductive"`
+	ReactiveCapacitive []float64             `json:"reactive_capacitive"`
+	Exported           []float64             `json:"exported"`
+	Delta              EnergyDelta           `json:"delta"`
+	DeltaPercentage    EnergyPercentageDelta `json:"delta_percentage"`
+}
+
+type EnergyDelta struct {
+	Active             []float64 `json:"active"`
+	ReactiveInductive  []float64 `json:"reactive_inductive"`
+	ReactiveCapacitive []float64 `json:"reactive_capacitive"`
+	Exported           []float64 `json:"exported"`
+}
+
+// EnergyPercentageDelta: the values are nil when the compared value is zero
+type EnergyPercentageDelta struct {
+	Active             []*float64 `json:"active"`
+	ReactiveInductive  []*float64 `json:"reactive_inductive"`
+	ReactiveCapacitive []*float64 `json:"reactive_capacitive"`
+	Exported           []*float64 `json:"exported"`
+}
+
+// ChekingCompareTo: this function check if the comparison mode is allowed and compute the window to compare
+//
+// Parameters:
+// compareTo: the comparison mode previous_period, previous_year or custom
+// startDate: the start date of the current window
+// endDate: the end date of the current window
+// compareStartDate: the start date of the custom window
+// compareEndDate: the end date of the custom window
+//
+// Returns:
+// return the start and end date of the window to compare
+func ChekingCompareTo(compareTo string, startDate, endDate time.Time, compareStartDate, compareEndDate string) (string, time.Time, time.Time, error) {
+	trimAndLowerCaseCompareTo := strings.Trim(strings.ToLower(compareTo), " ")
+	switch trimAndLowerCaseCompareTo {
+	case constants.CompareToPreviousPeriod:
+		if months, ok := wholeMonths(startDate, endDate); ok {
+			return trimAndLowerCaseCompareTo, startDate.AddDate(0, -months, 0), startDate.Add(-time.Second), nil
+		}
+		duration := endDate.Sub(startDate) + time.Second
+		return trimAndLowerCaseCompareTo, startDate.Add(-duration), startDate.Add(-time.Second), nil
+	case constants.CompareToPreviousYear:
+		return trimAndLowerCaseCompareTo, shiftYears(startDate, -1), shiftYears(endDate.Add(time.Second), -1).Add(-time.Second), nil
+	case constants.CompareToCustom:
+		timeCompareStartDate, err := domain.StrToDate(compareStartDate)
+		if err != nil {
+			logrus.Errorf("Error: converting string to date compareStartDate %s", err.Error())
+			return "", time.Time{}, time.Time{}, err
+		}
+		timeCompareEndDate, err := domain.StrToDate(compareEndDate)
+		if err != nil {
+			logrus.Errorf("Error: converting string to date compareEndDate %s", err.Error())
+			return "", time.Time{}, time.Time{}, err
+		}
+		if timeCompareStartDate.After(timeCompareEndDate) {
+			return "", time.Time{}, time.Time{}, domain.NewValidationError(constants.ErrorCodeInvalidDateRange, "compare_start_date", fmt.Sprintf("Error: Invalid compare dates, start date must be before end date %s %s", compareStartDate, compareEndDate))
+		}
+		return trimAndLowerCaseCompareTo, timeCompareStartDate, timeCompareEndDate.AddDate(0, 0, 1).Add(-time.Second), nil
+	default:
+		return "", time.Time{}, time.Time{}, domain.NewValidationError(constants.ErrorCodeInvalidParam, "compare_to", fmt.Sprintf("Error: compare to not allowed %s", trimAndLowerCaseCompareTo))
+	}
+}
+
+// ShiftToComparisonWindow: move a date of the current window to the equivalent date in the compared window
+//
+// Parameters:
+// queryParams: has the current and the compared windows
+// date: the date in the current window
+//
+// Returns:
+// return the equivalent date in the compared window
+func ShiftToComparisonWindow(queryParams *domain.UserConsumptionQueryParams, date time.Time) time.Time {
+	switch queryParams.CompareTo {
+	case constants.CompareToPreviousYear:
+		return shiftYears(date, -1)
+	case constants.CompareToPreviousPeriod:
+		if months, ok := wholeMonths(queryParams.StartDate, queryParams.EndDate); ok {
+			return shiftMonths(date, -months)
+		}
+	}
+	return date.Add(queryParams.CompareStartDate.Sub(queryParams.StartDate))
+}
+
+// CompareConsumptionEnergy: align the groups of the compared window with the groups of the current window and
+// compute the absolute and percentage deltas
+//
+// Parameters:
+// filter: the filter used to reduce the information, it gives the period labels
+// queryParams: has the current and the compared windows
+// current: the reduced groups of the current window
+// previous: the reduced groups of the compared window
+//
+// Returns:
+// return the compared series aligned one to one with the current series
+func CompareConsumptionEnergy(filter FilterOperations, queryParams *domain.UserConsumptionQueryParams, current, previous []*ConsumptionEnergy) *ComparisonSerializer {
+	comparison := &ComparisonSerializer{
+		StartDate: domain.TimeTostr(queryParams.CompareStartDate, constants.DateFormatDate),
+		EndDate:   domain.TimeTostr(queryParams.CompareEndDate, constants.DateFormatDate),
+	}
+	for _, currentEnergy := range current {
+		anchorDate := currentEnergy.StartDate
+		if anchorDate.Before(queryParams.StartDate) {
+			anchorDate = queryParams.StartDate
+		}
+		shiftedDate := ShiftToComparisonWindow(queryParams, anchorDate)
+		previousEnergy := &ConsumptionEnergy{
+			StartDate: ShiftToComparisonWindow(queryParams, currentEnergy.StartDate),
+			EndDate:   ShiftToComparisonWindow(queryParams, currentEnergy.EndDate),
+		}
+		for _, energy := range previous {
+			if !shiftedDate.Before(energy.StartDate) && !shiftedDate.After(energy.EndDate) {
+				previousEnergy = energy
+				break
+			}
+		}
+
+		comparison.Period = append(comparison.Period, filter.GroupsSerializedToString(previousEnergy.StartDate, previousEnergy.EndDate))
+		comparison.Active = append(comparison.Active, previousEnergy.ActiveEnergy)
+		comparison.ReactiveInductive = append(comparison.ReactiveInductive, previousEnergy.ReactiveEnergy)
+		comparison.ReactiveCapacitive = append(comparison.ReactiveCapacitive, previousEnergy.CapacitiveReactive)
+		comparison.Exported = append(comparison.Exported, previousEnergy.Exported)
+
+		comparison.Delta.Active = append(comparison.Delta.Active, currentEnergy.ActiveEnergy-previousEnergy.ActiveEnergy)
+		comparison.Delta.ReactiveInductive = append(comparison.Delta.ReactiveInductive, currentEnergy.ReactiveEnergy-previousEnergy.ReactiveEnergy)
+		comparison.Delta.ReactiveCapacitive = append(comparison.Delta.ReactiveCapacitive, currentEnergy.CapacitiveReactive-previousEnergy.CapacitiveReactive)
+		comparison.Delta.Exported = append(comparison.Delta.Exported, currentEnergy.Exported-previousEnergy.Exported)
+
+		comparison.DeltaPercentage.Active = append(comparison.DeltaPercentage.Active, percentageDelta(currentEnergy.ActiveEnergy, previousEnergy.ActiveEnergy))
+		comparison.DeltaPercentage.ReactiveInductive = append(comparison.DeltaPercentage.ReactiveInductive, percentageDelta(currentEnergy.ReactiveEnergy, previousEnergy.ReactiveEnergy))
+		comparison.DeltaPercentage.ReactiveCapacitive = append(comparison.DeltaPercentage.ReactiveCapacitive, percentageDelta(currentEnergy.CapacitiveReactive, previousEnergy.CapacitiveReactive))
+		comparison.DeltaPercentage.Exported = append(comparison.DeltaPercentage.Exported, percentageDelta(currentEnergy.Exported, previousEnergy.Exported))
+	}
+	logrus.Info("Comparison between windows is done")
+	return comparison
+}
+
+func percentageDelta(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percentage := (current - previous) / previous * 100
+	return &percentage
+}
+
+// wholeMonths: check if the window starts the first day of a month and ends the last day of a month
+func wholeMonths(startDate, endDate time.Time) (int, bool) {
+	nextDate := endDate.Add(time.Second)
+	if startDate.Day() != 1 || nextDate.Day() != 1 || !startDate.Equal(time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location())) {
+		return 0, false
+	}
+	months := (nextDate.Year()-startDate.Year())*12 + int(nextDate.Month()) - int(startDate.Month())
+	return months, months > 0
+}
+
+func shiftMonths(date time.Time, months int) time.Time {
+	shifted := date.AddDate(0, months, 0)
+	if shifted.Day() != date.Day() {
+		shifted = shifted.AddDate(0, 0, -shifted.Day())
+	}
+	return shifted
+}
+
+func shiftYears(date time.Time, years int) time.Time {
+	return shiftMonths(date, years*12)
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChekingCompareTo", func() {
+	var (
+		startDate time.Time
+		endDate   time.Time
+	)
+
+	BeforeEach(func() {
+		startDate = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+		endDate = time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC)
+	})
+
+	It("should compare a whole month with the previous month", func() {
+		compareTo, compareStartDate, compareEndDate, err := ChekingCompareTo("previous_period", startDate, endDate, "", "")
+		Expect(err).To(BeNil())
+		Expect(compareTo).To(Equal(constants.CompareToPreviousPeriod))
+		Expect(compareStartDate).To(Equal(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(compareEndDate).To(Equal(time.Date(2023, 5, 31, 23, 59, 59, 0, time.UTC)))
+	})
+
+	It("should compare a partial window with the same number of days before it", func() {
+		endDate = time.Date(2023, 6, 10, 23, 59, 59, 0, time.UTC)
+		_, compareStartDate, compareEndDate, err := ChekingCompareTo("previous_period", startDate, endDate, "", "")
+		Expect(err).To(BeNil())
+		Expect(compareStartDate).To(Equal(time.Date(2023, 5, 22, 0, 0, 0, 0, time.UTC)))
+		Expect(compareEndDate).To(Equal(time.Date(2023, 5, 31, 23, 59, 59, 0, time.UTC)))
+	})
+
+	It("should compare with the same window of the previous year", func() {
+		startDate = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		endDate = time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC)
+		_, compareStartDate, compareEndDate, err := ChekingCompareTo("PREVIOUS_YEAR ", startDate, endDate, "", "")
+		Expect(err).To(BeNil())
+		Expect(compareStartDate).To(Equal(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(compareEndDate).To(Equal(time.Date(2023, 2, 28, 23, 59, 59, 0, time.UTC)))
+	})
+
+	It("should use the custom window", func() {
+		_, compareStartDate, compareEndDate, err := ChekingCompareTo("custom", startDate, endDate, "2022-01-01", "2022-01-31")
+		Expect(err).To(BeNil())
+		Expect(compareStartDate).To(Equal(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(compareEndDate).To(Equal(time.Date(2022, 1, 31, 23, 59, 59, 0, time.UTC)))
+	})
+
+	It("should return an error when the custom window is invalid", func() {
+		_, _, _, err := ChekingCompareTo("custom", startDate, endDate, "2022-02-01", "2022-01-01")
+		Expect(err).To(HaveOccurred())
+		_, _, _, err = ChekingCompareTo("custom", startDate, endDate, "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for an unknown mode", func() {
+		_, _, _, err := ChekingCompareTo("last_decade", startDate, endDate, "", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CompareConsumptionEnergy", func() {
+	var (
+		queryParams *domain.UserConsumptionQueryParams
+		filter      FilterOperations
+	)
+
+	BeforeEach(func() {
+		queryParams = &domain.UserConsumptionQueryParams{
+			StartDate:        time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:          time.Date(2023, 7, 31, 23, 59, 59, 0, time.UTC),
+			KindPeriod:       constants.PeriodKindMonthly,
+			CompareTo:        constants.CompareToPreviousYear,
+			CompareStartDate: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+			CompareEndDate:   time.Date(2022, 7, 31, 23, 59, 59, 0, time.UTC),
+		}
+		filter = NewFilter(queryParams.KindPeriod, queryParams.StartDate, queryParams.EndDate, nil)
+	})
+
+	It("should align the groups and compute the deltas", func() {
+		current := []*ConsumptionEnergy{
+			{StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2023, 6, 30, 23, 59, 59, 59, time.UTC), ActiveEnergy: 150, Exported: 10},
+			{StartDate: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2023, 7, 31, 23, 59, 59, 59, time.UTC), ActiveEnergy: 80},
+		}
+		previous := []*ConsumptionEnergy{
+			{StartDate: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2022, 7, 31, 23, 59, 59, 59, time.UTC), ActiveEnergy: 100},
+		}
+
+		comparison := CompareConsumptionEnergy(filter, queryParams, current, previous)
+
+		Expect(comparison.StartDate).To(Equal("2022-06-01"))
+		Expect(comparison.EndDate).To(Equal("2022-07-31"))
+		Expect(comparison.Period).To(Equal([]string{"Jun 2022", "Jul 2022"}))
+		Expect(comparison.Active).To(Equal([]float64{0, 100}))
+		Expect(comparison.Delta.Active).To(Equal([]float64{150, -20}))
+		Expect(comparison.Delta.Exported).To(Equal([]float64{10, 0}))
+		Expect(comparison.DeltaPercentage.Active[0]).To(BeNil())
+		Expect(*comparison.DeltaPercentage.Active[1]).To(BeNumerically("~", -20))
+	})
+})
+
+var _ = Describe("GetConsumptionByMeterIDAndWindowTime with comparison", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo   *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+	})
+
+	It("should query both windows and attach the comparison", func() {
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeStub = func(startDate, endDate time.Time, meterID int) ([]domain.UserConsumption, error) {
+			return []domain.UserConsumption{
+				{MeterID: meterID, ActiveEnergy: float64(startDate.Month()), Date: startDate.Add(time.Hour)},
+			}, nil
+		}
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "monthly", domain.ConsumptionQueryOptions{CompareTo: "previous_period"})
+
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(2))
+		Expect(result[0].Active).To(Equal([]float64{6}))
+		Expect(result[0].Comparison).ToNot(BeNil())
+		Expect(result[0].Comparison.Period).To(Equal([]string{"May 2023"}))
+		Expect(result[0].Comparison.Active).To(Equal([]float64{5}))
+		Expect(result[0].Comparison.Delta.Active).To(Equal([]float64{1}))
+	})
+
+	It("should return an error when the compare mode is not allowed", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "monthly", domain.ConsumptionQueryOptions{CompareTo: "tomorrow"})
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("GetConsumptionByMeterIDAndWindowTime with interval", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo   *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 1, Date: time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 2, Date: time.Date(2023, 6, 5, 1, 0, 0, 0, time.UTC)},
+		}, nil)
+	})
+
+	It("should use the interval when the kind period is blank", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-06", "", domain.ConsumptionQueryOptions{Interval: "3d"})
+
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Period).To(Equal([]string{"2023-06-01 - 2023-06-03", "2023-06-04 - 2023-06-06"}))
+		Expect(result[0].Active).To(Equal([]float64{1, 2}))
+	})
+
+	It("should return an error when the interval is used with another kind period", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-06", "monthly", domain.ConsumptionQueryOptions{Interval: "3d"})
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("should return an error when the kind period is interval without an interval", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-06", "interval", domain.ConsumptionQueryOptions{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
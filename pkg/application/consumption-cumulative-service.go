@@ -0,0 +1,62 @@
+package application
+
+import (
+	"sort"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// ConvertCumulativeReadings: convert the register values of a meter in the energy of every interval, the energy of
+// a reading is the difference with the previous reading
+//
+// Parameters:
+// previous: the last reading before the window, nil if the meter does not have it
+// data: the register values of the window
+// registerMax: the value where the register goes back to zero, 0 if it's unknown
+//
+// Returns:
+// return the energy by interval, the first reading is dropped when there is not a previous reading
+func ConvertCumulativeReadings(previous *domain.UserConsumption, data []domain.UserConsumption, registerMax float64) []domain.UserConsumption {
+	readings := make([]domain.UserConsumption, len(data))
+	copy(readings, data)
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].Date.Before(readings[j].Date)
+	})
+
+	intervalReadings := []domain.UserConsumption{}
+	hasPrevious := previous != nil
+	var lastReading domain.UserConsumption
+	if hasPrevious {
+		lastReading = *previous
+	}
+	for _, reading := range readings {
+		if !hasPrevious {
+			hasPrevious = true
+			lastReading = reading
+			continue
+		}
+		intervalReading := reading
+		intervalReading.ActiveEnergy = registerDelta(lastReading.ActiveEnergy, reading.ActiveEnergy, registerMax)
+		intervalReading.ReactiveEnergy = registerDelta(lastReading.ReactiveEnergy, reading.ReactiveEnergy, registerMax)
+		intervalReading.CapacitiveReactive = registerDelta(lastReading.CapacitiveReactive, reading.CapacitiveReactive, registerMax)
+		intervalReading.Solar = registerDelta(lastReading.Solar, reading.Solar, registerMax)
+		intervalReadings = append(intervalReadings, intervalReading)
+		lastReading = reading
+	}
+	logrus.Infof("%d cumulative readings were converted to interval energy", len(intervalReadings))
+	return intervalReadings
+}
+
+// registerDelta: the energy between two register values, when the register goes down it's a rollover if the
+// previous value was near the max of the register, otherwise the meter was replaced and the register started at zero
+func registerDelta(previous, current, registerMax float64) float64 {
+	if current >= previous {
+		return current - previous
+	}
+	if registerMax > 0 && previous >= registerMax*constants.RegisterRolloverThreshold {
+		return registerMax - previous + current
+	}
+	return current
+}
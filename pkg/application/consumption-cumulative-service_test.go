@@ -0,0 +1,107 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConvertCumulativeReadings", func() {
+	var (
+		baseDate time.Time
+	)
+
+	BeforeEach(func() {
+		baseDate = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("should use the first reading as the start of the register", func() {
+		data := []domain.UserConsumption{
+			{ActiveEnergy: 110, Solar: 5, Date: baseDate.Add(2 * time.Hour)},
+			{ActiveEnergy: 100, Solar: 2, Date: baseDate},
+			{ActiveEnergy: 104, Solar: 3, Date: baseDate.Add(time.Hour)},
+		}
+
+		result := ConvertCumulativeReadings(nil, data, 0)
+
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Date).To(Equal(baseDate.Add(time.Hour)))
+		Expect(result[0].ActiveEnergy).To(Equal(4.0))
+		Expect(result[1].ActiveEnergy).To(Equal(6.0))
+		Expect(result[1].Solar).To(Equal(2.0))
+	})
+
+	It("should use the previous reading for the first interval", func() {
+		previous := &domain.UserConsumption{ActiveEnergy: 90, Date: baseDate.Add(-time.Hour)}
+
+		result := ConvertCumulativeReadings(previous, []domain.UserConsumption{{ActiveEnergy: 100, Date: baseDate}}, 0)
+
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].ActiveEnergy).To(Equal(10.0))
+		Expect(previous.ActiveEnergy).To(Equal(90.0))
+	})
+
+	It("should handle the rollover of the register", func() {
+		data := []domain.UserConsumption{
+			{ActiveEnergy: 99990, Date: baseDate},
+			{ActiveEnergy: 15, Date: baseDate.Add(time.Hour)},
+		}
+
+		result := ConvertCumulativeReadings(nil, data, 100000)
+
+		Expect(result[0].ActiveEnergy).To(Equal(25.0))
+	})
+
+	It("should handle the replacement of the meter", func() {
+		data := []domain.UserConsumption{
+			{ActiveEnergy: 5000, Date: baseDate},
+			{ActiveEnergy: 3, Date: baseDate.Add(time.Hour)},
+		}
+
+		result := ConvertCumulativeReadings(nil, data, 100000)
+
+		Expect(result[0].ActiveEnergy).To(Equal(3.0))
+	})
+})
+
+var _ = Describe("GetConsumptionByMeterIDAndWindowTime with cumulative meters", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 120, Date: time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 150, Date: time.Date(2023, 6, 20, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		mockMySQLRepo.GetLastConsumptionBeforeDateReturns(&domain.UserConsumption{MeterID: 1, ActiveEnergy: 100}, nil)
+	})
+
+	It("should reduce the energy of every interval", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDReturns(&domain.MeterSetting{MeterID: 1, Cumulative: true}, nil)
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "monthly", domain.ConsumptionQueryOptions{})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Active).To(Equal([]float64{50}))
+		date, meterID := mockMySQLRepo.GetLastConsumptionBeforeDateArgsForCall(0)
+		Expect(date).To(Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(meterID).To(Equal(1))
+	})
+
+	It("should not convert the readings of the other meters", func() {
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "monthly", domain.ConsumptionQueryOptions{})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Active).To(Equal([]float64{270}))
+		Expect(mockMySQLRepo.GetLastConsumptionBeforeDateCallCount()).To(Equal(0))
+	})
+})
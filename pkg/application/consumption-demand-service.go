@@ -0,0 +1,199 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type DemandSerializer struct {
+	MeterID         int                    `json:"meter_id"`
+	DemandWindow    string                 `json:"demand_window"`
+	WindowType      string                 `json:"window_type"`
+	ReadingInterval string                 `json:"reading_interval"`
+	Period          []string               `json:"period"`
+	Demand          []float64              `json:"demand"`
+	MonthlyPeaks    []PeakDemandSerializer `json:"monthly_peaks"`
+}
+
+type PeakDemandSerializer struct {
+	Period string  `json:"period"`
+	Date   string  `json:"date"`
+	Demand float64 `json:"demand"`
+}
+
+type DemandWindow struct {
+	StartDate time.Time
+	Demand    float64
+}
+
+// ChekingDemandWindow: this function check the length and the kind of the demand window
+//
+// Parameters:
+// demandWindow: the length of the demand window as a go duration example "15m", blank uses 15 minutes
+// windowType: fixed windows aligned to the clock or rolling windows, blank uses fixed
+//
+// Returns:
+// return the length and the kind of the demand window or an error if something is not allowed
+func ChekingDemandWindow(demandWindow, windowType string) (time.Duration, string, error) {
+	if strings.Trim(demandWindow, " ") == "" {
+		demandWindow = constants.DefaultDemandWindow
+	}
+	windowLength, err := time.ParseDuration(strings.Trim(demandWindow, " "))
+	if err != nil {
+		logrus.Errorf("Error: parsing the demand window %s", err.Error())
+		return 0, "", domain.NewValidationError(constants.ErrorCodeInvalidParam, "demand_window", err.Error())
+	}
+	if windowLength <= 0 || windowLength > 24*time.Hour {
+		return 0, "", domain.NewValidationError(constants.ErrorCodeInvalidParam, "demand_window", fmt.Sprintf("Error: the demand window must be between 0 and 24h %s", demandWindow))
+	}
+	trimAndLowerCaseWindowType := strings.Trim(strings.ToLower(windowType), " ")
+	switch trimAndLowerCaseWindowType {
+	case "", constants.DemandWindowTypeFixed:
+		return windowLength, constants.DemandWindowTypeFixed, nil
+	case constants.DemandWindowTypeRolling:
+		return windowLength, trimAndLowerCaseWindowType, nil
+	default:
+		return 0, "", domain.NewValidationError(constants.ErrorCodeInvalidParam, "window_type", fmt.Sprintf("Error: window type not allowed %s", trimAndLowerCaseWindowType))
+	}
+}
+
+// InferReadingInterval: infer the interval of the readings with the median of the gaps between readings
+//
+// Parameters:
+// data: the readings sorted by date
+// defaultInterval: the interval used when there are not enough readings
+//
+// Returns:
+// return the interval between readings
+func InferReadingInterval(data []domain.UserConsumption, defaultInterval time.Duration) time.Duration {
+	var gaps []time.Duration
+	for i := 1; i < len(data); i++ {
+		gap := data[i].Date.Sub(data[i-1].Date)
+		if gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return defaultInterval
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+// CalculateDemandWindows: convert the energy of the readings in average power (kW) by demand window, every reading
+// covers the interval that starts in its date
+//
+// Parameters:
+// data: the readings sorted by date
+// windowLength: the length of the demand window, it's never shorter than the reading interval
+// windowType: fixed windows aligned to the clock or rolling windows starting in every reading
+//
+// Returns:
+// return the average power of every demand window
+func CalculateDemandWindows(data []domain.UserConsumption, windowLength time.Duration, windowType string) []DemandWindow {
+	var windows []DemandWindow
+	hours := windowLength.Hours()
+	if windowType == constants.DemandWindowTypeRolling {
+		var energy float64
+		end := 0
+		for start := range data {
+			if start > 0 {
+				energy -= data[start-1].ActiveEnergy
+			}
+			for end < len(data) && data[end].Date.Before(data[start].Date.Add(windowLength)) {
+				energy += data[end].ActiveEnergy
+				end++
+			}
+			windows = append(windows, DemandWindow{StartDate: data[start].Date, Demand: energy / hours})
+		}
+		return windows
+	}
+
+	for _, reading := range data {
+		windowStart := reading.Date.Truncate(windowLength)
+		if len(windows) > 0 && windows[len(windows)-1].StartDate.Equal(windowStart) {
+			windows[len(windows)-1].Demand += reading.ActiveEnergy / hours
+			continue
+		}
+		windows = append(windows, DemandWindow{StartDate: windowStart, Demand: reading.ActiveEnergy / hours})
+	}
+	return windows
+}
+
+// MonthlyPeakDemand: find the maximum demand and the date when it happens in every month
+//
+// Parameters:
+// windows: the demand windows sorted by date
+//
+// Returns:
+// return the peak of every month in chronological order
+func MonthlyPeakDemand(windows []DemandWindow) []DemandWindow {
+	var peaks []DemandWindow
+	for _, window := range windows {
+		lastPeak := len(peaks) - 1
+		if lastPeak >= 0 && sameMonth(peaks[lastPeak].StartDate, window.StartDate) {
+			if window.Demand > peaks[lastPeak].Demand {
+				peaks[lastPeak] = window
+			}
+			continue
+		}
+		peaks = append(peaks, window)
+	}
+	return peaks
+}
+
+// GetDemandData: calculate the demand windows and the monthly peaks of a meter
+//
+// Parameters:
+// meterID: the meter of the readings
+// data: the readings of the meter
+// windowLength: the length of the demand window
+// windowType: fixed or rolling windows
+//
+// Returns:
+// return the demand by window and the monthly peaks ready to serialize
+func GetDemandData(meterID int, data []domain.UserConsumption, windowLength time.Duration, windowType string) DemandSerializer {
+	sortedData := append([]domain.UserConsumption(nil), data...)
+	sort.Slice(sortedData, func(i, j int) bool {
+		return sortedData[i].Date.Before(sortedData[j].Date)
+	})
+	readingInterval := InferReadingInterval(sortedData, windowLength)
+	effectiveWindow := windowLength
+	if readingInterval > effectiveWindow {
+		effectiveWindow = readingInterval
+	}
+
+	serializer := DemandSerializer{
+		MeterID:         meterID,
+		DemandWindow:    effectiveWindow.String(),
+		WindowType:      windowType,
+		ReadingInterval: readingInterval.String(),
+		Period:          []string{},
+		Demand:          []float64{},
+		MonthlyPeaks:    []PeakDemandSerializer{},
+	}
+	windows := CalculateDemandWindows(sortedData, effectiveWindow, windowType)
+	for _, window := range windows {
+		serializer.Period = append(serializer.Period, domain.TimeTostr(window.StartDate, constants.DateFormatDemandTimestamp))
+		serializer.Demand = append(serializer.Demand, window.Demand)
+	}
+	for _, peak := range MonthlyPeakDemand(windows) {
+		serializer.MonthlyPeaks = append(serializer.MonthlyPeaks, PeakDemandSerializer{
+			Period: domain.TimeTostr(peak.StartDate, constants.DateFormatMonthlyPeriod),
+			Date:   domain.TimeTostr(peak.StartDate, constants.DateFormatDemandTimestamp),
+			Demand: peak.Demand,
+		})
+	}
+	logrus.Infof("Peak demand of the meter %d is done", meterID)
+	return serializer
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func quarterHourReadings(start time.Time, energies ...float64) []domain.UserConsumption {
+	var readings []domain.UserConsumption
+	for i, energy := range energies {
+		readings = append(readings, domain.UserConsumption{
+			MeterID:      1,
+			ActiveEnergy: energy,
+			Date:         start.Add(time.Duration(i) * 15 * time.Minute),
+		})
+	}
+	return readings
+}
+
+var _ = Describe("ChekingDemandWindow", func() {
+	It("should use 15 minutes fixed windows by default", func() {
+		windowLength, windowType, err := ChekingDemandWindow("", "")
+		Expect(err).To(BeNil())
+		Expect(windowLength).To(Equal(15 * time.Minute))
+		Expect(windowType).To(Equal("fixed"))
+	})
+
+	It("should accept rolling windows", func() {
+		windowLength, windowType, err := ChekingDemandWindow("30m", "Rolling")
+		Expect(err).To(BeNil())
+		Expect(windowLength).To(Equal(30 * time.Minute))
+		Expect(windowType).To(Equal("rolling"))
+	})
+
+	It("should return an error for invalid windows", func() {
+		_, _, err := ChekingDemandWindow("15 minutes", "")
+		Expect(err).To(HaveOccurred())
+		_, _, err = ChekingDemandWindow("-15m", "")
+		Expect(err).To(HaveOccurred())
+		_, _, err = ChekingDemandWindow("15m", "sliding")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CalculateDemandWindows", func() {
+	var readings []domain.UserConsumption
+
+	BeforeEach(func() {
+		readings = quarterHourReadings(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), 1, 3, 2, 2)
+	})
+
+	It("should convert every reading in kW with windows equal to the interval", func() {
+		windows := CalculateDemandWindows(readings, 15*time.Minute, "fixed")
+		Expect(windows).To(HaveLen(4))
+		Expect(windows[1].Demand).To(Equal(float64(12)))
+	})
+
+	It("should average the readings in fixed windows aligned to the clock", func() {
+		windows := CalculateDemandWindows(readings, 30*time.Minute, "fixed")
+		Expect(windows).To(HaveLen(2))
+		Expect(windows[0].StartDate).To(Equal(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)))
+		Expect(windows[0].Demand).To(Equal(float64(8)))
+		Expect(windows[1].Demand).To(Equal(float64(8)))
+	})
+
+	It("should average the readings in rolling windows", func() {
+		windows := CalculateDemandWindows(readings, 30*time.Minute, "rolling")
+		Expect(windows).To(HaveLen(4))
+		Expect(windows[1].StartDate).To(Equal(time.Date(2023, 1, 2, 10, 15, 0, 0, time.UTC)))
+		Expect(windows[1].Demand).To(Equal(float64(10)))
+		Expect(windows[3].Demand).To(Equal(float64(4)))
+	})
+})
+
+var _ = Describe("GetDemandData", func() {
+	It("should find the monthly peak and when it happens", func() {
+		readings := append(
+			quarterHourReadings(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC), 1, 5, 2),
+			quarterHourReadings(time.Date(2023, 2, 1, 8, 0, 0, 0, time.UTC), 4, 1)...,
+		)
+
+		result := GetDemandData(1, readings, 15*time.Minute, "fixed")
+
+		Expect(result.ReadingInterval).To(Equal("15m0s"))
+		Expect(result.Demand).To(HaveLen(5))
+		Expect(result.MonthlyPeaks).To(Equal([]PeakDemandSerializer{
+			{Period: "Jan 2023", Date: "2023-01-31 23:15", Demand: 20},
+			{Period: "Feb 2023", Date: "2023-02-01 08:00", Demand: 16},
+		}))
+	})
+
+	It("should not use windows shorter than the reading interval", func() {
+		readings := []domain.UserConsumption{
+			{ActiveEnergy: 10, Date: time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)},
+			{ActiveEnergy: 20, Date: time.Date(2023, 1, 2, 11, 0, 0, 0, time.UTC)},
+		}
+
+		result := GetDemandData(1, readings, 15*time.Minute, "fixed")
+
+		Expect(result.DemandWindow).To(Equal("1h0m0s"))
+		Expect(result.Demand).To(Equal([]float64{10, 20}))
+	})
+})
+
+var _ = Describe("GetPeakDemandByMeterIDAndWindowTime", func() {
+	var (
+		mockMySQLRepo *domainfakes.FakeMySQLPowerConsumptionRepository
+		service       PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+	})
+
+	It("should return the demand of every meter", func() {
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns(quarterHourReadings(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), 1, 3), nil)
+
+		result, err := service.GetPeakDemandByMeterIDAndWindowTime("1,2", "2023-01-01", "2023-01-31", "", "")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].MeterID).To(Equal(1))
+		Expect(result[1].MeterID).To(Equal(2))
+		Expect(result[0].MonthlyPeaks[0].Demand).To(Equal(float64(12)))
+	})
+
+	It("should return an error when the demand window is not valid", func() {
+		result, err := service.GetPeakDemandByMeterIDAndWindowTime("1", "2023-01-01", "2023-01-31", "abc", "")
+
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+	})
+
+	It("should collect the demand window with the query parameters that are not valid", func() {
+		_, err := service.GetPeakDemandByMeterIDAndWindowTime("x", "2023-01-01", "2023-01-31", "abc", "")
+
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Fields).To(HaveLen(2))
+		Expect(domainError.Fields[0].Field).To(Equal("demand_window"))
+		Expect(domainError.Fields[1].Field).To(Equal("meter_ids"))
+	})
+})
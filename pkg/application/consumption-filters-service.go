@@ -3,6 +3,8 @@ package application
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	constants "github.com/jeffleon1/consumption-ms/internal/constans"
@@ -27,6 +29,13 @@ type FilterAdditionalOperations interface {
 	GroupsSerializedToString(time.Time, time.Time) string
 }
 
+// FilterWindowOperations: filters whose groups are not tied to the months of the window, the groups are built
+// for the whole window so a group could span months and years
+type FilterWindowOperations interface {
+	WindowGroupDivision() []TimeGroupDivision
+	WindowData() []domain.UserConsumption
+}
+
 type Filter struct {
 	StartDate time.Time
 	EndDate   time.Time
@@ -46,15 +55,20 @@ type ConsumptionEnergy struct {
 	ReactiveEnergy     float64
 	CapacitiveReactive float64
 	Exported           float64
+	Aggregations       map[string]EnergyAggregation
 }
 
 type Serializer struct {
-	Period             []string  `json:"period"`
-	MeterID            int       `json:"meter_id"`
-	Active             []float64 `json:"active"`
-	ReactiveInductive  []float64 `json:"reactive_inductive"`
-	ReactiveCapacitive []float64 `json:"reactive_capacitive"`
-	Exported           []float64 `json:"exported"`
+	Period             []string                         `json:"period"`
+	MeterID            int                              `json:"meter_id"`
+	Active             []float64                        `json:"active"`
+	ReactiveInductive  []float64                        `json:"reactive_inductive"`
+	ReactiveCapacitive []float64                        `json:"reactive_capacitive"`
+	Exported           []float64                        `json:"exported"`
+	Aggregations       map[string]AggregationSerializer `json:"aggregations,omitempty"`
+	Comparison         *ComparisonSerializer            `json:"comparison,omitempty"`
+	Unit               string                           `json:"unit,omitempty"`
+	ReactiveUnit       string                           `json:"reactive_unit,omitempty"`
 }
 
 type MonthlyFilter struct {
@@ -69,6 +83,16 @@ type WeeklyFilter struct {
 	Filter
 }
 
+type CalendarWeeklyFilter struct {
+	Filter
+	WeekStart time.Weekday
+}
+
+type IntervalFilter struct {
+	Filter
+	Interval time.Duration
+}
+
 // NewFilter: Factory to create filterss
 //
 // Parámeters:
@@ -87,11 +111,105 @@ func NewFilter(tipe string, startDate, endDate time.Time, data []domain.UserCons
 		return &WeeklyFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}}
 	case constants.PeriodKindDaily:
 		return &DailyFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}}
+	case constants.PeriodKindCalendarWeekly:
+		return &CalendarWeeklyFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}, time.Monday}
 	default:
 		return nil
 	}
 }
 
+// ChekingWeekStart: this function check the day that begins the calendar weeks
+//
+// Parameters:
+// weekStart: the name of the day, monday by default
+//
+// Returns:
+// return the week day or an error if the day is not valid
+func ChekingWeekStart(weekStart string) (time.Weekday, error) {
+	trimAndLowerCaseWeekStart := strings.ToLower(strings.Trim(weekStart, " "))
+	if trimAndLowerCaseWeekStart == "" {
+		return time.Monday, nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.ToLower(day.String()) == trimAndLowerCaseWeekStart {
+			return day, nil
+		}
+	}
+	return time.Sunday, domain.NewValidationError(constants.ErrorCodeInvalidParam, "week_start", fmt.Sprintf("Error: week start not allowed %s", trimAndLowerCaseWeekStart))
+}
+
+// ChekingInterval: this function check the length of the groups of an interval filter, it could be a duration
+// like "2h" or a number of days like "3d", a group could not be longer than the largest window of the queries
+//
+// Parameters:
+// interval: the length of the groups
+// startDate: the start of the window
+// endDate: the end of the window
+//
+// Returns:
+// return the length of the groups or an error if it's not valid, it's too long or the window has too many groups
+func ChekingInterval(interval string, startDate, endDate time.Time) (time.Duration, error) {
+	trimInterval := strings.ToLower(strings.Trim(interval, " "))
+	if trimInterval == "" {
+		return 0, domain.NewValidationError(constants.ErrorCodeMissingParam, "interval", "Error: the interval is empty")
+	}
+	var duration time.Duration
+	if strings.HasSuffix(trimInterval, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(trimInterval, "d"))
+		if err != nil {
+			return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "interval", fmt.Sprintf("Error: interval not allowed %s", trimInterval))
+		}
+		if days > constants.MaxWindowDaysMonthly {
+			return 0, intervalTooLongError(trimInterval)
+		}
+		duration = time.Duration(days) * 24 * time.Hour
+	} else {
+		parsedDuration, err := time.ParseDuration(trimInterval)
+		if err != nil {
+			return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "interval", fmt.Sprintf("Error: interval not allowed %s", trimInterval))
+		}
+		duration = parsedDuration
+	}
+	if duration <= 0 {
+		return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "interval", fmt.Sprintf("Error: the interval must be greater than 0 %s", trimInterval))
+	}
+	if duration > time.Duration(constants.MaxWindowDaysMonthly)*24*time.Hour {
+		return 0, intervalTooLongError(trimInterval)
+	}
+	if endDate.Sub(startDate)/duration >= time.Duration(constants.MaxIntervalGroups) {
+		return 0, domain.NewValidationError(constants.ErrorCodeInvalidParam, "interval", fmt.Sprintf("Error: the interval %s creates more than %d groups", trimInterval, constants.MaxIntervalGroups))
+	}
+	return duration, nil
+}
+
+// intervalTooLongError: the error of an interval longer than the largest window of the queries
+func intervalTooLongError(interval string) error {
+	return domain.NewValidationError(constants.ErrorCodeInvalidParam, "interval", fmt.Sprintf("Error: the interval %s is longer than %d days", interval, constants.MaxWindowDaysMonthly))
+}
+
+// NewFilterFromQueryParams: Factory to create filters with the settings of the query params
+//
+// Parámeters:
+// queryParams - the query params checked, they have the kind of filter and its settings
+// startDate - start date to retrieve or filter the information
+// endDate - end date to retrieve or filter the information
+// data - the records to filter
+//
+// Returns:
+// The filter for the kind period of the query params
+func NewFilterFromQueryParams(queryParams *domain.UserConsumptionQueryParams, startDate, endDate time.Time, data []domain.UserConsumption) FilterOperations {
+	switch queryParams.KindPeriod {
+	case constants.PeriodKindCalendarWeekly:
+		return &CalendarWeeklyFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}, queryParams.WeekStart}
+	case constants.PeriodKindInterval:
+		return &IntervalFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}, queryParams.Interval}
+	case constants.PeriodKindBillingCycle:
+		return &BillingCycleFilter{Filter{StartDate: startDate, EndDate: endDate, Data: data}, queryParams.BillingCycleDay}
+	default:
+		return NewFilter(queryParams.KindPeriod, startDate, endDate, data)
+	}
+}
+
 // GetConsumptionData: this function is the main function in this file becuase have all the logic to retrieve
 // the records then organize that records and return the information in the way that we want
 //
@@ -101,9 +219,25 @@ func NewFilter(tipe string, startDate, endDate time.Time, data []domain.UserCons
 // Returns:
 // The Serializer that is a kind of structure that has all the attributes that we need to serialize in consumption serializer
 func GetConsumptionData(filter FilterOperations) Serializer {
+	return SerializeConsumptionEnergy(filter, GetConsumptionEnergy(filter))
+}
+
+// GetConsumptionEnergy: run the filter pipeline divide --> group --> match --> reduce and return
+// the reduced groups sorted by start date
+//
+// Parámeters:
+// filter - is an interface that allow do the process with all types of filters no matter what kind of filter is
+//
+// Returns:
+// The reduced consumption energy by group division in chronological order
+func GetConsumptionEnergy(filter FilterOperations) []*ConsumptionEnergy {
+	if windowFilter, ok := filter.(FilterWindowOperations); ok {
+		informationMatched := filter.MatchConsumptionInTimeGroup(windowFilter.WindowData(), windowFilter.WindowGroupDivision())
+		filter.ReduceInformation(informationMatched)
+		return informationMatched
+	}
 	consumptionByYear := filter.DivideInformationByYears()
 	var consumptionEnergy []*ConsumptionEnergy
-	var objectSerializer Serializer
 	for year, consumptionYear := range consumptionByYear {
 		for month, conconsumptionInMonth := range consumptionYear {
 			dailyGroups := filter.GroupDivision(month, year)
@@ -115,6 +249,22 @@ func GetConsumptionData(filter FilterOperations) Serializer {
 		}
 	}
 
+	sort.Slice(consumptionEnergy, func(i, j int) bool {
+		return consumptionEnergy[i].StartDate.Before(consumptionEnergy[j].StartDate)
+	})
+	return consumptionEnergy
+}
+
+// SerializeConsumptionEnergy: flatten the reduced groups in the series that the serializer needs
+//
+// Parámeters:
+// filter - the filter used to reduce the information, it gives the period labels
+// consumptionEnergy - the reduced groups
+//
+// Returns:
+// The Serializer with one value by group division in every series
+func SerializeConsumptionEnergy(filter FilterOperations, consumptionEnergy []*ConsumptionEnergy) Serializer {
+	var objectSerializer Serializer
 	for _, serializer := range consumptionEnergy {
 		periodString := filter.GroupsSerializedToString(serializer.StartDate, serializer.EndDate)
 		objectSerializer.Period = append(objectSerializer.Period, periodString)
@@ -122,6 +272,17 @@ func GetConsumptionData(filter FilterOperations) Serializer {
 		objectSerializer.Exported = append(objectSerializer.Exported, serializer.Exported)
 		objectSerializer.ReactiveInductive = append(objectSerializer.ReactiveInductive, serializer.ReactiveEnergy)
 		objectSerializer.ReactiveCapacitive = append(objectSerializer.ReactiveCapacitive, serializer.CapacitiveReactive)
+		for aggregation, value := range serializer.Aggregations {
+			if objectSerializer.Aggregations == nil {
+				objectSerializer.Aggregations = make(map[string]AggregationSerializer)
+			}
+			aggregationSerializer := objectSerializer.Aggregations[aggregation]
+			aggregationSerializer.Active = append(aggregationSerializer.Active, value.ActiveEnergy)
+			aggregationSerializer.ReactiveInductive = append(aggregationSerializer.ReactiveInductive, value.ReactiveEnergy)
+			aggregationSerializer.ReactiveCapacitive = append(aggregationSerializer.ReactiveCapacitive, value.CapacitiveReactive)
+			aggregationSerializer.Exported = append(aggregationSerializer.Exported, value.Exported)
+			objectSerializer.Aggregations[aggregation] = aggregationSerializer
+		}
 	}
 	return objectSerializer
 }
@@ -175,7 +336,8 @@ func (f *Filter) DivideInformationByYears() map[int]map[int][]domain.UserConsump
 }
 
 // MatchConsumptionInTimeGroup: do the match between the userconsumption and
-// the group division no matter if it's a group division by monthly, weekly or daily
+// the group division no matter if it's a group division by monthly, weekly or daily, a record belongs to a group
+// from its init date up to its finish date, the finish date excluded
 //
 // Parameters:
 // consumptions: has the consumption information
@@ -188,7 +350,7 @@ func (f *Filter) MatchConsumptionInTimeGroup(consumptions []domain.UserConsumpti
 	for _, timeGroup := range timeGroups {
 		var data []domain.UserConsumption
 		for _, objectConsumption := range consumptions {
-			if objectConsumption.Date.After(timeGroup.InitDate) && objectConsumption.Date.Before(timeGroup.FinishDate) {
+			if !objectConsumption.Date.Before(timeGroup.InitDate) && objectConsumption.Date.Before(timeGroup.FinishDate) {
 				data = append(data, objectConsumption)
 			}
 		}
@@ -205,6 +367,14 @@ func (f *Filter) MatchConsumptionInTimeGroup(consumptions []domain.UserConsumpti
 	return serializer
 }
 
+// WindowData: return the records of the window
+//
+// Returns:
+// return the records to filter
+func (f *Filter) WindowData() []domain.UserConsumption {
+	return f.Data
+}
+
 // ReduceInformation: reduce the information a only one record by group division
 //
 // Parameters:
@@ -344,3 +514,125 @@ func (w *WeeklyFilter) GroupsSerializedToString(startDate time.Time, endDate tim
 	endDateString := domain.TimeTostr(endDate, constants.DateFormatWeeklyAndDailyPeriod)
 	return fmt.Sprintf("%s - %s", startDateString, endDateString)
 }
+
+// Calendar week filter
+// WindowGroupDivision: do the group division for a calendar weekly filter, true 7 days weeks that begin the week
+// start day and could span months and years
+//
+// Returns:
+// return the weeks that intersect the window
+func (w *CalendarWeeklyFilter) WindowGroupDivision() []TimeGroupDivision {
+	return w.weeksBetween(w.StartDate, w.EndDate)
+}
+
+// GroupDivision: do the group division for a calendar weekly filter, the weeks that intersect the month
+//
+// Parameters:
+// month
+// year
+//
+// Returns:
+// return the time group division for a calendar weekly filter
+func (w *CalendarWeeklyFilter) GroupDivision(month, year int) []TimeGroupDivision {
+	if month < 1 || month > 12 {
+		return []TimeGroupDivision{}
+	}
+	initialDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	lastDate := initialDate.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	return w.weeksBetween(initialDate, lastDate)
+}
+
+// GroupsSerializedToString: serialize the week with the ISO 8601 week number example "2023-06-05" --> "2023-W23",
+// when the week does not begin on monday the number is the ISO week of its fourth day
+//
+// Parameters:
+// startDate
+// endDate
+//
+// Returns:
+// return the week in a correct way "2023-W23"
+func (w *CalendarWeeklyFilter) GroupsSerializedToString(startDate time.Time, endDate time.Time) string {
+	if startDate.After(endDate) {
+		return ""
+	}
+	year, week := startDate.AddDate(0, 0, 3).ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+func (w *CalendarWeeklyFilter) weeksBetween(startDate, endDate time.Time) []TimeGroupDivision {
+	var weekGroups []TimeGroupDivision
+	day := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	offset := (int(day.Weekday()) - int(w.WeekStart) + 7) % 7
+	for initialDate := day.AddDate(0, 0, -offset); !initialDate.After(endDate); initialDate = initialDate.AddDate(0, 0, 7) {
+		weekGroups = append(weekGroups, TimeGroupDivision{
+			InitDate:   initialDate,
+			FinishDate: initialDate.AddDate(0, 0, 7).Add(-time.Nanosecond),
+		})
+	}
+	return weekGroups
+}
+
+// Interval filter
+// WindowGroupDivision: do the group division for an interval filter, groups of the same length anchored at the
+// start date
+//
+// Returns:
+// return the groups that intersect the window
+func (i *IntervalFilter) WindowGroupDivision() []TimeGroupDivision {
+	return i.intervalsBetween(i.StartDate, i.EndDate)
+}
+
+// GroupDivision: do the group division for an interval filter, the groups that intersect the month
+//
+// Parameters:
+// month
+// year
+//
+// Returns:
+// return the time group division for an interval filter
+func (i *IntervalFilter) GroupDivision(month, year int) []TimeGroupDivision {
+	if month < 1 || month > 12 {
+		return []TimeGroupDivision{}
+	}
+	initialDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	lastDate := initialDate.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	return i.intervalsBetween(initialDate, lastDate)
+}
+
+// GroupsSerializedToString: serialize the interval, the hours are only shown when the interval is not a number of
+// days example "2023-06-01" and "2023-06-03" --> "2023-06-01 - 2023-06-03"
+//
+// Parameters:
+// startDate
+// endDate
+//
+// Returns:
+// return the interval in a correct way "2023-06-01 - 2023-06-03"
+func (i *IntervalFilter) GroupsSerializedToString(startDate time.Time, endDate time.Time) string {
+	if startDate.After(endDate) {
+		return ""
+	}
+	dateFormat := constants.DateFormatDemandTimestamp
+	if i.Interval%(24*time.Hour) == 0 && startDate.Equal(startDate.Truncate(24*time.Hour)) {
+		dateFormat = constants.DateFormatDate
+	}
+	return fmt.Sprintf("%s - %s", startDate.Format(dateFormat), endDate.Format(dateFormat))
+}
+
+func (i *IntervalFilter) intervalsBetween(startDate, endDate time.Time) []TimeGroupDivision {
+	var intervalGroups []TimeGroupDivision
+	if i.Interval <= 0 || i.StartDate.After(endDate) {
+		return intervalGroups
+	}
+	initialDate := i.StartDate
+	if initialDate.Before(startDate) {
+		initialDate = initialDate.Add(startDate.Sub(initialDate) / i.Interval * i.Interval)
+	}
+	for ; !initialDate.After(endDate) && !initialDate.After(i.EndDate); initialDate = initialDate.Add(i.Interval) {
+		intervalGroups = append(intervalGroups, TimeGroupDivision{
+			InitDate:   initialDate,
+			FinishDate: initialDate.Add(i.Interval).Add(-time.Nanosecond),
+		})
+	}
+	return intervalGroups
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"fmt"
 	"time"
 
 	constants "github.com/jeffleon1/consumption-ms/internal/constans"
@@ -49,6 +50,14 @@ var _ = Describe("NewFilter Tests", func() {
 		})
 	})
 
+	Context("when tipe is PeriodKindCalendarWeekly", func() {
+		It("should return a CalendarWeeklyFilter that begins on monday", func() {
+			filter := NewFilter(constants.PeriodKindCalendarWeekly, startDate, endDate, data)
+			Expect(filter).To(BeAssignableToTypeOf(&CalendarWeeklyFilter{}))
+			Expect(filter.(*CalendarWeeklyFilter).WeekStart).To(Equal(time.Monday))
+		})
+	})
+
 	Context("when tipe is not valid", func() {
 		It("should return nil", func() {
 			filter := NewFilter("invalid_tipe", startDate, endDate, data)
@@ -237,6 +246,38 @@ var _ = Describe("MatchConsumptionInTimeGroup Tests", func() {
 
 			Expect(result).To(BeEmpty())
 		})
+
+		It("should match the records at the init date of a daily group but not at its finish date", func() {
+			dailyFilter := &DailyFilter{}
+			timeGroups := dailyFilter.GroupDivision(1, 2022)
+			consumptions := []domain.UserConsumption{
+				{Date: timeGroups[0].InitDate, ActiveEnergy: 1},
+				{Date: timeGroups[0].FinishDate, ActiveEnergy: 2},
+				{Date: timeGroups[1].InitDate, ActiveEnergy: 3},
+			}
+
+			result := dailyFilter.MatchConsumptionInTimeGroup(consumptions, timeGroups[:2])
+
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].Data).To(Equal([]domain.UserConsumption{consumptions[0]}))
+			Expect(result[1].Data).To(Equal([]domain.UserConsumption{consumptions[2]}))
+		})
+
+		It("should match the records at the init date of a calendar week but not at its finish date", func() {
+			calendarWeeklyFilter := &CalendarWeeklyFilter{Filter{StartDate: time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2023, 6, 18, 0, 0, 0, 0, time.UTC)}, time.Monday}
+			timeGroups := calendarWeeklyFilter.WindowGroupDivision()
+			consumptions := []domain.UserConsumption{
+				{Date: timeGroups[0].InitDate, ActiveEnergy: 1},
+				{Date: timeGroups[0].FinishDate, ActiveEnergy: 2},
+				{Date: timeGroups[1].InitDate, ActiveEnergy: 3},
+			}
+
+			result := calendarWeeklyFilter.MatchConsumptionInTimeGroup(consumptions, timeGroups)
+
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].Data).To(Equal([]domain.UserConsumption{consumptions[0]}))
+			Expect(result[1].Data).To(Equal([]domain.UserConsumption{consumptions[2]}))
+		})
 	})
 })
 
@@ -539,3 +580,212 @@ var _ = Describe("Weekly filter", func() {
 		})
 	})
 })
+
+var _ = Describe("Calendar weekly filter", func() {
+	var (
+		calendarWeeklyFilter *CalendarWeeklyFilter
+	)
+
+	BeforeEach(func() {
+		calendarWeeklyFilter = &CalendarWeeklyFilter{WeekStart: time.Monday}
+	})
+
+	Context("GroupDivision", func() {
+		It("should return 7 days weeks that cross the month boundaries", func() {
+			result := calendarWeeklyFilter.GroupDivision(6, 2023)
+			Expect(result).To(HaveLen(5))
+			Expect(result[0].InitDate).To(Equal(time.Date(2023, 5, 29, 0, 0, 0, 0, time.UTC)))
+			Expect(result[4].InitDate).To(Equal(time.Date(2023, 6, 26, 0, 0, 0, 0, time.UTC)))
+			Expect(result[4].FinishDate).To(Equal(time.Date(2023, 7, 2, 23, 59, 59, 999999999, time.UTC)))
+		})
+
+		It("should begin the weeks on the configured day", func() {
+			calendarWeeklyFilter.WeekStart = time.Sunday
+			result := calendarWeeklyFilter.GroupDivision(6, 2023)
+			Expect(result[0].InitDate).To(Equal(time.Date(2023, 5, 28, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should handle an invalid month correctly", func() {
+			result := calendarWeeklyFilter.GroupDivision(13, 2023)
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Context("GroupsSerializedToString", func() {
+		It("should serialize the ISO week", func() {
+			startDate := time.Date(2023, 5, 29, 0, 0, 0, 0, time.UTC)
+			result := calendarWeeklyFilter.GroupsSerializedToString(startDate, startDate.AddDate(0, 0, 7))
+			Expect(result).To(Equal("2023-W22"))
+		})
+
+		It("should use the ISO year when the week crosses the year", func() {
+			startDate := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
+			result := calendarWeeklyFilter.GroupsSerializedToString(startDate, startDate.AddDate(0, 0, 7))
+			Expect(result).To(Equal("2025-W01"))
+		})
+
+		It("should handle endDate before startDate correctly", func() {
+			startDate := time.Date(2023, 1, 7, 0, 0, 0, 0, time.UTC)
+			result := calendarWeeklyFilter.GroupsSerializedToString(startDate, startDate.AddDate(0, 0, -1))
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Context("GetConsumptionEnergy", func() {
+		It("should reduce the records of a week that crosses the year in only one group", func() {
+			data := []domain.UserConsumption{
+				{ActiveEnergy: 1, Date: time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC)},
+				{ActiveEnergy: 2, Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{ActiveEnergy: 3, Date: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+			}
+			filter := &CalendarWeeklyFilter{Filter{
+				StartDate: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC),
+				Data:      data,
+			}, time.Monday}
+
+			result := GetConsumptionEnergy(filter)
+
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].ActiveEnergy).To(Equal(1.0))
+			Expect(result[1].ActiveEnergy).To(Equal(5.0))
+			Expect(filter.GroupsSerializedToString(result[1].StartDate, result[1].EndDate)).To(Equal("2024-W01"))
+		})
+	})
+})
+
+var _ = Describe("ChekingWeekStart", func() {
+	It("should return monday by default", func() {
+		weekStart, err := ChekingWeekStart("")
+		Expect(err).To(BeNil())
+		Expect(weekStart).To(Equal(time.Monday))
+	})
+
+	It("should accept the name of the day", func() {
+		weekStart, err := ChekingWeekStart(" Sunday")
+		Expect(err).To(BeNil())
+		Expect(weekStart).To(Equal(time.Sunday))
+	})
+
+	It("should return an error for an unknown day", func() {
+		_, err := ChekingWeekStart("funday")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Interval filter", func() {
+	var (
+		intervalFilter *IntervalFilter
+	)
+
+	BeforeEach(func() {
+		intervalFilter = &IntervalFilter{Filter{
+			StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2023, 6, 10, 23, 59, 59, 0, time.UTC),
+		}, 72 * time.Hour}
+	})
+
+	Context("WindowGroupDivision", func() {
+		It("should anchor the groups at the start date", func() {
+			result := intervalFilter.WindowGroupDivision()
+			Expect(result).To(HaveLen(4))
+			Expect(result[1].InitDate).To(Equal(time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC)))
+			Expect(result[3].InitDate).To(Equal(time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("GroupDivision", func() {
+		It("should return the groups that intersect the month", func() {
+			intervalFilter.StartDate = time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+			intervalFilter.EndDate = time.Date(2023, 7, 31, 23, 59, 59, 0, time.UTC)
+			result := intervalFilter.GroupDivision(7, 2023)
+			Expect(result[0].InitDate).To(Equal(time.Date(2023, 6, 29, 0, 0, 0, 0, time.UTC)))
+			Expect(result[0].FinishDate).To(Equal(time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)))
+		})
+
+		It("should handle an invalid month correctly", func() {
+			result := intervalFilter.GroupDivision(13, 2023)
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Context("GroupsSerializedToString", func() {
+		It("should serialize the days of the interval", func() {
+			startDate := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+			result := intervalFilter.GroupsSerializedToString(startDate, startDate.Add(72*time.Hour-time.Nanosecond))
+			Expect(result).To(Equal("2023-06-01 - 2023-06-03"))
+		})
+
+		It("should serialize the hours when the interval is not a number of days", func() {
+			intervalFilter.Interval = 2 * time.Hour
+			startDate := time.Date(2023, 6, 1, 2, 0, 0, 0, time.UTC)
+			result := intervalFilter.GroupsSerializedToString(startDate, startDate.Add(2*time.Hour-time.Nanosecond))
+			Expect(result).To(Equal("2023-06-01 02:00 - 2023-06-01 03:59"))
+		})
+	})
+
+	Context("GetConsumptionEnergy", func() {
+		It("should reduce the records by interval", func() {
+			intervalFilter.Data = []domain.UserConsumption{
+				{ActiveEnergy: 1, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+				{ActiveEnergy: 2, Date: time.Date(2023, 6, 3, 23, 0, 0, 0, time.UTC)},
+				{ActiveEnergy: 4, Date: time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC)},
+			}
+			result := GetConsumptionEnergy(intervalFilter)
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].ActiveEnergy).To(Equal(3.0))
+			Expect(result[1].ActiveEnergy).To(Equal(4.0))
+		})
+	})
+})
+
+var _ = Describe("ChekingInterval", func() {
+	var (
+		startDate time.Time
+		endDate   time.Time
+	)
+
+	BeforeEach(func() {
+		startDate = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+		endDate = time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC)
+	})
+
+	It("should parse a number of days", func() {
+		interval, err := ChekingInterval("14d", startDate, endDate)
+		Expect(err).To(BeNil())
+		Expect(interval).To(Equal(14 * 24 * time.Hour))
+	})
+
+	It("should parse a duration", func() {
+		interval, err := ChekingInterval("2h", startDate, endDate)
+		Expect(err).To(BeNil())
+		Expect(interval).To(Equal(2 * time.Hour))
+	})
+
+	It("should return an error for an invalid interval", func() {
+		_, err := ChekingInterval("two days", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+		_, err = ChekingInterval("0d", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+		_, err = ChekingInterval("", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error when the window has too many groups", func() {
+		_, err := ChekingInterval("1m", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error when the interval is longer than the largest window instead of overflowing", func() {
+		_, err := ChekingInterval("106751d", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is longer than"))
+		_, err = ChekingInterval("999999999999d", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+		_, err = ChekingInterval("100000h", startDate, endDate)
+		Expect(err).To(HaveOccurred())
+		interval, err := ChekingInterval(fmt.Sprintf("%dd", constants.MaxWindowDaysMonthly), startDate, endDate)
+		Expect(err).To(BeNil())
+		Expect(interval).To(Equal(time.Duration(constants.MaxWindowDaysMonthly) * 24 * time.Hour))
+	})
+})
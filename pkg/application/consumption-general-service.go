@@ -3,6 +3,8 @@ package application
 import (
 	"fmt"
 	"mime/multipart"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,118 +16,426 @@ import (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . PowerConsumptionService
 type PowerConsumptionService interface {
-	GetConsumptionByMeterIDAndWindowTime(meterIDs string, kindPeriod string, startDate string, endDate string) ([]Serializer, error)
-	ImportCsvToDatabase(file *multipart.File) error
+	GetConsumptionByMeterIDAndWindowTime(meterIDs string, startDate string, endDate string, kindPeriod string, options domain.ConsumptionQueryOptions) ([]Serializer, error)
+	GetConsumptionByMeterIDsInBatch(meterIDs string, startDate string, endDate string, kindPeriod string, options domain.ConsumptionQueryOptions) ([]Serializer, map[int]error, error)
+	GetConsumptionByGroupAndWindowTime(groupID string, startDate string, endDate string, kindPeriod string, options domain.ConsumptionQueryOptions) (*GroupSerializer, error)
+	GetPeakDemandByMeterIDAndWindowTime(meterIDs string, startDate string, endDate string, demandWindow string, windowType string) ([]DemandSerializer, error)
+	GetAnalyticsByMeterIDAndWindowTime(meterIDs string, startDate string, endDate string, demandWindow string) ([]AnalyticsSerializer, error)
+	ImportCsvToDatabase(file *multipart.File, options domain.ImportOptions) (*ImportSummary, error)
+	ImportCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, error)
+	IngestCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, error)
 	ChekingKindPeriod(kindPeriod string) (string, error)
 	CheckingQueryParamConstrains(meterIDs string, kindPeriod string, startDate string, endDate string) (*domain.UserConsumptionQueryParams, error)
 }
 
 type PowerConsumptionServiceImpl struct {
-	mysqlRepository domain.MySQLPowerConsumptionRepository
-	csvRepository   domain.CSVPowerConsumptionRepository
+	mysqlRepository        domain.MySQLPowerConsumptionRepository
+	csvRepository          domain.CSVPowerConsumptionRepository
+	meterGroupRepository   domain.MeterGroupRepository
+	meterSettingRepository domain.MeterSettingRepository
+	qualityRuleRepository  domain.QualityRuleRepository
+	quarantineRepository   domain.QuarantineRepository
+	importRepository       domain.ImportRepository
 }
 
-func NewPowerConsumptionService(mysqlRepository domain.MySQLPowerConsumptionRepository, csvRepository domain.CSVPowerConsumptionRepository) PowerConsumptionService {
+type MeterConsumption struct {
+	Serializer  Serializer
+	Data        []domain.UserConsumption
+	CompareData []domain.UserConsumption
+}
+
+type ImportSummary struct {
+	ImportID    uint               `json:"import_id,omitempty"`
+	DuplicateOf *uint              `json:"duplicate_of,omitempty"`
+	Imported    int                `json:"imported"`
+	Skipped     int                `json:"skipped"`
+	Flagged     int                `json:"flagged"`
+	Quarantined int                `json:"quarantined"`
+	Rejected    int                `json:"rejected"`
+	Violations  []QualityViolation `json:"violations,omitempty"`
+	meterIDs    []int
+}
+
+type importRecord struct {
+	csvRecord *domain.CSVUserConsumption
+	reading   *domain.UserConsumption
+}
+
+type GroupSerializer struct {
+	GroupID int          `json:"group_id"`
+	Name    string       `json:"name"`
+	Meters  []Serializer `json:"meters"`
+	Total   Serializer   `json:"total"`
+}
+
+func NewPowerConsumptionService(mysqlRepository domain.MySQLPowerConsumptionRepository, csvRepository domain.CSVPowerConsumptionRepository, meterGroupRepository domain.MeterGroupRepository, meterSettingRepository domain.MeterSettingRepository, qualityRuleRepository domain.QualityRuleRepository, quarantineRepository domain.QuarantineRepository, importRepository domain.ImportRepository) PowerConsumptionService {
 	return &PowerConsumptionServiceImpl{
 		mysqlRepository,
 		csvRepository,
+		meterGroupRepository,
+		meterSettingRepository,
+		qualityRuleRepository,
+		quarantineRepository,
+		importRepository,
 	}
 }
 
-// ReduceInformation: reduce the information a only one record by group division
+// CheckingQueryParamConstrains: check the query params of the window time and collect every violation instead of
+// stopping on the first one
 //
 // Parameters:
-// groupConsumptions: has the information matched between groups and information
+// meterIDs: has all meterids separated by comma
+// kindPeriod: the period of time to organize the information
+// startDate: has the date to start findings
+// endDate: has the date to end findings
 //
 // Returns:
-// return reduced and one record by group division
+// return the query params checked or a validation error with every field that is not valid
 func (s *PowerConsumptionServiceImpl) CheckingQueryParamConstrains(meterIDs string, kindPeriod string, startDate string, endDate string) (*domain.UserConsumptionQueryParams, error) {
-	var numberArrayMeterIDs []int
-	timeStartDate, err := domain.StrToDate(startDate)
+	validator := NewQueryParamsValidator()
+	chekedQueryParams, _ := checkingQueryParams(validator, kindPeriod, startDate, endDate)
+	chekedQueryParams.MeterIDs = validator.CheckMeterIDs(meterIDs)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+	logrus.Info("the information was succefully checked all queryparms are available")
+	return chekedQueryParams, nil
+}
+
+// checkingQueryParams: check the query params of the window time and record the violations in the validator, the
+// meters are checked by the callers because they could come from the request or from a group
+//
+// Returns:
+// return the query params that could be checked and true if the window time is valid
+func checkingQueryParams(validator *QueryParamsValidator, kindPeriod, startDate, endDate string) (*domain.UserConsumptionQueryParams, bool) {
+	timeStartDate, validStartDate := validator.CheckDate("start_date", startDate)
+	timeEndDate, validEndDate := validator.CheckDate("end_date", endDate)
+	timeEndDateMidnight := timeEndDate.AddDate(0, 0, 1).Add(-time.Second)
+	checkedKindPeriod, validKindPeriod := validator.CheckKindPeriod(kindPeriod)
+
+	validWindow := validStartDate && validEndDate && validator.CheckDateRange(timeStartDate, timeEndDate)
+	if validWindow && validKindPeriod {
+		validWindow = validator.CheckWindowSize(checkedKindPeriod, timeStartDate, timeEndDateMidnight)
+	}
+	return &domain.UserConsumptionQueryParams{
+		StartDate:  timeStartDate,
+		EndDate:    timeEndDateMidnight,
+		KindPeriod: checkedKindPeriod,
+	}, validWindow
+}
+
+// GetConsumptionByMeterIDAndWindowTime: this function check the query params for see if everithing it's ok then
+// create a go routine by meter get all the information regarding this meter in a specific window time and then
+// organize the information and return it
+//
+// Parameters:
+// meterIDs: has all meterids
+// startDate: has the date to start findings
+// endDate: has the date to end findings
+// kindPeriod: the period of time to organize the information
+// options: optional query params like the window to compare
+//
+// Returns:
+// return reduced and one record by group division
+func (s *PowerConsumptionServiceImpl) GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod string, options domain.ConsumptionQueryOptions) ([]Serializer, error) {
+	chekedQueryParams, err := s.checkingQueryParamsAndOptions(meterIDs, kindPeriod, startDate, endDate, options)
 	if err != nil {
-		logrus.Errorf("Error: converting string to date startDate %s", err.Error())
 		return nil, err
 	}
-	timeEndDate, err := domain.StrToDate(endDate)
+
+	meterConsumptions, err := s.getConsumptionByQueryParams(chekedQueryParams)
 	if err != nil {
-		logrus.Errorf("Error: converting string to date endDate %s", err.Error())
 		return nil, err
 	}
-	if timeStartDate.After(timeEndDate) {
-		logrus.Errorf("Error: Invalid dates, start date must be before end date %s %s", startDate, endDate)
-		return nil, fmt.Errorf("Error: Invalid dates, start date must be before end date %s %s", startDate, endDate)
+
+	var allUserConsumptions []Serializer
+	for _, meterConsumption := range meterConsumptions {
+		allUserConsumptions = append(allUserConsumptions, meterConsumption.Serializer)
 	}
-	timeEndDateMidnight := timeEndDate.AddDate(0, 0, 1).Add(-time.Second)
+	return allUserConsumptions, nil
+}
 
-	arraymeterIDs := strings.Split(meterIDs, ",")
-	if len(arraymeterIDs) == 0 {
-		logrus.Errorf("Error: the array is empty %s", err.Error())
-		return nil, err
+// GetConsumptionByMeterIDsInBatch: this function get the information of all the meters with one query of readings
+// and one query of settings by window time and organize the information of every meter in memory, only the
+// cumulative meters need one more query to get their last reading before the window, a meter whose information
+// could not be organized does not stop the other meters
+//
+// Parameters:
+// meterIDs: has all meterids
+// startDate: has the date to start findings
+// endDate: has the date to end findings
+// kindPeriod: the period of time to organize the information
+// options: optional query params like the window to compare
+//
+// Returns:
+// return the information by meter in the same order of the meter ids, the errors of the meters that could not be
+// organized by meter id or an error if the information of the meters could not be read
+func (s *PowerConsumptionServiceImpl) GetConsumptionByMeterIDsInBatch(meterIDs, startDate, endDate, kindPeriod string, options domain.ConsumptionQueryOptions) ([]Serializer, map[int]error, error) {
+	chekedQueryParams, err := s.checkingQueryParamsAndOptions(meterIDs, kindPeriod, startDate, endDate, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	settings, err := s.meterSettingRepository.GetMeterSettingsByMeterIDs(chekedQueryParams.MeterIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	settingsByMeter := make(map[int]*domain.MeterSetting, len(settings))
+	for i := range settings {
+		settingsByMeter[settings[i].MeterID] = &settings[i]
+	}
+	readingsByMeter, err := s.getMetersReadings(chekedQueryParams.StartDate, chekedQueryParams.EndDate, chekedQueryParams.MeterIDs, settingsByMeter)
+	if err != nil {
+		return nil, nil, err
+	}
+	compareReadingsByMeter := map[int][]domain.UserConsumption{}
+	if chekedQueryParams.CompareTo != "" {
+		compareReadingsByMeter, err = s.getMetersReadings(chekedQueryParams.CompareStartDate, chekedQueryParams.CompareEndDate, chekedQueryParams.MeterIDs, settingsByMeter)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	for _, meterID := range arraymeterIDs {
-		numberMeterID, err := domain.StrToInt(meterID)
+	var allUserConsumptions []Serializer
+	meterErrors := map[int]error{}
+	for _, meterID := range chekedQueryParams.MeterIDs {
+		meterQueryParams, err := meterSettingQueryParams(chekedQueryParams, meterID, settingsByMeter[meterID])
 		if err != nil {
-			logrus.Errorf("Error: converting str to int meterID %s", err.Error())
-			return nil, err
+			meterErrors[meterID] = err
+			continue
+		}
+		meterConsumption, err := newMeterConsumption(meterQueryParams, meterID, readingsByMeter[meterID], compareReadingsByMeter[meterID])
+		if err != nil {
+			meterErrors[meterID] = err
+			continue
 		}
-		numberArrayMeterIDs = append(numberArrayMeterIDs, numberMeterID)
+		allUserConsumptions = append(allUserConsumptions, meterConsumption.Serializer)
 	}
+	return allUserConsumptions, meterErrors, nil
+}
 
-	checkedKindPeriod, err := s.ChekingKindPeriod(kindPeriod)
+// GetConsumptionByGroupAndWindowTime: this function resolve all the meters in a group and its nested groups, get
+// the information of every meter and sum the information of all the meters in a total series
+//
+// Parameters:
+// groupID: the id of the meter group
+// startDate: has the date to start findings
+// endDate: has the date to end findings
+// kindPeriod: the period of time to organize the information
+// options: optional query params like the window to compare
+//
+// Returns:
+// return the information by meter and the total of the group
+func (s *PowerConsumptionServiceImpl) GetConsumptionByGroupAndWindowTime(groupID, startDate, endDate, kindPeriod string, options domain.ConsumptionQueryOptions) (*GroupSerializer, error) {
+	validator := NewQueryParamsValidator()
+	numberGroupID, _ := validator.CheckGroupID(groupID)
+	chekedQueryParams := checkingQueryOptions(validator, kindPeriod, startDate, endDate, options)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+	group, meterIDs, err := ResolveMeterGroup(s.meterGroupRepository, numberGroupID)
 	if err != nil {
-		logrus.Errorf("Error: cheking kind period %s", err.Error())
 		return nil, err
 	}
-	logrus.Info("the information was succefully checked all queryparms are available")
-	return &domain.UserConsumptionQueryParams{
-		StartDate:  timeStartDate,
-		EndDate:    timeEndDateMidnight,
-		MeterIDs:   numberArrayMeterIDs,
-		KindPeriod: checkedKindPeriod,
-	}, nil
+	if len(meterIDs) == 0 {
+		logrus.Errorf("Error: the group %d does not have meters", numberGroupID)
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "group_id", fmt.Sprintf("Error: the group %d does not have meters", numberGroupID))
+	}
+	chekedQueryParams.MeterIDs = meterIDs
+
+	meterConsumptions, err := s.getConsumptionByQueryParams(chekedQueryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	groupSerializer := &GroupSerializer{
+		GroupID: int(group.ID),
+		Name:    group.Name,
+	}
+	var groupData, groupCompareData []domain.UserConsumption
+	for _, meterConsumption := range meterConsumptions {
+		groupSerializer.Meters = append(groupSerializer.Meters, meterConsumption.Serializer)
+		groupData = append(groupData, meterConsumption.Data...)
+		groupCompareData = append(groupCompareData, meterConsumption.CompareData...)
+	}
+
+	chekedQueryParams, err = s.settingsQueryParams(chekedQueryParams, meterIDs)
+	if err != nil {
+		return nil, err
+	}
+	filter, consumptionEnergy := reduceConsumption(chekedQueryParams, groupData)
+	groupSerializer.Total = SerializeConsumptionEnergy(filter, consumptionEnergy)
+	if len(groupSerializer.Meters) > 0 {
+		groupSerializer.Total.Unit = groupSerializer.Meters[0].Unit
+		groupSerializer.Total.ReactiveUnit = groupSerializer.Meters[0].ReactiveUnit
+	}
+	if chekedQueryParams.CompareTo != "" {
+		groupSerializer.Total.Comparison = compareConsumption(chekedQueryParams, filter, consumptionEnergy, groupCompareData)
+	}
+	logrus.Infof("the information of the group %d was succesfully reduced", group.ID)
+	return groupSerializer, nil
 }
 
-// GetConsumptionByMeterIDAndWindowTime: this function check the query params for see if everithing it's ok then
-// create a go routine by meter get all the information regarding this meter in a specific window time and then
-// organize the information and return it
+// GetPeakDemandByMeterIDAndWindowTime: this function convert the active energy of the readings in average power (kW)
+// by demand window and find the monthly peak of every meter
 //
 // Parameters:
 // meterIDs: has all meterids
 // startDate: has the date to start findings
 // endDate: has the date to end findings
-// kindPeriod: the period of time to organize the information
+// demandWindow: the length of the demand window example "15m"
+// windowType: fixed or rolling windows
 //
 // Returns:
-// return reduced and one record by group division
-func (s *PowerConsumptionServiceImpl) GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod string) ([]Serializer, error) {
+// return the demand and the monthly peaks by meter
+func (s *PowerConsumptionServiceImpl) GetPeakDemandByMeterIDAndWindowTime(meterIDs, startDate, endDate, demandWindow, windowType string) ([]DemandSerializer, error) {
+	validator := NewQueryParamsValidator()
+	windowLength, checkedWindowType, _ := validator.CheckDemandWindow(demandWindow, windowType)
+	chekedQueryParams, _ := checkingQueryParams(validator, constants.PeriodKindMonthly, startDate, endDate)
+	chekedQueryParams.MeterIDs = validator.CheckMeterIDs(meterIDs)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
 
-	chekedQueryParams, err := s.CheckingQueryParamConstrains(meterIDs, kindPeriod, startDate, endDate)
+	meterConsumptions, err := s.getConsumptionByQueryParams(chekedQueryParams)
 	if err != nil {
 		return nil, err
 	}
-	userConsumptionChannel := make(chan Serializer, len(chekedQueryParams.MeterIDs))
-	errorUserConsumptionChannel := make(chan error, len(chekedQueryParams.MeterIDs))
-	wg := sync.WaitGroup{}
+
+	var allDemands []DemandSerializer
+	for _, meterConsumption := range meterConsumptions {
+		allDemands = append(allDemands, GetDemandData(meterConsumption.Serializer.MeterID, meterConsumption.Data, windowLength, checkedWindowType))
+	}
+	return allDemands, nil
+}
+
+// GetAnalyticsByMeterIDAndWindowTime: this function compute the average daily load profile and the monthly load
+// factor of every meter
+//
+// Parameters:
+// meterIDs: has all meterids
+// startDate: has the date to start findings
+// endDate: has the date to end findings
+// demandWindow: the length of the demand window used to find the peak demand example "15m"
+//
+// Returns:
+// return the load profile and the load factor by meter
+func (s *PowerConsumptionServiceImpl) GetAnalyticsByMeterIDAndWindowTime(meterIDs, startDate, endDate, demandWindow string) ([]AnalyticsSerializer, error) {
+	validator := NewQueryParamsValidator()
+	windowLength, _, _ := validator.CheckDemandWindow(demandWindow, constants.DemandWindowTypeFixed)
+	chekedQueryParams, _ := checkingQueryParams(validator, constants.PeriodKindMonthly, startDate, endDate)
+	chekedQueryParams.MeterIDs = validator.CheckMeterIDs(meterIDs)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+
+	meterConsumptions, err := s.getConsumptionByQueryParams(chekedQueryParams)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, meterID := range chekedQueryParams.MeterIDs {
+	var allAnalytics []AnalyticsSerializer
+	for _, meterConsumption := range meterConsumptions {
+		allAnalytics = append(allAnalytics, GetAnalyticsData(meterConsumption.Serializer.MeterID, meterConsumption.Data, chekedQueryParams, windowLength))
+	}
+	return allAnalytics, nil
+}
+
+// checkingQueryParamsAndOptions: check the query params and the optional query params
+//
+// Returns:
+// return the query params checked with the options
+func (s *PowerConsumptionServiceImpl) checkingQueryParamsAndOptions(meterIDs, kindPeriod, startDate, endDate string, options domain.ConsumptionQueryOptions) (*domain.UserConsumptionQueryParams, error) {
+	validator := NewQueryParamsValidator()
+	chekedQueryParams := checkingQueryOptions(validator, kindPeriod, startDate, endDate, options)
+	chekedQueryParams.MeterIDs = validator.CheckMeterIDs(meterIDs)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+	return chekedQueryParams, nil
+}
+
+// checkingQueryOptions: check the query params of the window time and the optional query params and record the
+// violations in the validator
+//
+// Returns:
+// return the query params that could be checked with the options
+func checkingQueryOptions(validator *QueryParamsValidator, kindPeriod, startDate, endDate string, options domain.ConsumptionQueryOptions) *domain.UserConsumptionQueryParams {
+	if options.Interval != "" && strings.Trim(kindPeriod, " ") == "" {
+		kindPeriod = constants.PeriodKindInterval
+	}
+	chekedQueryParams, validWindow := checkingQueryParams(validator, kindPeriod, startDate, endDate)
+	validKindPeriod := chekedQueryParams.KindPeriod != ""
+	if validKindPeriod && (chekedQueryParams.KindPeriod == constants.PeriodKindInterval || options.Interval != "") {
+		if chekedQueryParams.KindPeriod != constants.PeriodKindInterval {
+			validator.Add("interval", constants.ErrorCodeInvalidParam, fmt.Sprintf("Error: the interval only could be used with the kind period interval %s", chekedQueryParams.KindPeriod))
+		} else if validWindow {
+			interval, err := ChekingInterval(options.Interval, chekedQueryParams.StartDate, chekedQueryParams.EndDate)
+			if err != nil {
+				validator.Add("interval", constants.ErrorCodeInvalidParam, err.Error())
+			}
+			chekedQueryParams.Interval = interval
+		}
+	}
+	if options.BillingCycleDay != "" && validKindPeriod && chekedQueryParams.KindPeriod != constants.PeriodKindBillingCycle {
+		validator.Add("billing_cycle_day", constants.ErrorCodeInvalidParam, fmt.Sprintf("Error: the billing cycle day only could be used with the kind period billing_cycle %s", chekedQueryParams.KindPeriod))
+	} else {
+		billingCycleDay, err := ChekingBillingCycleOption(options.BillingCycleDay)
+		if err != nil {
+			validator.Add("billing_cycle_day", constants.ErrorCodeInvalidParam, err.Error())
+		}
+		chekedQueryParams.BillingCycleDay = billingCycleDay
+	}
+	unit, err := ChekingUnit(options.Unit)
+	if err != nil {
+		validator.Add("unit", constants.ErrorCodeInvalidParam, err.Error())
+	}
+	chekedQueryParams.Unit = unit.Active
+	if options.CompareTo != "" && validWindow {
+		compareTo, compareStartDate, compareEndDate, err := ChekingCompareTo(options.CompareTo, chekedQueryParams.StartDate, chekedQueryParams.EndDate, options.CompareStartDate, options.CompareEndDate)
+		if err != nil {
+			validator.Add("compare_to", constants.ErrorCodeInvalidParam, err.Error())
+		}
+		chekedQueryParams.CompareTo = compareTo
+		chekedQueryParams.CompareStartDate = compareStartDate
+		chekedQueryParams.CompareEndDate = compareEndDate
+	}
+	if options.Aggregations != "" {
+		aggregations, err := ChekingAggregations(options.Aggregations)
+		if err != nil {
+			validator.Add("aggregations", constants.ErrorCodeInvalidParam, err.Error())
+		}
+		chekedQueryParams.Aggregations = aggregations
+	}
+	weekStart, err := ChekingWeekStart(options.WeekStart)
+	if err != nil {
+		validator.Add("week_start", constants.ErrorCodeInvalidParam, err.Error())
+	}
+	chekedQueryParams.WeekStart = weekStart
+	return chekedQueryParams
+}
+
+// getConsumptionByQueryParams: create a go routine by meter to get and organize the information of every meter
+//
+// Parameters:
+// queryParams: the query params checked
+//
+// Returns:
+// return the information by meter in the same order of the meter ids
+func (s *PowerConsumptionServiceImpl) getConsumptionByQueryParams(queryParams *domain.UserConsumptionQueryParams) ([]*MeterConsumption, error) {
+	userConsumptionChannel := make(chan *MeterConsumption, len(queryParams.MeterIDs))
+	errorUserConsumptionChannel := make(chan error, len(queryParams.MeterIDs))
+	wg := sync.WaitGroup{}
+
+	for _, meterID := range queryParams.MeterIDs {
 		wg.Add(1)
 		go func(meterID int) {
-			getInformation, err := s.mysqlRepository.GetConsumptionByMeterIDAndWindowTime(chekedQueryParams.StartDate, chekedQueryParams.EndDate, meterID)
 			defer wg.Done()
+			meterConsumption, err := s.getMeterConsumption(queryParams, meterID)
 			if err != nil {
-				logrus.Errorf("Error geting the information %s meterID %d", err.Error(), meterID)
 				errorUserConsumptionChannel <- err
 				return
 			}
-			filter := NewFilter(chekedQueryParams.KindPeriod, chekedQueryParams.StartDate, chekedQueryParams.EndDate, getInformation)
-			serializer := GetConsumptionData(filter)
-			serializer.MeterID = meterID
-			userConsumptionChannel <- serializer
-
+			userConsumptionChannel <- meterConsumption
 		}(meterID)
 	}
 
@@ -135,19 +445,226 @@ func (s *PowerConsumptionServiceImpl) GetConsumptionByMeterIDAndWindowTime(meter
 		close(errorUserConsumptionChannel)
 	}()
 
-	var allUserConsumptions []Serializer
+	var allUserConsumptions []*MeterConsumption
 	for userConsumption := range userConsumptionChannel {
 		allUserConsumptions = append(allUserConsumptions, userConsumption)
 	}
 
-	err = <-errorUserConsumptionChannel
+	err := <-errorUserConsumptionChannel
 	if err != nil {
 		return nil, err
 	}
 
+	positions := make(map[int]int, len(queryParams.MeterIDs))
+	for position, meterID := range queryParams.MeterIDs {
+		positions[meterID] = position
+	}
+	sort.Slice(allUserConsumptions, func(i, j int) bool {
+		return positions[allUserConsumptions[i].Serializer.MeterID] < positions[allUserConsumptions[j].Serializer.MeterID]
+	})
 	return allUserConsumptions, nil
 }
 
+// getMeterConsumption: get all the information regarding a meter in the window time and in the compared window
+// and organize it
+//
+// Parameters:
+// queryParams: the query params checked
+// meterID: the meter to get the information
+//
+// Returns:
+// return the information organized and the records used to organize it
+func (s *PowerConsumptionServiceImpl) getMeterConsumption(queryParams *domain.UserConsumptionQueryParams, meterID int) (*MeterConsumption, error) {
+	queryParams, err := s.settingsQueryParams(queryParams, []int{meterID})
+	if err != nil {
+		return nil, err
+	}
+	getInformation, err := s.getMeterReadings(queryParams.StartDate, queryParams.EndDate, meterID)
+	if err != nil {
+		logrus.Errorf("Error geting the information %s meterID %d", err.Error(), meterID)
+		return nil, err
+	}
+	var getCompareInformation []domain.UserConsumption
+	if queryParams.CompareTo != "" {
+		getCompareInformation, err = s.getMeterReadings(queryParams.CompareStartDate, queryParams.CompareEndDate, meterID)
+		if err != nil {
+			logrus.Errorf("Error geting the compared information %s meterID %d", err.Error(), meterID)
+			return nil, err
+		}
+	}
+	return newMeterConsumption(queryParams, meterID, getInformation, getCompareInformation)
+}
+
+// newMeterConsumption: organize the readings of a meter already loaded in the window time and in the compared window
+//
+// Parameters:
+// queryParams: the query params checked with the settings of the meter
+// meterID: the meter of the readings
+// data: the readings of the window time
+// compareData: the readings of the compared window, nil when the query does not compare
+//
+// Returns:
+// return the information organized and the records used to organize it
+func newMeterConsumption(queryParams *domain.UserConsumptionQueryParams, meterID int, data, compareData []domain.UserConsumption) (*MeterConsumption, error) {
+	unit, err := ChekingUnit(queryParams.Unit)
+	if err != nil {
+		return nil, err
+	}
+	data = unit.ToUnit(data)
+	filter, consumptionEnergy := reduceConsumption(queryParams, data)
+	meterConsumption := &MeterConsumption{
+		Serializer: SerializeConsumptionEnergy(filter, consumptionEnergy),
+		Data:       data,
+	}
+	meterConsumption.Serializer.MeterID = meterID
+	meterConsumption.Serializer.Unit = unit.Active
+	meterConsumption.Serializer.ReactiveUnit = unit.Reactive
+
+	if queryParams.CompareTo != "" {
+		compareData = unit.ToUnit(compareData)
+		meterConsumption.CompareData = compareData
+		meterConsumption.Serializer.Comparison = compareConsumption(queryParams, filter, consumptionEnergy, compareData)
+	}
+	return meterConsumption, nil
+}
+
+// getMeterReadings: get the readings of a meter in a window time, the register values of the cumulative meters are
+// converted in the energy of every interval
+//
+// Parameters:
+// startDate: the start of the window
+// endDate: the end of the window
+// meterID: the meter to get the readings
+//
+// Returns:
+// return the energy by reading
+func (s *PowerConsumptionServiceImpl) getMeterReadings(startDate, endDate time.Time, meterID int) ([]domain.UserConsumption, error) {
+	readings, err := s.mysqlRepository.GetConsumptionByMeterIDAndWindowTime(startDate, endDate, meterID)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := s.meterSettingRepository.GetMeterSettingByMeterID(meterID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil || !setting.Cumulative {
+		return readings, nil
+	}
+	previous, err := s.mysqlRepository.GetLastConsumptionBeforeDate(startDate, meterID)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertCumulativeReadings(previous, readings, setting.RegisterMax), nil
+}
+
+// getMetersReadings: get the readings of several meters in a window time with only one query, the register values of
+// the cumulative meters are converted in the energy of every interval
+//
+// Parameters:
+// startDate: the start of the window
+// endDate: the end of the window
+// meterIDs: the meters to get the readings
+// settingsByMeter: the settings of the meters already loaded
+//
+// Returns:
+// return the energy by reading of every meter
+func (s *PowerConsumptionServiceImpl) getMetersReadings(startDate, endDate time.Time, meterIDs []int, settingsByMeter map[int]*domain.MeterSetting) (map[int][]domain.UserConsumption, error) {
+	readings, err := s.mysqlRepository.GetConsumptionByMeterIDsAndWindowTime(startDate, endDate, meterIDs)
+	if err != nil {
+		return nil, err
+	}
+	readingsByMeter := make(map[int][]domain.UserConsumption, len(meterIDs))
+	for _, reading := range readings {
+		readingsByMeter[reading.MeterID] = append(readingsByMeter[reading.MeterID], reading)
+	}
+	for _, meterID := range meterIDs {
+		setting := settingsByMeter[meterID]
+		if setting == nil || !setting.Cumulative {
+			continue
+		}
+		previous, err := s.mysqlRepository.GetLastConsumptionBeforeDate(startDate, meterID)
+		if err != nil {
+			return nil, err
+		}
+		readingsByMeter[meterID] = ConvertCumulativeReadings(previous, readingsByMeter[meterID], setting.RegisterMax)
+	}
+	return readingsByMeter, nil
+}
+
+// settingsQueryParams: complete the query params with the settings of the meters when the request does not have them
+//
+// Parameters:
+// queryParams: the query params checked
+// meterIDs: the meters that will be organized with the query params
+//
+// Returns:
+// return a copy of the query params with the settings of the meters
+func (s *PowerConsumptionServiceImpl) settingsQueryParams(queryParams *domain.UserConsumptionQueryParams, meterIDs []int) (*domain.UserConsumptionQueryParams, error) {
+	if queryParams.KindPeriod != constants.PeriodKindBillingCycle || queryParams.BillingCycleDay != 0 {
+		return queryParams, nil
+	}
+	billingCycleDay, err := ResolveBillingCycleDay(s.meterSettingRepository, meterIDs)
+	if err != nil {
+		return nil, err
+	}
+	meterQueryParams := *queryParams
+	meterQueryParams.BillingCycleDay = billingCycleDay
+	return &meterQueryParams, nil
+}
+
+// meterSettingQueryParams: complete the query params with the settings of a meter already loaded when the request
+// does not have them
+//
+// Parameters:
+// queryParams: the query params checked
+// meterID: the meter that will be organized with the query params
+// setting: the settings of the meter, nil if the meter does not have settings
+//
+// Returns:
+// return a copy of the query params with the settings of the meter
+func meterSettingQueryParams(queryParams *domain.UserConsumptionQueryParams, meterID int, setting *domain.MeterSetting) (*domain.UserConsumptionQueryParams, error) {
+	if queryParams.KindPeriod != constants.PeriodKindBillingCycle || queryParams.BillingCycleDay != 0 {
+		return queryParams, nil
+	}
+	if setting == nil || setting.BillingCycleDay == 0 {
+		logrus.Errorf("Error: the meter %d does not have a billing cycle day", meterID)
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", fmt.Sprintf("Error: the meter %d does not have a billing cycle day", meterID))
+	}
+	meterQueryParams := *queryParams
+	meterQueryParams.BillingCycleDay = setting.BillingCycleDay
+	return &meterQueryParams, nil
+}
+
+// reduceConsumption: organize the records of the window by group division and run the selected aggregations
+//
+// Parameters:
+// queryParams: the query params checked
+// data: the records of the window
+//
+// Returns:
+// return the filter used and the reduced groups
+func reduceConsumption(queryParams *domain.UserConsumptionQueryParams, data []domain.UserConsumption) (FilterOperations, []*ConsumptionEnergy) {
+	filter := NewFilterFromQueryParams(queryParams, queryParams.StartDate, queryParams.EndDate, data)
+	consumptionEnergy := GetConsumptionEnergy(filter)
+	ReduceAggregations(consumptionEnergy, queryParams.Aggregations)
+	return filter, consumptionEnergy
+}
+
+// compareConsumption: organize the information of the compared window and align it with the current window
+//
+// Parameters:
+// queryParams: has the current and the compared windows
+// filter: the filter used in the current window
+// currentEnergy: the reduced groups of the current window
+// compareData: the records of the compared window
+//
+// Returns:
+// return the compared series aligned with the current series
+func compareConsumption(queryParams *domain.UserConsumptionQueryParams, filter FilterOperations, currentEnergy []*ConsumptionEnergy, compareData []domain.UserConsumption) *ComparisonSerializer {
+	compareFilter := NewFilterFromQueryParams(queryParams, queryParams.CompareStartDate, queryParams.CompareEndDate, compareData)
+	return CompareConsumptionEnergy(filter, queryParams, currentEnergy, GetConsumptionEnergy(compareFilter))
+}
+
 // ChekingKindPeriod: this function check if the kind of period is allowed
 //
 // Parameters:
@@ -159,42 +676,326 @@ func (s *PowerConsumptionServiceImpl) GetConsumptionByMeterIDAndWindowTime(meter
 // Returns:
 // return reduced and one record by group division
 func (s *PowerConsumptionServiceImpl) ChekingKindPeriod(kindPeriod string) (string, error) {
-	lowerCaseKindPeriod := strings.ToLower(kindPeriod)
-	trimAndLowerCaseKindPeriod := strings.Trim(lowerCaseKindPeriod, " ")
-	switch trimAndLowerCaseKindPeriod {
-	case constants.PeriodKindMonthly:
-		return trimAndLowerCaseKindPeriod, nil
-	case constants.PeriodKindWeekly:
-		return trimAndLowerCaseKindPeriod, nil
-	case constants.PeriodKindDaily:
-		return trimAndLowerCaseKindPeriod, nil
-	default:
-		return "", fmt.Errorf("Error: kind period not allowed %s", trimAndLowerCaseKindPeriod)
-	}
+	validator := NewQueryParamsValidator()
+	checkedKindPeriod, _ := validator.CheckKindPeriod(kindPeriod)
+	return checkedKindPeriod, validator.Err()
 }
 
-// ImportCsvToDatabase: this function convert and multipart file with extension csv to struct then push the information
-// in the database
+// ImportCsvToDatabase: this function convert and multipart file with extension csv to struct, run the data quality
+// rules over the records then push the information in the database, the values are stored in kWh and kvarh, every
+// import is recorded with the checksum of the file and its row counts and the records keep the id of the import, a
+// file with the same content as a previous import is rejected unless the import is forced
 //
 // Parameters:
 // file
+// options: the name of the file, the uploader, the unit of the values, blank to use the unit of every meter, and
+// force to import a file again
 //
 // Returns:
-// return and error if the function fails or nil if it's not
-func (s *PowerConsumptionServiceImpl) ImportCsvToDatabase(file *multipart.File) error {
+// return the id of the import and the number of records imported, flagged, quarantined and rejected or an error if
+// the function fails
+func (s *PowerConsumptionServiceImpl) ImportCsvToDatabase(file *multipart.File, options domain.ImportOptions) (*ImportSummary, error) {
+	if options.Unit != "" {
+		if _, err := ChekingUnit(options.Unit); err != nil {
+			logrus.Errorf("Error: cheking unit %s", err.Error())
+			return nil, err
+		}
+	}
+	checksum, err := s.csvRepository.FileChecksum(file)
+	if err != nil {
+		return nil, err
+	}
+	originalImport, err := s.importRepository.GetImportByChecksum(checksum)
+	if err != nil {
+		return nil, err
+	}
+	if originalImport != nil && !options.Force {
+		logrus.Errorf("Error: the file %s was already imported in the import %d", options.FileName, originalImport.ID)
+		importedAt := originalImport.CreatedAt.Format(time.RFC3339)
+		duplicateImportError := domain.NewConflictError(constants.ErrorCodeDuplicateImport, fmt.Sprintf("Error: the file was already imported in the import %d at %s, use force to import it again", originalImport.ID, importedAt), nil)
+		duplicateImportError.Fields = []domain.FieldError{
+			{Field: "import_id", Code: constants.ErrorCodeDuplicateImport, Message: strconv.FormatUint(uint64(originalImport.ID), 10)},
+			{Field: "imported_at", Code: constants.ErrorCodeDuplicateImport, Message: importedAt},
+		}
+		return nil, duplicateImportError
+	}
 	csvUsersConsumption, err := s.csvRepository.ConvertCSVToStruct(file)
-	var usersConsumption []*domain.UserConsumption
 	if err != nil {
-		return err
+		return nil, err
+	}
+	summary, err := s.importCSVRecords(csvUsersConsumption, checksum, options)
+	if err != nil {
+		return nil, err
+	}
+	if originalImport != nil {
+		logrus.Warnf("the file %s was imported again, the original import is %d", options.FileName, originalImport.ID)
+		summary.DuplicateOf = &originalImport.ID
+	}
+	return summary, nil
+}
+
+// ImportCSVRecords: import records that do not come from a file, like the readings of a grpc stream, with the same
+// history, data quality rules and events of the csv files
+//
+// Parameters:
+// csvUsersConsumption: the csv records
+// options: the source, the uploader and the unit of the values, blank to use the unit of every meter
+//
+// Returns:
+// return the id of the import and the number of records imported, flagged, quarantined and rejected or an error if
+// the function fails
+func (s *PowerConsumptionServiceImpl) ImportCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, error) {
+	if options.Unit != "" {
+		if _, err := ChekingUnit(options.Unit); err != nil {
+			logrus.Errorf("Error: cheking unit %s", err.Error())
+			return nil, err
+		}
+	}
+	return s.importCSVRecords(csvUsersConsumption, "", options)
+}
+
+// importCSVRecords: record an import in progress and complete it with its records, the import is marked as failed
+// when its records could not be saved
+//
+// Parameters:
+// csvUsersConsumption: the csv records of the import
+// checksum: the hash of the file, blank when the records do not come from a file
+// options: the source, the uploader and the unit of the values
+//
+// Returns:
+// return the id of the import and the number of records imported, flagged, quarantined and rejected or an error if
+// nothing was saved
+func (s *PowerConsumptionServiceImpl) importCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, checksum string, options domain.ImportOptions) (*ImportSummary, error) {
+	importRecord := &domain.Import{
+		FileName: options.FileName,
+		Checksum: checksum,
+		Uploader: options.Uploader,
+		Unit:     options.Unit,
+		Status:   constants.ImportStatusInProgress,
+		Total:    len(csvUsersConsumption),
+	}
+	if err := s.importRepository.CreateImport(importRecord); err != nil {
+		return nil, err
+	}
+	options.ImportID = &importRecord.ID
+	summary, err := s.completeImport(importRecord, csvUsersConsumption, options)
+	if err != nil {
+		importRecord.Status = constants.ImportStatusFailed
+		if updateErr := s.importRepository.UpdateImport(importRecord); updateErr != nil {
+			logrus.Errorf("Error: the import %d could not be marked as failed %s", importRecord.ID, updateErr.Error())
+		}
+		return nil, err
+	}
+	summary.ImportID = importRecord.ID
+	return summary, nil
+}
+
+// completeImport: check the records of an import and save them with the import completed in only one transaction
+//
+// Parameters:
+// importRecord: the import in progress
+// csvUsersConsumption: the csv records of the import
+// options: the source and the import of the records and the unit of the values
+//
+// Returns:
+// return the number of records imported, flagged, quarantined and rejected or an error if nothing was saved
+func (s *PowerConsumptionServiceImpl) completeImport(importRecord *domain.Import, csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, error) {
+	summary, acceptedConsumption, quarantinedReadings, err := s.checkCSVRecords(csvUsersConsumption, options)
+	if err != nil {
+		return nil, err
+	}
+	importRecord.Status = constants.ImportStatusCompleted
+	importRecord.Imported = summary.Imported
+	importRecord.Flagged = summary.Flagged
+	importRecord.Quarantined = summary.Quarantined
+	importRecord.Rejected = summary.Rejected
+	if _, err := s.mysqlRepository.CreateImportRecords(acceptedConsumption, quarantinedReadings, importRecord, summary.meterIDs); err != nil {
+		return nil, err
 	}
+	summary.Imported = importRecord.Imported
+	summary.Skipped = importRecord.Skipped
+	logrus.Infof("import %d done imported=%d skipped=%d flagged=%d quarantined=%d rejected=%d", importRecord.ID, summary.Imported, summary.Skipped, summary.Flagged, summary.Quarantined, summary.Rejected)
+	return summary, nil
+}
 
+// IngestCSVRecords: convert the csv records, run the data quality rules over them then push the information in the
+// database, the records that could not be converted are quarantined in the same transaction
+//
+// Parameters:
+// csvUsersConsumption: the csv records
+// options: the source and the import of the records and the unit of the values, blank to use the unit of every meter
+//
+// Returns:
+// return the number of records imported, flagged, quarantined and rejected or an error if the function fails
+func (s *PowerConsumptionServiceImpl) IngestCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, error) {
+	summary, acceptedConsumption, quarantinedReadings, err := s.checkCSVRecords(csvUsersConsumption, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(acceptedConsumption) > 0 || len(quarantinedReadings) > 0 {
+		insertedConsumption, err := s.mysqlRepository.CreateIngestedRecords(acceptedConsumption, quarantinedReadings)
+		if err != nil {
+			return nil, err
+		}
+		summary.Imported = len(insertedConsumption)
+		summary.Skipped = len(acceptedConsumption) - len(insertedConsumption)
+	}
+	logrus.Infof("import done imported=%d skipped=%d flagged=%d quarantined=%d rejected=%d", summary.Imported, summary.Skipped, summary.Flagged, summary.Quarantined, summary.Rejected)
+	return summary, nil
+}
+
+// checkCSVRecords: convert the csv records and run the data quality rules over them without saving anything, the
+// records that could not be converted are quarantined
+//
+// Parameters:
+// csvUsersConsumption: the csv records
+// options: the source and the import of the records and the unit of the values, blank to use the unit of every meter
+//
+// Returns:
+// return the summary, the records to import and the records to quarantine
+func (s *PowerConsumptionServiceImpl) checkCSVRecords(csvUsersConsumption []*domain.CSVUserConsumption, options domain.ImportOptions) (*ImportSummary, []*domain.UserConsumption, []*domain.QuarantinedReading, error) {
+	summary := &ImportSummary{}
+	var records []*importRecord
+	var quarantinedReadings []*domain.QuarantinedReading
+	meterUnits := make(map[int]EnergyUnit)
 	for _, csvUserConsumption := range csvUsersConsumption {
 		userConsumption, err := csvUserConsumption.ToUserConsumption()
 		if err != nil {
-			return err
+			violation := QualityViolation{Rule: constants.QualityRuleParse, Action: constants.QualityActionQuarantine, Reason: err.Error()}
+			summary.Quarantined++
+			summary.Violations = append(summary.Violations, violation)
+			quarantinedReading, err := s.newQuarantinedReading(&importRecord{csvRecord: csvUserConsumption, reading: &domain.UserConsumption{}}, options, []QualityViolation{violation})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			quarantinedReadings = append(quarantinedReadings, quarantinedReading)
+			continue
 		}
-		usersConsumption = append(usersConsumption, userConsumption)
+		meterUnit, ok := meterUnits[userConsumption.MeterID]
+		if !ok {
+			meterUnit, err = s.importUnit(userConsumption.MeterID, options.Unit)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			meterUnits[userConsumption.MeterID] = meterUnit
+		}
+		*userConsumption = meterUnit.FromUnit(*userConsumption)
+		userConsumption.ImportID = options.ImportID
+		records = append(records, &importRecord{csvRecord: csvUserConsumption, reading: userConsumption})
+	}
+
+	acceptedConsumption, err := s.checkQualityRules(records, options, summary, &quarantinedReadings)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	seenMeterIDs := make(map[int]bool)
+	for _, userConsumption := range acceptedConsumption {
+		if !seenMeterIDs[userConsumption.MeterID] {
+			seenMeterIDs[userConsumption.MeterID] = true
+			summary.meterIDs = append(summary.meterIDs, userConsumption.MeterID)
+		}
+	}
+	return summary, acceptedConsumption, quarantinedReadings, nil
+}
 
-	return s.mysqlRepository.CreatePowerConsumptionRecords(usersConsumption)
+// checkQualityRules: run the data quality rules over the records in date order, the rejected records are dropped,
+// the quarantined records are kept to review them and the flagged records are imported with the rules they do not pass
+//
+// Parameters:
+// records: the records of the import
+// options: the source of the records
+// summary: the summary of the import to update
+// quarantinedReadings: the records to quarantine
+//
+// Returns:
+// return the records to import
+func (s *PowerConsumptionServiceImpl) checkQualityRules(records []*importRecord, options domain.ImportOptions, summary *ImportSummary, quarantinedReadings *[]*domain.QuarantinedReading) ([]*domain.UserConsumption, error) {
+	rules, err := s.qualityRuleRepository.GetQualityRules()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].reading.Date.Before(records[j].reading.Date)
+	})
+	engine := NewQualityRuleEngine(rules, s.mysqlRepository, s.meterSettingRepository, time.Now())
+	var acceptedConsumption []*domain.UserConsumption
+	for _, record := range records {
+		violations, err := engine.CheckReading(*record.reading)
+		if err != nil {
+			return nil, err
+		}
+		summary.Violations = append(summary.Violations, violations...)
+		switch StrongestAction(violations) {
+		case constants.QualityActionReject:
+			summary.Rejected++
+			continue
+		case constants.QualityActionQuarantine:
+			summary.Quarantined++
+			quarantinedReading, err := s.newQuarantinedReading(record, options, violations)
+			if err != nil {
+				return nil, err
+			}
+			*quarantinedReadings = append(*quarantinedReadings, quarantinedReading)
+			continue
+		case constants.QualityActionFlag:
+			summary.Flagged++
+			var ruleNames []string
+			for _, violation := range violations {
+				ruleNames = append(ruleNames, violation.Rule)
+			}
+			record.reading.Flags = strings.Join(ruleNames, ",")
+		}
+		engine.Accept(*record.reading)
+		acceptedConsumption = append(acceptedConsumption, record.reading)
+	}
+	summary.Imported = len(acceptedConsumption)
+	return acceptedConsumption, nil
+}
+
+// newQuarantinedReading: keep the csv line of a record with the reasons why it was quarantined
+//
+// Parameters:
+// record: the csv record and its reading
+// options: the source of the record
+// violations: the rules that the record does not pass
+//
+// Returns:
+// return the quarantined reading
+func (s *PowerConsumptionServiceImpl) newQuarantinedReading(record *importRecord, options domain.ImportOptions, violations []QualityViolation) (*domain.QuarantinedReading, error) {
+	var reasons []string
+	for _, violation := range violations {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", violation.Rule, violation.Reason))
+	}
+	rawLine, err := s.csvRepository.ConvertStructToCSVLine(record.csvRecord)
+	if err != nil {
+		return nil, err
+	}
+	quarantinedReading := domain.NewQuarantinedReading(*record.reading, strings.Join(reasons, "; "))
+	quarantinedReading.RawLine = rawLine
+	quarantinedReading.Source = options.FileName
+	quarantinedReading.Unit = options.Unit
+	quarantinedReading.ImportID = options.ImportID
+	return quarantinedReading, nil
+}
+
+// importUnit: find the unit of the values of a meter in an imported file
+//
+// Parameters:
+// meterID: the meter of the values
+// unit: the unit of the file, blank to use the unit of the meter
+//
+// Returns:
+// return the unit of the file, the unit of the meter or kWh
+func (s *PowerConsumptionServiceImpl) importUnit(meterID int, unit string) (EnergyUnit, error) {
+	if unit == "" {
+		setting, err := s.meterSettingRepository.GetMeterSettingByMeterID(meterID)
+		if err != nil {
+			return EnergyUnit{}, err
+		}
+		if setting != nil {
+			unit = setting.Unit
+		}
+	}
+	return ChekingUnit(unit)
 }
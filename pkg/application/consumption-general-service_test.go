@@ -9,6 +9,7 @@ import (
 	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
 )
 
 const (
@@ -21,13 +22,17 @@ var _ = Describe("chekingQueryParamConstrains", func() {
 	var (
 		mockMySQLRepo               *domainfakes.FakeMySQLPowerConsumptionRepository
 		mockCSVRepo                 *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo          *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo        *domainfakes.FakeMeterSettingRepository
 		mockPowerConsumptionService PowerConsumptionService
 	)
 
 	BeforeEach(func() {
 		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
 		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
-		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo)
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
 	})
 
 	Context("checkingQueryParamConstrains", func() {
@@ -73,6 +78,42 @@ var _ = Describe("chekingQueryParamConstrains", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(queryParams).To(BeNil())
 		})
+
+		It("should collect every field that is not valid", func() {
+			queryParams, err := mockPowerConsumptionService.CheckingQueryParamConstrains("1,a,,1", "hourly", "2023-13-01", "2023-02-01")
+
+			Expect(queryParams).To(BeNil())
+			domainError, ok := domain.AsDomainError(err)
+			Expect(ok).To(BeTrue())
+			Expect(domainError.Code).To(Equal("invalid_request"))
+			Expect(domainError.Fields).To(Equal([]domain.FieldError{
+				{Field: "start_date", Code: "invalid_date", Message: "Invalid date time 2023-13-01 00:00:00+00"},
+				{Field: "kind_period", Code: "invalid_kind_period", Message: "Error: kind period not allowed hourly"},
+				{Field: "meter_ids", Code: "invalid_meter_id", Message: "Error: the meter id is not a number a"},
+				{Field: "meter_ids", Code: "invalid_meter_id", Message: "Error: the meter id in the position 3 is empty"},
+				{Field: "meter_ids", Code: "duplicate_meter_id", Message: "Error: the meter id is duplicated 1"},
+			}))
+		})
+
+		It("should not allow a start date after the end date", func() {
+			_, err := mockPowerConsumptionService.CheckingQueryParamConstrains("1", "monthly", "2023-03-01", "2023-02-01")
+
+			domainError, ok := domain.AsDomainError(err)
+			Expect(ok).To(BeTrue())
+			Expect(domainError.Code).To(Equal("invalid_date_range"))
+			Expect(domainError.Fields[0].Field).To(Equal("start_date"))
+		})
+
+		It("should not allow a window too large for the kind period", func() {
+			_, err := mockPowerConsumptionService.CheckingQueryParamConstrains("1", "daily", "2020-01-01", "2023-01-01")
+
+			domainError, ok := domain.AsDomainError(err)
+			Expect(ok).To(BeTrue())
+			Expect(domainError.Code).To(Equal("window_too_large"))
+
+			_, err = mockPowerConsumptionService.CheckingQueryParamConstrains("1", "monthly", "2020-01-01", "2023-01-01")
+			Expect(err).To(BeNil())
+		})
 	})
 
 })
@@ -81,13 +122,17 @@ var _ = Describe("PowerConsumptionService", func() {
 	var (
 		mockMySQLRepo               *domainfakes.FakeMySQLPowerConsumptionRepository
 		mockCSVRepo                 *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo          *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo        *domainfakes.FakeMeterSettingRepository
 		mockPowerConsumptionService PowerConsumptionService
 	)
 
 	BeforeEach(func() {
 		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
 		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
-		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo)
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
 	})
 
 	Context("chekingKindPeriod", func() {
@@ -112,7 +157,11 @@ var _ = Describe("PowerConsumptionService", func() {
 		It("should return error for invalid kind period", func() {
 			result, err := mockPowerConsumptionService.ChekingKindPeriod("invalid")
 			Expect(err).ToNot(BeNil())
-			Expect(err).To(MatchError(fmt.Errorf("Error: kind period not allowed %s", "invalid")))
+			Expect(err).To(MatchError("Error: kind period not allowed invalid"))
+			domainError, ok := domain.AsDomainError(err)
+			Expect(ok).To(BeTrue())
+			Expect(domainError.Kind).To(Equal(domain.ErrorKindValidation))
+			Expect(domainError.Fields[0].Field).To(Equal("kind_period"))
 			Expect(result).To(Equal(""))
 		})
 	})
@@ -123,13 +172,17 @@ var _ = Describe("PowerConsumptionService", func() {
 	var (
 		mockMySQLRepo               *domainfakes.FakeMySQLPowerConsumptionRepository
 		mockCSVRepo                 *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo          *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo        *domainfakes.FakeMeterSettingRepository
 		mockPowerConsumptionService PowerConsumptionService
 	)
 
 	BeforeEach(func() {
 		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
 		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
-		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo)
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		mockPowerConsumptionService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
 	})
 
 	Context("ImportCsvToDatabase", func() {
@@ -157,23 +210,23 @@ var _ = Describe("PowerConsumptionService", func() {
 
 			mockCSVRepo.ConvertCSVToStructReturns(csvData, nil)
 
-			mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil)
+			mockMySQLRepo.CreateImportRecordsReturns(nil, nil)
 
-			err := mockPowerConsumptionService.ImportCsvToDatabase(nil)
+			_, err := mockPowerConsumptionService.ImportCsvToDatabase(nil, domain.ImportOptions{})
 
 			Expect(err).To(BeNil())
 			Expect(mockCSVRepo.ConvertCSVToStructCallCount()).To(Equal(1))
-			Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(1))
+			Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(1))
 		})
 
 		It("should return error when CSV conversion fails", func() {
 			mockCSVRepo.ConvertCSVToStructReturns(nil, errors.New("Error reading CSV"))
-			err := mockPowerConsumptionService.ImportCsvToDatabase(nil)
+			_, err := mockPowerConsumptionService.ImportCsvToDatabase(nil, domain.ImportOptions{})
 
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(Equal("Error reading CSV"))
 
-			Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(0))
+			Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(0))
 		})
 
 		It("should return error when CSV record conversion fails", func() {
@@ -198,12 +251,12 @@ var _ = Describe("PowerConsumptionService", func() {
 				},
 			}
 			mockCSVRepo.ConvertCSVToStructReturns(csvData, nil)
-			mockMySQLRepo.CreatePowerConsumptionRecordsReturns(errors.New("Error creating records"))
-			err := mockPowerConsumptionService.ImportCsvToDatabase(nil)
+			mockMySQLRepo.CreateImportRecordsReturns(nil, errors.New("Error creating records"))
+			_, err := mockPowerConsumptionService.ImportCsvToDatabase(nil, domain.ImportOptions{})
 
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(Equal("Error creating records"))
-			Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(1))
+			Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(1))
 		})
 	})
 
@@ -211,13 +264,17 @@ var _ = Describe("PowerConsumptionService", func() {
 
 var _ = Describe("PowerConsumptionServiceImpl", func() {
 	var (
-		mockMySQLRepo *domainfakes.FakeMySQLPowerConsumptionRepository
-		mockCSVRepo   *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterGroupRepo   *domainfakes.FakeMeterGroupRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
 	)
 
 	BeforeEach(func() {
 		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
 		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterGroupRepo = &domainfakes.FakeMeterGroupRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
 	})
 
 	Describe("GetConsumptionByMeterIDAndWindowTime", func() {
@@ -237,10 +294,30 @@ var _ = Describe("PowerConsumptionServiceImpl", func() {
 				}
 				mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns(nil, expectedError)
 
-				result, err := mockService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod)
+				result, err := mockService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod, domain.ConsumptionQueryOptions{})
 				Expect(result).To(BeNil())
 				Expect(err).To(Equal(expectedError))
 			})
+
+			It("should collect the options that are not valid with the query parameters", func() {
+				mockService := PowerConsumptionServiceImpl{
+					mysqlRepository: mockMySQLRepo,
+					csvRepository:   mockCSVRepo,
+				}
+				result, err := mockService.GetConsumptionByMeterIDAndWindowTime("1,x", startDate, endDate, kindPeriod, domain.ConsumptionQueryOptions{
+					Unit:      "joules",
+					WeekStart: "someday",
+				})
+				Expect(result).To(BeNil())
+				domainError, ok := domain.AsDomainError(err)
+				Expect(ok).To(BeTrue())
+				var fields []string
+				for _, field := range domainError.Fields {
+					fields = append(fields, field.Field)
+				}
+				Expect(fields).To(Equal([]string{"unit", "week_start", "meter_ids"}))
+				Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("when getting consumption data from MySQL repository", func() {
@@ -258,7 +335,7 @@ var _ = Describe("PowerConsumptionServiceImpl", func() {
 					return nil, expectedError
 				}
 
-				result, err := mockService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod)
+				result, err := mockService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod, domain.ConsumptionQueryOptions{})
 				Expect(result).To(BeNil())
 				Expect(err).To(Equal(expectedError))
 			})
@@ -266,4 +343,82 @@ var _ = Describe("PowerConsumptionServiceImpl", func() {
 
 	})
 
+	Describe("GetConsumptionByGroupAndWindowTime", func() {
+		var mockService PowerConsumptionService
+
+		BeforeEach(func() {
+			mockService = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, mockMeterGroupRepo, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+			parentID := uint(1)
+			mockMeterGroupRepo.GetMeterGroupByIDReturns(&domain.MeterGroup{
+				Model:  gorm.Model{ID: 1},
+				Name:   "Building A",
+				Meters: []domain.MeterGroupMeter{{MeterID: 1}, {MeterID: 2}},
+			}, nil)
+			mockMeterGroupRepo.GetMeterGroupsByParentIDStub = func(groupID uint) ([]domain.MeterGroup, error) {
+				if groupID == 1 {
+					return []domain.MeterGroup{{
+						Model:    gorm.Model{ID: 2},
+						ParentID: &parentID,
+						Meters:   []domain.MeterGroupMeter{{MeterID: 2}, {MeterID: 3}},
+					}}, nil
+				}
+				return nil, nil
+			}
+		})
+
+		It("should return the information by meter and the total of the group", func() {
+			mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeStub = func(startDate, endDate time.Time, meterID int) ([]domain.UserConsumption, error) {
+				return []domain.UserConsumption{
+					{MeterID: meterID, ActiveEnergy: float64(meterID * 10), Solar: 1, Date: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+					{MeterID: meterID, ActiveEnergy: float64(meterID), Date: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)},
+				}, nil
+			}
+
+			result, err := mockService.GetConsumptionByGroupAndWindowTime("1", startDate, endDate, "monthly", domain.ConsumptionQueryOptions{})
+
+			Expect(err).To(BeNil())
+			Expect(result.Name).To(Equal("Building A"))
+			Expect(result.Meters).To(HaveLen(3))
+			Expect(result.Meters[0].MeterID).To(Equal(1))
+			Expect(result.Meters[2].MeterID).To(Equal(3))
+			Expect(result.Total.Period).To(Equal([]string{"Jan 2023"}))
+			Expect(result.Total.Active).To(Equal([]float64{66}))
+			Expect(result.Total.Exported).To(Equal([]float64{3}))
+		})
+
+		It("should return an error when the group does not have meters", func() {
+			mockMeterGroupRepo.GetMeterGroupByIDReturns(&domain.MeterGroup{Model: gorm.Model{ID: 1}}, nil)
+			mockMeterGroupRepo.GetMeterGroupsByParentIDStub = nil
+
+			result, err := mockService.GetConsumptionByGroupAndWindowTime("1", startDate, endDate, "monthly", domain.ConsumptionQueryOptions{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+			Expect(mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+		})
+
+		It("should collect the group id with the query parameters that are not valid", func() {
+			result, err := mockService.GetConsumptionByGroupAndWindowTime("abc", "2023-13-01", endDate, "hourly", domain.ConsumptionQueryOptions{})
+
+			Expect(result).To(BeNil())
+			domainError, ok := domain.AsDomainError(err)
+			Expect(ok).To(BeTrue())
+			var fields []string
+			for _, field := range domainError.Fields {
+				fields = append(fields, field.Field)
+			}
+			Expect(fields).To(Equal([]string{"group_id", "start_date", "kind_period"}))
+			Expect(mockMeterGroupRepo.GetMeterGroupByIDCallCount()).To(Equal(0))
+		})
+
+		It("should return an error when the group does not exist", func() {
+			mockMeterGroupRepo.GetMeterGroupByIDReturns(nil, gorm.ErrRecordNotFound)
+
+			result, err := mockService.GetConsumptionByGroupAndWindowTime("7", startDate, endDate, "monthly", domain.ConsumptionQueryOptions{})
+
+			Expect(err).To(Equal(gorm.ErrRecordNotFound))
+			Expect(result).To(BeNil())
+		})
+	})
+
 })
@@ -0,0 +1,269 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// ConsumptionLoader: collects the keys asked by the resolvers of a graphql query and resolves them in batches, the
+// loader is built by request so the results are only cached while the query is being resolved
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ConsumptionLoader
+type ConsumptionLoader interface {
+	LoadReadings(key ReadingsKey) func() ([]domain.UserConsumption, error)
+	LoadMeterSetting(meterID int) func() (*domain.MeterSetting, error)
+	LoadConsumption(key ConsumptionKey) func() (*Serializer, error)
+}
+
+// ConsumptionLoaderFactory: builds a new loader for every graphql request
+type ConsumptionLoaderFactory func() ConsumptionLoader
+
+type ReadingsKey struct {
+	MeterID   int
+	StartDate string
+	EndDate   string
+}
+
+type ConsumptionKey struct {
+	MeterID      int
+	StartDate    string
+	EndDate      string
+	KindPeriod   string
+	Aggregations string
+	Unit         string
+}
+
+type readingsWindow struct {
+	startDate string
+	endDate   string
+}
+
+type consumptionQuery struct {
+	startDate    string
+	endDate      string
+	kindPeriod   string
+	aggregations string
+	unit         string
+}
+
+type ConsumptionLoaderImpl struct {
+	mysqlRepository         domain.MySQLPowerConsumptionRepository
+	meterSettingRepository  domain.MeterSettingRepository
+	powerConsumptionService PowerConsumptionService
+	mutex                   sync.Mutex
+	pendingReadings         []ReadingsKey
+	readings                map[ReadingsKey][]domain.UserConsumption
+	readingsErrors          map[ReadingsKey]error
+	pendingSettings         []int
+	settings                map[int]*domain.MeterSetting
+	settingsErrors          map[int]error
+	pendingConsumptions     []ConsumptionKey
+	consumptions            map[ConsumptionKey]*Serializer
+	consumptionsErrors      map[ConsumptionKey]error
+}
+
+func NewConsumptionLoader(mysqlRepository domain.MySQLPowerConsumptionRepository, meterSettingRepository domain.MeterSettingRepository, powerConsumptionService PowerConsumptionService) ConsumptionLoader {
+	return &ConsumptionLoaderImpl{
+		mysqlRepository:         mysqlRepository,
+		meterSettingRepository:  meterSettingRepository,
+		powerConsumptionService: powerConsumptionService,
+		readings:                map[ReadingsKey][]domain.UserConsumption{},
+		readingsErrors:          map[ReadingsKey]error{},
+		settings:                map[int]*domain.MeterSetting{},
+		settingsErrors:          map[int]error{},
+		consumptions:            map[ConsumptionKey]*Serializer{},
+		consumptionsErrors:      map[ConsumptionKey]error{},
+	}
+}
+
+// LoadReadings: queue the readings of a meter in a window time, the returned func resolves every queued key with
+// one query by window time the first time it's called
+//
+// Parameters:
+// key: the meter and the window time of the readings
+//
+// Returns:
+// return a func that gives the readings of the meter
+func (l *ConsumptionLoaderImpl) LoadReadings(key ReadingsKey) func() ([]domain.UserConsumption, error) {
+	l.mutex.Lock()
+	if _, loaded := l.readings[key]; !loaded {
+		l.pendingReadings = append(l.pendingReadings, key)
+	}
+	l.mutex.Unlock()
+
+	return func() ([]domain.UserConsumption, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if len(l.pendingReadings) > 0 {
+			l.loadPendingReadings()
+		}
+		return l.readings[key], l.readingsErrors[key]
+	}
+}
+
+// LoadMeterSetting: queue the settings of a meter, the returned func resolves every queued meter with one query the
+// first time it's called
+//
+// Parameters:
+// meterID: the id of the meter
+//
+// Returns:
+// return a func that gives the settings of the meter or nil if the meter does not have settings
+func (l *ConsumptionLoaderImpl) LoadMeterSetting(meterID int) func() (*domain.MeterSetting, error) {
+	l.mutex.Lock()
+	if _, loaded := l.settings[meterID]; !loaded {
+		l.pendingSettings = append(l.pendingSettings, meterID)
+	}
+	l.mutex.Unlock()
+
+	return func() (*domain.MeterSetting, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if len(l.pendingSettings) > 0 {
+			l.loadPendingSettings()
+		}
+		return l.settings[meterID], l.settingsErrors[meterID]
+	}
+}
+
+// LoadConsumption: queue the series of a meter, the returned func resolves every queued key with one query of readings
+// and one query of settings by distinct query the first time it's called
+//
+// Parameters:
+// key: the meter and the query of the series
+//
+// Returns:
+// return a func that gives the series of the meter
+func (l *ConsumptionLoaderImpl) LoadConsumption(key ConsumptionKey) func() (*Serializer, error) {
+	l.mutex.Lock()
+	if _, loaded := l.consumptions[key]; !loaded {
+		l.pendingConsumptions = append(l.pendingConsumptions, key)
+	}
+	l.mutex.Unlock()
+
+	return func() (*Serializer, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if len(l.pendingConsumptions) > 0 {
+			l.loadPendingConsumptions()
+		}
+		return l.consumptions[key], l.consumptionsErrors[key]
+	}
+}
+
+func (l *ConsumptionLoaderImpl) loadPendingReadings() {
+	meterIDsByWindow := map[readingsWindow][]int{}
+	var windows []readingsWindow
+	for _, key := range l.pendingReadings {
+		window := readingsWindow{key.StartDate, key.EndDate}
+		if _, ok := meterIDsByWindow[window]; !ok {
+			windows = append(windows, window)
+		}
+		meterIDsByWindow[window] = append(meterIDsByWindow[window], key.MeterID)
+	}
+	pendingReadings := l.pendingReadings
+	l.pendingReadings = nil
+
+	for _, window := range windows {
+		readingsByMeter, err := l.getReadingsByWindow(window, meterIDsByWindow[window])
+		for _, meterID := range meterIDsByWindow[window] {
+			key := ReadingsKey{meterID, window.startDate, window.endDate}
+			l.readings[key] = readingsByMeter[meterID]
+			if err != nil {
+				l.readingsErrors[key] = err
+			}
+		}
+	}
+	logrus.Infof("the readings of %d keys were loaded in %d queries", len(pendingReadings), len(windows))
+}
+
+// getReadingsByWindow: get the readings of the meters in a window time, the window has the limit of the daily series
+// of the consumption queries because the readings are not reduced
+func (l *ConsumptionLoaderImpl) getReadingsByWindow(window readingsWindow, meterIDs []int) (map[int][]domain.UserConsumption, error) {
+	validator := NewQueryParamsValidator()
+	timeStartDate, validStartDate := validator.CheckDate("start_date", window.startDate)
+	timeEndDate, validEndDate := validator.CheckDate("end_date", window.endDate)
+	timeEndDateMidnight := timeEndDate.AddDate(0, 0, 1).Add(-time.Second)
+	if validStartDate && validEndDate && validator.CheckDateRange(timeStartDate, timeEndDate) {
+		validator.CheckWindowSize(constants.PeriodKindDaily, timeStartDate, timeEndDateMidnight)
+	}
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+
+	userConsumptions, err := l.mysqlRepository.GetConsumptionByMeterIDsAndWindowTime(timeStartDate, timeEndDateMidnight, meterIDs)
+	if err != nil {
+		return nil, err
+	}
+	readingsByMeter := map[int][]domain.UserConsumption{}
+	for _, userConsumption := range userConsumptions {
+		readingsByMeter[userConsumption.MeterID] = append(readingsByMeter[userConsumption.MeterID], userConsumption)
+	}
+	return readingsByMeter, nil
+}
+
+func (l *ConsumptionLoaderImpl) loadPendingSettings() {
+	meterIDs := l.pendingSettings
+	l.pendingSettings = nil
+
+	settings, err := l.meterSettingRepository.GetMeterSettingsByMeterIDs(meterIDs)
+	for _, meterID := range meterIDs {
+		l.settings[meterID] = nil
+		if err != nil {
+			l.settingsErrors[meterID] = err
+		}
+	}
+	for i := range settings {
+		l.settings[settings[i].MeterID] = &settings[i]
+	}
+	logrus.Infof("the settings of %d meters were loaded in one query", len(meterIDs))
+}
+
+func (l *ConsumptionLoaderImpl) loadPendingConsumptions() {
+	meterIDsByQuery := map[consumptionQuery][]int{}
+	var queries []consumptionQuery
+	seenKeys := map[ConsumptionKey]bool{}
+	for _, key := range l.pendingConsumptions {
+		if seenKeys[key] {
+			continue
+		}
+		seenKeys[key] = true
+		query := consumptionQuery{key.StartDate, key.EndDate, key.KindPeriod, key.Aggregations, key.Unit}
+		if _, ok := meterIDsByQuery[query]; !ok {
+			queries = append(queries, query)
+		}
+		meterIDsByQuery[query] = append(meterIDsByQuery[query], key.MeterID)
+	}
+	l.pendingConsumptions = nil
+
+	for _, query := range queries {
+		var stringMeterIDs []string
+		for _, meterID := range meterIDsByQuery[query] {
+			stringMeterIDs = append(stringMeterIDs, strconv.Itoa(meterID))
+		}
+		serializers, meterErrors, err := l.powerConsumptionService.GetConsumptionByMeterIDsInBatch(strings.Join(stringMeterIDs, ","), query.startDate, query.endDate, query.kindPeriod, domain.ConsumptionQueryOptions{
+			Aggregations: query.aggregations,
+			Unit:         query.unit,
+		})
+		serializersByMeter := map[int]*Serializer{}
+		for i := range serializers {
+			serializersByMeter[serializers[i].MeterID] = &serializers[i]
+		}
+		for _, meterID := range meterIDsByQuery[query] {
+			key := ConsumptionKey{meterID, query.startDate, query.endDate, query.kindPeriod, query.aggregations, query.unit}
+			l.consumptions[key] = serializersByMeter[meterID]
+			if err != nil {
+				l.consumptionsErrors[key] = err
+			} else if meterErrors[meterID] != nil {
+				l.consumptionsErrors[key] = meterErrors[meterID]
+			}
+		}
+	}
+	logrus.Infof("the series of %d meters were loaded in %d batches", len(seenKeys), len(queries))
+}
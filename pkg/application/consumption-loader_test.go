@@ -0,0 +1,127 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConsumptionLoader", func() {
+	var (
+		mockMySQLRepository        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterSettingRepository *domainfakes.FakeMeterSettingRepository
+		loader                     ConsumptionLoader
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepository = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterSettingRepository = &domainfakes.FakeMeterSettingRepository{}
+		loader = NewConsumptionLoader(mockMySQLRepository, mockMeterSettingRepository, nil)
+	})
+
+	It("should query once by distinct window time", func() {
+		mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeReturns([]domain.UserConsumption{{MeterID: 1}, {MeterID: 2}}, nil)
+		first := loader.LoadReadings(ReadingsKey{MeterID: 1, StartDate: "2023-06-01", EndDate: "2023-06-30"})
+		second := loader.LoadReadings(ReadingsKey{MeterID: 2, StartDate: "2023-06-01", EndDate: "2023-06-30"})
+		other := loader.LoadReadings(ReadingsKey{MeterID: 1, StartDate: "2023-07-01", EndDate: "2023-07-31"})
+
+		readings, err := second()
+		Expect(err).To(BeNil())
+		Expect(readings).To(HaveLen(1))
+		_, err = first()
+		Expect(err).To(BeNil())
+		_, err = other()
+		Expect(err).To(BeNil())
+		Expect(mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeCallCount()).To(Equal(2))
+		_, _, meterIDs := mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeArgsForCall(0)
+		Expect(meterIDs).To(Equal([]int{1, 2}))
+	})
+
+	It("should not query again the keys already loaded", func() {
+		loader.LoadMeterSetting(1)()
+		_, err := loader.LoadMeterSetting(1)()
+		Expect(err).To(BeNil())
+		Expect(mockMeterSettingRepository.GetMeterSettingsByMeterIDsCallCount()).To(Equal(1))
+	})
+
+	It("should return the error to every key of the window", func() {
+		first := loader.LoadReadings(ReadingsKey{MeterID: 1, StartDate: "2023-06-30", EndDate: "2023-06-01"})
+		second := loader.LoadReadings(ReadingsKey{MeterID: 2, StartDate: "2023-06-30", EndDate: "2023-06-01"})
+		_, err := first()
+		Expect(err).ToNot(BeNil())
+		_, err = second()
+		Expect(err).ToNot(BeNil())
+		Expect(mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeCallCount()).To(Equal(0))
+	})
+
+	It("should load the series of all the meters with one query of readings and one of settings", func() {
+		service := NewPowerConsumptionService(mockMySQLRepository, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepository, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		loader = NewConsumptionLoader(mockMySQLRepository, mockMeterSettingRepository, service)
+		mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 2, ActiveEnergy: 120, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 30, Date: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 2, ActiveEnergy: 150, Date: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		mockMeterSettingRepository.GetMeterSettingsByMeterIDsReturns([]domain.MeterSetting{{MeterID: 2, Cumulative: true}}, nil)
+		mockMySQLRepository.GetLastConsumptionBeforeDateReturns(&domain.UserConsumption{MeterID: 2, ActiveEnergy: 100}, nil)
+		first := loader.LoadConsumption(ConsumptionKey{MeterID: 1, StartDate: "2023-06-01", EndDate: "2023-06-30", KindPeriod: "monthly"})
+		second := loader.LoadConsumption(ConsumptionKey{MeterID: 2, StartDate: "2023-06-01", EndDate: "2023-06-30", KindPeriod: "monthly"})
+
+		firstSeries, err := first()
+		Expect(err).To(BeNil())
+		secondSeries, err := second()
+		Expect(err).To(BeNil())
+		Expect(firstSeries.Active).To(Equal([]float64{40}))
+		Expect(secondSeries.Active).To(Equal([]float64{50}))
+		Expect(mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeCallCount()).To(Equal(1))
+		Expect(mockMeterSettingRepository.GetMeterSettingsByMeterIDsCallCount()).To(Equal(1))
+		Expect(mockMySQLRepository.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(0))
+		Expect(mockMeterSettingRepository.GetMeterSettingByMeterIDCallCount()).To(Equal(0))
+		_, lastReadingMeterID := mockMySQLRepository.GetLastConsumptionBeforeDateArgsForCall(0)
+		Expect(lastReadingMeterID).To(Equal(2))
+	})
+
+	It("should return the error only to the keys of the meters that could not be organized", func() {
+		service := NewPowerConsumptionService(mockMySQLRepository, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepository, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		loader = NewConsumptionLoader(mockMySQLRepository, mockMeterSettingRepository, service)
+		mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 2, ActiveEnergy: 20, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		mockMeterSettingRepository.GetMeterSettingsByMeterIDsReturns([]domain.MeterSetting{{MeterID: 1, BillingCycleDay: 15}}, nil)
+		first := loader.LoadConsumption(ConsumptionKey{MeterID: 1, StartDate: "2023-06-01", EndDate: "2023-06-30", KindPeriod: "billing_cycle"})
+		second := loader.LoadConsumption(ConsumptionKey{MeterID: 2, StartDate: "2023-06-01", EndDate: "2023-06-30", KindPeriod: "billing_cycle"})
+
+		firstSeries, err := first()
+		Expect(err).To(BeNil())
+		Expect(firstSeries.MeterID).To(Equal(1))
+		secondSeries, err := second()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("the meter 2 does not have a billing cycle day"))
+		Expect(secondSeries).To(BeNil())
+	})
+
+	It("should reject the readings of a window larger than the limit of the daily series", func() {
+		readings := loader.LoadReadings(ReadingsKey{MeterID: 1, StartDate: "2020-01-01", EndDate: "2023-06-30"})
+
+		_, err := readings()
+
+		Expect(err).ToNot(BeNil())
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Fields[0].Code).To(Equal(constants.ErrorCodeWindowTooLarge))
+		Expect(mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeCallCount()).To(Equal(0))
+	})
+
+	It("should return the error of the repository", func() {
+		mockMeterSettingRepository.GetMeterSettingsByMeterIDsReturns(nil, fmt.Errorf("Error: connection refused"))
+		_, err := loader.LoadMeterSetting(1)()
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+// EnergyUnit: a unit of the active energy and its reactive energy unit, the values are stored in kWh and kvarh and
+// the scale is the number of units in a kWh
+type EnergyUnit struct {
+	Active   string
+	Reactive string
+	Scale    float64
+}
+
+var energyUnits = map[string]EnergyUnit{
+	"wh":    {Active: "Wh", Reactive: "varh", Scale: 1000},
+	"varh":  {Active: "Wh", Reactive: "varh", Scale: 1000},
+	"kwh":   {Active: "kWh", Reactive: "kvarh", Scale: 1},
+	"kvarh": {Active: "kWh", Reactive: "kvarh", Scale: 1},
+	"mwh":   {Active: "MWh", Reactive: "Mvarh", Scale: 0.001},
+	"mvarh": {Active: "MWh", Reactive: "Mvarh", Scale: 0.001},
+}
+
+// ChekingUnit: this function check if the unit is allowed, the units of the active and the reactive energy with the
+// same prefix are the same unit example "Wh" and "varh"
+//
+// Parameters:
+// unit: the unit, kWh by default
+//
+// Returns:
+// return the unit or an error if the unit is not allowed
+func ChekingUnit(unit string) (EnergyUnit, error) {
+	trimAndLowerCaseUnit := strings.ToLower(strings.Trim(unit, " "))
+	if trimAndLowerCaseUnit == "" {
+		return energyUnits["kwh"], nil
+	}
+	energyUnit, ok := energyUnits[trimAndLowerCaseUnit]
+	if !ok {
+		return EnergyUnit{}, domain.NewValidationError(constants.ErrorCodeInvalidParam, "unit", fmt.Sprintf("Error: unit not allowed %s", unit))
+	}
+	return energyUnit, nil
+}
+
+// ToUnit: convert the records stored in kWh and kvarh to the unit
+//
+// Parameters:
+// data: the records in kWh and kvarh
+//
+// Returns:
+// return a copy of the records in the unit
+func (e EnergyUnit) ToUnit(data []domain.UserConsumption) []domain.UserConsumption {
+	return scaleReadings(data, e.Scale)
+}
+
+// FromUnit: convert a record in the unit to kWh and kvarh to store it
+//
+// Parameters:
+// reading: the record in the unit
+//
+// Returns:
+// return a copy of the record in kWh and kvarh
+func (e EnergyUnit) FromUnit(reading domain.UserConsumption) domain.UserConsumption {
+	return scaleReading(reading, 1/e.Scale)
+}
+
+func scaleReadings(data []domain.UserConsumption, scale float64) []domain.UserConsumption {
+	if scale == 1 {
+		return data
+	}
+	scaledReadings := make([]domain.UserConsumption, len(data))
+	for i, reading := range data {
+		scaledReadings[i] = scaleReading(reading, scale)
+	}
+	return scaledReadings
+}
+
+func scaleReading(reading domain.UserConsumption, scale float64) domain.UserConsumption {
+	reading.ActiveEnergy *= scale
+	reading.ReactiveEnergy *= scale
+	reading.CapacitiveReactive *= scale
+	reading.Solar *= scale
+	return reading
+}
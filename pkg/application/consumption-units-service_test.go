@@ -0,0 +1,112 @@
+package application
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChekingUnit", func() {
+	It("should return kWh by default", func() {
+		unit, err := ChekingUnit("")
+		Expect(err).To(BeNil())
+		Expect(unit.Active).To(Equal("kWh"))
+		Expect(unit.Reactive).To(Equal("kvarh"))
+	})
+
+	It("should accept the reactive units", func() {
+		unit, err := ChekingUnit(" varh")
+		Expect(err).To(BeNil())
+		Expect(unit.Active).To(Equal("Wh"))
+		Expect(unit.Scale).To(Equal(1000.0))
+	})
+
+	It("should return an error for an unknown unit", func() {
+		_, err := ChekingUnit("GJ")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("EnergyUnit", func() {
+	It("should convert the records from and to the unit", func() {
+		unit, _ := ChekingUnit("Wh")
+		reading := unit.FromUnit(domain.UserConsumption{ActiveEnergy: 1500, ReactiveEnergy: 500, CapacitiveReactive: 250, Solar: 100})
+		Expect(reading.ActiveEnergy).To(Equal(1.5))
+		Expect(reading.Solar).To(Equal(0.1))
+
+		readings := unit.ToUnit([]domain.UserConsumption{reading})
+		Expect(readings[0].ActiveEnergy).To(Equal(1500.0))
+		Expect(readings[0].CapacitiveReactive).To(Equal(250.0))
+		Expect(reading.ActiveEnergy).To(Equal(1.5))
+	})
+})
+
+var _ = Describe("Units in the consumption service", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		mockCSVRepo.ConvertCSVToStructReturns([]*domain.CSVUserConsumption{
+			{ID: "1", MeterID: "1", ActiveEnergy: 2000, Date: "2023-08-01"},
+			{ID: "2", MeterID: "2", ActiveEnergy: 3, Date: "2023-08-01"},
+		}, nil)
+	})
+
+	It("should store the imported values in kWh", func() {
+		_, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{Unit: "Wh"})
+
+		Expect(err).To(BeNil())
+		records, _, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(records[0].ActiveEnergy).To(Equal(2.0))
+		Expect(records[1].ActiveEnergy).To(Equal(0.003))
+		Expect(mockMeterSettingRepo.GetMeterSettingByMeterIDCallCount()).To(Equal(0))
+	})
+
+	It("should use the unit of every meter when the import does not have a unit", func() {
+		mockMeterSettingRepo.GetMeterSettingByMeterIDStub = func(meterID int) (*domain.MeterSetting, error) {
+			if meterID == 1 {
+				return &domain.MeterSetting{MeterID: 1, Unit: "Wh"}, nil
+			}
+			return nil, nil
+		}
+
+		_, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{})
+
+		Expect(err).To(BeNil())
+		records, _, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(records[0].ActiveEnergy).To(Equal(2.0))
+		Expect(records[1].ActiveEnergy).To(Equal(3.0))
+	})
+
+	It("should return an error when the import unit is not allowed", func() {
+		_, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{Unit: "GJ"})
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(0))
+	})
+
+	It("should convert the series to the unit of the request", func() {
+		mockMySQLRepo.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 1.5, ReactiveEnergy: 0.5, Date: time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		result, err := service.GetConsumptionByMeterIDAndWindowTime("1", "2023-06-01", "2023-06-30", "monthly", domain.ConsumptionQueryOptions{Unit: "wh"})
+
+		Expect(err).To(BeNil())
+		Expect(result[0].Active).To(Equal([]float64{1500}))
+		Expect(result[0].ReactiveInductive).To(Equal([]float64{500}))
+		Expect(result[0].Unit).To(Equal("Wh"))
+		Expect(result[0].ReactiveUnit).To(Equal("varh"))
+	})
+})
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"fmt"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ImportService
+type ImportService interface {
+	GetImports() ([]domain.Import, error)
+	GetImportReadings(importID string) (*domain.Import, []domain.UserConsumption, error)
+	RollbackImport(importID string) (*domain.Import, error)
+}
+
+type ImportServiceImpl struct {
+	importRepository domain.ImportRepository
+	mysqlRepository  domain.MySQLPowerConsumptionRepository
+}
+
+func NewImportService(importRepository domain.ImportRepository, mysqlRepository domain.MySQLPowerConsumptionRepository) ImportService {
+	return &ImportServiceImpl{
+		importRepository,
+		mysqlRepository,
+	}
+}
+
+// GetImports: get the history of the imported files
+//
+// Returns:
+// return all the imports, the newest first
+func (i *ImportServiceImpl) GetImports() ([]domain.Import, error) {
+	return i.importRepository.GetImports()
+}
+
+// GetImportReadings: get an import and the readings that came from its file
+//
+// Parameters:
+// importID: the id of the import
+//
+// Returns:
+// return the import and its readings or an error if the import does not exist
+func (i *ImportServiceImpl) GetImportReadings(importID string) (*domain.Import, []domain.UserConsumption, error) {
+	importRecord, err := i.getImport(importID)
+	if err != nil {
+		return nil, nil, err
+	}
+	readings, err := i.mysqlRepository.GetConsumptionByImportID(importRecord.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return importRecord, readings, nil
+}
+
+// RollbackImport: delete all the readings and the quarantined readings of an import, the import is kept in the
+// history as rolled back
+//
+// Parameters:
+// importID: the id of the import
+//
+// Returns:
+// return the rolled back import or an error if the import could not be rolled back
+func (i *ImportServiceImpl) RollbackImport(importID string) (*domain.Import, error) {
+	importRecord, err := i.getImport(importID)
+	if err != nil {
+		return nil, err
+	}
+	if importRecord.Status == constants.ImportStatusRolledBack {
+		logrus.Errorf("Error: the import %d was already rolled back", importRecord.ID)
+		return nil, domain.NewConflictError(constants.ErrorCodeImportRolledBack, fmt.Sprintf("Error: the import %d was already rolled back", importRecord.ID), nil)
+	}
+	deleted, err := i.importRepository.RollbackImport(importRecord)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("the import %d was rolled back, %d readings were deleted", importRecord.ID, deleted)
+	return importRecord, nil
+}
+
+func (i *ImportServiceImpl) getImport(importID string) (*domain.Import, error) {
+	numberImportID, err := domain.StrToInt(importID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int importID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the import id is not a number %s", importID))
+	}
+	return i.importRepository.GetImportByID(uint(numberImportID))
+}
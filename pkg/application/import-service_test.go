@@ -0,0 +1,164 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("ImportCsvToDatabase history", func() {
+	var (
+		mockMySQLRepo  *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo    *domainfakes.FakeCSVPowerConsumptionRepository
+		mockImportRepo *domainfakes.FakeImportRepository
+		service        PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockImportRepo = &domainfakes.FakeImportRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, &domainfakes.FakeMeterGroupRepository{}, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, mockImportRepo)
+		mockCSVRepo.FileChecksumReturns("abc123", nil)
+		mockCSVRepo.ConvertCSVToStructReturns([]*domain.CSVUserConsumption{
+			{ID: "1", MeterID: "1", ActiveEnergy: 10, Date: "2023-08-01"},
+			{ID: "2", MeterID: "1", ActiveEnergy: 20, Date: "2023-08-02"},
+		}, nil)
+		mockImportRepo.CreateImportStub = func(importRecord *domain.Import) error {
+			importRecord.ID = 7
+			return nil
+		}
+	})
+
+	It("should record the import and attach its id to the readings", func() {
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{FileName: "august.csv", Uploader: "ana", Unit: "kWh"})
+
+		Expect(err).To(BeNil())
+		Expect(summary.ImportID).To(Equal(uint(7)))
+		created := mockImportRepo.CreateImportArgsForCall(0)
+		Expect(created.FileName).To(Equal("august.csv"))
+		Expect(created.Checksum).To(Equal("abc123"))
+		Expect(created.Uploader).To(Equal("ana"))
+		Expect(created.Total).To(Equal(2))
+		records, _, updated, meterIDs := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(updated.Status).To(Equal(constants.ImportStatusCompleted))
+		Expect(updated.Imported).To(Equal(2))
+		Expect(meterIDs).To(Equal([]int{1}))
+		Expect(*records[0].ImportID).To(Equal(uint(7)))
+		Expect(*records[1].ImportID).To(Equal(uint(7)))
+	})
+
+	It("should reject a file that was already imported", func() {
+		importedAt := time.Date(2023, 8, 2, 10, 0, 0, 0, time.UTC)
+		mockImportRepo.GetImportByChecksumReturns(&domain.Import{Model: gorm.Model{ID: 3, CreatedAt: importedAt}}, nil)
+
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{})
+
+		Expect(summary).To(BeNil())
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Kind).To(Equal(domain.ErrorKindConflict))
+		Expect(domainError.Code).To(Equal(constants.ErrorCodeDuplicateImport))
+		Expect(domainError.Fields).To(Equal([]domain.FieldError{
+			{Field: "import_id", Code: constants.ErrorCodeDuplicateImport, Message: "3"},
+			{Field: "imported_at", Code: constants.ErrorCodeDuplicateImport, Message: importedAt.Format(time.RFC3339)},
+		}))
+		Expect(mockImportRepo.GetImportByChecksumArgsForCall(0)).To(Equal("abc123"))
+		Expect(mockImportRepo.CreateImportCallCount()).To(Equal(0))
+		Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(0))
+	})
+
+	It("should import a file again when the import is forced", func() {
+		mockImportRepo.GetImportByChecksumReturns(&domain.Import{Model: gorm.Model{ID: 3}}, nil)
+
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{Force: true})
+
+		Expect(err).To(BeNil())
+		Expect(summary.ImportID).To(Equal(uint(7)))
+		Expect(*summary.DuplicateOf).To(Equal(uint(3)))
+		Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(1))
+	})
+
+	It("should mark the import as failed when the records could not be saved", func() {
+		mockMySQLRepo.CreateImportRecordsReturns(nil, errors.New("Error creating records"))
+
+		_, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockImportRepo.UpdateImportArgsForCall(0).Status).To(Equal(constants.ImportStatusFailed))
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(0))
+	})
+
+	It("should record the records that do not come from a file as an import", func() {
+		records := []*domain.CSVUserConsumption{{ID: "1", MeterID: "2", ActiveEnergy: 10, Date: "2023-08-01"}}
+
+		summary, err := service.ImportCSVRecords(records, domain.ImportOptions{FileName: "scada", Uploader: constants.GRPCUploader})
+
+		Expect(err).To(BeNil())
+		Expect(summary.ImportID).To(Equal(uint(7)))
+		created := mockImportRepo.CreateImportArgsForCall(0)
+		Expect(created.FileName).To(Equal("scada"))
+		Expect(created.Checksum).To(BeEmpty())
+		Expect(created.Uploader).To(Equal(constants.GRPCUploader))
+		readings, _, updated, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(updated.Status).To(Equal(constants.ImportStatusCompleted))
+		Expect(*readings[0].ImportID).To(Equal(uint(7)))
+		Expect(mockCSVRepo.FileChecksumCallCount()).To(Equal(0))
+		Expect(mockImportRepo.GetImportByChecksumCallCount()).To(Equal(0))
+	})
+
+	It("should reject the records of an unknown unit", func() {
+		_, err := service.ImportCSVRecords([]*domain.CSVUserConsumption{{ID: "1", MeterID: "2", Date: "2023-08-01"}}, domain.ImportOptions{Unit: "GJ"})
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockImportRepo.CreateImportCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("RollbackImport", func() {
+	var (
+		mockImportRepo *domainfakes.FakeImportRepository
+		service        ImportService
+	)
+
+	BeforeEach(func() {
+		mockImportRepo = &domainfakes.FakeImportRepository{}
+		service = NewImportService(mockImportRepo, &domainfakes.FakeMySQLPowerConsumptionRepository{})
+	})
+
+	It("should delete the readings of the import and keep it as rolled back", func() {
+		mockImportRepo.GetImportByIDReturns(&domain.Import{Model: gorm.Model{ID: 7}, Status: constants.ImportStatusCompleted}, nil)
+
+		importRecord, err := service.RollbackImport("7")
+
+		Expect(err).To(BeNil())
+		Expect(importRecord.ID).To(Equal(uint(7)))
+		Expect(mockImportRepo.RollbackImportArgsForCall(0).ID).To(Equal(uint(7)))
+		Expect(mockImportRepo.UpdateImportCallCount()).To(Equal(0))
+	})
+
+	It("should return an error when the import was already rolled back", func() {
+		mockImportRepo.GetImportByIDReturns(&domain.Import{Model: gorm.Model{ID: 7}, Status: constants.ImportStatusRolledBack}, nil)
+
+		_, err := service.RollbackImport("7")
+
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Kind).To(Equal(domain.ErrorKindConflict))
+		Expect(domainError.Code).To(Equal(constants.ErrorCodeImportRolledBack))
+		Expect(mockImportRepo.RollbackImportCallCount()).To(Equal(0))
+	})
+
+	It("should return an error when the id is not valid", func() {
+		_, err := service.RollbackImport("seven")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockImportRepo.GetImportByIDCallCount()).To(Equal(0))
+	})
+})
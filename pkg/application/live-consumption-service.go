@@ -0,0 +1,236 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// LiveConsumptionBroker: in process pub/sub of the readings written in the database, every subscriber receives
+// only the readings of its meters
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . LiveConsumptionBroker
+type LiveConsumptionBroker interface {
+	Subscribe(meterIDs []int) *LiveConsumptionSubscription
+	Unsubscribe(subscription *LiveConsumptionSubscription)
+	PublishReadings(usersPowerConsumption []*domain.UserConsumption)
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . LiveConsumptionService
+type LiveConsumptionService interface {
+	Subscribe(meterIDs string, kindPeriod string) (*LiveConsumptionSubscription, error)
+	Unsubscribe(subscription *LiveConsumptionSubscription)
+	GetCurrentPeriodConsumption(subscription *LiveConsumptionSubscription) ([]Serializer, error)
+}
+
+type LiveConsumptionSubscription struct {
+	MeterIDs   []int
+	KindPeriod string
+	Readings   chan []domain.UserConsumption
+	meterIDs   map[int]bool
+}
+
+type LiveConsumptionBrokerImpl struct {
+	mutex         sync.RWMutex
+	subscriptions map[*LiveConsumptionSubscription]bool
+}
+
+type LiveConsumptionRepositoryImpl struct {
+	domain.MySQLPowerConsumptionRepository
+	broker LiveConsumptionBroker
+}
+
+type LiveConsumptionServiceImpl struct {
+	broker                  LiveConsumptionBroker
+	powerConsumptionService PowerConsumptionService
+	now                     func() time.Time
+}
+
+func NewLiveConsumptionBroker() LiveConsumptionBroker {
+	return &LiveConsumptionBrokerImpl{
+		subscriptions: map[*LiveConsumptionSubscription]bool{},
+	}
+}
+
+// NewLiveConsumptionRepository: wrap the repository of the readings so every reading written is published in the
+// broker once the write was successful, no matter if it comes from an import, the live ingestion or the quarantine
+func NewLiveConsumptionRepository(mysqlRepository domain.MySQLPowerConsumptionRepository, broker LiveConsumptionBroker) domain.MySQLPowerConsumptionRepository {
+	return &LiveConsumptionRepositoryImpl{
+		mysqlRepository,
+		broker,
+	}
+}
+
+func NewLiveConsumptionService(broker LiveConsumptionBroker, powerConsumptionService PowerConsumptionService) LiveConsumptionService {
+	return &LiveConsumptionServiceImpl{
+		broker:                  broker,
+		powerConsumptionService: powerConsumptionService,
+		now:                     time.Now,
+	}
+}
+
+// Subscribe: register a subscriber of the readings of some meters
+//
+// Parameters:
+// meterIDs: the meters of the subscriber
+//
+// Returns:
+// return the subscription with the channel where the readings are sent
+func (b *LiveConsumptionBrokerImpl) Subscribe(meterIDs []int) *LiveConsumptionSubscription {
+	subscription := &LiveConsumptionSubscription{
+		MeterIDs: meterIDs,
+		Readings: make(chan []domain.UserConsumption, constants.LiveConsumptionBufferSize),
+		meterIDs: map[int]bool{},
+	}
+	for _, meterID := range meterIDs {
+		subscription.meterIDs[meterID] = true
+	}
+	b.mutex.Lock()
+	b.subscriptions[subscription] = true
+	b.mutex.Unlock()
+	logrus.Infof("new live consumption subscriber of the meters %v", meterIDs)
+	return subscription
+}
+
+// Unsubscribe: remove a subscriber and close its channel
+//
+// Parameters:
+// subscription: the subscription to remove
+func (b *LiveConsumptionBrokerImpl) Unsubscribe(subscription *LiveConsumptionSubscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.subscriptions[subscription] {
+		return
+	}
+	delete(b.subscriptions, subscription)
+	close(subscription.Readings)
+}
+
+// PublishReadings: send the readings to the subscribers of their meters, the write path never waits for a slow
+// subscriber so the readings are dropped when its buffer is full
+//
+// Parameters:
+// usersPowerConsumption: the readings written in the database
+func (b *LiveConsumptionBrokerImpl) PublishReadings(usersPowerConsumption []*domain.UserConsumption) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	for subscription := range b.subscriptions {
+		var readings []domain.UserConsumption
+		for _, userPowerConsumption := range usersPowerConsumption {
+			if subscription.meterIDs[userPowerConsumption.MeterID] {
+				readings = append(readings, *userPowerConsumption)
+			}
+		}
+		if len(readings) == 0 {
+			continue
+		}
+		select {
+		case subscription.Readings <- readings:
+		default:
+			logrus.Warnf("the live consumption subscriber of the meters %v is slow, %d readings were dropped", subscription.MeterIDs, len(readings))
+		}
+	}
+}
+
+// CreatePowerConsumptionRecords: create the records and publish in the broker only the records inserted
+//
+// Parameters:
+// usersPowerConsumption: the readings to create
+//
+// Returns:
+// return the records inserted or an error if the records were not created
+func (l *LiveConsumptionRepositoryImpl) CreatePowerConsumptionRecords(usersPowerConsumption []*domain.UserConsumption) ([]*domain.UserConsumption, error) {
+	insertedRecords, err := l.MySQLPowerConsumptionRepository.CreatePowerConsumptionRecords(usersPowerConsumption)
+	if err != nil {
+		return nil, err
+	}
+	l.broker.PublishReadings(insertedRecords)
+	return insertedRecords, nil
+}
+
+// CreateImportRecords: save the records of an import and publish in the broker only the records inserted
+//
+// Parameters:
+// usersPowerConsumption: the readings to create
+// quarantinedReadings: the readings to quarantine
+// importRecord: the import to complete
+// meterIDs: the meters of the import
+//
+// Returns:
+// return the records inserted or an error if the records were not created
+func (l *LiveConsumptionRepositoryImpl) CreateImportRecords(usersPowerConsumption []*domain.UserConsumption, quarantinedReadings []*domain.QuarantinedReading, importRecord *domain.Import, meterIDs []int) ([]*domain.UserConsumption, error) {
+	insertedRecords, err := l.MySQLPowerConsumptionRepository.CreateImportRecords(usersPowerConsumption, quarantinedReadings, importRecord, meterIDs)
+	if err != nil {
+		return nil, err
+	}
+	l.broker.PublishReadings(insertedRecords)
+	return insertedRecords, nil
+}
+
+// CreateIngestedRecords: save the records of an ingestion and publish in the broker only the records inserted
+//
+// Parameters:
+// usersPowerConsumption: the readings to create
+// quarantinedReadings: the readings to quarantine
+//
+// Returns:
+// return the records inserted or an error if the records were not created
+func (l *LiveConsumptionRepositoryImpl) CreateIngestedRecords(usersPowerConsumption []*domain.UserConsumption, quarantinedReadings []*domain.QuarantinedReading) ([]*domain.UserConsumption, error) {
+	insertedRecords, err := l.MySQLPowerConsumptionRepository.CreateIngestedRecords(usersPowerConsumption, quarantinedReadings)
+	if err != nil {
+		return nil, err
+	}
+	l.broker.PublishReadings(insertedRecords)
+	return insertedRecords, nil
+}
+
+// Subscribe: check the meters and the period kind and subscribe to the readings of the meters, the repeated meters
+// are subscribed only once
+//
+// Parameters:
+// meterIDs: the meter ids separated by comma
+// kindPeriod: the current period of the aggregates, daily, calendar_weekly or monthly
+//
+// Returns:
+// return the subscription or an error if the query is not valid
+func (l *LiveConsumptionServiceImpl) Subscribe(meterIDs string, kindPeriod string) (*LiveConsumptionSubscription, error) {
+	validator := NewQueryParamsValidator()
+	numberMeterIDs := validator.CheckDistinctMeterIDs(meterIDs)
+	checkedKindPeriod, _ := validator.CheckKindPeriod(kindPeriod, constants.PeriodKindDaily, constants.PeriodKindCalendarWeekly, constants.PeriodKindMonthly)
+	if err := validator.Err(); err != nil {
+		return nil, err
+	}
+	subscription := l.broker.Subscribe(numberMeterIDs)
+	subscription.KindPeriod = checkedKindPeriod
+	return subscription, nil
+}
+
+// Unsubscribe: remove the subscription of the broker
+//
+// Parameters:
+// subscription: the subscription to remove
+func (l *LiveConsumptionServiceImpl) Unsubscribe(subscription *LiveConsumptionSubscription) {
+	l.broker.Unsubscribe(subscription)
+}
+
+// GetCurrentPeriodConsumption: get the consumption of the meters of a subscription in the period that is running
+//
+// Parameters:
+// subscription: the subscription with the meters and the period kind
+//
+// Returns:
+// return one series by meter with only the current period
+func (l *LiveConsumptionServiceImpl) GetCurrentPeriodConsumption(subscription *LiveConsumptionSubscription) ([]Serializer, error) {
+	periodStart := AlertPeriodStart(subscription.KindPeriod, l.now().UTC())
+	periodEnd := AlertPeriodEnd(subscription.KindPeriod, periodStart)
+	var stringMeterIDs []string
+	for _, meterID := range subscription.MeterIDs {
+		stringMeterIDs = append(stringMeterIDs, strconv.Itoa(meterID))
+	}
+	return l.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(strings.Join(stringMeterIDs, ","), domain.TimeTostr(periodStart, constants.DateFormatDate), domain.TimeTostr(periodEnd, constants.DateFormatDate), subscription.KindPeriod, domain.ConsumptionQueryOptions{})
+}
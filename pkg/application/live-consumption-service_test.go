@@ -0,0 +1,135 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LiveConsumptionBroker", func() {
+	var broker LiveConsumptionBroker
+
+	BeforeEach(func() {
+		broker = NewLiveConsumptionBroker()
+	})
+
+	It("should send every subscriber only the readings of its meters", func() {
+		first := broker.Subscribe([]int{1})
+		second := broker.Subscribe([]int{2, 3})
+		broker.PublishReadings([]*domain.UserConsumption{{MeterID: 1}, {MeterID: 3}, {MeterID: 4}})
+
+		Expect(<-first.Readings).To(Equal([]domain.UserConsumption{{MeterID: 1}}))
+		Expect(<-second.Readings).To(Equal([]domain.UserConsumption{{MeterID: 3}}))
+	})
+
+	It("should drop the readings of a slow subscriber instead of blocking", func() {
+		subscription := broker.Subscribe([]int{1})
+		for i := 0; i < cap(subscription.Readings)+5; i++ {
+			broker.PublishReadings([]*domain.UserConsumption{{MeterID: 1}})
+		}
+		Expect(subscription.Readings).To(HaveLen(cap(subscription.Readings)))
+	})
+
+	It("should close the channel of the subscriber when it unsubscribes", func() {
+		subscription := broker.Subscribe([]int{1})
+		broker.Unsubscribe(subscription)
+		broker.Unsubscribe(subscription)
+		broker.PublishReadings([]*domain.UserConsumption{{MeterID: 1}})
+		_, ok := <-subscription.Readings
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("LiveConsumptionRepository", func() {
+	var (
+		mockMySQLRepository *domainfakes.FakeMySQLPowerConsumptionRepository
+		broker              LiveConsumptionBroker
+		repository          domain.MySQLPowerConsumptionRepository
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepository = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		broker = NewLiveConsumptionBroker()
+		repository = NewLiveConsumptionRepository(mockMySQLRepository, broker)
+	})
+
+	It("should publish the readings once they were written", func() {
+		subscription := broker.Subscribe([]int{1})
+		mockMySQLRepository.CreatePowerConsumptionRecordsReturns([]*domain.UserConsumption{{MeterID: 1, ActiveEnergy: 10}}, nil)
+		_, err := repository.CreatePowerConsumptionRecords([]*domain.UserConsumption{{MeterID: 1, ActiveEnergy: 10}})
+		Expect(err).To(BeNil())
+		Expect(mockMySQLRepository.CreatePowerConsumptionRecordsCallCount()).To(Equal(1))
+		Expect(<-subscription.Readings).To(HaveLen(1))
+	})
+
+	It("should publish only the readings that were inserted", func() {
+		subscription := broker.Subscribe([]int{1})
+		inserted := &domain.UserConsumption{MeterID: 1, ActiveEnergy: 20, Date: time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)}
+		mockMySQLRepository.CreatePowerConsumptionRecordsReturns([]*domain.UserConsumption{inserted}, nil)
+		_, err := repository.CreatePowerConsumptionRecords([]*domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			inserted,
+		})
+		Expect(err).To(BeNil())
+		Expect(<-subscription.Readings).To(Equal([]domain.UserConsumption{*inserted}))
+	})
+
+	It("should not publish the readings when the write fails", func() {
+		subscription := broker.Subscribe([]int{1})
+		mockMySQLRepository.CreatePowerConsumptionRecordsReturns(nil, fmt.Errorf("Error: connection refused"))
+		_, err := repository.CreatePowerConsumptionRecords([]*domain.UserConsumption{{MeterID: 1}})
+		Expect(err).ToNot(BeNil())
+		Expect(subscription.Readings).To(BeEmpty())
+	})
+})
+
+var _ = Describe("LiveConsumptionService", func() {
+	var (
+		mockMySQLRepository *domainfakes.FakeMySQLPowerConsumptionRepository
+		service             *LiveConsumptionServiceImpl
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepository = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		powerConsumptionService := NewPowerConsumptionService(mockMySQLRepository, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})
+		service = NewLiveConsumptionService(NewLiveConsumptionBroker(), powerConsumptionService).(*LiveConsumptionServiceImpl)
+		service.now = func() time.Time { return time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC) }
+	})
+
+	It("should not allow a period kind without a current period", func() {
+		_, err := service.Subscribe("1", "interval")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should not allow meters that are not numbers", func() {
+		_, err := service.Subscribe("", "daily")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should subscribe the repeated meters only once", func() {
+		subscription, err := service.Subscribe("1, 2, 1", "monthly")
+		Expect(err).To(BeNil())
+		Expect(subscription.MeterIDs).To(Equal([]int{1, 2}))
+	})
+
+	It("should get the consumption of the current month", func() {
+		subscription, err := service.Subscribe("1", "Monthly")
+		Expect(err).To(BeNil())
+		Expect(subscription.MeterIDs).To(Equal([]int{1}))
+		mockMySQLRepository.GetConsumptionByMeterIDAndWindowTimeReturns([]domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{MeterID: 1, ActiveEnergy: 30, Date: time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		serializers, err := service.GetCurrentPeriodConsumption(subscription)
+		Expect(err).To(BeNil())
+		Expect(serializers).To(HaveLen(1))
+		startDate, endDate, _ := mockMySQLRepository.GetConsumptionByMeterIDAndWindowTimeArgsForCall(0)
+		Expect(startDate).To(Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(endDate).To(Equal(time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC)))
+	})
+})
@@ -0,0 +1,393 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/linkedin/goavro/v2"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . LiveReadingService
+type LiveReadingService interface {
+	SubscriptionTopic() string
+	HandleMessage(topic string, payload []byte, ack func()) error
+	Flush() error
+}
+
+type LiveReadingFormat struct {
+	TopicPattern  string
+	PayloadFormat string
+	Unit          string
+	BatchSize     int
+	AvroSchema    string
+}
+
+type LiveReadingPayload struct {
+	MeterID            *int    `json:"meter_id"`
+	ActiveEnergy       float64 `json:"active_energy"`
+	ReactiveEnergy     float64 `json:"reactive_energy"`
+	CapacitiveReactive float64 `json:"capacitive_reactive"`
+	Solar              float64 `json:"solar"`
+	Date               string  `json:"date"`
+}
+
+type LiveReadingServiceImpl struct {
+	mysqlRepository      domain.MySQLPowerConsumptionRepository
+	csvRepository        domain.CSVPowerConsumptionRepository
+	quarantineRepository domain.QuarantineRepository
+	format               LiveReadingFormat
+	unit                 EnergyUnit
+	avroCodec            *goavro.Codec
+	mutex                sync.Mutex
+	pending              []pendingReading
+}
+
+type pendingReading struct {
+	reading *domain.UserConsumption
+	ack     func()
+}
+
+func NewLiveReadingService(mysqlRepository domain.MySQLPowerConsumptionRepository, csvRepository domain.CSVPowerConsumptionRepository, quarantineRepository domain.QuarantineRepository, format LiveReadingFormat) (LiveReadingService, error) {
+	checkedFormat, unit, err := ChekingLiveReadingFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	var avroCodec *goavro.Codec
+	if checkedFormat.PayloadFormat == constants.LivePayloadAvro {
+		avroCodec, err = goavro.NewCodec(checkedFormat.AvroSchema)
+		if err != nil {
+			logrus.Errorf("Error: the avro schema is not valid %s", err.Error())
+			return nil, err
+		}
+	}
+	return &LiveReadingServiceImpl{
+		mysqlRepository:      mysqlRepository,
+		csvRepository:        csvRepository,
+		quarantineRepository: quarantineRepository,
+		format:               checkedFormat,
+		unit:                 unit,
+		avroCodec:            avroCodec,
+	}, nil
+}
+
+// ChekingLiveReadingFormat: check the topic pattern, the payload format, the unit and the batch size of the live
+// readings, the avro payloads need a schema
+//
+// Parameters:
+// format: the format of the live readings
+//
+// Returns:
+// return the format with the defaults, the unit of the values or an error if the format is not valid
+func ChekingLiveReadingFormat(format LiveReadingFormat) (LiveReadingFormat, EnergyUnit, error) {
+	format.TopicPattern = strings.Trim(format.TopicPattern, " ")
+	if format.TopicPattern == "" {
+		return format, EnergyUnit{}, fmt.Errorf("Error: the topic pattern is empty")
+	}
+	if strings.Count(format.TopicPattern, constants.LiveTopicMeterID) > 1 {
+		return format, EnergyUnit{}, fmt.Errorf("Error: the topic pattern has more than one %s %s", constants.LiveTopicMeterID, format.TopicPattern)
+	}
+	format.PayloadFormat = strings.ToLower(strings.Trim(format.PayloadFormat, " "))
+	if format.PayloadFormat == "" {
+		format.PayloadFormat = constants.LivePayloadJSON
+	}
+	switch format.PayloadFormat {
+	case constants.LivePayloadJSON, constants.LivePayloadCSV:
+	case constants.LivePayloadAvro:
+		if strings.Trim(format.AvroSchema, " ") == "" {
+			return format, EnergyUnit{}, fmt.Errorf("Error: the avro payload format needs a schema")
+		}
+	default:
+		return format, EnergyUnit{}, fmt.Errorf("Error: payload format not allowed %s", format.PayloadFormat)
+	}
+	if format.BatchSize <= 0 {
+		format.BatchSize = constants.LiveBatchSize
+	}
+	unit, err := ChekingUnit(format.Unit)
+	if err != nil {
+		return format, EnergyUnit{}, err
+	}
+	return format, unit, nil
+}
+
+// SubscriptionTopic: the topic to subscribe, the meter id level of the pattern is a single level wildcard
+//
+// Returns:
+// return the topic filter
+func (l *LiveReadingServiceImpl) SubscriptionTopic() string {
+	return strings.ReplaceAll(l.format.TopicPattern, constants.LiveTopicMeterID, "+")
+}
+
+// HandleMessage: parse a message into a reading and keep it in the buffer until the next flush, the buffer is flushed
+// when it is full, the message is acknowledged when the reading is stored and the messages that could not be parsed
+// are quarantined and acknowledged, when an error is returned the message is not kept so it could be handled again
+//
+// Parameters:
+// topic: the topic of the message
+// payload: the payload of the message
+// ack: the function to acknowledge the message
+//
+// Returns:
+// return an error if the reading could not be stored, the message is not acknowledged to receive it again
+func (l *LiveReadingServiceImpl) HandleMessage(topic string, payload []byte, ack func()) error {
+	reading, err := l.parseMessage(topic, payload)
+	if err != nil {
+		logrus.Errorf("Error: parsing the message of the topic %s %s", topic, err.Error())
+		quarantinedReading := domain.NewQuarantinedReading(domain.UserConsumption{}, fmt.Sprintf("%s: %s", constants.QualityRuleParse, err.Error()))
+		quarantinedReading.RawLine = string(payload)
+		quarantinedReading.Source = topic
+		quarantinedReading.Unit = l.format.Unit
+		if err := l.quarantineRepository.CreateQuarantinedReadings([]*domain.QuarantinedReading{quarantinedReading}); err != nil {
+			return err
+		}
+		ack()
+		return nil
+	}
+
+	l.mutex.Lock()
+	l.pending = append(l.pending, pendingReading{reading: reading, ack: ack})
+	full := len(l.pending) >= l.format.BatchSize
+	l.mutex.Unlock()
+	if !full {
+		return nil
+	}
+	if err := l.Flush(); err != nil {
+		l.removePending(reading)
+		return err
+	}
+	return nil
+}
+
+// removePending: take a reading out of the buffer when its message will be handled again
+func (l *LiveReadingServiceImpl) removePending(reading *domain.UserConsumption) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for i, pending := range l.pending {
+		if pending.reading == reading {
+			l.pending = append(l.pending[:i], l.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// Flush: write the readings of the buffer in the database and acknowledge their messages, the readings that already
+// exist for the same meter and date are skipped so a message delivered twice is stored once
+//
+// Returns:
+// return an error if the readings could not be written, they are kept in the buffer to try again
+func (l *LiveReadingServiceImpl) Flush() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(l.pending) == 0 {
+		return nil
+	}
+	newReadings, err := l.newReadings()
+	if err != nil {
+		return err
+	}
+	var insertedReadings []*domain.UserConsumption
+	if len(newReadings) > 0 {
+		insertedReadings, err = l.mysqlRepository.CreatePowerConsumptionRecords(newReadings)
+		if err != nil {
+			return err
+		}
+	}
+	for _, pending := range l.pending {
+		pending.ack()
+	}
+	logrus.Infof("%d live readings were stored, %d were duplicated", len(insertedReadings), len(l.pending)-len(insertedReadings))
+	l.pending = nil
+	return nil
+}
+
+func (l *LiveReadingServiceImpl) newReadings() ([]*domain.UserConsumption, error) {
+	seenReadings := make(map[string]bool)
+	datesByMeter := make(map[int][]time.Time)
+	var meterIDs []int
+	for _, pending := range l.pending {
+		if _, ok := datesByMeter[pending.reading.MeterID]; !ok {
+			meterIDs = append(meterIDs, pending.reading.MeterID)
+		}
+		datesByMeter[pending.reading.MeterID] = append(datesByMeter[pending.reading.MeterID], pending.reading.Date)
+	}
+	for _, meterID := range meterIDs {
+		storedReadings, err := l.mysqlRepository.GetConsumptionByMeterIDAndDates(meterID, datesByMeter[meterID])
+		if err != nil {
+			return nil, err
+		}
+		for _, storedReading := range storedReadings {
+			seenReadings[liveReadingKey(storedReading.MeterID, storedReading.Date)] = true
+		}
+	}
+	var newReadings []*domain.UserConsumption
+	for _, pending := range l.pending {
+		key := liveReadingKey(pending.reading.MeterID, pending.reading.Date)
+		if seenReadings[key] {
+			continue
+		}
+		seenReadings[key] = true
+		newReadings = append(newReadings, pending.reading)
+	}
+	return newReadings, nil
+}
+
+func (l *LiveReadingServiceImpl) parseMessage(topic string, payload []byte) (*domain.UserConsumption, error) {
+	topicMeterID, err := l.topicMeterID(topic)
+	if err != nil {
+		return nil, err
+	}
+	var reading *domain.UserConsumption
+	switch l.format.PayloadFormat {
+	case constants.LivePayloadCSV:
+		reading, err = l.parseCSVPayload(topicMeterID, payload)
+	case constants.LivePayloadAvro:
+		reading, err = l.parseAvroPayload(topicMeterID, payload)
+	default:
+		reading, err = parseJSONPayload(topicMeterID, payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	*reading = l.unit.FromUnit(*reading)
+	return reading, nil
+}
+
+func (l *LiveReadingServiceImpl) topicMeterID(topic string) (*int, error) {
+	patternLevels := strings.Split(l.format.TopicPattern, "/")
+	topicLevels := strings.Split(topic, "/")
+	if len(patternLevels) != len(topicLevels) {
+		return nil, fmt.Errorf("Error: the topic %s does not match the pattern %s", topic, l.format.TopicPattern)
+	}
+	for i, level := range patternLevels {
+		if level != constants.LiveTopicMeterID {
+			continue
+		}
+		meterID, err := strconv.Atoi(topicLevels[i])
+		if err != nil {
+			return nil, fmt.Errorf("Error: the meter id of the topic is not valid %s", topicLevels[i])
+		}
+		return &meterID, nil
+	}
+	return nil, nil
+}
+
+func (l *LiveReadingServiceImpl) parseCSVPayload(topicMeterID *int, payload []byte) (*domain.UserConsumption, error) {
+	csvRecord, err := l.csvRepository.ConvertCSVLineToStruct(string(payload))
+	if err != nil {
+		return nil, err
+	}
+	if topicMeterID != nil {
+		if csvRecord.MeterID == "" {
+			csvRecord.MeterID = strconv.Itoa(*topicMeterID)
+		}
+		if csvRecord.MeterID != strconv.Itoa(*topicMeterID) {
+			return nil, fmt.Errorf("Error: the meter id of the payload %s is not the meter id of the topic %d", csvRecord.MeterID, *topicMeterID)
+		}
+	}
+	return csvRecord.ToUserConsumption()
+}
+
+// parseAvroPayload: decode an avro record with the same fields as the json payload, the date is a string or a
+// timestamp and the confluent wire format header is skipped
+func (l *LiveReadingServiceImpl) parseAvroPayload(topicMeterID *int, payload []byte) (*domain.UserConsumption, error) {
+	if len(payload) > 5 && payload[0] == 0 {
+		payload = payload[5:]
+	}
+	native, _, err := l.avroCodec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Error: the avro payload is not a record")
+	}
+	var livePayload LiveReadingPayload
+	if meterID, ok := avroNumber(record["meter_id"]); ok {
+		numberMeterID := int(meterID)
+		livePayload.MeterID = &numberMeterID
+	}
+	livePayload.ActiveEnergy, _ = avroNumber(record["active_energy"])
+	livePayload.ReactiveEnergy, _ = avroNumber(record["reactive_energy"])
+	livePayload.CapacitiveReactive, _ = avroNumber(record["capacitive_reactive"])
+	livePayload.Solar, _ = avroNumber(record["solar"])
+	switch date := avroValue(record["date"]).(type) {
+	case string:
+		livePayload.Date = date
+	case time.Time:
+		livePayload.Date = date.UTC().Format(time.RFC3339Nano)
+	case int64:
+		livePayload.Date = time.UnixMilli(date).UTC().Format(time.RFC3339Nano)
+	}
+	return livePayload.toUserConsumption(topicMeterID)
+}
+
+func parseJSONPayload(topicMeterID *int, payload []byte) (*domain.UserConsumption, error) {
+	var livePayload LiveReadingPayload
+	if err := json.Unmarshal(payload, &livePayload); err != nil {
+		return nil, err
+	}
+	return livePayload.toUserConsumption(topicMeterID)
+}
+
+func (livePayload LiveReadingPayload) toUserConsumption(topicMeterID *int) (*domain.UserConsumption, error) {
+	meterID := livePayload.MeterID
+	if topicMeterID != nil {
+		if meterID != nil && *meterID != *topicMeterID {
+			return nil, fmt.Errorf("Error: the meter id of the payload %d is not the meter id of the topic %d", *meterID, *topicMeterID)
+		}
+		meterID = topicMeterID
+	}
+	if meterID == nil {
+		return nil, fmt.Errorf("Error: the message has no meter id")
+	}
+	date, err := time.Parse(time.RFC3339, livePayload.Date)
+	if err != nil {
+		date, err = domain.StrToDate(livePayload.Date)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &domain.UserConsumption{
+		MeterID:            *meterID,
+		ActiveEnergy:       livePayload.ActiveEnergy,
+		ReactiveEnergy:     livePayload.ReactiveEnergy,
+		CapacitiveReactive: livePayload.CapacitiveReactive,
+		Solar:              livePayload.Solar,
+		Date:               date.UTC(),
+	}, nil
+}
+
+// avroValue: unwrap the value of an avro union
+func avroValue(value interface{}) interface{} {
+	if union, ok := value.(map[string]interface{}); ok && len(union) == 1 {
+		for _, unionValue := range union {
+			return unionValue
+		}
+	}
+	return value
+}
+
+func avroNumber(value interface{}) (float64, bool) {
+	switch number := avroValue(value).(type) {
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case float32:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+func liveReadingKey(meterID int, date time.Time) string {
+	return fmt.Sprintf("%d|%d", meterID, date.UnixNano())
+}
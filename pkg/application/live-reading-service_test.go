@@ -0,0 +1,177 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	"github.com/linkedin/goavro/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LiveReadingService", func() {
+	var (
+		mockMySQLRepo      *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo        *domainfakes.FakeCSVPowerConsumptionRepository
+		mockQuarantineRepo *domainfakes.FakeQuarantineRepository
+		format             LiveReadingFormat
+		acks               int
+		ack                func()
+	)
+
+	newService := func() LiveReadingService {
+		service, err := NewLiveReadingService(mockMySQLRepo, mockCSVRepo, mockQuarantineRepo, format)
+		Expect(err).To(BeNil())
+		return service
+	}
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockQuarantineRepo = &domainfakes.FakeQuarantineRepository{}
+		format = LiveReadingFormat{TopicPattern: "meters/{meter_id}/readings", BatchSize: 10}
+		acks = 0
+		ack = func() { acks++ }
+	})
+
+	It("should subscribe to the topic with a wildcard for the meter", func() {
+		Expect(newService().SubscriptionTopic()).To(Equal("meters/+/readings"))
+	})
+
+	It("should take the meter from the topic and acknowledge the messages after the flush", func() {
+		format.Unit = "Wh"
+		service := newService()
+
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"active_energy":2000,"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+		Expect(acks).To(Equal(0))
+		Expect(service.Flush()).To(Succeed())
+
+		Expect(acks).To(Equal(1))
+		records := mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(0)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].MeterID).To(Equal(7))
+		Expect(records[0].ActiveEnergy).To(Equal(2.0))
+		Expect(records[0].Date).To(Equal(time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)))
+	})
+
+	It("should store a reading delivered twice only once", func() {
+		service := newService()
+		date := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+		mockMySQLRepo.GetConsumptionByMeterIDAndDatesReturns([]domain.UserConsumption{{MeterID: 7, Date: date}}, nil)
+		payload := []byte(`{"active_energy":2,"date":"2023-08-01T11:00:00Z"}`)
+
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"active_energy":1,"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+		Expect(service.HandleMessage("meters/7/readings", payload, ack)).To(Succeed())
+		Expect(service.HandleMessage("meters/7/readings", payload, ack)).To(Succeed())
+		Expect(service.Flush()).To(Succeed())
+
+		Expect(acks).To(Equal(3))
+		records := mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(0)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].ActiveEnergy).To(Equal(2.0))
+	})
+
+	It("should flush when the buffer is full", func() {
+		format.BatchSize = 2
+		service := newService()
+
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(0))
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T11:00:00Z"}`), ack)).To(Succeed())
+
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(1))
+		Expect(acks).To(Equal(2))
+	})
+
+	It("should not keep the message that filled the buffer when the flush fails", func() {
+		format.BatchSize = 2
+		service := newService()
+		mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil, errors.New("Error creating records"))
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T11:00:00Z"}`), ack)).To(HaveOccurred())
+
+		mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil, nil)
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T11:00:00Z"}`), ack)).To(Succeed())
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(1)).To(HaveLen(2))
+		Expect(acks).To(Equal(2))
+	})
+
+	It("should keep the readings without acknowledging them when they could not be written", func() {
+		service := newService()
+		mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil, errors.New("Error creating records"))
+		Expect(service.HandleMessage("meters/7/readings", []byte(`{"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+
+		Expect(service.Flush()).To(HaveOccurred())
+		Expect(acks).To(Equal(0))
+
+		mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil, nil)
+		Expect(service.Flush()).To(Succeed())
+		Expect(acks).To(Equal(1))
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(1)).To(HaveLen(1))
+	})
+
+	It("should quarantine and acknowledge the messages that could not be parsed", func() {
+		service := newService()
+
+		Expect(service.HandleMessage("meters/seven/readings", []byte(`{"date":"2023-08-01T10:00:00Z"}`), ack)).To(Succeed())
+
+		Expect(acks).To(Equal(1))
+		quarantined := mockQuarantineRepo.CreateQuarantinedReadingsArgsForCall(0)
+		Expect(quarantined[0].Source).To(Equal("meters/seven/readings"))
+		Expect(quarantined[0].RawLine).To(Equal(`{"date":"2023-08-01T10:00:00Z"}`))
+	})
+
+	It("should parse the csv payloads", func() {
+		format.PayloadFormat = "CSV"
+		service := newService()
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ActiveEnergy: 3, Date: "2023-08-01"}, nil)
+
+		Expect(service.HandleMessage("meters/7/readings", []byte(",,3,0,0,0,2023-08-01"), ack)).To(Succeed())
+		Expect(service.Flush()).To(Succeed())
+
+		records := mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(0)
+		Expect(records[0].MeterID).To(Equal(7))
+		Expect(records[0].ActiveEnergy).To(Equal(3.0))
+	})
+
+	It("should decode the avro payloads", func() {
+		format = LiveReadingFormat{TopicPattern: "readings", PayloadFormat: "avro", BatchSize: 10, AvroSchema: `{
+			"type": "record", "name": "Reading", "fields": [
+				{"name": "meter_id", "type": "int"},
+				{"name": "active_energy", "type": "double"},
+				{"name": "solar", "type": ["null", "double"], "default": null},
+				{"name": "date", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+			]}`}
+		service := newService()
+		codec, err := goavro.NewCodec(format.AvroSchema)
+		Expect(err).To(BeNil())
+		date := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+		payload, err := codec.BinaryFromNative([]byte{0, 0, 0, 0, 1}, map[string]interface{}{
+			"meter_id":      7,
+			"active_energy": 4.5,
+			"solar":         goavro.Union("double", 1.5),
+			"date":          date,
+		})
+		Expect(err).To(BeNil())
+
+		Expect(service.HandleMessage("readings", payload, ack)).To(Succeed())
+		Expect(service.Flush()).To(Succeed())
+
+		records := mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(0)
+		Expect(records[0].MeterID).To(Equal(7))
+		Expect(records[0].ActiveEnergy).To(Equal(4.5))
+		Expect(records[0].Solar).To(Equal(1.5))
+		Expect(records[0].Date).To(Equal(date))
+	})
+
+	It("should return an error when the format is not valid", func() {
+		_, err := NewLiveReadingService(mockMySQLRepo, mockCSVRepo, mockQuarantineRepo, LiveReadingFormat{TopicPattern: "meters/+", PayloadFormat: "xml"})
+		Expect(err).To(HaveOccurred())
+		_, err = NewLiveReadingService(mockMySQLRepo, mockCSVRepo, mockQuarantineRepo, LiveReadingFormat{})
+		Expect(err).To(HaveOccurred())
+		_, err = NewLiveReadingService(mockMySQLRepo, mockCSVRepo, mockQuarantineRepo, LiveReadingFormat{TopicPattern: "readings", PayloadFormat: "avro"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MeterGroupService
+type MeterGroupService interface {
+	CreateMeterGroup(name string, parentID *uint, meterIDs []int) (*domain.MeterGroup, error)
+	GetMeterGroups() ([]domain.MeterGroup, error)
+	GetMeterGroupByID(groupID string) (*domain.MeterGroup, []int, error)
+}
+
+type MeterGroupServiceImpl struct {
+	meterGroupRepository domain.MeterGroupRepository
+}
+
+func NewMeterGroupService(meterGroupRepository domain.MeterGroupRepository) MeterGroupService {
+	return &MeterGroupServiceImpl{
+		meterGroupRepository,
+	}
+}
+
+// CreateMeterGroup: create a named set of meters, the group could be nested in another group
+//
+// Parameters:
+// name: the name of the group
+// parentID: the group that contains this group, nil if it's a root group
+// meterIDs: the meters in the group
+//
+// Returns:
+// return the created group or an error if the information is not valid
+func (m *MeterGroupServiceImpl) CreateMeterGroup(name string, parentID *uint, meterIDs []int) (*domain.MeterGroup, error) {
+	trimName := strings.Trim(name, " ")
+	if trimName == "" {
+		return nil, domain.NewValidationError(constants.ErrorCodeMissingParam, "name", "Error: the group name is empty")
+	}
+	if parentID != nil {
+		if _, err := m.meterGroupRepository.GetMeterGroupByID(*parentID); err != nil {
+			if domainError, ok := domain.AsDomainError(err); ok && domainError.Kind != domain.ErrorKindNotFound {
+				return nil, err
+			}
+			logrus.Errorf("Error: the parent group does not exist %d", *parentID)
+			return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "parent_id", fmt.Sprintf("Error: the parent group does not exist %d", *parentID))
+		}
+	}
+	group := &domain.MeterGroup{
+		Name:     trimName,
+		ParentID: parentID,
+	}
+	seenMeterIDs := make(map[int]bool)
+	for _, meterID := range meterIDs {
+		if seenMeterIDs[meterID] {
+			continue
+		}
+		seenMeterIDs[meterID] = true
+		group.Meters = append(group.Meters, domain.MeterGroupMeter{MeterID: meterID})
+	}
+	if err := m.meterGroupRepository.CreateMeterGroup(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// GetMeterGroups: get all the meter groups
+//
+// Returns:
+// return all the meter groups
+func (m *MeterGroupServiceImpl) GetMeterGroups() ([]domain.MeterGroup, error) {
+	return m.meterGroupRepository.GetMeterGroups()
+}
+
+// GetMeterGroupByID: get a meter group and all the meters in it and in its nested groups
+//
+// Parameters:
+// groupID: the id of the group
+//
+// Returns:
+// return the group and the meter ids resolved
+func (m *MeterGroupServiceImpl) GetMeterGroupByID(groupID string) (*domain.MeterGroup, []int, error) {
+	numberGroupID, err := domain.StrToInt(groupID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int groupID %s", err.Error())
+		return nil, nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the group id is not a number %s", groupID))
+	}
+	return ResolveMeterGroup(m.meterGroupRepository, uint(numberGroupID))
+}
+
+// ResolveMeterGroup: walk the group and its nested groups and collect all the meters without duplicates
+//
+// Parameters:
+// meterGroupRepository: the repository to get the groups
+// groupID: the id of the root group
+//
+// Returns:
+// return the root group and the meter ids in the order they were found
+func ResolveMeterGroup(meterGroupRepository domain.MeterGroupRepository, groupID uint) (*domain.MeterGroup, []int, error) {
+	group, err := meterGroupRepository.GetMeterGroupByID(groupID)
+	if domainError, ok := domain.AsDomainError(err); ok && domainError.Kind == domain.ErrorKindNotFound {
+		return nil, nil, domain.NewNotFoundError(constants.ErrorCodeMeterGroupNotFound, fmt.Sprintf("Error: the group %d does not exist", groupID), err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var meterIDs []int
+	seenMeterIDs := make(map[int]bool)
+	visitedGroups := make(map[uint]bool)
+	pendingGroups := []domain.MeterGroup{*group}
+	for len(pendingGroups) > 0 {
+		currentGroup := pendingGroups[0]
+		pendingGroups = pendingGroups[1:]
+		if visitedGroups[currentGroup.ID] {
+			continue
+		}
+		visitedGroups[currentGroup.ID] = true
+		for _, meter := range currentGroup.Meters {
+			if !seenMeterIDs[meter.MeterID] {
+				seenMeterIDs[meter.MeterID] = true
+				meterIDs = append(meterIDs, meter.MeterID)
+			}
+		}
+		nestedGroups, err := meterGroupRepository.GetMeterGroupsByParentID(currentGroup.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		pendingGroups = append(pendingGroups, nestedGroups...)
+	}
+	logrus.Infof("the group %d was resolved in %d meters", groupID, len(meterIDs))
+	return group, meterIDs, nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"fmt"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MeterSettingService
+type MeterSettingService interface {
+	SaveMeterSetting(meterID string, setting domain.MeterSetting) (*domain.MeterSetting, error)
+	GetMeterSettingByMeterID(meterID string) (*domain.MeterSetting, error)
+}
+
+type MeterSettingServiceImpl struct {
+	meterSettingRepository domain.MeterSettingRepository
+}
+
+func NewMeterSettingService(meterSettingRepository domain.MeterSettingRepository) MeterSettingService {
+	return &MeterSettingServiceImpl{
+		meterSettingRepository,
+	}
+}
+
+// SaveMeterSetting: check and save the settings of a meter
+//
+// Parameters:
+// meterID: the id of the meter
+// setting: the settings of the meter
+//
+// Returns:
+// return the saved settings or an error if the settings are not valid
+func (m *MeterSettingServiceImpl) SaveMeterSetting(meterID string, setting domain.MeterSetting) (*domain.MeterSetting, error) {
+	numberMeterID, err := domain.StrToInt(meterID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int meterID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "meter_id", fmt.Sprintf("Error: the meter id is not a number %s", meterID))
+	}
+	if setting.BillingCycleDay != 0 {
+		if err := ChekingBillingCycleDay(setting.BillingCycleDay); err != nil {
+			return nil, err
+		}
+	}
+	if setting.Unit != "" {
+		unit, err := ChekingUnit(setting.Unit)
+		if err != nil {
+			return nil, err
+		}
+		setting.Unit = unit.Active
+	}
+	if setting.RegisterMax < 0 {
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "register_max", fmt.Sprintf("Error: register max not allowed %f", setting.RegisterMax))
+	}
+	setting.MeterID = numberMeterID
+	if err := m.meterSettingRepository.SaveMeterSetting(&setting); err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// GetMeterSettingByMeterID: get the settings of a meter
+//
+// Parameters:
+// meterID: the id of the meter
+//
+// Returns:
+// return the settings of the meter or an error if the meter does not have settings
+func (m *MeterSettingServiceImpl) GetMeterSettingByMeterID(meterID string) (*domain.MeterSetting, error) {
+	numberMeterID, err := domain.StrToInt(meterID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int meterID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "meter_id", fmt.Sprintf("Error: the meter id is not a number %s", meterID))
+	}
+	setting, err := m.meterSettingRepository.GetMeterSettingByMeterID(numberMeterID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		return nil, domain.NewNotFoundError(constants.ErrorCodeMeterSettingNotFound, fmt.Sprintf("Error: the meter %d does not have settings", numberMeterID), nil)
+	}
+	return setting, nil
+}
@@ -0,0 +1,148 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type EventEnvelope struct {
+	ID        uint            `json:"id"`
+	Kind      string          `json:"kind"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . EventSink
+type EventSink interface {
+	Name() string
+	Publish(envelope EventEnvelope) error
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . OutboxService
+type OutboxService interface {
+	PublishPendingEvents() (int, error)
+}
+
+type OutboxServiceImpl struct {
+	outboxRepository domain.OutboxRepository
+	sinks            []EventSink
+}
+
+func NewOutboxService(outboxRepository domain.OutboxRepository, sinks ...EventSink) OutboxService {
+	return &OutboxServiceImpl{
+		outboxRepository,
+		sinks,
+	}
+}
+
+// NewEventEnvelope: build the message of an outbox event sent to the sinks, the id of the event lets the receivers
+// discard the events delivered more than once
+//
+// Parameters:
+// event: the outbox event
+//
+// Returns:
+// return the message of the event
+func NewEventEnvelope(event domain.OutboxEvent) EventEnvelope {
+	return EventEnvelope{
+		ID:        event.ID,
+		Kind:      event.Kind,
+		CreatedAt: event.CreatedAt,
+		Data:      json.RawMessage(event.Payload),
+	}
+}
+
+// PublishPendingEvents: send the pending events of the outbox to all the sinks in the order they were written, an
+// event that fails does not block the next ones, it is sent again later with an exponential backoff only to the
+// sinks that did not receive it and it is dead lettered when it reaches the max number of attempts
+//
+// Returns:
+// return the number of published events or an error if an event could not be published
+func (o *OutboxServiceImpl) PublishPendingEvents() (int, error) {
+	now := time.Now().UTC()
+	events, err := o.outboxRepository.GetPendingOutboxEvents(now, constants.OutboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	var failedEventIDs []string
+	for _, event := range events {
+		envelope := NewEventEnvelope(event)
+		deliveredSinks := event.GetDeliveredSinks()
+		var reasons []string
+		for _, sink := range o.sinks {
+			if event.DeliveredTo(sink.Name()) {
+				continue
+			}
+			if err := sink.Publish(envelope); err != nil {
+				logrus.Errorf("Error: publishing the event %d in the sink %s %s", event.ID, sink.Name(), err.Error())
+				reasons = append(reasons, fmt.Sprintf("%s: %s", sink.Name(), err.Error()))
+				continue
+			}
+			deliveredSinks = append(deliveredSinks, sink.Name())
+		}
+		if len(reasons) > 0 {
+			if err := o.markEventFailed(event, deliveredSinks, strings.Join(reasons, "; "), now); err != nil {
+				return published, err
+			}
+			failedEventIDs = append(failedEventIDs, fmt.Sprint(event.ID))
+			continue
+		}
+		if err := o.outboxRepository.MarkOutboxEventPublished(event.ID, now); err != nil {
+			return published, err
+		}
+		published++
+	}
+	if published > 0 {
+		logrus.Infof("%d outbox events were published", published)
+	}
+	if len(failedEventIDs) > 0 {
+		return published, fmt.Errorf("Error: the events %s could not be published", strings.Join(failedEventIDs, ","))
+	}
+	return published, nil
+}
+
+// markEventFailed: schedule the next attempt of an event that could not be published or dead letter it when it
+// reached the max number of attempts
+//
+// Parameters:
+// event: the outbox event
+// deliveredSinks: the names of the sinks that already received the event
+// reason: the errors of the sinks that failed
+// now: the time of the attempt
+//
+// Returns:
+// return an error if the event could not be updated
+func (o *OutboxServiceImpl) markEventFailed(event domain.OutboxEvent, deliveredSinks []string, reason string, now time.Time) error {
+	attempts := event.Attempts + 1
+	if attempts >= constants.OutboxMaxAttempts {
+		logrus.Errorf("Error: the event %d was dead lettered after %d attempts %s", event.ID, attempts, reason)
+		return o.outboxRepository.MarkOutboxEventDeadLettered(event.ID, deliveredSinks, reason, now)
+	}
+	return o.outboxRepository.MarkOutboxEventFailed(event.ID, deliveredSinks, reason, now.Add(OutboxRetryDelay(attempts)))
+}
+
+// OutboxRetryDelay: the time to wait before the next attempt of an event, it doubles with every attempt until the max
+// delay
+//
+// Parameters:
+// attempts: the attempts already done
+//
+// Returns:
+// return the delay of the next attempt
+func OutboxRetryDelay(attempts int) time.Duration {
+	delay := constants.OutboxRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= constants.OutboxRetryMaxDelay {
+			return constants.OutboxRetryMaxDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+// recordingEventSink: keeps the published events, the fakes of the package can not be used here by the import cycle
+type recordingEventSink struct {
+	name      string
+	published []EventEnvelope
+	err       error
+	// failedIDs: the events that fail always, the other events are published
+	failedIDs map[uint]bool
+}
+
+func (r *recordingEventSink) Name() string {
+	return r.name
+}
+
+func (r *recordingEventSink) Publish(envelope EventEnvelope) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.failedIDs[envelope.ID] {
+		return errors.New("event rejected")
+	}
+	r.published = append(r.published, envelope)
+	return nil
+}
+
+var _ = Describe("PublishPendingEvents", func() {
+	var (
+		mockOutboxRepo *domainfakes.FakeOutboxRepository
+		webhookSink    *recordingEventSink
+		brokerSink     *recordingEventSink
+		service        OutboxService
+	)
+
+	BeforeEach(func() {
+		mockOutboxRepo = &domainfakes.FakeOutboxRepository{}
+		webhookSink = &recordingEventSink{name: "webhook"}
+		brokerSink = &recordingEventSink{name: "kafka"}
+		service = NewOutboxService(mockOutboxRepo, webhookSink, brokerSink)
+		mockOutboxRepo.GetPendingOutboxEventsReturns([]domain.OutboxEvent{
+			{Model: gorm.Model{ID: 1}, Kind: "readings_imported", Payload: `{"meter_ids":[1],"count":2}`},
+			{Model: gorm.Model{ID: 2}, Kind: "readings_imported", Payload: `{"meter_ids":[2],"count":1}`},
+		}, nil)
+	})
+
+	It("should publish the events to every sink and mark them as published", func() {
+		published, err := service.PublishPendingEvents()
+		Expect(err).To(BeNil())
+		Expect(published).To(Equal(2))
+		Expect(webhookSink.published).To(HaveLen(2))
+		Expect(brokerSink.published).To(HaveLen(2))
+		Expect(webhookSink.published[0].ID).To(Equal(uint(1)))
+		Expect(string(webhookSink.published[0].Data)).To(Equal(`{"meter_ids":[1],"count":2}`))
+		Expect(mockOutboxRepo.MarkOutboxEventPublishedCallCount()).To(Equal(2))
+		Expect(mockOutboxRepo.MarkOutboxEventFailedCallCount()).To(Equal(0))
+	})
+
+	It("should mark the event as failed with its next attempt when a sink fails", func() {
+		brokerSink.err = errors.New("broker down")
+		published, err := service.PublishPendingEvents()
+		Expect(err).ToNot(BeNil())
+		Expect(published).To(Equal(0))
+		Expect(mockOutboxRepo.MarkOutboxEventPublishedCallCount()).To(Equal(0))
+		Expect(mockOutboxRepo.MarkOutboxEventFailedCallCount()).To(Equal(2))
+		eventID, deliveredSinks, reason, nextAttemptAt := mockOutboxRepo.MarkOutboxEventFailedArgsForCall(0)
+		Expect(eventID).To(Equal(uint(1)))
+		Expect(deliveredSinks).To(Equal([]string{"webhook"}))
+		Expect(reason).To(ContainSubstring("kafka: broker down"))
+		Expect(nextAttemptAt).To(BeTemporally("~", time.Now().UTC().Add(OutboxRetryDelay(1)), time.Second))
+		Expect(webhookSink.published).To(HaveLen(2))
+	})
+
+	It("should keep publishing the next events when an event fails permanently", func() {
+		brokerSink.failedIDs = map[uint]bool{1: true}
+		mockOutboxRepo.GetPendingOutboxEventsReturns([]domain.OutboxEvent{
+			{Model: gorm.Model{ID: 1}, Kind: "readings_imported", Payload: `{"meter_ids":[1],"count":2}`, Attempts: constants.OutboxMaxAttempts - 1, DeliveredSinks: "webhook"},
+			{Model: gorm.Model{ID: 2}, Kind: "readings_imported", Payload: `{"meter_ids":[2],"count":1}`},
+		}, nil)
+		published, err := service.PublishPendingEvents()
+		Expect(err).ToNot(BeNil())
+		Expect(published).To(Equal(1))
+		Expect(brokerSink.published).To(HaveLen(1))
+		Expect(brokerSink.published[0].ID).To(Equal(uint(2)))
+		Expect(mockOutboxRepo.MarkOutboxEventFailedCallCount()).To(Equal(0))
+		Expect(mockOutboxRepo.MarkOutboxEventDeadLetteredCallCount()).To(Equal(1))
+		eventID, deliveredSinks, reason, _ := mockOutboxRepo.MarkOutboxEventDeadLetteredArgsForCall(0)
+		Expect(eventID).To(Equal(uint(1)))
+		Expect(deliveredSinks).To(Equal([]string{"webhook"}))
+		Expect(reason).To(ContainSubstring("kafka: event rejected"))
+		Expect(mockOutboxRepo.MarkOutboxEventPublishedCallCount()).To(Equal(1))
+		publishedID, _ := mockOutboxRepo.MarkOutboxEventPublishedArgsForCall(0)
+		Expect(publishedID).To(Equal(uint(2)))
+	})
+
+	It("should double the delay of every attempt until the max delay", func() {
+		Expect(OutboxRetryDelay(1)).To(Equal(constants.OutboxRetryBaseDelay))
+		Expect(OutboxRetryDelay(2)).To(Equal(2 * constants.OutboxRetryBaseDelay))
+		Expect(OutboxRetryDelay(constants.OutboxMaxAttempts * 10)).To(Equal(constants.OutboxRetryMaxDelay))
+	})
+
+	It("should send a pending event only to the sinks that did not receive it", func() {
+		mockOutboxRepo.GetPendingOutboxEventsReturns([]domain.OutboxEvent{
+			{Model: gorm.Model{ID: 1}, Kind: "readings_imported", Payload: `{"meter_ids":[1],"count":2}`, DeliveredSinks: "webhook"},
+		}, nil)
+		published, err := service.PublishPendingEvents()
+		Expect(err).To(BeNil())
+		Expect(published).To(Equal(1))
+		Expect(webhookSink.published).To(BeEmpty())
+		Expect(brokerSink.published).To(HaveLen(1))
+		Expect(mockOutboxRepo.MarkOutboxEventPublishedCallCount()).To(Equal(1))
+	})
+
+	It("should return the error of the repository", func() {
+		mockOutboxRepo.GetPendingOutboxEventsReturns(nil, errors.New("db error"))
+		_, err := service.PublishPendingEvents()
+		Expect(err).ToNot(BeNil())
+	})
+})
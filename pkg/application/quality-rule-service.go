@@ -0,0 +1,287 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . QualityRuleService
+type QualityRuleService interface {
+	CreateQualityRule(rule domain.QualityRule) (*domain.QualityRule, error)
+	GetQualityRules() ([]domain.QualityRule, error)
+	DeleteQualityRule(ruleID string) error
+}
+
+type QualityRuleServiceImpl struct {
+	qualityRuleRepository domain.QualityRuleRepository
+}
+
+func NewQualityRuleService(qualityRuleRepository domain.QualityRuleRepository) QualityRuleService {
+	return &QualityRuleServiceImpl{
+		qualityRuleRepository,
+	}
+}
+
+type QualityViolation struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// QualityRuleEngine: run the data quality rules over the readings of an import, it keeps the last accepted reading
+// of every meter to check the max delta rules
+type QualityRuleEngine struct {
+	rules                  []domain.QualityRule
+	mysqlRepository        domain.MySQLPowerConsumptionRepository
+	meterSettingRepository domain.MeterSettingRepository
+	previousReadings       map[int]*domain.UserConsumption
+	existingMeters         map[int]bool
+	now                    time.Time
+}
+
+func NewQualityRuleEngine(rules []domain.QualityRule, mysqlRepository domain.MySQLPowerConsumptionRepository, meterSettingRepository domain.MeterSettingRepository, now time.Time) *QualityRuleEngine {
+	return &QualityRuleEngine{
+		rules:                  rules,
+		mysqlRepository:        mysqlRepository,
+		meterSettingRepository: meterSettingRepository,
+		previousReadings:       make(map[int]*domain.UserConsumption),
+		existingMeters:         make(map[int]bool),
+		now:                    now,
+	}
+}
+
+// CreateQualityRule: check and create a data quality rule
+//
+// Parameters:
+// rule: the rule to create
+//
+// Returns:
+// return the created rule or an error if the rule is not valid
+func (q *QualityRuleServiceImpl) CreateQualityRule(rule domain.QualityRule) (*domain.QualityRule, error) {
+	checkedRule, err := ChekingQualityRule(rule)
+	if err != nil {
+		logrus.Errorf("Error: cheking quality rule %s", err.Error())
+		return nil, err
+	}
+	if err := q.qualityRuleRepository.CreateQualityRule(&checkedRule); err != nil {
+		return nil, err
+	}
+	return &checkedRule, nil
+}
+
+// GetQualityRules: get all the data quality rules
+//
+// Returns:
+// return all the rules
+func (q *QualityRuleServiceImpl) GetQualityRules() ([]domain.QualityRule, error) {
+	return q.qualityRuleRepository.GetQualityRules()
+}
+
+// DeleteQualityRule: delete a data quality rule
+//
+// Parameters:
+// ruleID: the id of the rule
+//
+// Returns:
+// return an error if the rule could not be deleted
+func (q *QualityRuleServiceImpl) DeleteQualityRule(ruleID string) error {
+	numberRuleID, err := domain.StrToInt(ruleID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int ruleID %s", err.Error())
+		return domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the rule id is not a number %s", ruleID))
+	}
+	return q.qualityRuleRepository.DeleteQualityRule(uint(numberRuleID))
+}
+
+// ChekingQualityRule: this function check if the kind, the field and the action of the rule are allowed
+//
+// Parameters:
+// rule: the rule to check
+//
+// Returns:
+// return the rule with the kind, the field and the action in lower case or an error if the rule is not valid
+func ChekingQualityRule(rule domain.QualityRule) (domain.QualityRule, error) {
+	rule.Kind = strings.ToLower(strings.Trim(rule.Kind, " "))
+	rule.Field = strings.ToLower(strings.Trim(rule.Field, " "))
+	rule.Action = strings.ToLower(strings.Trim(rule.Action, " "))
+	switch rule.Kind {
+	case constants.QualityRuleMin, constants.QualityRuleMax, constants.QualityRuleMaxDelta:
+		if !isReadingField(rule.Field) {
+			return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "field", fmt.Sprintf("Error: field not allowed %s", rule.Field))
+		}
+		if rule.Kind == constants.QualityRuleMaxDelta && rule.Value < 0 {
+			return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "value", fmt.Sprintf("Error: the max delta must be positive %f", rule.Value))
+		}
+	case constants.QualityRuleDateRange:
+		if rule.StartDate != nil && rule.EndDate != nil && rule.StartDate.After(*rule.EndDate) {
+			return rule, domain.NewValidationError(constants.ErrorCodeInvalidDateRange, "start_date", "Error: Invalid dates, start date must be before end date")
+		}
+	case constants.QualityRuleMeterExists:
+	default:
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "kind", fmt.Sprintf("Error: quality rule kind not allowed %s", rule.Kind))
+	}
+	switch rule.Action {
+	case constants.QualityActionReject, constants.QualityActionQuarantine, constants.QualityActionFlag:
+	default:
+		return rule, domain.NewValidationError(constants.ErrorCodeInvalidParam, "action", fmt.Sprintf("Error: quality rule action not allowed %s", rule.Action))
+	}
+	return rule, nil
+}
+
+// CheckReading: run the rules of the meter over a reading, the values are compared in kWh and kvarh
+//
+// Parameters:
+// reading: the reading to check
+//
+// Returns:
+// return the rules that the reading does not pass
+func (q *QualityRuleEngine) CheckReading(reading domain.UserConsumption) ([]QualityViolation, error) {
+	var violations []QualityViolation
+	for _, rule := range q.rules {
+		if rule.MeterID != nil && *rule.MeterID != reading.MeterID {
+			continue
+		}
+		reason, err := q.checkRule(rule, reading)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			continue
+		}
+		violations = append(violations, QualityViolation{
+			Rule:   qualityRuleName(rule),
+			Action: rule.Action,
+			Reason: reason,
+		})
+	}
+	return violations, nil
+}
+
+// Accept: keep the reading as the last reading of the meter for the max delta rules
+//
+// Parameters:
+// reading: the accepted reading
+func (q *QualityRuleEngine) Accept(reading domain.UserConsumption) {
+	q.previousReadings[reading.MeterID] = &reading
+}
+
+func (q *QualityRuleEngine) checkRule(rule domain.QualityRule, reading domain.UserConsumption) (string, error) {
+	switch rule.Kind {
+	case constants.QualityRuleMin:
+		if value := readingField(reading, rule.Field); value < rule.Value {
+			return fmt.Sprintf("%s %.3f is lower than %.3f", rule.Field, value, rule.Value), nil
+		}
+	case constants.QualityRuleMax:
+		if value := readingField(reading, rule.Field); value > rule.Value {
+			return fmt.Sprintf("%s %.3f is greater than %.3f", rule.Field, value, rule.Value), nil
+		}
+	case constants.QualityRuleMaxDelta:
+		previous, err := q.previousReading(reading)
+		if err != nil || previous == nil {
+			return "", err
+		}
+		delta := readingField(reading, rule.Field) - readingField(*previous, rule.Field)
+		if delta > rule.Value || -delta > rule.Value {
+			return fmt.Sprintf("%s changed %.3f from the previous reading, the max is %.3f", rule.Field, delta, rule.Value), nil
+		}
+	case constants.QualityRuleDateRange:
+		endDate := q.now
+		if rule.EndDate != nil {
+			endDate = *rule.EndDate
+		}
+		if (rule.StartDate != nil && reading.Date.Before(*rule.StartDate)) || reading.Date.After(endDate) {
+			return fmt.Sprintf("the date %s is out of the allowed range", domain.TimeTostr(reading.Date, constants.DateFormatDateTimeWithTZ)), nil
+		}
+	case constants.QualityRuleMeterExists:
+		exists, err := q.meterExists(reading.MeterID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return fmt.Sprintf("the meter %d does not exist", reading.MeterID), nil
+		}
+	}
+	return "", nil
+}
+
+func (q *QualityRuleEngine) previousReading(reading domain.UserConsumption) (*domain.UserConsumption, error) {
+	if previous, ok := q.previousReadings[reading.MeterID]; ok {
+		return previous, nil
+	}
+	previous, err := q.mysqlRepository.GetLastConsumptionBeforeDate(reading.Date, reading.MeterID)
+	if err != nil {
+		return nil, err
+	}
+	q.previousReadings[reading.MeterID] = previous
+	return previous, nil
+}
+
+func (q *QualityRuleEngine) meterExists(meterID int) (bool, error) {
+	if exists, ok := q.existingMeters[meterID]; ok {
+		return exists, nil
+	}
+	setting, err := q.meterSettingRepository.GetMeterSettingByMeterID(meterID)
+	if err != nil {
+		return false, err
+	}
+	q.existingMeters[meterID] = setting != nil
+	return setting != nil, nil
+}
+
+// StrongestAction: find the action to do with a reading, reject goes before quarantine and quarantine before flag
+//
+// Parameters:
+// violations: the rules that the reading does not pass
+//
+// Returns:
+// return the action or blank if the reading pass all the rules
+func StrongestAction(violations []QualityViolation) string {
+	action := ""
+	for _, violation := range violations {
+		switch {
+		case violation.Action == constants.QualityActionReject:
+			return constants.QualityActionReject
+		case violation.Action == constants.QualityActionQuarantine:
+			action = constants.QualityActionQuarantine
+		case action == "":
+			action = violation.Action
+		}
+	}
+	return action
+}
+
+func qualityRuleName(rule domain.QualityRule) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return rule.Kind
+}
+
+func isReadingField(field string) bool {
+	switch field {
+	case constants.FieldActiveEnergy, constants.FieldReactiveEnergy, constants.FieldCapacitiveReactive, constants.FieldSolar:
+		return true
+	default:
+		return false
+	}
+}
+
+func readingField(reading domain.UserConsumption, field string) float64 {
+	switch field {
+	case constants.FieldActiveEnergy:
+		return reading.ActiveEnergy
+	case constants.FieldReactiveEnergy:
+		return reading.ReactiveEnergy
+	case constants.FieldCapacitiveReactive:
+		return reading.CapacitiveReactive
+	case constants.FieldSolar:
+		return reading.Solar
+	default:
+		return 0
+	}
+}
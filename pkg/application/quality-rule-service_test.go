@@ -0,0 +1,181 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChekingQualityRule", func() {
+	It("should accept a valid rule", func() {
+		rule, err := ChekingQualityRule(domain.QualityRule{Kind: " MIN", Field: "active_energy", Action: "Reject"})
+		Expect(err).To(BeNil())
+		Expect(rule.Kind).To(Equal(constants.QualityRuleMin))
+		Expect(rule.Action).To(Equal(constants.QualityActionReject))
+	})
+
+	It("should return an error for an unknown field, kind or action", func() {
+		_, err := ChekingQualityRule(domain.QualityRule{Kind: "max", Field: "voltage", Action: "flag"})
+		Expect(err).To(HaveOccurred())
+		_, err = ChekingQualityRule(domain.QualityRule{Kind: "median", Field: "solar", Action: "flag"})
+		Expect(err).To(HaveOccurred())
+		_, err = ChekingQualityRule(domain.QualityRule{Kind: "meter_exists", Action: "delete"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("QualityRuleEngine", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		now                  time.Time
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		now = time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("should check the thresholds of the fields", func() {
+		rules := []domain.QualityRule{
+			{Name: "negative", Kind: constants.QualityRuleMin, Field: constants.FieldActiveEnergy, Value: 0, Action: constants.QualityActionReject},
+			{Kind: constants.QualityRuleMax, Field: constants.FieldSolar, Value: 10, Action: constants.QualityActionFlag},
+		}
+		engine := NewQualityRuleEngine(rules, mockMySQLRepo, mockMeterSettingRepo, now)
+
+		violations, err := engine.CheckReading(domain.UserConsumption{ActiveEnergy: -1, Solar: 11, Date: now})
+
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(2))
+		Expect(violations[0].Rule).To(Equal("negative"))
+		Expect(violations[1].Rule).To(Equal(constants.QualityRuleMax))
+		Expect(StrongestAction(violations)).To(Equal(constants.QualityActionReject))
+	})
+
+	It("should only check the rules of the meter", func() {
+		meterID := 2
+		rules := []domain.QualityRule{{MeterID: &meterID, Kind: constants.QualityRuleMin, Field: constants.FieldActiveEnergy, Action: constants.QualityActionReject}}
+		engine := NewQualityRuleEngine(rules, mockMySQLRepo, mockMeterSettingRepo, now)
+
+		violations, err := engine.CheckReading(domain.UserConsumption{MeterID: 1, ActiveEnergy: -1, Date: now})
+
+		Expect(err).To(BeNil())
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should compare with the previous reading", func() {
+		rules := []domain.QualityRule{{Kind: constants.QualityRuleMaxDelta, Field: constants.FieldActiveEnergy, Value: 50, Action: constants.QualityActionQuarantine}}
+		mockMySQLRepo.GetLastConsumptionBeforeDateReturns(&domain.UserConsumption{MeterID: 1, ActiveEnergy: 10}, nil)
+		engine := NewQualityRuleEngine(rules, mockMySQLRepo, mockMeterSettingRepo, now)
+
+		violations, err := engine.CheckReading(domain.UserConsumption{MeterID: 1, ActiveEnergy: 100, Date: now})
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(1))
+
+		engine.Accept(domain.UserConsumption{MeterID: 1, ActiveEnergy: 90, Date: now})
+		violations, err = engine.CheckReading(domain.UserConsumption{MeterID: 1, ActiveEnergy: 100, Date: now.Add(time.Hour)})
+		Expect(err).To(BeNil())
+		Expect(violations).To(BeEmpty())
+		Expect(mockMySQLRepo.GetLastConsumptionBeforeDateCallCount()).To(Equal(1))
+	})
+
+	It("should check the date range and the meter", func() {
+		rules := []domain.QualityRule{
+			{Kind: constants.QualityRuleDateRange, Action: constants.QualityActionReject},
+			{Kind: constants.QualityRuleMeterExists, Action: constants.QualityActionQuarantine},
+		}
+		engine := NewQualityRuleEngine(rules, mockMySQLRepo, mockMeterSettingRepo, now)
+
+		violations, err := engine.CheckReading(domain.UserConsumption{MeterID: 1, Date: now.AddDate(3, 0, 0)})
+
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(2))
+		Expect(violations[1].Reason).To(Equal("the meter 1 does not exist"))
+	})
+})
+
+var _ = Describe("ImportCsvToDatabase with quality rules", func() {
+	var (
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockQualityRuleRepo  *domainfakes.FakeQualityRuleRepository
+		mockQuarantineRepo   *domainfakes.FakeQuarantineRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              PowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockQualityRuleRepo = &domainfakes.FakeQualityRuleRepository{}
+		mockQuarantineRepo = &domainfakes.FakeQuarantineRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		service = NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepo, mockQualityRuleRepo, mockQuarantineRepo, &domainfakes.FakeImportRepository{})
+		mockCSVRepo.ConvertCSVToStructReturns([]*domain.CSVUserConsumption{
+			{ID: "1", MeterID: "1", ActiveEnergy: -5, Date: "2023-08-01"},
+			{ID: "2", MeterID: "1", ActiveEnergy: 5000, Date: "2023-08-02"},
+			{ID: "3", MeterID: "1", ActiveEnergy: 150, Date: "2023-08-03"},
+			{ID: "4", MeterID: "1", ActiveEnergy: 10, Date: "2023-08-04"},
+		}, nil)
+		mockQualityRuleRepo.GetQualityRulesReturns([]domain.QualityRule{
+			{Name: "negative", Kind: constants.QualityRuleMin, Field: constants.FieldActiveEnergy, Value: 0, Action: constants.QualityActionReject},
+			{Name: "spike", Kind: constants.QualityRuleMax, Field: constants.FieldActiveEnergy, Value: 1000, Action: constants.QualityActionQuarantine},
+			{Name: "high", Kind: constants.QualityRuleMax, Field: constants.FieldActiveEnergy, Value: 100, Action: constants.QualityActionFlag},
+		}, nil)
+	})
+
+	It("should reject, quarantine and flag the records", func() {
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{})
+
+		Expect(err).To(BeNil())
+		Expect(summary.Imported).To(Equal(2))
+		Expect(summary.Rejected).To(Equal(1))
+		Expect(summary.Quarantined).To(Equal(1))
+		Expect(summary.Flagged).To(Equal(1))
+
+		records, _, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].Flags).To(Equal("high"))
+		Expect(records[1].Flags).To(BeEmpty())
+
+		_, quarantined, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(quarantined).To(HaveLen(1))
+		Expect(quarantined[0].ActiveEnergy).To(Equal(5000.0))
+		Expect(quarantined[0].Reason).To(ContainSubstring("spike"))
+	})
+
+	It("should quarantine the records that could not be parsed with their raw line", func() {
+		mockCSVRepo.ConvertCSVToStructReturns([]*domain.CSVUserConsumption{
+			{ID: "1", MeterID: "one", ActiveEnergy: 10, Date: "2023-08-01"},
+			{ID: "2", MeterID: "1", ActiveEnergy: 10, Date: "2023-08-02"},
+		}, nil)
+		mockCSVRepo.ConvertStructToCSVLineReturns("1,one,10,0,0,0,2023-08-01", nil)
+
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{FileName: "august.csv"})
+
+		Expect(err).To(BeNil())
+		Expect(summary.Imported).To(Equal(1))
+		Expect(summary.Quarantined).To(Equal(1))
+		Expect(summary.Violations[0].Rule).To(Equal(constants.QualityRuleParse))
+		_, quarantined, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+		Expect(quarantined).To(HaveLen(1))
+		Expect(quarantined[0].RawLine).To(Equal("1,one,10,0,0,0,2023-08-01"))
+		Expect(quarantined[0].Source).To(Equal("august.csv"))
+	})
+
+	It("should return an error when the rules could not be loaded", func() {
+		mockQualityRuleRepo.GetQualityRulesReturns(nil, errors.New("Error getting the rules"))
+
+		summary, err := service.ImportCsvToDatabase(nil, domain.ImportOptions{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(summary).To(BeNil())
+		Expect(mockMySQLRepo.CreateImportRecordsCallCount()).To(Equal(0))
+	})
+})
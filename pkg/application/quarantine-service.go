@@ -0,0 +1,163 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . QuarantineService
+type QuarantineService interface {
+	GetQuarantinedReadings() ([]domain.QuarantinedReading, error)
+	ReleaseQuarantinedReading(readingID string) (*domain.UserConsumption, error)
+	UpdateQuarantinedReading(readingID string, rawLine string) (*domain.QuarantinedReading, error)
+	ResubmitQuarantinedReading(readingID string) (*ImportSummary, error)
+}
+
+type QuarantineServiceImpl struct {
+	quarantineRepository    domain.QuarantineRepository
+	mysqlRepository         domain.MySQLPowerConsumptionRepository
+	csvRepository           domain.CSVPowerConsumptionRepository
+	powerConsumptionService PowerConsumptionService
+}
+
+func NewQuarantineService(quarantineRepository domain.QuarantineRepository, mysqlRepository domain.MySQLPowerConsumptionRepository, csvRepository domain.CSVPowerConsumptionRepository, powerConsumptionService PowerConsumptionService) QuarantineService {
+	return &QuarantineServiceImpl{
+		quarantineRepository,
+		mysqlRepository,
+		csvRepository,
+		powerConsumptionService,
+	}
+}
+
+// GetQuarantinedReadings: get all the readings that did not pass the data quality rules to review them
+//
+// Returns:
+// return all the quarantined readings
+func (q *QuarantineServiceImpl) GetQuarantinedReadings() ([]domain.QuarantinedReading, error) {
+	return q.quarantineRepository.GetQuarantinedReadings()
+}
+
+// ReleaseQuarantinedReading: save a reviewed reading in the user consumption database without checking the rules
+// and remove it from the quarantine
+//
+// Parameters:
+// readingID: the id of the quarantined reading
+//
+// Returns:
+// return the saved reading or an error if the reading could not be released
+func (q *QuarantineServiceImpl) ReleaseQuarantinedReading(readingID string) (*domain.UserConsumption, error) {
+	reading, err := q.getQuarantinedReading(readingID)
+	if err != nil {
+		return nil, err
+	}
+	if reading.RawLine != "" {
+		csvRecord, err := q.csvRepository.ConvertCSVLineToStruct(reading.RawLine)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := csvRecord.ToUserConsumption(); err != nil {
+			logrus.Errorf("Error: the quarantined reading %d could not be parsed %s", reading.ID, err.Error())
+			return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "raw_line", fmt.Sprintf("Error: the quarantined reading %d could not be parsed, edit it before releasing it", reading.ID))
+		}
+	}
+	userConsumption := reading.ToUserConsumption()
+	if _, err := q.mysqlRepository.CreatePowerConsumptionRecords([]*domain.UserConsumption{userConsumption}); err != nil {
+		return nil, err
+	}
+	if err := q.quarantineRepository.DeleteQuarantinedReading(reading.ID); err != nil {
+		return nil, err
+	}
+	logrus.Infof("the quarantined reading %d was released", reading.ID)
+	return userConsumption, nil
+}
+
+// UpdateQuarantinedReading: replace the csv line of a quarantined reading to fix it before re-submitting it, the
+// values of the reading are refreshed from the line when it could be parsed
+//
+// Parameters:
+// readingID: the id of the quarantined reading
+// rawLine: the fixed csv line with the same columns as the imported file
+//
+// Returns:
+// return the updated reading or an error if the line is not a valid csv line
+func (q *QuarantineServiceImpl) UpdateQuarantinedReading(readingID string, rawLine string) (*domain.QuarantinedReading, error) {
+	reading, err := q.getQuarantinedReading(readingID)
+	if err != nil {
+		return nil, err
+	}
+	trimRawLine := strings.Trim(rawLine, " \r\n")
+	if trimRawLine == "" {
+		return nil, domain.NewValidationError(constants.ErrorCodeMissingParam, "raw_line", "Error: the raw line is empty")
+	}
+	csvRecord, err := q.csvRepository.ConvertCSVLineToStruct(trimRawLine)
+	if err != nil {
+		return nil, err
+	}
+	reading.RawLine = trimRawLine
+	if userConsumption, err := csvRecord.ToUserConsumption(); err == nil {
+		unit, err := ChekingUnit(reading.Unit)
+		if err != nil {
+			return nil, err
+		}
+		*userConsumption = unit.FromUnit(*userConsumption)
+		refreshed := domain.NewQuarantinedReading(*userConsumption, reading.Reason)
+		reading.MeterID = refreshed.MeterID
+		reading.ActiveEnergy = refreshed.ActiveEnergy
+		reading.ReactiveEnergy = refreshed.ReactiveEnergy
+		reading.CapacitiveReactive = refreshed.CapacitiveReactive
+		reading.Solar = refreshed.Solar
+		reading.Date = refreshed.Date
+	}
+	if err := q.quarantineRepository.UpdateQuarantinedReading(reading); err != nil {
+		return nil, err
+	}
+	logrus.Infof("the quarantined reading %d was updated", reading.ID)
+	return reading, nil
+}
+
+// ResubmitQuarantinedReading: send the csv line of a quarantined reading through the import again, the data quality
+// rules are checked as in any import and the old quarantined reading is removed
+//
+// Parameters:
+// readingID: the id of the quarantined reading
+//
+// Returns:
+// return the summary of the import or an error if the reading could not be re-submitted
+func (q *QuarantineServiceImpl) ResubmitQuarantinedReading(readingID string) (*ImportSummary, error) {
+	reading, err := q.getQuarantinedReading(readingID)
+	if err != nil {
+		return nil, err
+	}
+	if reading.RawLine == "" {
+		return nil, domain.NewValidationError(constants.ErrorCodeMissingParam, "raw_line", fmt.Sprintf("Error: the quarantined reading %d has no raw line to re-submit", reading.ID))
+	}
+	csvRecord, err := q.csvRepository.ConvertCSVLineToStruct(reading.RawLine)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := q.powerConsumptionService.IngestCSVRecords([]*domain.CSVUserConsumption{csvRecord}, domain.ImportOptions{
+		FileName: reading.Source,
+		Unit:     reading.Unit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := q.quarantineRepository.DeleteQuarantinedReading(reading.ID); err != nil {
+		return nil, err
+	}
+	logrus.Infof("the quarantined reading %d was re-submitted", reading.ID)
+	return summary, nil
+}
+
+func (q *QuarantineServiceImpl) getQuarantinedReading(readingID string) (*domain.QuarantinedReading, error) {
+	numberReadingID, err := domain.StrToInt(readingID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int readingID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the reading id is not a number %s", readingID))
+	}
+	return q.quarantineRepository.GetQuarantinedReadingByID(uint(numberReadingID))
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("ReleaseQuarantinedReading", func() {
+	var (
+		mockQuarantineRepo *domainfakes.FakeQuarantineRepository
+		mockMySQLRepo      *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo        *domainfakes.FakeCSVPowerConsumptionRepository
+		service            QuarantineService
+	)
+
+	BeforeEach(func() {
+		mockQuarantineRepo = &domainfakes.FakeQuarantineRepository{}
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		service = NewQuarantineService(mockQuarantineRepo, mockMySQLRepo, mockCSVRepo, nil)
+	})
+
+	It("should save the reading and remove it from the quarantine", func() {
+		date := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+		mockQuarantineRepo.GetQuarantinedReadingByIDReturns(&domain.QuarantinedReading{Model: gorm.Model{ID: 4}, MeterID: 1, ActiveEnergy: 5000, Date: date}, nil)
+
+		reading, err := service.ReleaseQuarantinedReading("4")
+
+		Expect(err).To(BeNil())
+		Expect(reading.ActiveEnergy).To(Equal(5000.0))
+		records := mockMySQLRepo.CreatePowerConsumptionRecordsArgsForCall(0)
+		Expect(records[0].Date).To(Equal(date))
+		Expect(mockQuarantineRepo.DeleteQuarantinedReadingArgsForCall(0)).To(Equal(uint(4)))
+	})
+
+	It("should keep the reading in the quarantine when it could not be saved", func() {
+		mockQuarantineRepo.GetQuarantinedReadingByIDReturns(&domain.QuarantinedReading{Model: gorm.Model{ID: 4}}, nil)
+		mockMySQLRepo.CreatePowerConsumptionRecordsReturns(nil, errors.New("Error creating records"))
+
+		_, err := service.ReleaseQuarantinedReading("4")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockQuarantineRepo.DeleteQuarantinedReadingCallCount()).To(Equal(0))
+	})
+
+	It("should not release a reading whose raw line could not be parsed", func() {
+		mockQuarantineRepo.GetQuarantinedReadingByIDReturns(&domain.QuarantinedReading{Model: gorm.Model{ID: 4}, RawLine: "1,a,1,1,1,1,2023-08-01"}, nil)
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ID: "1", MeterID: "a", Date: "2023-08-01"}, nil)
+
+		_, err := service.ReleaseQuarantinedReading("4")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockMySQLRepo.CreatePowerConsumptionRecordsCallCount()).To(Equal(0))
+	})
+
+	It("should return an error when the id is not valid", func() {
+		_, err := service.ReleaseQuarantinedReading("four")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockQuarantineRepo.GetQuarantinedReadingByIDCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("UpdateQuarantinedReading and ResubmitQuarantinedReading", func() {
+	var (
+		mockQuarantineRepo   *domainfakes.FakeQuarantineRepository
+		mockMySQLRepo        *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockCSVRepo          *domainfakes.FakeCSVPowerConsumptionRepository
+		mockMeterSettingRepo *domainfakes.FakeMeterSettingRepository
+		service              QuarantineService
+	)
+
+	BeforeEach(func() {
+		mockQuarantineRepo = &domainfakes.FakeQuarantineRepository{}
+		mockMySQLRepo = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockCSVRepo = &domainfakes.FakeCSVPowerConsumptionRepository{}
+		mockMeterSettingRepo = &domainfakes.FakeMeterSettingRepository{}
+		powerConsumptionService := NewPowerConsumptionService(mockMySQLRepo, mockCSVRepo, &domainfakes.FakeMeterGroupRepository{}, mockMeterSettingRepo, &domainfakes.FakeQualityRuleRepository{}, mockQuarantineRepo, &domainfakes.FakeImportRepository{})
+		service = NewQuarantineService(mockQuarantineRepo, mockMySQLRepo, mockCSVRepo, powerConsumptionService)
+		mockQuarantineRepo.GetQuarantinedReadingByIDReturns(&domain.QuarantinedReading{
+			Model:   gorm.Model{ID: 4},
+			RawLine: "1,a,1,1,1,1,2023-08-01",
+			Source:  "august.csv",
+			Unit:    "Wh",
+		}, nil)
+	})
+
+	It("should replace the raw line and refresh the values in kWh", func() {
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ID: "1", MeterID: "1", ActiveEnergy: 2000, Date: "2023-08-01"}, nil)
+
+		reading, err := service.UpdateQuarantinedReading("4", " 1,1,2000,0,0,0,2023-08-01\n")
+
+		Expect(err).To(BeNil())
+		Expect(mockCSVRepo.ConvertCSVLineToStructArgsForCall(0)).To(Equal("1,1,2000,0,0,0,2023-08-01"))
+		Expect(reading.RawLine).To(Equal("1,1,2000,0,0,0,2023-08-01"))
+		Expect(reading.MeterID).To(Equal(1))
+		Expect(reading.ActiveEnergy).To(Equal(2.0))
+		Expect(mockQuarantineRepo.UpdateQuarantinedReadingCallCount()).To(Equal(1))
+	})
+
+	It("should return an error when the raw line is empty", func() {
+		_, err := service.UpdateQuarantinedReading("4", " ")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockQuarantineRepo.UpdateQuarantinedReadingCallCount()).To(Equal(0))
+	})
+
+	It("should send the raw line through the import and remove the old reading", func() {
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ID: "1", MeterID: "1", ActiveEnergy: 3000, Date: "2023-08-01"}, nil)
+		mockMySQLRepo.CreateIngestedRecordsStub = func(records []*domain.UserConsumption, _ []*domain.QuarantinedReading) ([]*domain.UserConsumption, error) {
+			return records, nil
+		}
+
+		summary, err := service.ResubmitQuarantinedReading("4")
+
+		Expect(err).To(BeNil())
+		Expect(summary.Imported).To(Equal(1))
+		records, quarantined := mockMySQLRepo.CreateIngestedRecordsArgsForCall(0)
+		Expect(records[0].ActiveEnergy).To(Equal(3.0))
+		Expect(quarantined).To(BeEmpty())
+		Expect(mockQuarantineRepo.DeleteQuarantinedReadingArgsForCall(0)).To(Equal(uint(4)))
+	})
+
+	It("should keep the reading when the import fails", func() {
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ID: "1", MeterID: "1", ActiveEnergy: 3000, Date: "2023-08-01"}, nil)
+		mockMySQLRepo.CreateIngestedRecordsReturns(nil, errors.New("Error creating records"))
+
+		_, err := service.ResubmitQuarantinedReading("4")
+
+		Expect(err).To(HaveOccurred())
+		Expect(mockQuarantineRepo.DeleteQuarantinedReadingCallCount()).To(Equal(0))
+	})
+
+	It("should quarantine the raw line again in the same write when it could not be parsed", func() {
+		mockCSVRepo.ConvertCSVLineToStructReturns(&domain.CSVUserConsumption{ID: "1", MeterID: "a", ActiveEnergy: 3000, Date: "2023-08-01"}, nil)
+
+		summary, err := service.ResubmitQuarantinedReading("4")
+
+		Expect(err).To(BeNil())
+		Expect(summary.Quarantined).To(Equal(1))
+		Expect(mockMySQLRepo.CreateIngestedRecordsCallCount()).To(Equal(1))
+		records, quarantined := mockMySQLRepo.CreateIngestedRecordsArgsForCall(0)
+		Expect(records).To(BeEmpty())
+		Expect(quarantined).To(HaveLen(1))
+		Expect(mockQuarantineRepo.CreateQuarantinedReadingsCallCount()).To(Equal(0))
+	})
+})
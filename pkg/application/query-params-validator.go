@@ -0,0 +1,261 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+var allowedKindPeriods = []string{
+	constants.PeriodKindMonthly,
+	constants.PeriodKindWeekly,
+	constants.PeriodKindDaily,
+	constants.PeriodKindCalendarWeekly,
+	constants.PeriodKindInterval,
+	constants.PeriodKindBillingCycle,
+}
+
+// maxWindowDays: the longest window time in days that could be asked by kind period, the interval kind is limited
+// by the number of groups of the interval
+var maxWindowDays = map[string]int{
+	constants.PeriodKindDaily:          constants.MaxWindowDaysDaily,
+	constants.PeriodKindWeekly:         constants.MaxWindowDaysWeekly,
+	constants.PeriodKindCalendarWeekly: constants.MaxWindowDaysWeekly,
+	constants.PeriodKindMonthly:        constants.MaxWindowDaysMonthly,
+	constants.PeriodKindBillingCycle:   constants.MaxWindowDaysMonthly,
+}
+
+// QueryParamsValidator: collects every violation of the inputs of a request instead of stopping on the first one,
+// so the clients could fix all the fields in only one try
+type QueryParamsValidator struct {
+	fields []domain.FieldError
+}
+
+func NewQueryParamsValidator() *QueryParamsValidator {
+	return &QueryParamsValidator{}
+}
+
+// Add: record the violation of a field
+//
+// Parameters:
+// field: the name of the field in the request
+// code: the machine readable code of the violation
+// message: the description of the violation
+func (v *QueryParamsValidator) Add(field, code, message string) {
+	logrus.Errorf("Error: the field %s is not valid %s", field, message)
+	v.fields = append(v.fields, domain.FieldError{Field: field, Code: code, Message: message})
+}
+
+// AddError: record the violations of the error of a check, the fields of a validation error are kept and the other
+// errors are recorded in the field
+//
+// Parameters:
+// field: the name of the field in the request
+// err: the error of the check
+func (v *QueryParamsValidator) AddError(field string, err error) {
+	if domainError, ok := domain.AsDomainError(err); ok && len(domainError.Fields) > 0 {
+		for _, fieldError := range domainError.Fields {
+			v.Add(fieldError.Field, fieldError.Code, fieldError.Message)
+		}
+		return
+	}
+	v.Add(field, constants.ErrorCodeInvalidParam, err.Error())
+}
+
+// Valid: tell if there is not any violation recorded
+func (v *QueryParamsValidator) Valid() bool {
+	return len(v.fields) == 0
+}
+
+// Err: build the validation error with all the violations recorded
+//
+// Returns:
+// return nil when there are not violations, the error of the field when there is only one and an invalid_request
+// error with every field when there are several
+func (v *QueryParamsValidator) Err() error {
+	switch len(v.fields) {
+	case 0:
+		return nil
+	case 1:
+		return domain.NewFieldsValidationError(v.fields[0].Code, v.fields[0].Message, v.fields)
+	}
+	var messages []string
+	for _, field := range v.fields {
+		messages = append(messages, field.Message)
+	}
+	return domain.NewFieldsValidationError(constants.ErrorCodeInvalidRequest, strings.Join(messages, "; "), v.fields)
+}
+
+// CheckDate: check a date of the request
+//
+// Parameters:
+// field: the name of the field in the request
+// date: the date with the format 2006-01-02 or 2006-01-02 15:04:05+00
+//
+// Returns:
+// return the date and false if the date is not valid
+func (v *QueryParamsValidator) CheckDate(field, date string) (time.Time, bool) {
+	if strings.Trim(date, " ") == "" {
+		v.Add(field, constants.ErrorCodeMissingParam, fmt.Sprintf("Error: the param %s is required", field))
+		return time.Time{}, false
+	}
+	timeDate, err := domain.StrToDate(date)
+	if err != nil {
+		v.Add(field, constants.ErrorCodeInvalidDate, err.Error())
+		return time.Time{}, false
+	}
+	return timeDate, true
+}
+
+// CheckDateRange: check the start date is not after the end date
+//
+// Parameters:
+// startDate: the start of the window time
+// endDate: the end of the window time
+func (v *QueryParamsValidator) CheckDateRange(startDate, endDate time.Time) bool {
+	if startDate.After(endDate) {
+		v.Add("start_date", constants.ErrorCodeInvalidDateRange, fmt.Sprintf("Error: Invalid dates, start date must be before end date %s %s", domain.TimeTostr(startDate, constants.DateFormatDate), domain.TimeTostr(endDate, constants.DateFormatDate)))
+		return false
+	}
+	return true
+}
+
+// CheckMeterIDs: check the meter ids separated by comma, every id must be a positive number and appears only once
+//
+// Parameters:
+// meterIDs: the meter ids separated by comma
+//
+// Returns:
+// return the meter ids in the same order of the request
+func (v *QueryParamsValidator) CheckMeterIDs(meterIDs string) []int {
+	return v.checkMeterIDs(meterIDs, true)
+}
+
+// CheckDistinctMeterIDs: check the meter ids separated by comma like CheckMeterIDs but the repeated ids are removed
+// instead of being rejected
+//
+// Parameters:
+// meterIDs: the meter ids separated by comma
+//
+// Returns:
+// return the meter ids without duplicates in the order they appear first
+func (v *QueryParamsValidator) CheckDistinctMeterIDs(meterIDs string) []int {
+	return v.checkMeterIDs(meterIDs, false)
+}
+
+func (v *QueryParamsValidator) checkMeterIDs(meterIDs string, rejectDuplicates bool) []int {
+	if strings.Trim(meterIDs, " ") == "" {
+		v.Add("meter_ids", constants.ErrorCodeMissingParam, "Error: the meter ids are empty")
+		return nil
+	}
+	var numberMeterIDs []int
+	seenMeterIDs := map[int]bool{}
+	for position, meterID := range strings.Split(meterIDs, ",") {
+		trimMeterID := strings.Trim(meterID, " ")
+		if trimMeterID == "" {
+			v.Add("meter_ids", constants.ErrorCodeInvalidMeterID, fmt.Sprintf("Error: the meter id in the position %d is empty", position+1))
+			continue
+		}
+		numberMeterID, err := domain.StrToInt(trimMeterID)
+		if err != nil {
+			v.Add("meter_ids", constants.ErrorCodeInvalidMeterID, fmt.Sprintf("Error: the meter id is not a number %s", trimMeterID))
+			continue
+		}
+		if numberMeterID <= 0 {
+			v.Add("meter_ids", constants.ErrorCodeInvalidMeterID, fmt.Sprintf("Error: the meter id must be greater than zero %d", numberMeterID))
+			continue
+		}
+		if seenMeterIDs[numberMeterID] {
+			if !rejectDuplicates {
+				continue
+			}
+			v.Add("meter_ids", constants.ErrorCodeDuplicateMeterID, fmt.Sprintf("Error: the meter id is duplicated %d", numberMeterID))
+			continue
+		}
+		seenMeterIDs[numberMeterID] = true
+		numberMeterIDs = append(numberMeterIDs, numberMeterID)
+	}
+	return numberMeterIDs
+}
+
+// CheckGroupID: check the id of a group of meters
+//
+// Parameters:
+// groupID: the id of the group
+//
+// Returns:
+// return the id of the group and false if it's not a positive number
+func (v *QueryParamsValidator) CheckGroupID(groupID string) (uint, bool) {
+	numberGroupID, err := domain.StrToInt(strings.Trim(groupID, " "))
+	if err != nil {
+		v.Add("group_id", constants.ErrorCodeInvalidParam, fmt.Sprintf("Error: the group id is not a number %s", groupID))
+		return 0, false
+	}
+	if numberGroupID <= 0 {
+		v.Add("group_id", constants.ErrorCodeInvalidParam, fmt.Sprintf("Error: the group id must be greater than zero %d", numberGroupID))
+		return 0, false
+	}
+	return uint(numberGroupID), true
+}
+
+// CheckDemandWindow: check the length and the kind of the demand window
+//
+// Parameters:
+// demandWindow: the length of the demand window, 15m by default
+// windowType: fixed or rolling, fixed by default
+//
+// Returns:
+// return the length and the kind of the demand window and false if one of them is not allowed
+func (v *QueryParamsValidator) CheckDemandWindow(demandWindow, windowType string) (time.Duration, string, bool) {
+	windowLength, checkedWindowType, err := ChekingDemandWindow(demandWindow, windowType)
+	if err != nil {
+		v.AddError("demand_window", err)
+		return 0, "", false
+	}
+	return windowLength, checkedWindowType, true
+}
+
+// CheckKindPeriod: check the kind period is one of the allowed
+//
+// Parameters:
+// kindPeriod: the kind period of the request
+// allowed: the kind periods allowed, all of them when it's empty
+//
+// Returns:
+// return the kind period in lower case and false if it's not allowed
+func (v *QueryParamsValidator) CheckKindPeriod(kindPeriod string, allowed ...string) (string, bool) {
+	trimAndLowerCaseKindPeriod := strings.ToLower(strings.Trim(kindPeriod, " "))
+	if len(allowed) == 0 {
+		allowed = allowedKindPeriods
+	}
+	for _, allowedKindPeriod := range allowed {
+		if trimAndLowerCaseKindPeriod == allowedKindPeriod {
+			return trimAndLowerCaseKindPeriod, true
+		}
+	}
+	v.Add("kind_period", constants.ErrorCodeInvalidKindPeriod, fmt.Sprintf("Error: kind period not allowed %s", trimAndLowerCaseKindPeriod))
+	return "", false
+}
+
+// CheckWindowSize: check the window time is not larger than the limit of its kind period, a long window with a short
+// period kind builds series too large to be answered
+//
+// Parameters:
+// kindPeriod: the kind period already checked
+// startDate: the start of the window time
+// endDate: the end of the window time
+func (v *QueryParamsValidator) CheckWindowSize(kindPeriod string, startDate, endDate time.Time) bool {
+	maxDays, ok := maxWindowDays[kindPeriod]
+	if !ok {
+		return true
+	}
+	if endDate.Sub(startDate) > time.Duration(maxDays)*24*time.Hour {
+		v.Add("end_date", constants.ErrorCodeWindowTooLarge, fmt.Sprintf("Error: the window time is too large for the kind period %s, the limit is %d days", kindPeriod, maxDays))
+		return false
+	}
+	return true
+}
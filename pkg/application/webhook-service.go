@@ -0,0 +1,409 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . WebhookSender
+type WebhookSender interface {
+	Send(url string, headers map[string]string, body []byte) (int, error)
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . WebhookService
+type WebhookService interface {
+	CreateWebhookSubscription(subscription domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetWebhookSubscriptions() ([]domain.WebhookSubscription, error)
+	UpdateWebhookSubscriptionActive(subscriptionID string, active bool) (*domain.WebhookSubscription, error)
+	DeleteWebhookSubscription(subscriptionID string) error
+	GetWebhookDeliveries(subscriptionID string) ([]domain.WebhookDelivery, error)
+	DeliverDueWebhooks() (int, error)
+	Name() string
+	Publish(envelope EventEnvelope) error
+}
+
+type WebhookServiceImpl struct {
+	webhookRepository domain.WebhookRepository
+	sender            WebhookSender
+}
+
+type webhookEventMeters struct {
+	MeterIDs []int `json:"meter_ids"`
+	MeterID  *int  `json:"meter_id"`
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository, sender WebhookSender) WebhookService {
+	return &WebhookServiceImpl{
+		webhookRepository,
+		sender,
+	}
+}
+
+// CreateWebhookSubscription: check and create an active webhook subscription, a secret to sign the payloads is
+// generated when the subscription does not have one
+//
+// Parameters:
+// subscription: the url, the events and the meters of the subscription, blank meters to receive the events of all
+// the meters
+//
+// Returns:
+// return the created subscription with its secret or an error if the subscription is not valid
+func (w *WebhookServiceImpl) CreateWebhookSubscription(subscription domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	subscription, err := ChekingWebhookSubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Secret == "" {
+		secret := make([]byte, constants.WebhookSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+	subscription.Active = true
+	if err := w.webhookRepository.CreateWebhookSubscription(&subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetWebhookSubscriptions: get all the webhook subscriptions without their secrets
+//
+// Returns:
+// return all the webhook subscriptions
+func (w *WebhookServiceImpl) GetWebhookSubscriptions() ([]domain.WebhookSubscription, error) {
+	subscriptions, err := w.webhookRepository.GetWebhookSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// UpdateWebhookSubscriptionActive: activate or deactivate a webhook subscription, an inactive subscription does not
+// receive new events and its pending deliveries are not sent
+//
+// Parameters:
+// subscriptionID: the id of the subscription
+// active: true to activate the subscription and false to deactivate it
+//
+// Returns:
+// return the updated subscription without its secret or an error if the subscription does not exist
+func (w *WebhookServiceImpl) UpdateWebhookSubscriptionActive(subscriptionID string, active bool) (*domain.WebhookSubscription, error) {
+	numberSubscriptionID, err := domain.StrToInt(subscriptionID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int subscriptionID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the subscription id is not a number %s", subscriptionID))
+	}
+	subscription, err := w.webhookRepository.GetWebhookSubscriptionByID(uint(numberSubscriptionID))
+	if err != nil {
+		return nil, err
+	}
+	subscription.Active = active
+	if err := w.webhookRepository.UpdateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// DeleteWebhookSubscription: delete a webhook subscription, its pending deliveries are not sent anymore
+//
+// Parameters:
+// subscriptionID: the id of the subscription
+//
+// Returns:
+// return an error if the subscription could not be deleted
+func (w *WebhookServiceImpl) DeleteWebhookSubscription(subscriptionID string) error {
+	numberSubscriptionID, err := domain.StrToInt(subscriptionID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int subscriptionID %s", err.Error())
+		return domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the subscription id is not a number %s", subscriptionID))
+	}
+	return w.webhookRepository.DeleteWebhookSubscription(uint(numberSubscriptionID))
+}
+
+// GetWebhookDeliveries: get the delivery log of a webhook subscription
+//
+// Parameters:
+// subscriptionID: the id of the subscription
+//
+// Returns:
+// return the deliveries of the subscription, the newest first, or an error if the subscription does not exist
+func (w *WebhookServiceImpl) GetWebhookDeliveries(subscriptionID string) ([]domain.WebhookDelivery, error) {
+	numberSubscriptionID, err := domain.StrToInt(subscriptionID)
+	if err != nil {
+		logrus.Errorf("Error: converting str to int subscriptionID %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "id", fmt.Sprintf("Error: the subscription id is not a number %s", subscriptionID))
+	}
+	if _, err := w.webhookRepository.GetWebhookSubscriptionByID(uint(numberSubscriptionID)); err != nil {
+		return nil, err
+	}
+	return w.webhookRepository.GetWebhookDeliveriesBySubscriptionID(uint(numberSubscriptionID))
+}
+
+func (w *WebhookServiceImpl) Name() string {
+	return "webhook-subscriptions"
+}
+
+// Publish: queue a delivery of the event for every subscription to its kind and to one of its meters, the
+// deliveries are sent by DeliverDueWebhooks
+//
+// Parameters:
+// envelope: the event of the outbox
+//
+// Returns:
+// return an error if the deliveries could not be queued
+func (w *WebhookServiceImpl) Publish(envelope EventEnvelope) error {
+	subscriptions, err := w.webhookRepository.GetWebhookSubscriptions()
+	if err != nil {
+		return err
+	}
+	var eventMeters webhookEventMeters
+	if err := json.Unmarshal(envelope.Data, &eventMeters); err != nil {
+		return fmt.Errorf("Error: reading the meters of the event %d %s", envelope.ID, err.Error())
+	}
+	if eventMeters.MeterID != nil {
+		eventMeters.MeterIDs = append(eventMeters.MeterIDs, *eventMeters.MeterID)
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	var deliveries []*domain.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !webhookSubscriptionMatches(subscription, envelope.Kind, eventMeters.MeterIDs) {
+			continue
+		}
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        envelope.ID,
+			Kind:           envelope.Kind,
+			Payload:        string(payload),
+			Status:         constants.WebhookStatusPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	return w.webhookRepository.CreateWebhookDeliveries(deliveries)
+}
+
+// DeliverDueWebhooks: send the deliveries whose next attempt is due, a failed delivery is tried again later with an
+// exponential backoff until it reaches the max number of attempts
+//
+// Returns:
+// return the number of deliveries that were sent or an error if the deliveries could not be read or updated
+func (w *WebhookServiceImpl) DeliverDueWebhooks() (int, error) {
+	now := time.Now().UTC()
+	deliveries, err := w.webhookRepository.GetDueWebhookDeliveries(now, constants.WebhookBatchSize)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+	subscriptions, err := w.webhookRepository.GetWebhookSubscriptions()
+	if err != nil {
+		return 0, err
+	}
+	subscriptionsByID := make(map[uint]domain.WebhookSubscription)
+	for _, subscription := range subscriptions {
+		subscriptionsByID[subscription.ID] = subscription
+	}
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		subscription, ok := subscriptionsByID[delivery.SubscriptionID]
+		if !ok {
+			delivery.Status = constants.WebhookStatusFailed
+			delivery.LastError = "the subscription was deleted"
+			delivery.NextAttemptAt = nil
+		} else if !subscription.Active {
+			delivery.Status = constants.WebhookStatusFailed
+			delivery.LastError = "the subscription is not active"
+			delivery.NextAttemptAt = nil
+		} else if w.deliver(subscription, delivery, now) {
+			delivered++
+		}
+		if err := w.webhookRepository.UpdateWebhookDelivery(delivery); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// deliver: send a delivery signed with the secret of its subscription and update its status
+//
+// Parameters:
+// subscription: the subscription of the delivery
+// delivery: the delivery to send
+// now: the time of the attempt
+//
+// Returns:
+// return true if the webhook received the delivery
+func (w *WebhookServiceImpl) deliver(subscription domain.WebhookSubscription, delivery *domain.WebhookDelivery, now time.Time) bool {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	headers := map[string]string{
+		constants.WebhookEventHeader:     delivery.Kind,
+		constants.WebhookDeliveryHeader:  strconv.FormatUint(uint64(delivery.ID), 10),
+		constants.WebhookTimestampHeader: timestamp,
+		constants.WebhookSignatureHeader: SignWebhookPayload(subscription.Secret, timestamp, []byte(delivery.Payload)),
+	}
+	statusCode, err := w.sender.Send(subscription.URL, headers, []byte(delivery.Payload))
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	if err == nil && statusCode >= 200 && statusCode <= 299 {
+		delivery.Status = constants.WebhookStatusDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		return true
+	}
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = fmt.Sprintf("the webhook answered with the status %d", statusCode)
+	}
+	logrus.Errorf("Error: sending the delivery %d to %s %s", delivery.ID, subscription.URL, delivery.LastError)
+	if delivery.Attempts >= constants.WebhookMaxAttempts {
+		delivery.Status = constants.WebhookStatusFailed
+		delivery.NextAttemptAt = nil
+		return false
+	}
+	nextAttemptAt := now.Add(WebhookRetryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &nextAttemptAt
+	return false
+}
+
+// SignWebhookPayload: sign the timestamp and the payload of a delivery with HMAC SHA256, the receivers check the
+// signature with the secret of the subscription and reject old timestamps to avoid replays
+//
+// Parameters:
+// secret: the secret of the subscription
+// timestamp: the unix time of the attempt
+// payload: the body of the delivery
+//
+// Returns:
+// return the signature like sha256=<hex>
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookRetryDelay: the time to wait before the next attempt of a delivery, it doubles with every attempt until the
+// max delay
+//
+// Parameters:
+// attempts: the attempts already done
+//
+// Returns:
+// return the delay of the next attempt
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := constants.WebhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= constants.WebhookRetryMaxDelay {
+			return constants.WebhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// ChekingWebhookSubscription: this function check the url, the events and the meters of a webhook subscription
+//
+// Parameters:
+// subscription: the subscription to check
+//
+// Returns:
+// return the subscription with the events in lower case or an error if the subscription is not valid
+func ChekingWebhookSubscription(subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	subscriptionURL, err := url.Parse(subscription.URL)
+	if err != nil || (subscriptionURL.Scheme != "http" && subscriptionURL.Scheme != "https") || subscriptionURL.Host == "" {
+		return subscription, domain.NewValidationError(constants.ErrorCodeInvalidParam, "url", fmt.Sprintf("Error: url not allowed %s", subscription.URL))
+	}
+	var events []string
+	for _, event := range strings.Split(subscription.Events, ",") {
+		event = strings.ToLower(strings.Trim(event, " "))
+		switch event {
+		case constants.EventImportCompleted, constants.EventAnomalyDetected, constants.EventThresholdExceeded:
+			events = append(events, event)
+		default:
+			return subscription, domain.NewValidationError(constants.ErrorCodeInvalidParam, "events", fmt.Sprintf("Error: event not allowed %s", event))
+		}
+	}
+	subscription.Events = strings.Join(events, ",")
+	if subscription.MeterIDs != "" {
+		if _, err := webhookMeterIDs(subscription.MeterIDs); err != nil {
+			return subscription, err
+		}
+	}
+	return subscription, nil
+}
+
+// webhookSubscriptionMatches: check if a subscription receives an event
+//
+// Parameters:
+// subscription: the subscription
+// kind: the kind of the event
+// meterIDs: the meters of the event
+//
+// Returns:
+// return true if the subscription is active and it is to the kind and to all the meters or to one of the meters of
+// the event
+func webhookSubscriptionMatches(subscription domain.WebhookSubscription, kind string, meterIDs []int) bool {
+	if !subscription.Active {
+		return false
+	}
+	subscribed := false
+	for _, event := range strings.Split(subscription.Events, ",") {
+		if event == kind {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if subscription.MeterIDs == "" {
+		return true
+	}
+	subscriptionMeterIDs, err := webhookMeterIDs(subscription.MeterIDs)
+	if err != nil {
+		return false
+	}
+	for _, subscriptionMeterID := range subscriptionMeterIDs {
+		for _, meterID := range meterIDs {
+			if subscriptionMeterID == meterID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// webhookMeterIDs: convert the meters of a subscription separated by commas
+func webhookMeterIDs(meterIDs string) ([]int, error) {
+	var numberMeterIDs []int
+	for _, meterID := range strings.Split(meterIDs, ",") {
+		numberMeterID, err := domain.StrToInt(strings.Trim(meterID, " "))
+		if err != nil {
+			return nil, domain.NewValidationError(constants.ErrorCodeInvalidMeterID, "meter_ids", fmt.Sprintf("Error: meter id not allowed %s", meterID))
+		}
+		numberMeterIDs = append(numberMeterIDs, numberMeterID)
+	}
+	return numberMeterIDs, nil
+}
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+// standInWebhookSender: keeps the sent requests, the fakes of the package can not be used here by the import cycle
+type standInWebhookSender struct {
+	headers    []map[string]string
+	bodies     [][]byte
+	statusCode int
+	err        error
+}
+
+func (s *standInWebhookSender) Send(url string, headers map[string]string, body []byte) (int, error) {
+	s.headers = append(s.headers, headers)
+	s.bodies = append(s.bodies, body)
+	return s.statusCode, s.err
+}
+
+var _ = Describe("WebhookService", func() {
+	var (
+		mockWebhookRepo *domainfakes.FakeWebhookRepository
+		sender          *standInWebhookSender
+		service         WebhookService
+	)
+
+	BeforeEach(func() {
+		mockWebhookRepo = &domainfakes.FakeWebhookRepository{}
+		sender = &standInWebhookSender{statusCode: http.StatusOK}
+		service = NewWebhookService(mockWebhookRepo, sender)
+		mockWebhookRepo.GetWebhookSubscriptionsReturns([]domain.WebhookSubscription{
+			{Model: gorm.Model{ID: 1}, URL: "https://partner.test/hook", Secret: "s3cret", Events: "import_completed", Active: true},
+			{Model: gorm.Model{ID: 2}, URL: "https://other.test/hook", Secret: "other", Events: "import_completed,anomaly_detected", MeterIDs: "2,3", Active: true},
+		}, nil)
+	})
+
+	Context("CreateWebhookSubscription", func() {
+		It("should generate a secret when the subscription does not have one", func() {
+			subscription, err := service.CreateWebhookSubscription(domain.WebhookSubscription{URL: "https://partner.test/hook", Events: "Import_Completed"})
+			Expect(err).To(BeNil())
+			Expect(subscription.Events).To(Equal(constants.EventImportCompleted))
+			Expect(subscription.Secret).To(HaveLen(2 * constants.WebhookSecretSize))
+			Expect(subscription.Active).To(BeTrue())
+			Expect(mockWebhookRepo.CreateWebhookSubscriptionCallCount()).To(Equal(1))
+		})
+
+		It("should reject unknown events and urls that are not http", func() {
+			_, err := service.CreateWebhookSubscription(domain.WebhookSubscription{URL: "https://partner.test/hook", Events: "readings_deleted"})
+			Expect(err).ToNot(BeNil())
+			_, err = service.CreateWebhookSubscription(domain.WebhookSubscription{URL: "ftp://partner.test", Events: "import_completed"})
+			Expect(err).ToNot(BeNil())
+			_, err = service.CreateWebhookSubscription(domain.WebhookSubscription{URL: "https://partner.test/hook", Events: "import_completed", MeterIDs: "1,a"})
+			Expect(err).ToNot(BeNil())
+			Expect(mockWebhookRepo.CreateWebhookSubscriptionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("UpdateWebhookSubscriptionActive", func() {
+		It("should deactivate the subscription without returning its secret", func() {
+			mockWebhookRepo.GetWebhookSubscriptionByIDReturns(&domain.WebhookSubscription{Model: gorm.Model{ID: 1}, Secret: "s3cret", Active: true}, nil)
+			subscription, err := service.UpdateWebhookSubscriptionActive("1", false)
+			Expect(err).To(BeNil())
+			Expect(subscription.Active).To(BeFalse())
+			Expect(subscription.Secret).To(BeEmpty())
+			Expect(mockWebhookRepo.GetWebhookSubscriptionByIDArgsForCall(0)).To(Equal(uint(1)))
+			Expect(mockWebhookRepo.UpdateWebhookSubscriptionArgsForCall(0).Active).To(BeFalse())
+		})
+
+		It("should return the error of a subscription that does not exist", func() {
+			mockWebhookRepo.GetWebhookSubscriptionByIDReturns(nil, domain.NewNotFoundError(constants.ErrorCodeNotFound, "Error: the record was not found", nil))
+			_, err := service.UpdateWebhookSubscriptionActive("9", false)
+			Expect(err).ToNot(BeNil())
+			Expect(mockWebhookRepo.UpdateWebhookSubscriptionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("Publish", func() {
+		It("should queue a delivery for the subscriptions to the kind and the meters of the event", func() {
+			err := service.Publish(EventEnvelope{ID: 9, Kind: constants.EventImportCompleted, Data: json.RawMessage(`{"import_id":7,"meter_ids":[1]}`)})
+			Expect(err).To(BeNil())
+			deliveries := mockWebhookRepo.CreateWebhookDeliveriesArgsForCall(0)
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].SubscriptionID).To(Equal(uint(1)))
+			Expect(deliveries[0].EventID).To(Equal(uint(9)))
+			Expect(deliveries[0].Status).To(Equal(constants.WebhookStatusPending))
+		})
+
+		It("should not queue deliveries for the inactive subscriptions", func() {
+			mockWebhookRepo.GetWebhookSubscriptionsReturns([]domain.WebhookSubscription{
+				{Model: gorm.Model{ID: 1}, URL: "https://partner.test/hook", Secret: "s3cret", Events: "import_completed"},
+			}, nil)
+			err := service.Publish(EventEnvelope{ID: 9, Kind: constants.EventImportCompleted, Data: json.RawMessage(`{"import_id":7,"meter_ids":[1]}`)})
+			Expect(err).To(BeNil())
+			Expect(mockWebhookRepo.CreateWebhookDeliveriesArgsForCall(0)).To(BeEmpty())
+		})
+
+		It("should not queue deliveries for the kinds nobody is subscribed to", func() {
+			err := service.Publish(EventEnvelope{ID: 10, Kind: constants.EventReadingsImported, Data: json.RawMessage(`{"meter_ids":[2]}`)})
+			Expect(err).To(BeNil())
+			Expect(mockWebhookRepo.CreateWebhookDeliveriesArgsForCall(0)).To(BeEmpty())
+		})
+	})
+
+	Context("DeliverDueWebhooks", func() {
+		BeforeEach(func() {
+			mockWebhookRepo.GetDueWebhookDeliveriesReturns([]domain.WebhookDelivery{
+				{Model: gorm.Model{ID: 4}, SubscriptionID: 1, EventID: 9, Kind: constants.EventImportCompleted, Payload: `{"id":9}`, Status: constants.WebhookStatusPending},
+			}, nil)
+		})
+
+		It("should send the signed payload and mark the delivery as delivered", func() {
+			delivered, err := service.DeliverDueWebhooks()
+			Expect(err).To(BeNil())
+			Expect(delivered).To(Equal(1))
+			headers := sender.headers[0]
+			Expect(headers[constants.WebhookEventHeader]).To(Equal(constants.EventImportCompleted))
+			Expect(headers[constants.WebhookDeliveryHeader]).To(Equal("4"))
+			Expect(headers[constants.WebhookSignatureHeader]).To(Equal(SignWebhookPayload("s3cret", headers[constants.WebhookTimestampHeader], []byte(`{"id":9}`))))
+			updated := mockWebhookRepo.UpdateWebhookDeliveryArgsForCall(0)
+			Expect(updated.Status).To(Equal(constants.WebhookStatusDelivered))
+			Expect(updated.Attempts).To(Equal(1))
+			Expect(updated.DeliveredAt).ToNot(BeNil())
+		})
+
+		It("should schedule a new attempt when the webhook fails", func() {
+			sender.statusCode = http.StatusServiceUnavailable
+			delivered, err := service.DeliverDueWebhooks()
+			Expect(err).To(BeNil())
+			Expect(delivered).To(Equal(0))
+			updated := mockWebhookRepo.UpdateWebhookDeliveryArgsForCall(0)
+			Expect(updated.Status).To(Equal(constants.WebhookStatusPending))
+			Expect(updated.StatusCode).To(Equal(http.StatusServiceUnavailable))
+			Expect(updated.NextAttemptAt.Sub(time.Now())).To(BeNumerically("~", constants.WebhookRetryBaseDelay, time.Second))
+		})
+
+		It("should give up after the max number of attempts", func() {
+			sender.statusCode = 0
+			sender.err = errors.New("connection refused")
+			mockWebhookRepo.GetDueWebhookDeliveriesReturns([]domain.WebhookDelivery{
+				{Model: gorm.Model{ID: 4}, SubscriptionID: 1, Attempts: constants.WebhookMaxAttempts - 1, Status: constants.WebhookStatusPending},
+			}, nil)
+			_, err := service.DeliverDueWebhooks()
+			Expect(err).To(BeNil())
+			updated := mockWebhookRepo.UpdateWebhookDeliveryArgsForCall(0)
+			Expect(updated.Status).To(Equal(constants.WebhookStatusFailed))
+			Expect(updated.LastError).To(Equal("connection refused"))
+			Expect(updated.NextAttemptAt).To(BeNil())
+		})
+
+		It("should fail the deliveries of deleted subscriptions without sending them", func() {
+			mockWebhookRepo.GetWebhookSubscriptionsReturns(nil, nil)
+			_, err := service.DeliverDueWebhooks()
+			Expect(err).To(BeNil())
+			Expect(sender.bodies).To(BeEmpty())
+			Expect(mockWebhookRepo.UpdateWebhookDeliveryArgsForCall(0).Status).To(Equal(constants.WebhookStatusFailed))
+		})
+
+		It("should fail the deliveries of inactive subscriptions without sending them", func() {
+			mockWebhookRepo.GetWebhookSubscriptionsReturns([]domain.WebhookSubscription{{Model: gorm.Model{ID: 1}, Secret: "s3cret"}}, nil)
+			_, err := service.DeliverDueWebhooks()
+			Expect(err).To(BeNil())
+			Expect(sender.bodies).To(BeEmpty())
+			updated := mockWebhookRepo.UpdateWebhookDeliveryArgsForCall(0)
+			Expect(updated.Status).To(Equal(constants.WebhookStatusFailed))
+			Expect(updated.LastError).To(Equal("the subscription is not active"))
+		})
+	})
+
+	Context("WebhookRetryDelay", func() {
+		It("should double the delay until the max delay", func() {
+			Expect(WebhookRetryDelay(1)).To(Equal(constants.WebhookRetryBaseDelay))
+			Expect(WebhookRetryDelay(3)).To(Equal(4 * constants.WebhookRetryBaseDelay))
+			Expect(WebhookRetryDelay(20)).To(Equal(constants.WebhookRetryMaxDelay))
+		})
+	})
+})
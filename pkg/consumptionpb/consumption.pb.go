@@ -0,0 +1,938 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: consumption/v1/consumption.proto
+
+package consumptionpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConsumptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MeterIds []int32 `protobuf:"varint,1,rep,packed,name=meter_ids,json=meterIds,proto3" json:"meter_ids,omitempty"`
+	// start_date and end_date are inclusive dates like 2023-08-01.
+	StartDate string `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// kind_period could be monthly, weekly, daily, calendar_weekly, interval or billing_cycle.
+	KindPeriod string `protobuf:"bytes,4,opt,name=kind_period,json=kindPeriod,proto3" json:"kind_period,omitempty"`
+	// aggregations separated by commas like max,p95.
+	Aggregations    string `protobuf:"bytes,5,opt,name=aggregations,proto3" json:"aggregations,omitempty"`
+	WeekStart       string `protobuf:"bytes,6,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	Interval        string `protobuf:"bytes,7,opt,name=interval,proto3" json:"interval,omitempty"`
+	BillingCycleDay int32  `protobuf:"varint,8,opt,name=billing_cycle_day,json=billingCycleDay,proto3" json:"billing_cycle_day,omitempty"`
+	Unit            string `protobuf:"bytes,9,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (x *ConsumptionRequest) Reset() {
+	*x = ConsumptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsumptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumptionRequest) ProtoMessage() {}
+
+func (x *ConsumptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumptionRequest.ProtoReflect.Descriptor instead.
+func (*ConsumptionRequest) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConsumptionRequest) GetMeterIds() []int32 {
+	if x != nil {
+		return x.MeterIds
+	}
+	return nil
+}
+
+func (x *ConsumptionRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetKindPeriod() string {
+	if x != nil {
+		return x.KindPeriod
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetAggregations() string {
+	if x != nil {
+		return x.Aggregations
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetWeekStart() string {
+	if x != nil {
+		return x.WeekStart
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *ConsumptionRequest) GetBillingCycleDay() int32 {
+	if x != nil {
+		return x.BillingCycleDay
+	}
+	return 0
+}
+
+func (x *ConsumptionRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type Series struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Active             []float64 `protobuf:"fixed64,1,rep,packed,name=active,proto3" json:"active,omitempty"`
+	ReactiveInductive  []float64 `protobuf:"fixed64,2,rep,packed,name=reactive_inductive,json=reactiveInductive,proto3" json:"reactive_inductive,omitempty"`
+	ReactiveCapacitive []float64 `protobuf:"fixed64,3,rep,packed,name=reactive_capacitive,json=reactiveCapacitive,proto3" json:"reactive_capacitive,omitempty"`
+	Exported           []float64 `protobuf:"fixed64,4,rep,packed,name=exported,proto3" json:"exported,omitempty"`
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Series) GetActive() []float64 {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+func (x *Series) GetReactiveInductive() []float64 {
+	if x != nil {
+		return x.ReactiveInductive
+	}
+	return nil
+}
+
+func (x *Series) GetReactiveCapacitive() []float64 {
+	if x != nil {
+		return x.ReactiveCapacitive
+	}
+	return nil
+}
+
+func (x *Series) GetExported() []float64 {
+	if x != nil {
+		return x.Exported
+	}
+	return nil
+}
+
+type MeterConsumption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MeterId      int32              `protobuf:"varint,1,opt,name=meter_id,json=meterId,proto3" json:"meter_id,omitempty"`
+	Period       []string           `protobuf:"bytes,2,rep,name=period,proto3" json:"period,omitempty"`
+	Consumption  *Series            `protobuf:"bytes,3,opt,name=consumption,proto3" json:"consumption,omitempty"`
+	Aggregations map[string]*Series `protobuf:"bytes,4,rep,name=aggregations,proto3" json:"aggregations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Unit         string             `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	ReactiveUnit string             `protobuf:"bytes,6,opt,name=reactive_unit,json=reactiveUnit,proto3" json:"reactive_unit,omitempty"`
+}
+
+func (x *MeterConsumption) Reset() {
+	*x = MeterConsumption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeterConsumption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeterConsumption) ProtoMessage() {}
+
+func (x *MeterConsumption) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeterConsumption.ProtoReflect.Descriptor instead.
+func (*MeterConsumption) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MeterConsumption) GetMeterId() int32 {
+	if x != nil {
+		return x.MeterId
+	}
+	return 0
+}
+
+func (x *MeterConsumption) GetPeriod() []string {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *MeterConsumption) GetConsumption() *Series {
+	if x != nil {
+		return x.Consumption
+	}
+	return nil
+}
+
+func (x *MeterConsumption) GetAggregations() map[string]*Series {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *MeterConsumption) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MeterConsumption) GetReactiveUnit() string {
+	if x != nil {
+		return x.ReactiveUnit
+	}
+	return ""
+}
+
+type ConsumptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Meters []*MeterConsumption `protobuf:"bytes,1,rep,name=meters,proto3" json:"meters,omitempty"`
+}
+
+func (x *ConsumptionResponse) Reset() {
+	*x = ConsumptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsumptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumptionResponse) ProtoMessage() {}
+
+func (x *ConsumptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumptionResponse.ProtoReflect.Descriptor instead.
+func (*ConsumptionResponse) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConsumptionResponse) GetMeters() []*MeterConsumption {
+	if x != nil {
+		return x.Meters
+	}
+	return nil
+}
+
+type Reading struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MeterId            int32   `protobuf:"varint,2,opt,name=meter_id,json=meterId,proto3" json:"meter_id,omitempty"`
+	ActiveEnergy       float64 `protobuf:"fixed64,3,opt,name=active_energy,json=activeEnergy,proto3" json:"active_energy,omitempty"`
+	ReactiveEnergy     float64 `protobuf:"fixed64,4,opt,name=reactive_energy,json=reactiveEnergy,proto3" json:"reactive_energy,omitempty"`
+	CapacitiveReactive float64 `protobuf:"fixed64,5,opt,name=capacitive_reactive,json=capacitiveReactive,proto3" json:"capacitive_reactive,omitempty"`
+	Solar              float64 `protobuf:"fixed64,6,opt,name=solar,proto3" json:"solar,omitempty"`
+	// date like 2023-08-01 00:15:00+00.
+	Date string `protobuf:"bytes,7,opt,name=date,proto3" json:"date,omitempty"`
+}
+
+func (x *Reading) Reset() {
+	*x = Reading{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reading) ProtoMessage() {}
+
+func (x *Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reading.ProtoReflect.Descriptor instead.
+func (*Reading) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Reading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reading) GetMeterId() int32 {
+	if x != nil {
+		return x.MeterId
+	}
+	return 0
+}
+
+func (x *Reading) GetActiveEnergy() float64 {
+	if x != nil {
+		return x.ActiveEnergy
+	}
+	return 0
+}
+
+func (x *Reading) GetReactiveEnergy() float64 {
+	if x != nil {
+		return x.ReactiveEnergy
+	}
+	return 0
+}
+
+func (x *Reading) GetCapacitiveReactive() float64 {
+	if x != nil {
+		return x.CapacitiveReactive
+	}
+	return 0
+}
+
+func (x *Reading) GetSolar() float64 {
+	if x != nil {
+		return x.Solar
+	}
+	return 0
+}
+
+func (x *Reading) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type IngestReadingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// source and unit are read from the first message of the stream, a blank unit uses the unit of every meter.
+	Source   string     `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Unit     string     `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Readings []*Reading `protobuf:"bytes,3,rep,name=readings,proto3" json:"readings,omitempty"`
+}
+
+func (x *IngestReadingsRequest) Reset() {
+	*x = IngestReadingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestReadingsRequest) ProtoMessage() {}
+
+func (x *IngestReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestReadingsRequest.ProtoReflect.Descriptor instead.
+func (*IngestReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IngestReadingsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *IngestReadingsRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *IngestReadingsRequest) GetReadings() []*Reading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+type QualityViolation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule   string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *QualityViolation) Reset() {
+	*x = QualityViolation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QualityViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualityViolation) ProtoMessage() {}
+
+func (x *QualityViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualityViolation.ProtoReflect.Descriptor instead.
+func (*QualityViolation) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QualityViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *QualityViolation) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *QualityViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type IngestReadingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Imported    int32               `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Flagged     int32               `protobuf:"varint,2,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	Quarantined int32               `protobuf:"varint,3,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Rejected    int32               `protobuf:"varint,4,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Violations  []*QualityViolation `protobuf:"bytes,5,rep,name=violations,proto3" json:"violations,omitempty"`
+}
+
+func (x *IngestReadingsResponse) Reset() {
+	*x = IngestReadingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consumption_v1_consumption_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestReadingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestReadingsResponse) ProtoMessage() {}
+
+func (x *IngestReadingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumption_v1_consumption_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestReadingsResponse.ProtoReflect.Descriptor instead.
+func (*IngestReadingsResponse) Descriptor() ([]byte, []int) {
+	return file_consumption_v1_consumption_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IngestReadingsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *IngestReadingsResponse) GetFlagged() int32 {
+	if x != nil {
+		return x.Flagged
+	}
+	return 0
+}
+
+func (x *IngestReadingsResponse) GetQuarantined() int32 {
+	if x != nil {
+		return x.Quarantined
+	}
+	return 0
+}
+
+func (x *IngestReadingsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestReadingsResponse) GetViolations() []*QualityViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+var File_consumption_v1_consumption_proto protoreflect.FileDescriptor
+
+var file_consumption_v1_consumption_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x22, 0xab, 0x02, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x69, 0x6e, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x69, 0x6e, 0x64, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x65, 0x65, 0x6b, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x77, 0x65, 0x65, 0x6b, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x12, 0x2a, 0x0a, 0x11, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x62, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74,
+	0x22, 0x9c, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x69, 0x6e, 0x64, 0x75, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x11, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x64, 0x75, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x12, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22,
+	0xe9, 0x02, 0x0a, 0x10, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x56, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x6e,
+	0x69, 0x74, 0x1a, 0x57, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4f, 0x0a, 0x13, 0x43,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0xdd, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x45, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x65, 0x72, 0x67,
+	0x79, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12,
+	0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0x78, 0x0a, 0x15,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x12, 0x33, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x72, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x56, 0x0a, 0x10, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xce,
+	0x01, 0x0a, 0x16, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a,
+	0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x32,
+	0xaf, 0x02, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5b, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x12, 0x61,
+	0x0a, 0x0e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28,
+	0x01, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6a, 0x65, 0x66, 0x66, 0x6c, 0x65, 0x6f, 0x6e, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x6d, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x3b, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_consumption_v1_consumption_proto_rawDescOnce sync.Once
+	file_consumption_v1_consumption_proto_rawDescData = file_consumption_v1_consumption_proto_rawDesc
+)
+
+func file_consumption_v1_consumption_proto_rawDescGZIP() []byte {
+	file_consumption_v1_consumption_proto_rawDescOnce.Do(func() {
+		file_consumption_v1_consumption_proto_rawDescData = protoimpl.X.CompressGZIP(file_consumption_v1_consumption_proto_rawDescData)
+	})
+	return file_consumption_v1_consumption_proto_rawDescData
+}
+
+var file_consumption_v1_consumption_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_consumption_v1_consumption_proto_goTypes = []interface{}{
+	(*ConsumptionRequest)(nil),     // 0: consumption.v1.ConsumptionRequest
+	(*Series)(nil),                 // 1: consumption.v1.Series
+	(*MeterConsumption)(nil),       // 2: consumption.v1.MeterConsumption
+	(*ConsumptionResponse)(nil),    // 3: consumption.v1.ConsumptionResponse
+	(*Reading)(nil),                // 4: consumption.v1.Reading
+	(*IngestReadingsRequest)(nil),  // 5: consumption.v1.IngestReadingsRequest
+	(*QualityViolation)(nil),       // 6: consumption.v1.QualityViolation
+	(*IngestReadingsResponse)(nil), // 7: consumption.v1.IngestReadingsResponse
+	nil,                            // 8: consumption.v1.MeterConsumption.AggregationsEntry
+}
+var file_consumption_v1_consumption_proto_depIdxs = []int32{
+	1, // 0: consumption.v1.MeterConsumption.consumption:type_name -> consumption.v1.Series
+	8, // 1: consumption.v1.MeterConsumption.aggregations:type_name -> consumption.v1.MeterConsumption.AggregationsEntry
+	2, // 2: consumption.v1.ConsumptionResponse.meters:type_name -> consumption.v1.MeterConsumption
+	4, // 3: consumption.v1.IngestReadingsRequest.readings:type_name -> consumption.v1.Reading
+	6, // 4: consumption.v1.IngestReadingsResponse.violations:type_name -> consumption.v1.QualityViolation
+	1, // 5: consumption.v1.MeterConsumption.AggregationsEntry.value:type_name -> consumption.v1.Series
+	0, // 6: consumption.v1.ConsumptionService.GetConsumption:input_type -> consumption.v1.ConsumptionRequest
+	0, // 7: consumption.v1.ConsumptionService.StreamConsumption:input_type -> consumption.v1.ConsumptionRequest
+	5, // 8: consumption.v1.ConsumptionService.IngestReadings:input_type -> consumption.v1.IngestReadingsRequest
+	3, // 9: consumption.v1.ConsumptionService.GetConsumption:output_type -> consumption.v1.ConsumptionResponse
+	2, // 10: consumption.v1.ConsumptionService.StreamConsumption:output_type -> consumption.v1.MeterConsumption
+	7, // 11: consumption.v1.ConsumptionService.IngestReadings:output_type -> consumption.v1.IngestReadingsResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_consumption_v1_consumption_proto_init() }
+func file_consumption_v1_consumption_proto_init() {
+	if File_consumption_v1_consumption_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consumption_v1_consumption_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsumptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Series); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MeterConsumption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsumptionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reading); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestReadingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QualityViolation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consumption_v1_consumption_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestReadingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consumption_v1_consumption_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consumption_v1_consumption_proto_goTypes,
+		DependencyIndexes: file_consumption_v1_consumption_proto_depIdxs,
+		MessageInfos:      file_consumption_v1_consumption_proto_msgTypes,
+	}.Build()
+	File_consumption_v1_consumption_proto = out.File
+	file_consumption_v1_consumption_proto_rawDesc = nil
+	file_consumption_v1_consumption_proto_goTypes = nil
+	file_consumption_v1_consumption_proto_depIdxs = nil
+}
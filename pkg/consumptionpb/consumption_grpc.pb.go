@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: consumption/v1/consumption.proto
+
+package consumptionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ConsumptionService_GetConsumption_FullMethodName    = "/consumption.v1.ConsumptionService/GetConsumption"
+	ConsumptionService_StreamConsumption_FullMethodName = "/consumption.v1.ConsumptionService/StreamConsumption"
+	ConsumptionService_IngestReadings_FullMethodName    = "/consumption.v1.ConsumptionService/IngestReadings"
+)
+
+// ConsumptionServiceClient is the client API for ConsumptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConsumptionServiceClient interface {
+	// GetConsumption returns the consumption of all the meters in one response.
+	GetConsumption(ctx context.Context, in *ConsumptionRequest, opts ...grpc.CallOption) (*ConsumptionResponse, error)
+	// StreamConsumption sends the consumption of every meter as soon as it is ready, use it for large results.
+	StreamConsumption(ctx context.Context, in *ConsumptionRequest, opts ...grpc.CallOption) (ConsumptionService_StreamConsumptionClient, error)
+	// IngestReadings receives chunks of readings and imports them in batches with the data quality rules.
+	IngestReadings(ctx context.Context, opts ...grpc.CallOption) (ConsumptionService_IngestReadingsClient, error)
+}
+
+type consumptionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsumptionServiceClient(cc grpc.ClientConnInterface) ConsumptionServiceClient {
+	return &consumptionServiceClient{cc}
+}
+
+func (c *consumptionServiceClient) GetConsumption(ctx context.Context, in *ConsumptionRequest, opts ...grpc.CallOption) (*ConsumptionResponse, error) {
+	out := new(ConsumptionResponse)
+	err := c.cc.Invoke(ctx, ConsumptionService_GetConsumption_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumptionServiceClient) StreamConsumption(ctx context.Context, in *ConsumptionRequest, opts ...grpc.CallOption) (ConsumptionService_StreamConsumptionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConsumptionService_ServiceDesc.Streams[0], ConsumptionService_StreamConsumption_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &consumptionServiceStreamConsumptionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConsumptionService_StreamConsumptionClient interface {
+	Recv() (*MeterConsumption, error)
+	grpc.ClientStream
+}
+
+type consumptionServiceStreamConsumptionClient struct {
+	grpc.ClientStream
+}
+
+func (x *consumptionServiceStreamConsumptionClient) Recv() (*MeterConsumption, error) {
+	m := new(MeterConsumption)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *consumptionServiceClient) IngestReadings(ctx context.Context, opts ...grpc.CallOption) (ConsumptionService_IngestReadingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConsumptionService_ServiceDesc.Streams[1], ConsumptionService_IngestReadings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &consumptionServiceIngestReadingsClient{stream}
+	return x, nil
+}
+
+type ConsumptionService_IngestReadingsClient interface {
+	Send(*IngestReadingsRequest) error
+	CloseAndRecv() (*IngestReadingsResponse, error)
+	grpc.ClientStream
+}
+
+type consumptionServiceIngestReadingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *consumptionServiceIngestReadingsClient) Send(m *IngestReadingsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *consumptionServiceIngestReadingsClient) CloseAndRecv() (*IngestReadingsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IngestReadingsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConsumptionServiceServer is the server API for ConsumptionService service.
+// All implementations must embed UnimplementedConsumptionServiceServer
+// for forward compatibility
+type ConsumptionServiceServer interface {
+	// GetConsumption returns the consumption of all the meters in one response.
+	GetConsumption(context.Context, *ConsumptionRequest) (*ConsumptionResponse, error)
+	// StreamConsumption sends the consumption of every meter as soon as it is ready, use it for large results.
+	StreamConsumption(*ConsumptionRequest, ConsumptionService_StreamConsumptionServer) error
+	// IngestReadings receives chunks of readings and imports them in batches with the data quality rules.
+	IngestReadings(ConsumptionService_IngestReadingsServer) error
+	mustEmbedUnimplementedConsumptionServiceServer()
+}
+
+// UnimplementedConsumptionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedConsumptionServiceServer struct {
+}
+
+func (UnimplementedConsumptionServiceServer) GetConsumption(context.Context, *ConsumptionRequest) (*ConsumptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsumption not implemented")
+}
+func (UnimplementedConsumptionServiceServer) StreamConsumption(*ConsumptionRequest, ConsumptionService_StreamConsumptionServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamConsumption not implemented")
+}
+func (UnimplementedConsumptionServiceServer) IngestReadings(ConsumptionService_IngestReadingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method IngestReadings not implemented")
+}
+func (UnimplementedConsumptionServiceServer) mustEmbedUnimplementedConsumptionServiceServer() {}
+
+// UnsafeConsumptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsumptionServiceServer will
+// result in compilation errors.
+type UnsafeConsumptionServiceServer interface {
+	mustEmbedUnimplementedConsumptionServiceServer()
+}
+
+func RegisterConsumptionServiceServer(s grpc.ServiceRegistrar, srv ConsumptionServiceServer) {
+	s.RegisterService(&ConsumptionService_ServiceDesc, srv)
+}
+
+func _ConsumptionService_GetConsumption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsumptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumptionServiceServer).GetConsumption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsumptionService_GetConsumption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumptionServiceServer).GetConsumption(ctx, req.(*ConsumptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsumptionService_StreamConsumption_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConsumptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConsumptionServiceServer).StreamConsumption(m, &consumptionServiceStreamConsumptionServer{stream})
+}
+
+type ConsumptionService_StreamConsumptionServer interface {
+	Send(*MeterConsumption) error
+	grpc.ServerStream
+}
+
+type consumptionServiceStreamConsumptionServer struct {
+	grpc.ServerStream
+}
+
+func (x *consumptionServiceStreamConsumptionServer) Send(m *MeterConsumption) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ConsumptionService_IngestReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConsumptionServiceServer).IngestReadings(&consumptionServiceIngestReadingsServer{stream})
+}
+
+type ConsumptionService_IngestReadingsServer interface {
+	SendAndClose(*IngestReadingsResponse) error
+	Recv() (*IngestReadingsRequest, error)
+	grpc.ServerStream
+}
+
+type consumptionServiceIngestReadingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *consumptionServiceIngestReadingsServer) SendAndClose(m *IngestReadingsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *consumptionServiceIngestReadingsServer) Recv() (*IngestReadingsRequest, error) {
+	m := new(IngestReadingsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConsumptionService_ServiceDesc is the grpc.ServiceDesc for ConsumptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConsumptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "consumption.v1.ConsumptionService",
+	HandlerType: (*ConsumptionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConsumption",
+			Handler:    _ConsumptionService_GetConsumption_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamConsumption",
+			Handler:       _ConsumptionService_StreamConsumption_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestReadings",
+			Handler:       _ConsumptionService_IngestReadings_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "consumption/v1/consumption.proto",
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AlertRule struct {
+	gorm.Model
+	Name       string  `gorm:"name" json:"name"`
+	MeterID    *int    `gorm:"meter_id" json:"meter_id"`
+	GroupID    *uint   `gorm:"group_id" json:"group_id"`
+	Metric     string  `gorm:"metric" json:"metric"`
+	PeriodKind string  `gorm:"period_kind" json:"period_kind"`
+	Threshold  float64 `gorm:"threshold" json:"threshold"`
+	Comparison string  `gorm:"comparison" json:"comparison"`
+}
+
+type Alert struct {
+	gorm.Model
+	RuleID      uint      `gorm:"rule_id;uniqueIndex:idx_alert_rule_period" json:"rule_id"`
+	MeterID     *int      `gorm:"meter_id" json:"meter_id"`
+	GroupID     *uint     `gorm:"group_id" json:"group_id"`
+	Metric      string    `gorm:"metric" json:"metric"`
+	PeriodKind  string    `gorm:"period_kind" json:"period_kind"`
+	PeriodStart time.Time `gorm:"period_start;uniqueIndex:idx_alert_rule_period" json:"period_start"`
+	PeriodEnd   time.Time `gorm:"period_end" json:"period_end"`
+	Value       float64   `gorm:"value" json:"value"`
+	Projected   float64   `gorm:"projected" json:"projected"`
+	Threshold   float64   `gorm:"threshold" json:"threshold"`
+	Comparison  string    `gorm:"comparison" json:"comparison"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . AlertRepository
+type AlertRepository interface {
+	CreateAlertRule(rule *AlertRule) error
+	GetAlertRules() ([]AlertRule, error)
+	DeleteAlertRule(ruleID uint) error
+	CreateAlert(alert *Alert) (bool, error)
+	GetAlertPeriodStarts(ruleID uint, startDate, endDate time.Time) ([]time.Time, error)
+	GetAlerts() ([]Alert, error)
+	ModelMigration() error
+}
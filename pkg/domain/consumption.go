@@ -15,19 +15,48 @@ import (
 type UserConsumption struct {
 	gorm.Model
 	ID                 string    `gorm:"primary_key;auto_increment" json:"id" csv:"id"`
-	MeterID            int       `gorm:"meter_id" json:"meter_id" csv:"meter_id"`
+	MeterID            int       `gorm:"meter_id;uniqueIndex:idx_user_consumption_meter_date" json:"meter_id" csv:"meter_id"`
 	ActiveEnergy       float64   `gorm:"active_energy" json:"active_energy" csv:"active_energy"`
 	ReactiveEnergy     float64   `gorm:"reactive_energy" json:"reactive_energy" csv:"reactive_energy"`
 	CapacitiveReactive float64   `gorm:"capacity_energy" json:"capacitive_reactive" csv:"capacitive_reactive"`
 	Solar              float64   `gorm:"solar" json:"solar" csv:"solar"`
-	Date               time.Time `gorm:"date" json:"date" csv:"date"`
+	Date               time.Time `gorm:"date;uniqueIndex:idx_user_consumption_meter_date" json:"date" csv:"date"`
+	Flags              string    `gorm:"flags" json:"flags" csv:"-"`
+	ImportID           *uint     `gorm:"import_id;index" json:"import_id" csv:"-"`
 }
 
 type UserConsumptionQueryParams struct {
-	StartDate  time.Time
-	EndDate    time.Time
-	MeterIDs   []int
-	KindPeriod string
+	StartDate        time.Time
+	EndDate          time.Time
+	MeterIDs         []int
+	KindPeriod       string
+	CompareTo        string
+	CompareStartDate time.Time
+	CompareEndDate   time.Time
+	Aggregations     []string
+	WeekStart        time.Weekday
+	Interval         time.Duration
+	BillingCycleDay  int
+	Unit             string
+}
+
+type ConsumptionQueryOptions struct {
+	CompareTo        string
+	CompareStartDate string
+	CompareEndDate   string
+	Aggregations     string
+	WeekStart        string
+	Interval         string
+	BillingCycleDay  string
+	Unit             string
+}
+
+type ImportOptions struct {
+	FileName string
+	Unit     string
+	Uploader string
+	ImportID *uint
+	Force    bool
 }
 
 type CSVUserConsumption struct {
@@ -57,7 +86,7 @@ func (u CSVUserConsumption) ToUserConsumption() (*UserConsumption, error) {
 		MeterID:            numberMeterID,
 		ActiveEnergy:       u.ActiveEnergy,
 		ReactiveEnergy:     u.ReactiveEnergy,
-		CapacitiveReactive: u.ReactiveEnergy,
+		CapacitiveReactive: u.CapacitiveReactive,
 		Solar:              u.Solar,
 		Date:               objectDate,
 	}, nil
@@ -109,11 +138,20 @@ func hasHourAndMinutes(date string) bool {
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MySQLPowerConsumptionRepository
 type MySQLPowerConsumptionRepository interface {
 	GetConsumptionByMeterIDAndWindowTime(startDate, endDate time.Time, meterID int) ([]UserConsumption, error)
-	CreatePowerConsumptionRecords(usersPowerConsumption []*UserConsumption) error
+	GetConsumptionByMeterIDsAndWindowTime(startDate, endDate time.Time, meterIDs []int) ([]UserConsumption, error)
+	GetLastConsumptionBeforeDate(date time.Time, meterID int) (*UserConsumption, error)
+	GetConsumptionByMeterIDAndDates(meterID int, dates []time.Time) ([]UserConsumption, error)
+	GetConsumptionByImportID(importID uint) ([]UserConsumption, error)
+	CreatePowerConsumptionRecords(usersPowerConsumption []*UserConsumption) ([]*UserConsumption, error)
+	CreateImportRecords(usersPowerConsumption []*UserConsumption, quarantinedReadings []*QuarantinedReading, importRecord *Import, meterIDs []int) ([]*UserConsumption, error)
+	CreateIngestedRecords(usersPowerConsumption []*UserConsumption, quarantinedReadings []*QuarantinedReading) ([]*UserConsumption, error)
 	ModelMigration() error
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . CSVPowerConsumptionRepository
 type CSVPowerConsumptionRepository interface {
 	ConvertCSVToStruct(file *multipart.File) ([]*CSVUserConsumption, error)
+	ConvertCSVLineToStruct(line string) (*CSVUserConsumption, error)
+	ConvertStructToCSVLine(record *CSVUserConsumption) (string, error)
+	FileChecksum(file *multipart.File) (string, error)
 }
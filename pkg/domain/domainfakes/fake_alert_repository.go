@@ -0,0 +1,553 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeAlertRepository struct {
+	CreateAlertStub        func(*domain.Alert) (bool, error)
+	createAlertMutex       sync.RWMutex
+	createAlertArgsForCall []struct {
+		arg1 *domain.Alert
+	}
+	createAlertReturns struct {
+		result1 bool
+		result2 error
+	}
+	createAlertReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateAlertRuleStub        func(*domain.AlertRule) error
+	createAlertRuleMutex       sync.RWMutex
+	createAlertRuleArgsForCall []struct {
+		arg1 *domain.AlertRule
+	}
+	createAlertRuleReturns struct {
+		result1 error
+	}
+	createAlertRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAlertRuleStub        func(uint) error
+	deleteAlertRuleMutex       sync.RWMutex
+	deleteAlertRuleArgsForCall []struct {
+		arg1 uint
+	}
+	deleteAlertRuleReturns struct {
+		result1 error
+	}
+	deleteAlertRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetAlertPeriodStartsStub        func(uint, time.Time, time.Time) ([]time.Time, error)
+	getAlertPeriodStartsMutex       sync.RWMutex
+	getAlertPeriodStartsArgsForCall []struct {
+		arg1 uint
+		arg2 time.Time
+		arg3 time.Time
+	}
+	getAlertPeriodStartsReturns struct {
+		result1 []time.Time
+		result2 error
+	}
+	getAlertPeriodStartsReturnsOnCall map[int]struct {
+		result1 []time.Time
+		result2 error
+	}
+	GetAlertRulesStub        func() ([]domain.AlertRule, error)
+	getAlertRulesMutex       sync.RWMutex
+	getAlertRulesArgsForCall []struct {
+	}
+	getAlertRulesReturns struct {
+		result1 []domain.AlertRule
+		result2 error
+	}
+	getAlertRulesReturnsOnCall map[int]struct {
+		result1 []domain.AlertRule
+		result2 error
+	}
+	GetAlertsStub        func() ([]domain.Alert, error)
+	getAlertsMutex       sync.RWMutex
+	getAlertsArgsForCall []struct {
+	}
+	getAlertsReturns struct {
+		result1 []domain.Alert
+		result2 error
+	}
+	getAlertsReturnsOnCall map[int]struct {
+		result1 []domain.Alert
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAlertRepository) CreateAlert(arg1 *domain.Alert) (bool, error) {
+	fake.createAlertMutex.Lock()
+	ret, specificReturn := fake.createAlertReturnsOnCall[len(fake.createAlertArgsForCall)]
+	fake.createAlertArgsForCall = append(fake.createAlertArgsForCall, struct {
+		arg1 *domain.Alert
+	}{arg1})
+	stub := fake.CreateAlertStub
+	fakeReturns := fake.createAlertReturns
+	fake.recordInvocation("CreateAlert", []interface{}{arg1})
+	fake.createAlertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertRepository) CreateAlertCallCount() int {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	return len(fake.createAlertArgsForCall)
+}
+
+func (fake *FakeAlertRepository) CreateAlertCalls(stub func(*domain.Alert) (bool, error)) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = stub
+}
+
+func (fake *FakeAlertRepository) CreateAlertArgsForCall(i int) *domain.Alert {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	argsForCall := fake.createAlertArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertRepository) CreateAlertReturns(result1 bool, result2 error) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = nil
+	fake.createAlertReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) CreateAlertReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = nil
+	if fake.createAlertReturnsOnCall == nil {
+		fake.createAlertReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.createAlertReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) CreateAlertRule(arg1 *domain.AlertRule) error {
+	fake.createAlertRuleMutex.Lock()
+	ret, specificReturn := fake.createAlertRuleReturnsOnCall[len(fake.createAlertRuleArgsForCall)]
+	fake.createAlertRuleArgsForCall = append(fake.createAlertRuleArgsForCall, struct {
+		arg1 *domain.AlertRule
+	}{arg1})
+	stub := fake.CreateAlertRuleStub
+	fakeReturns := fake.createAlertRuleReturns
+	fake.recordInvocation("CreateAlertRule", []interface{}{arg1})
+	fake.createAlertRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertRepository) CreateAlertRuleCallCount() int {
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	return len(fake.createAlertRuleArgsForCall)
+}
+
+func (fake *FakeAlertRepository) CreateAlertRuleCalls(stub func(*domain.AlertRule) error) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = stub
+}
+
+func (fake *FakeAlertRepository) CreateAlertRuleArgsForCall(i int) *domain.AlertRule {
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	argsForCall := fake.createAlertRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertRepository) CreateAlertRuleReturns(result1 error) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = nil
+	fake.createAlertRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) CreateAlertRuleReturnsOnCall(i int, result1 error) {
+	fake.createAlertRuleMutex.Lock()
+	defer fake.createAlertRuleMutex.Unlock()
+	fake.CreateAlertRuleStub = nil
+	if fake.createAlertRuleReturnsOnCall == nil {
+		fake.createAlertRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAlertRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRule(arg1 uint) error {
+	fake.deleteAlertRuleMutex.Lock()
+	ret, specificReturn := fake.deleteAlertRuleReturnsOnCall[len(fake.deleteAlertRuleArgsForCall)]
+	fake.deleteAlertRuleArgsForCall = append(fake.deleteAlertRuleArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.DeleteAlertRuleStub
+	fakeReturns := fake.deleteAlertRuleReturns
+	fake.recordInvocation("DeleteAlertRule", []interface{}{arg1})
+	fake.deleteAlertRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRuleCallCount() int {
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	return len(fake.deleteAlertRuleArgsForCall)
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRuleCalls(stub func(uint) error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = stub
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRuleArgsForCall(i int) uint {
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	argsForCall := fake.deleteAlertRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRuleReturns(result1 error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = nil
+	fake.deleteAlertRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) DeleteAlertRuleReturnsOnCall(i int, result1 error) {
+	fake.deleteAlertRuleMutex.Lock()
+	defer fake.deleteAlertRuleMutex.Unlock()
+	fake.DeleteAlertRuleStub = nil
+	if fake.deleteAlertRuleReturnsOnCall == nil {
+		fake.deleteAlertRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAlertRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStarts(arg1 uint, arg2 time.Time, arg3 time.Time) ([]time.Time, error) {
+	fake.getAlertPeriodStartsMutex.Lock()
+	ret, specificReturn := fake.getAlertPeriodStartsReturnsOnCall[len(fake.getAlertPeriodStartsArgsForCall)]
+	fake.getAlertPeriodStartsArgsForCall = append(fake.getAlertPeriodStartsArgsForCall, struct {
+		arg1 uint
+		arg2 time.Time
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.GetAlertPeriodStartsStub
+	fakeReturns := fake.getAlertPeriodStartsReturns
+	fake.recordInvocation("GetAlertPeriodStarts", []interface{}{arg1, arg2, arg3})
+	fake.getAlertPeriodStartsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStartsCallCount() int {
+	fake.getAlertPeriodStartsMutex.RLock()
+	defer fake.getAlertPeriodStartsMutex.RUnlock()
+	return len(fake.getAlertPeriodStartsArgsForCall)
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStartsCalls(stub func(uint, time.Time, time.Time) ([]time.Time, error)) {
+	fake.getAlertPeriodStartsMutex.Lock()
+	defer fake.getAlertPeriodStartsMutex.Unlock()
+	fake.GetAlertPeriodStartsStub = stub
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStartsArgsForCall(i int) (uint, time.Time, time.Time) {
+	fake.getAlertPeriodStartsMutex.RLock()
+	defer fake.getAlertPeriodStartsMutex.RUnlock()
+	argsForCall := fake.getAlertPeriodStartsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStartsReturns(result1 []time.Time, result2 error) {
+	fake.getAlertPeriodStartsMutex.Lock()
+	defer fake.getAlertPeriodStartsMutex.Unlock()
+	fake.GetAlertPeriodStartsStub = nil
+	fake.getAlertPeriodStartsReturns = struct {
+		result1 []time.Time
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) GetAlertPeriodStartsReturnsOnCall(i int, result1 []time.Time, result2 error) {
+	fake.getAlertPeriodStartsMutex.Lock()
+	defer fake.getAlertPeriodStartsMutex.Unlock()
+	fake.GetAlertPeriodStartsStub = nil
+	if fake.getAlertPeriodStartsReturnsOnCall == nil {
+		fake.getAlertPeriodStartsReturnsOnCall = make(map[int]struct {
+			result1 []time.Time
+			result2 error
+		})
+	}
+	fake.getAlertPeriodStartsReturnsOnCall[i] = struct {
+		result1 []time.Time
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) GetAlertRules() ([]domain.AlertRule, error) {
+	fake.getAlertRulesMutex.Lock()
+	ret, specificReturn := fake.getAlertRulesReturnsOnCall[len(fake.getAlertRulesArgsForCall)]
+	fake.getAlertRulesArgsForCall = append(fake.getAlertRulesArgsForCall, struct {
+	}{})
+	stub := fake.GetAlertRulesStub
+	fakeReturns := fake.getAlertRulesReturns
+	fake.recordInvocation("GetAlertRules", []interface{}{})
+	fake.getAlertRulesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertRepository) GetAlertRulesCallCount() int {
+	fake.getAlertRulesMutex.RLock()
+	defer fake.getAlertRulesMutex.RUnlock()
+	return len(fake.getAlertRulesArgsForCall)
+}
+
+func (fake *FakeAlertRepository) GetAlertRulesCalls(stub func() ([]domain.AlertRule, error)) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = stub
+}
+
+func (fake *FakeAlertRepository) GetAlertRulesReturns(result1 []domain.AlertRule, result2 error) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = nil
+	fake.getAlertRulesReturns = struct {
+		result1 []domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) GetAlertRulesReturnsOnCall(i int, result1 []domain.AlertRule, result2 error) {
+	fake.getAlertRulesMutex.Lock()
+	defer fake.getAlertRulesMutex.Unlock()
+	fake.GetAlertRulesStub = nil
+	if fake.getAlertRulesReturnsOnCall == nil {
+		fake.getAlertRulesReturnsOnCall = make(map[int]struct {
+			result1 []domain.AlertRule
+			result2 error
+		})
+	}
+	fake.getAlertRulesReturnsOnCall[i] = struct {
+		result1 []domain.AlertRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) GetAlerts() ([]domain.Alert, error) {
+	fake.getAlertsMutex.Lock()
+	ret, specificReturn := fake.getAlertsReturnsOnCall[len(fake.getAlertsArgsForCall)]
+	fake.getAlertsArgsForCall = append(fake.getAlertsArgsForCall, struct {
+	}{})
+	stub := fake.GetAlertsStub
+	fakeReturns := fake.getAlertsReturns
+	fake.recordInvocation("GetAlerts", []interface{}{})
+	fake.getAlertsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAlertRepository) GetAlertsCallCount() int {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	return len(fake.getAlertsArgsForCall)
+}
+
+func (fake *FakeAlertRepository) GetAlertsCalls(stub func() ([]domain.Alert, error)) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = stub
+}
+
+func (fake *FakeAlertRepository) GetAlertsReturns(result1 []domain.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	fake.getAlertsReturns = struct {
+		result1 []domain.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) GetAlertsReturnsOnCall(i int, result1 []domain.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	if fake.getAlertsReturnsOnCall == nil {
+		fake.getAlertsReturnsOnCall = make(map[int]struct {
+			result1 []domain.Alert
+			result2 error
+		})
+	}
+	fake.getAlertsReturnsOnCall[i] = struct {
+		result1 []domain.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAlertRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAlertRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeAlertRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeAlertRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAlertRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	fake.createAlertRuleMutex.RLock()
+	defer fake.createAlertRuleMutex.RUnlock()
+	fake.deleteAlertRuleMutex.RLock()
+	defer fake.deleteAlertRuleMutex.RUnlock()
+	fake.getAlertPeriodStartsMutex.RLock()
+	defer fake.getAlertPeriodStartsMutex.RUnlock()
+	fake.getAlertRulesMutex.RLock()
+	defer fake.getAlertRulesMutex.RUnlock()
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAlertRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.AlertRepository = new(FakeAlertRepository)
@@ -9,6 +9,19 @@ import (
 )
 
 type FakeCSVPowerConsumptionRepository struct {
+	ConvertCSVLineToStructStub        func(string) (*domain.CSVUserConsumption, error)
+	convertCSVLineToStructMutex       sync.RWMutex
+	convertCSVLineToStructArgsForCall []struct {
+		arg1 string
+	}
+	convertCSVLineToStructReturns struct {
+		result1 *domain.CSVUserConsumption
+		result2 error
+	}
+	convertCSVLineToStructReturnsOnCall map[int]struct {
+		result1 *domain.CSVUserConsumption
+		result2 error
+	}
 	ConvertCSVToStructStub        func(*multipart.File) ([]*domain.CSVUserConsumption, error)
 	convertCSVToStructMutex       sync.RWMutex
 	convertCSVToStructArgsForCall []struct {
@@ -22,10 +35,100 @@ type FakeCSVPowerConsumptionRepository struct {
 		result1 []*domain.CSVUserConsumption
 		result2 error
 	}
+	ConvertStructToCSVLineStub        func(*domain.CSVUserConsumption) (string, error)
+	convertStructToCSVLineMutex       sync.RWMutex
+	convertStructToCSVLineArgsForCall []struct {
+		arg1 *domain.CSVUserConsumption
+	}
+	convertStructToCSVLineReturns struct {
+		result1 string
+		result2 error
+	}
+	convertStructToCSVLineReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	FileChecksumStub        func(*multipart.File) (string, error)
+	fileChecksumMutex       sync.RWMutex
+	fileChecksumArgsForCall []struct {
+		arg1 *multipart.File
+	}
+	fileChecksumReturns struct {
+		result1 string
+		result2 error
+	}
+	fileChecksumReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStruct(arg1 string) (*domain.CSVUserConsumption, error) {
+	fake.convertCSVLineToStructMutex.Lock()
+	ret, specificReturn := fake.convertCSVLineToStructReturnsOnCall[len(fake.convertCSVLineToStructArgsForCall)]
+	fake.convertCSVLineToStructArgsForCall = append(fake.convertCSVLineToStructArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ConvertCSVLineToStructStub
+	fakeReturns := fake.convertCSVLineToStructReturns
+	fake.recordInvocation("ConvertCSVLineToStruct", []interface{}{arg1})
+	fake.convertCSVLineToStructMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStructCallCount() int {
+	fake.convertCSVLineToStructMutex.RLock()
+	defer fake.convertCSVLineToStructMutex.RUnlock()
+	return len(fake.convertCSVLineToStructArgsForCall)
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStructCalls(stub func(string) (*domain.CSVUserConsumption, error)) {
+	fake.convertCSVLineToStructMutex.Lock()
+	defer fake.convertCSVLineToStructMutex.Unlock()
+	fake.ConvertCSVLineToStructStub = stub
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStructArgsForCall(i int) string {
+	fake.convertCSVLineToStructMutex.RLock()
+	defer fake.convertCSVLineToStructMutex.RUnlock()
+	argsForCall := fake.convertCSVLineToStructArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStructReturns(result1 *domain.CSVUserConsumption, result2 error) {
+	fake.convertCSVLineToStructMutex.Lock()
+	defer fake.convertCSVLineToStructMutex.Unlock()
+	fake.ConvertCSVLineToStructStub = nil
+	fake.convertCSVLineToStructReturns = struct {
+		result1 *domain.CSVUserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVLineToStructReturnsOnCall(i int, result1 *domain.CSVUserConsumption, result2 error) {
+	fake.convertCSVLineToStructMutex.Lock()
+	defer fake.convertCSVLineToStructMutex.Unlock()
+	fake.ConvertCSVLineToStructStub = nil
+	if fake.convertCSVLineToStructReturnsOnCall == nil {
+		fake.convertCSVLineToStructReturnsOnCall = make(map[int]struct {
+			result1 *domain.CSVUserConsumption
+			result2 error
+		})
+	}
+	fake.convertCSVLineToStructReturnsOnCall[i] = struct {
+		result1 *domain.CSVUserConsumption
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVToStruct(arg1 *multipart.File) ([]*domain.CSVUserConsumption, error) {
 	fake.convertCSVToStructMutex.Lock()
 	ret, specificReturn := fake.convertCSVToStructReturnsOnCall[len(fake.convertCSVToStructArgsForCall)]
@@ -90,11 +193,145 @@ func (fake *FakeCSVPowerConsumptionRepository) ConvertCSVToStructReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLine(arg1 *domain.CSVUserConsumption) (string, error) {
+	fake.convertStructToCSVLineMutex.Lock()
+	ret, specificReturn := fake.convertStructToCSVLineReturnsOnCall[len(fake.convertStructToCSVLineArgsForCall)]
+	fake.convertStructToCSVLineArgsForCall = append(fake.convertStructToCSVLineArgsForCall, struct {
+		arg1 *domain.CSVUserConsumption
+	}{arg1})
+	stub := fake.ConvertStructToCSVLineStub
+	fakeReturns := fake.convertStructToCSVLineReturns
+	fake.recordInvocation("ConvertStructToCSVLine", []interface{}{arg1})
+	fake.convertStructToCSVLineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLineCallCount() int {
+	fake.convertStructToCSVLineMutex.RLock()
+	defer fake.convertStructToCSVLineMutex.RUnlock()
+	return len(fake.convertStructToCSVLineArgsForCall)
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLineCalls(stub func(*domain.CSVUserConsumption) (string, error)) {
+	fake.convertStructToCSVLineMutex.Lock()
+	defer fake.convertStructToCSVLineMutex.Unlock()
+	fake.ConvertStructToCSVLineStub = stub
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLineArgsForCall(i int) *domain.CSVUserConsumption {
+	fake.convertStructToCSVLineMutex.RLock()
+	defer fake.convertStructToCSVLineMutex.RUnlock()
+	argsForCall := fake.convertStructToCSVLineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLineReturns(result1 string, result2 error) {
+	fake.convertStructToCSVLineMutex.Lock()
+	defer fake.convertStructToCSVLineMutex.Unlock()
+	fake.ConvertStructToCSVLineStub = nil
+	fake.convertStructToCSVLineReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) ConvertStructToCSVLineReturnsOnCall(i int, result1 string, result2 error) {
+	fake.convertStructToCSVLineMutex.Lock()
+	defer fake.convertStructToCSVLineMutex.Unlock()
+	fake.ConvertStructToCSVLineStub = nil
+	if fake.convertStructToCSVLineReturnsOnCall == nil {
+		fake.convertStructToCSVLineReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.convertStructToCSVLineReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksum(arg1 *multipart.File) (string, error) {
+	fake.fileChecksumMutex.Lock()
+	ret, specificReturn := fake.fileChecksumReturnsOnCall[len(fake.fileChecksumArgsForCall)]
+	fake.fileChecksumArgsForCall = append(fake.fileChecksumArgsForCall, struct {
+		arg1 *multipart.File
+	}{arg1})
+	stub := fake.FileChecksumStub
+	fakeReturns := fake.fileChecksumReturns
+	fake.recordInvocation("FileChecksum", []interface{}{arg1})
+	fake.fileChecksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksumCallCount() int {
+	fake.fileChecksumMutex.RLock()
+	defer fake.fileChecksumMutex.RUnlock()
+	return len(fake.fileChecksumArgsForCall)
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksumCalls(stub func(*multipart.File) (string, error)) {
+	fake.fileChecksumMutex.Lock()
+	defer fake.fileChecksumMutex.Unlock()
+	fake.FileChecksumStub = stub
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksumArgsForCall(i int) *multipart.File {
+	fake.fileChecksumMutex.RLock()
+	defer fake.fileChecksumMutex.RUnlock()
+	argsForCall := fake.fileChecksumArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksumReturns(result1 string, result2 error) {
+	fake.fileChecksumMutex.Lock()
+	defer fake.fileChecksumMutex.Unlock()
+	fake.FileChecksumStub = nil
+	fake.fileChecksumReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCSVPowerConsumptionRepository) FileChecksumReturnsOnCall(i int, result1 string, result2 error) {
+	fake.fileChecksumMutex.Lock()
+	defer fake.fileChecksumMutex.Unlock()
+	fake.FileChecksumStub = nil
+	if fake.fileChecksumReturnsOnCall == nil {
+		fake.fileChecksumReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.fileChecksumReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCSVPowerConsumptionRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.convertCSVLineToStructMutex.RLock()
+	defer fake.convertCSVLineToStructMutex.RUnlock()
 	fake.convertCSVToStructMutex.RLock()
 	defer fake.convertCSVToStructMutex.RUnlock()
+	fake.convertStructToCSVLineMutex.RLock()
+	defer fake.convertStructToCSVLineMutex.RUnlock()
+	fake.fileChecksumMutex.RLock()
+	defer fake.fileChecksumMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
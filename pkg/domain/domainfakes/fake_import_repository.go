@@ -0,0 +1,557 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeImportRepository struct {
+	CreateImportStub        func(*domain.Import) error
+	createImportMutex       sync.RWMutex
+	createImportArgsForCall []struct {
+		arg1 *domain.Import
+	}
+	createImportReturns struct {
+		result1 error
+	}
+	createImportReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetImportByChecksumStub        func(string) (*domain.Import, error)
+	getImportByChecksumMutex       sync.RWMutex
+	getImportByChecksumArgsForCall []struct {
+		arg1 string
+	}
+	getImportByChecksumReturns struct {
+		result1 *domain.Import
+		result2 error
+	}
+	getImportByChecksumReturnsOnCall map[int]struct {
+		result1 *domain.Import
+		result2 error
+	}
+	GetImportByIDStub        func(uint) (*domain.Import, error)
+	getImportByIDMutex       sync.RWMutex
+	getImportByIDArgsForCall []struct {
+		arg1 uint
+	}
+	getImportByIDReturns struct {
+		result1 *domain.Import
+		result2 error
+	}
+	getImportByIDReturnsOnCall map[int]struct {
+		result1 *domain.Import
+		result2 error
+	}
+	GetImportsStub        func() ([]domain.Import, error)
+	getImportsMutex       sync.RWMutex
+	getImportsArgsForCall []struct {
+	}
+	getImportsReturns struct {
+		result1 []domain.Import
+		result2 error
+	}
+	getImportsReturnsOnCall map[int]struct {
+		result1 []domain.Import
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RollbackImportStub        func(*domain.Import) (int64, error)
+	rollbackImportMutex       sync.RWMutex
+	rollbackImportArgsForCall []struct {
+		arg1 *domain.Import
+	}
+	rollbackImportReturns struct {
+		result1 int64
+		result2 error
+	}
+	rollbackImportReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	UpdateImportStub        func(*domain.Import) error
+	updateImportMutex       sync.RWMutex
+	updateImportArgsForCall []struct {
+		arg1 *domain.Import
+	}
+	updateImportReturns struct {
+		result1 error
+	}
+	updateImportReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImportRepository) CreateImport(arg1 *domain.Import) error {
+	fake.createImportMutex.Lock()
+	ret, specificReturn := fake.createImportReturnsOnCall[len(fake.createImportArgsForCall)]
+	fake.createImportArgsForCall = append(fake.createImportArgsForCall, struct {
+		arg1 *domain.Import
+	}{arg1})
+	stub := fake.CreateImportStub
+	fakeReturns := fake.createImportReturns
+	fake.recordInvocation("CreateImport", []interface{}{arg1})
+	fake.createImportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImportRepository) CreateImportCallCount() int {
+	fake.createImportMutex.RLock()
+	defer fake.createImportMutex.RUnlock()
+	return len(fake.createImportArgsForCall)
+}
+
+func (fake *FakeImportRepository) CreateImportCalls(stub func(*domain.Import) error) {
+	fake.createImportMutex.Lock()
+	defer fake.createImportMutex.Unlock()
+	fake.CreateImportStub = stub
+}
+
+func (fake *FakeImportRepository) CreateImportArgsForCall(i int) *domain.Import {
+	fake.createImportMutex.RLock()
+	defer fake.createImportMutex.RUnlock()
+	argsForCall := fake.createImportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportRepository) CreateImportReturns(result1 error) {
+	fake.createImportMutex.Lock()
+	defer fake.createImportMutex.Unlock()
+	fake.CreateImportStub = nil
+	fake.createImportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) CreateImportReturnsOnCall(i int, result1 error) {
+	fake.createImportMutex.Lock()
+	defer fake.createImportMutex.Unlock()
+	fake.CreateImportStub = nil
+	if fake.createImportReturnsOnCall == nil {
+		fake.createImportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createImportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) GetImportByChecksum(arg1 string) (*domain.Import, error) {
+	fake.getImportByChecksumMutex.Lock()
+	ret, specificReturn := fake.getImportByChecksumReturnsOnCall[len(fake.getImportByChecksumArgsForCall)]
+	fake.getImportByChecksumArgsForCall = append(fake.getImportByChecksumArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetImportByChecksumStub
+	fakeReturns := fake.getImportByChecksumReturns
+	fake.recordInvocation("GetImportByChecksum", []interface{}{arg1})
+	fake.getImportByChecksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportRepository) GetImportByChecksumCallCount() int {
+	fake.getImportByChecksumMutex.RLock()
+	defer fake.getImportByChecksumMutex.RUnlock()
+	return len(fake.getImportByChecksumArgsForCall)
+}
+
+func (fake *FakeImportRepository) GetImportByChecksumCalls(stub func(string) (*domain.Import, error)) {
+	fake.getImportByChecksumMutex.Lock()
+	defer fake.getImportByChecksumMutex.Unlock()
+	fake.GetImportByChecksumStub = stub
+}
+
+func (fake *FakeImportRepository) GetImportByChecksumArgsForCall(i int) string {
+	fake.getImportByChecksumMutex.RLock()
+	defer fake.getImportByChecksumMutex.RUnlock()
+	argsForCall := fake.getImportByChecksumArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportRepository) GetImportByChecksumReturns(result1 *domain.Import, result2 error) {
+	fake.getImportByChecksumMutex.Lock()
+	defer fake.getImportByChecksumMutex.Unlock()
+	fake.GetImportByChecksumStub = nil
+	fake.getImportByChecksumReturns = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) GetImportByChecksumReturnsOnCall(i int, result1 *domain.Import, result2 error) {
+	fake.getImportByChecksumMutex.Lock()
+	defer fake.getImportByChecksumMutex.Unlock()
+	fake.GetImportByChecksumStub = nil
+	if fake.getImportByChecksumReturnsOnCall == nil {
+		fake.getImportByChecksumReturnsOnCall = make(map[int]struct {
+			result1 *domain.Import
+			result2 error
+		})
+	}
+	fake.getImportByChecksumReturnsOnCall[i] = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) GetImportByID(arg1 uint) (*domain.Import, error) {
+	fake.getImportByIDMutex.Lock()
+	ret, specificReturn := fake.getImportByIDReturnsOnCall[len(fake.getImportByIDArgsForCall)]
+	fake.getImportByIDArgsForCall = append(fake.getImportByIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetImportByIDStub
+	fakeReturns := fake.getImportByIDReturns
+	fake.recordInvocation("GetImportByID", []interface{}{arg1})
+	fake.getImportByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportRepository) GetImportByIDCallCount() int {
+	fake.getImportByIDMutex.RLock()
+	defer fake.getImportByIDMutex.RUnlock()
+	return len(fake.getImportByIDArgsForCall)
+}
+
+func (fake *FakeImportRepository) GetImportByIDCalls(stub func(uint) (*domain.Import, error)) {
+	fake.getImportByIDMutex.Lock()
+	defer fake.getImportByIDMutex.Unlock()
+	fake.GetImportByIDStub = stub
+}
+
+func (fake *FakeImportRepository) GetImportByIDArgsForCall(i int) uint {
+	fake.getImportByIDMutex.RLock()
+	defer fake.getImportByIDMutex.RUnlock()
+	argsForCall := fake.getImportByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportRepository) GetImportByIDReturns(result1 *domain.Import, result2 error) {
+	fake.getImportByIDMutex.Lock()
+	defer fake.getImportByIDMutex.Unlock()
+	fake.GetImportByIDStub = nil
+	fake.getImportByIDReturns = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) GetImportByIDReturnsOnCall(i int, result1 *domain.Import, result2 error) {
+	fake.getImportByIDMutex.Lock()
+	defer fake.getImportByIDMutex.Unlock()
+	fake.GetImportByIDStub = nil
+	if fake.getImportByIDReturnsOnCall == nil {
+		fake.getImportByIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.Import
+			result2 error
+		})
+	}
+	fake.getImportByIDReturnsOnCall[i] = struct {
+		result1 *domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) GetImports() ([]domain.Import, error) {
+	fake.getImportsMutex.Lock()
+	ret, specificReturn := fake.getImportsReturnsOnCall[len(fake.getImportsArgsForCall)]
+	fake.getImportsArgsForCall = append(fake.getImportsArgsForCall, struct {
+	}{})
+	stub := fake.GetImportsStub
+	fakeReturns := fake.getImportsReturns
+	fake.recordInvocation("GetImports", []interface{}{})
+	fake.getImportsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportRepository) GetImportsCallCount() int {
+	fake.getImportsMutex.RLock()
+	defer fake.getImportsMutex.RUnlock()
+	return len(fake.getImportsArgsForCall)
+}
+
+func (fake *FakeImportRepository) GetImportsCalls(stub func() ([]domain.Import, error)) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = stub
+}
+
+func (fake *FakeImportRepository) GetImportsReturns(result1 []domain.Import, result2 error) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = nil
+	fake.getImportsReturns = struct {
+		result1 []domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) GetImportsReturnsOnCall(i int, result1 []domain.Import, result2 error) {
+	fake.getImportsMutex.Lock()
+	defer fake.getImportsMutex.Unlock()
+	fake.GetImportsStub = nil
+	if fake.getImportsReturnsOnCall == nil {
+		fake.getImportsReturnsOnCall = make(map[int]struct {
+			result1 []domain.Import
+			result2 error
+		})
+	}
+	fake.getImportsReturnsOnCall[i] = struct {
+		result1 []domain.Import
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImportRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeImportRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeImportRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) RollbackImport(arg1 *domain.Import) (int64, error) {
+	fake.rollbackImportMutex.Lock()
+	ret, specificReturn := fake.rollbackImportReturnsOnCall[len(fake.rollbackImportArgsForCall)]
+	fake.rollbackImportArgsForCall = append(fake.rollbackImportArgsForCall, struct {
+		arg1 *domain.Import
+	}{arg1})
+	stub := fake.RollbackImportStub
+	fakeReturns := fake.rollbackImportReturns
+	fake.recordInvocation("RollbackImport", []interface{}{arg1})
+	fake.rollbackImportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImportRepository) RollbackImportCallCount() int {
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	return len(fake.rollbackImportArgsForCall)
+}
+
+func (fake *FakeImportRepository) RollbackImportCalls(stub func(*domain.Import) (int64, error)) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = stub
+}
+
+func (fake *FakeImportRepository) RollbackImportArgsForCall(i int) *domain.Import {
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	argsForCall := fake.rollbackImportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportRepository) RollbackImportReturns(result1 int64, result2 error) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = nil
+	fake.rollbackImportReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) RollbackImportReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.rollbackImportMutex.Lock()
+	defer fake.rollbackImportMutex.Unlock()
+	fake.RollbackImportStub = nil
+	if fake.rollbackImportReturnsOnCall == nil {
+		fake.rollbackImportReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.rollbackImportReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImportRepository) UpdateImport(arg1 *domain.Import) error {
+	fake.updateImportMutex.Lock()
+	ret, specificReturn := fake.updateImportReturnsOnCall[len(fake.updateImportArgsForCall)]
+	fake.updateImportArgsForCall = append(fake.updateImportArgsForCall, struct {
+		arg1 *domain.Import
+	}{arg1})
+	stub := fake.UpdateImportStub
+	fakeReturns := fake.updateImportReturns
+	fake.recordInvocation("UpdateImport", []interface{}{arg1})
+	fake.updateImportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImportRepository) UpdateImportCallCount() int {
+	fake.updateImportMutex.RLock()
+	defer fake.updateImportMutex.RUnlock()
+	return len(fake.updateImportArgsForCall)
+}
+
+func (fake *FakeImportRepository) UpdateImportCalls(stub func(*domain.Import) error) {
+	fake.updateImportMutex.Lock()
+	defer fake.updateImportMutex.Unlock()
+	fake.UpdateImportStub = stub
+}
+
+func (fake *FakeImportRepository) UpdateImportArgsForCall(i int) *domain.Import {
+	fake.updateImportMutex.RLock()
+	defer fake.updateImportMutex.RUnlock()
+	argsForCall := fake.updateImportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImportRepository) UpdateImportReturns(result1 error) {
+	fake.updateImportMutex.Lock()
+	defer fake.updateImportMutex.Unlock()
+	fake.UpdateImportStub = nil
+	fake.updateImportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) UpdateImportReturnsOnCall(i int, result1 error) {
+	fake.updateImportMutex.Lock()
+	defer fake.updateImportMutex.Unlock()
+	fake.UpdateImportStub = nil
+	if fake.updateImportReturnsOnCall == nil {
+		fake.updateImportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateImportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImportRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createImportMutex.RLock()
+	defer fake.createImportMutex.RUnlock()
+	fake.getImportByChecksumMutex.RLock()
+	defer fake.getImportByChecksumMutex.RUnlock()
+	fake.getImportByIDMutex.RLock()
+	defer fake.getImportByIDMutex.RUnlock()
+	fake.getImportsMutex.RLock()
+	defer fake.getImportsMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	fake.rollbackImportMutex.RLock()
+	defer fake.rollbackImportMutex.RUnlock()
+	fake.updateImportMutex.RLock()
+	defer fake.updateImportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImportRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.ImportRepository = new(FakeImportRepository)
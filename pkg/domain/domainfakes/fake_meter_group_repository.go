@@ -0,0 +1,404 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeMeterGroupRepository struct {
+	CreateMeterGroupStub        func(*domain.MeterGroup) error
+	createMeterGroupMutex       sync.RWMutex
+	createMeterGroupArgsForCall []struct {
+		arg1 *domain.MeterGroup
+	}
+	createMeterGroupReturns struct {
+		result1 error
+	}
+	createMeterGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetMeterGroupByIDStub        func(uint) (*domain.MeterGroup, error)
+	getMeterGroupByIDMutex       sync.RWMutex
+	getMeterGroupByIDArgsForCall []struct {
+		arg1 uint
+	}
+	getMeterGroupByIDReturns struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}
+	getMeterGroupByIDReturnsOnCall map[int]struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}
+	GetMeterGroupsStub        func() ([]domain.MeterGroup, error)
+	getMeterGroupsMutex       sync.RWMutex
+	getMeterGroupsArgsForCall []struct {
+	}
+	getMeterGroupsReturns struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	getMeterGroupsReturnsOnCall map[int]struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	GetMeterGroupsByParentIDStub        func(uint) ([]domain.MeterGroup, error)
+	getMeterGroupsByParentIDMutex       sync.RWMutex
+	getMeterGroupsByParentIDArgsForCall []struct {
+		arg1 uint
+	}
+	getMeterGroupsByParentIDReturns struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	getMeterGroupsByParentIDReturnsOnCall map[int]struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroup(arg1 *domain.MeterGroup) error {
+	fake.createMeterGroupMutex.Lock()
+	ret, specificReturn := fake.createMeterGroupReturnsOnCall[len(fake.createMeterGroupArgsForCall)]
+	fake.createMeterGroupArgsForCall = append(fake.createMeterGroupArgsForCall, struct {
+		arg1 *domain.MeterGroup
+	}{arg1})
+	stub := fake.CreateMeterGroupStub
+	fakeReturns := fake.createMeterGroupReturns
+	fake.recordInvocation("CreateMeterGroup", []interface{}{arg1})
+	fake.createMeterGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroupCallCount() int {
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	return len(fake.createMeterGroupArgsForCall)
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroupCalls(stub func(*domain.MeterGroup) error) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = stub
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroupArgsForCall(i int) *domain.MeterGroup {
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	argsForCall := fake.createMeterGroupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroupReturns(result1 error) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = nil
+	fake.createMeterGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterGroupRepository) CreateMeterGroupReturnsOnCall(i int, result1 error) {
+	fake.createMeterGroupMutex.Lock()
+	defer fake.createMeterGroupMutex.Unlock()
+	fake.CreateMeterGroupStub = nil
+	if fake.createMeterGroupReturnsOnCall == nil {
+		fake.createMeterGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createMeterGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByID(arg1 uint) (*domain.MeterGroup, error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	ret, specificReturn := fake.getMeterGroupByIDReturnsOnCall[len(fake.getMeterGroupByIDArgsForCall)]
+	fake.getMeterGroupByIDArgsForCall = append(fake.getMeterGroupByIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetMeterGroupByIDStub
+	fakeReturns := fake.getMeterGroupByIDReturns
+	fake.recordInvocation("GetMeterGroupByID", []interface{}{arg1})
+	fake.getMeterGroupByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByIDCallCount() int {
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	return len(fake.getMeterGroupByIDArgsForCall)
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByIDCalls(stub func(uint) (*domain.MeterGroup, error)) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = stub
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByIDArgsForCall(i int) uint {
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	argsForCall := fake.getMeterGroupByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByIDReturns(result1 *domain.MeterGroup, result2 error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = nil
+	fake.getMeterGroupByIDReturns = struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupByIDReturnsOnCall(i int, result1 *domain.MeterGroup, result2 error) {
+	fake.getMeterGroupByIDMutex.Lock()
+	defer fake.getMeterGroupByIDMutex.Unlock()
+	fake.GetMeterGroupByIDStub = nil
+	if fake.getMeterGroupByIDReturnsOnCall == nil {
+		fake.getMeterGroupByIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterGroup
+			result2 error
+		})
+	}
+	fake.getMeterGroupByIDReturnsOnCall[i] = struct {
+		result1 *domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroups() ([]domain.MeterGroup, error) {
+	fake.getMeterGroupsMutex.Lock()
+	ret, specificReturn := fake.getMeterGroupsReturnsOnCall[len(fake.getMeterGroupsArgsForCall)]
+	fake.getMeterGroupsArgsForCall = append(fake.getMeterGroupsArgsForCall, struct {
+	}{})
+	stub := fake.GetMeterGroupsStub
+	fakeReturns := fake.getMeterGroupsReturns
+	fake.recordInvocation("GetMeterGroups", []interface{}{})
+	fake.getMeterGroupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsCallCount() int {
+	fake.getMeterGroupsMutex.RLock()
+	defer fake.getMeterGroupsMutex.RUnlock()
+	return len(fake.getMeterGroupsArgsForCall)
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsCalls(stub func() ([]domain.MeterGroup, error)) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = stub
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsReturns(result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = nil
+	fake.getMeterGroupsReturns = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsReturnsOnCall(i int, result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsMutex.Lock()
+	defer fake.getMeterGroupsMutex.Unlock()
+	fake.GetMeterGroupsStub = nil
+	if fake.getMeterGroupsReturnsOnCall == nil {
+		fake.getMeterGroupsReturnsOnCall = make(map[int]struct {
+			result1 []domain.MeterGroup
+			result2 error
+		})
+	}
+	fake.getMeterGroupsReturnsOnCall[i] = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentID(arg1 uint) ([]domain.MeterGroup, error) {
+	fake.getMeterGroupsByParentIDMutex.Lock()
+	ret, specificReturn := fake.getMeterGroupsByParentIDReturnsOnCall[len(fake.getMeterGroupsByParentIDArgsForCall)]
+	fake.getMeterGroupsByParentIDArgsForCall = append(fake.getMeterGroupsByParentIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetMeterGroupsByParentIDStub
+	fakeReturns := fake.getMeterGroupsByParentIDReturns
+	fake.recordInvocation("GetMeterGroupsByParentID", []interface{}{arg1})
+	fake.getMeterGroupsByParentIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentIDCallCount() int {
+	fake.getMeterGroupsByParentIDMutex.RLock()
+	defer fake.getMeterGroupsByParentIDMutex.RUnlock()
+	return len(fake.getMeterGroupsByParentIDArgsForCall)
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentIDCalls(stub func(uint) ([]domain.MeterGroup, error)) {
+	fake.getMeterGroupsByParentIDMutex.Lock()
+	defer fake.getMeterGroupsByParentIDMutex.Unlock()
+	fake.GetMeterGroupsByParentIDStub = stub
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentIDArgsForCall(i int) uint {
+	fake.getMeterGroupsByParentIDMutex.RLock()
+	defer fake.getMeterGroupsByParentIDMutex.RUnlock()
+	argsForCall := fake.getMeterGroupsByParentIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentIDReturns(result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsByParentIDMutex.Lock()
+	defer fake.getMeterGroupsByParentIDMutex.Unlock()
+	fake.GetMeterGroupsByParentIDStub = nil
+	fake.getMeterGroupsByParentIDReturns = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) GetMeterGroupsByParentIDReturnsOnCall(i int, result1 []domain.MeterGroup, result2 error) {
+	fake.getMeterGroupsByParentIDMutex.Lock()
+	defer fake.getMeterGroupsByParentIDMutex.Unlock()
+	fake.GetMeterGroupsByParentIDStub = nil
+	if fake.getMeterGroupsByParentIDReturnsOnCall == nil {
+		fake.getMeterGroupsByParentIDReturnsOnCall = make(map[int]struct {
+			result1 []domain.MeterGroup
+			result2 error
+		})
+	}
+	fake.getMeterGroupsByParentIDReturnsOnCall[i] = struct {
+		result1 []domain.MeterGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterGroupRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMeterGroupRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeMeterGroupRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeMeterGroupRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterGroupRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createMeterGroupMutex.RLock()
+	defer fake.createMeterGroupMutex.RUnlock()
+	fake.getMeterGroupByIDMutex.RLock()
+	defer fake.getMeterGroupByIDMutex.RUnlock()
+	fake.getMeterGroupsMutex.RLock()
+	defer fake.getMeterGroupsMutex.RUnlock()
+	fake.getMeterGroupsByParentIDMutex.RLock()
+	defer fake.getMeterGroupsByParentIDMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMeterGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.MeterGroupRepository = new(FakeMeterGroupRepository)
@@ -0,0 +1,339 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeMeterSettingRepository struct {
+	GetMeterSettingByMeterIDStub        func(int) (*domain.MeterSetting, error)
+	getMeterSettingByMeterIDMutex       sync.RWMutex
+	getMeterSettingByMeterIDArgsForCall []struct {
+		arg1 int
+	}
+	getMeterSettingByMeterIDReturns struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	getMeterSettingByMeterIDReturnsOnCall map[int]struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}
+	GetMeterSettingsByMeterIDsStub        func([]int) ([]domain.MeterSetting, error)
+	getMeterSettingsByMeterIDsMutex       sync.RWMutex
+	getMeterSettingsByMeterIDsArgsForCall []struct {
+		arg1 []int
+	}
+	getMeterSettingsByMeterIDsReturns struct {
+		result1 []domain.MeterSetting
+		result2 error
+	}
+	getMeterSettingsByMeterIDsReturnsOnCall map[int]struct {
+		result1 []domain.MeterSetting
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveMeterSettingStub        func(*domain.MeterSetting) error
+	saveMeterSettingMutex       sync.RWMutex
+	saveMeterSettingArgsForCall []struct {
+		arg1 *domain.MeterSetting
+	}
+	saveMeterSettingReturns struct {
+		result1 error
+	}
+	saveMeterSettingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterID(arg1 int) (*domain.MeterSetting, error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	ret, specificReturn := fake.getMeterSettingByMeterIDReturnsOnCall[len(fake.getMeterSettingByMeterIDArgsForCall)]
+	fake.getMeterSettingByMeterIDArgsForCall = append(fake.getMeterSettingByMeterIDArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.GetMeterSettingByMeterIDStub
+	fakeReturns := fake.getMeterSettingByMeterIDReturns
+	fake.recordInvocation("GetMeterSettingByMeterID", []interface{}{arg1})
+	fake.getMeterSettingByMeterIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterIDCallCount() int {
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	return len(fake.getMeterSettingByMeterIDArgsForCall)
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterIDCalls(stub func(int) (*domain.MeterSetting, error)) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = stub
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterIDArgsForCall(i int) int {
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	argsForCall := fake.getMeterSettingByMeterIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterIDReturns(result1 *domain.MeterSetting, result2 error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = nil
+	fake.getMeterSettingByMeterIDReturns = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingByMeterIDReturnsOnCall(i int, result1 *domain.MeterSetting, result2 error) {
+	fake.getMeterSettingByMeterIDMutex.Lock()
+	defer fake.getMeterSettingByMeterIDMutex.Unlock()
+	fake.GetMeterSettingByMeterIDStub = nil
+	if fake.getMeterSettingByMeterIDReturnsOnCall == nil {
+		fake.getMeterSettingByMeterIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.MeterSetting
+			result2 error
+		})
+	}
+	fake.getMeterSettingByMeterIDReturnsOnCall[i] = struct {
+		result1 *domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDs(arg1 []int) ([]domain.MeterSetting, error) {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.getMeterSettingsByMeterIDsMutex.Lock()
+	ret, specificReturn := fake.getMeterSettingsByMeterIDsReturnsOnCall[len(fake.getMeterSettingsByMeterIDsArgsForCall)]
+	fake.getMeterSettingsByMeterIDsArgsForCall = append(fake.getMeterSettingsByMeterIDsArgsForCall, struct {
+		arg1 []int
+	}{arg1Copy})
+	stub := fake.GetMeterSettingsByMeterIDsStub
+	fakeReturns := fake.getMeterSettingsByMeterIDsReturns
+	fake.recordInvocation("GetMeterSettingsByMeterIDs", []interface{}{arg1Copy})
+	fake.getMeterSettingsByMeterIDsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDsCallCount() int {
+	fake.getMeterSettingsByMeterIDsMutex.RLock()
+	defer fake.getMeterSettingsByMeterIDsMutex.RUnlock()
+	return len(fake.getMeterSettingsByMeterIDsArgsForCall)
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDsCalls(stub func([]int) ([]domain.MeterSetting, error)) {
+	fake.getMeterSettingsByMeterIDsMutex.Lock()
+	defer fake.getMeterSettingsByMeterIDsMutex.Unlock()
+	fake.GetMeterSettingsByMeterIDsStub = stub
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDsArgsForCall(i int) []int {
+	fake.getMeterSettingsByMeterIDsMutex.RLock()
+	defer fake.getMeterSettingsByMeterIDsMutex.RUnlock()
+	argsForCall := fake.getMeterSettingsByMeterIDsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDsReturns(result1 []domain.MeterSetting, result2 error) {
+	fake.getMeterSettingsByMeterIDsMutex.Lock()
+	defer fake.getMeterSettingsByMeterIDsMutex.Unlock()
+	fake.GetMeterSettingsByMeterIDsStub = nil
+	fake.getMeterSettingsByMeterIDsReturns = struct {
+		result1 []domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingRepository) GetMeterSettingsByMeterIDsReturnsOnCall(i int, result1 []domain.MeterSetting, result2 error) {
+	fake.getMeterSettingsByMeterIDsMutex.Lock()
+	defer fake.getMeterSettingsByMeterIDsMutex.Unlock()
+	fake.GetMeterSettingsByMeterIDsStub = nil
+	if fake.getMeterSettingsByMeterIDsReturnsOnCall == nil {
+		fake.getMeterSettingsByMeterIDsReturnsOnCall = make(map[int]struct {
+			result1 []domain.MeterSetting
+			result2 error
+		})
+	}
+	fake.getMeterSettingsByMeterIDsReturnsOnCall[i] = struct {
+		result1 []domain.MeterSetting
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMeterSettingRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMeterSettingRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeMeterSettingRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeMeterSettingRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterSettingRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSetting(arg1 *domain.MeterSetting) error {
+	fake.saveMeterSettingMutex.Lock()
+	ret, specificReturn := fake.saveMeterSettingReturnsOnCall[len(fake.saveMeterSettingArgsForCall)]
+	fake.saveMeterSettingArgsForCall = append(fake.saveMeterSettingArgsForCall, struct {
+		arg1 *domain.MeterSetting
+	}{arg1})
+	stub := fake.SaveMeterSettingStub
+	fakeReturns := fake.saveMeterSettingReturns
+	fake.recordInvocation("SaveMeterSetting", []interface{}{arg1})
+	fake.saveMeterSettingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSettingCallCount() int {
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	return len(fake.saveMeterSettingArgsForCall)
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSettingCalls(stub func(*domain.MeterSetting) error) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = stub
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSettingArgsForCall(i int) *domain.MeterSetting {
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	argsForCall := fake.saveMeterSettingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSettingReturns(result1 error) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = nil
+	fake.saveMeterSettingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterSettingRepository) SaveMeterSettingReturnsOnCall(i int, result1 error) {
+	fake.saveMeterSettingMutex.Lock()
+	defer fake.saveMeterSettingMutex.Unlock()
+	fake.SaveMeterSettingStub = nil
+	if fake.saveMeterSettingReturnsOnCall == nil {
+		fake.saveMeterSettingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMeterSettingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMeterSettingRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMeterSettingByMeterIDMutex.RLock()
+	defer fake.getMeterSettingByMeterIDMutex.RUnlock()
+	fake.getMeterSettingsByMeterIDsMutex.RLock()
+	defer fake.getMeterSettingsByMeterIDsMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	fake.saveMeterSettingMutex.RLock()
+	defer fake.saveMeterSettingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMeterSettingRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.MeterSettingRepository = new(FakeMeterSettingRepository)
@@ -9,16 +9,75 @@ import (
 )
 
 type FakeMySQLPowerConsumptionRepository struct {
-	CreatePowerConsumptionRecordsStub        func([]*domain.UserConsumption) error
+	CreateImportRecordsStub        func([]*domain.UserConsumption, []*domain.QuarantinedReading, *domain.Import, []int) ([]*domain.UserConsumption, error)
+	createImportRecordsMutex       sync.RWMutex
+	createImportRecordsArgsForCall []struct {
+		arg1 []*domain.UserConsumption
+		arg2 []*domain.QuarantinedReading
+		arg3 *domain.Import
+		arg4 []int
+	}
+	createImportRecordsReturns struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}
+	createImportRecordsReturnsOnCall map[int]struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}
+	CreateIngestedRecordsStub        func([]*domain.UserConsumption, []*domain.QuarantinedReading) ([]*domain.UserConsumption, error)
+	createIngestedRecordsMutex       sync.RWMutex
+	createIngestedRecordsArgsForCall []struct {
+		arg1 []*domain.UserConsumption
+		arg2 []*domain.QuarantinedReading
+	}
+	createIngestedRecordsReturns struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}
+	createIngestedRecordsReturnsOnCall map[int]struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}
+	CreatePowerConsumptionRecordsStub        func([]*domain.UserConsumption) ([]*domain.UserConsumption, error)
 	createPowerConsumptionRecordsMutex       sync.RWMutex
 	createPowerConsumptionRecordsArgsForCall []struct {
 		arg1 []*domain.UserConsumption
 	}
 	createPowerConsumptionRecordsReturns struct {
-		result1 error
+		result1 []*domain.UserConsumption
+		result2 error
 	}
 	createPowerConsumptionRecordsReturnsOnCall map[int]struct {
-		result1 error
+		result1 []*domain.UserConsumption
+		result2 error
+	}
+	GetConsumptionByImportIDStub        func(uint) ([]domain.UserConsumption, error)
+	getConsumptionByImportIDMutex       sync.RWMutex
+	getConsumptionByImportIDArgsForCall []struct {
+		arg1 uint
+	}
+	getConsumptionByImportIDReturns struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}
+	getConsumptionByImportIDReturnsOnCall map[int]struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}
+	GetConsumptionByMeterIDAndDatesStub        func(int, []time.Time) ([]domain.UserConsumption, error)
+	getConsumptionByMeterIDAndDatesMutex       sync.RWMutex
+	getConsumptionByMeterIDAndDatesArgsForCall []struct {
+		arg1 int
+		arg2 []time.Time
+	}
+	getConsumptionByMeterIDAndDatesReturns struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}
+	getConsumptionByMeterIDAndDatesReturnsOnCall map[int]struct {
+		result1 []domain.UserConsumption
+		result2 error
 	}
 	GetConsumptionByMeterIDAndWindowTimeStub        func(time.Time, time.Time, int) ([]domain.UserConsumption, error)
 	getConsumptionByMeterIDAndWindowTimeMutex       sync.RWMutex
@@ -35,6 +94,35 @@ type FakeMySQLPowerConsumptionRepository struct {
 		result1 []domain.UserConsumption
 		result2 error
 	}
+	GetConsumptionByMeterIDsAndWindowTimeStub        func(time.Time, time.Time, []int) ([]domain.UserConsumption, error)
+	getConsumptionByMeterIDsAndWindowTimeMutex       sync.RWMutex
+	getConsumptionByMeterIDsAndWindowTimeArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 []int
+	}
+	getConsumptionByMeterIDsAndWindowTimeReturns struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}
+	getConsumptionByMeterIDsAndWindowTimeReturnsOnCall map[int]struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}
+	GetLastConsumptionBeforeDateStub        func(time.Time, int) (*domain.UserConsumption, error)
+	getLastConsumptionBeforeDateMutex       sync.RWMutex
+	getLastConsumptionBeforeDateArgsForCall []struct {
+		arg1 time.Time
+		arg2 int
+	}
+	getLastConsumptionBeforeDateReturns struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}
+	getLastConsumptionBeforeDateReturnsOnCall map[int]struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}
 	ModelMigrationStub        func() error
 	modelMigrationMutex       sync.RWMutex
 	modelMigrationArgsForCall []struct {
@@ -49,7 +137,164 @@ type FakeMySQLPowerConsumptionRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecords(arg1 []*domain.UserConsumption) error {
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecords(arg1 []*domain.UserConsumption, arg2 []*domain.QuarantinedReading, arg3 *domain.Import, arg4 []int) ([]*domain.UserConsumption, error) {
+	var arg1Copy []*domain.UserConsumption
+	if arg1 != nil {
+		arg1Copy = make([]*domain.UserConsumption, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*domain.QuarantinedReading
+	if arg2 != nil {
+		arg2Copy = make([]*domain.QuarantinedReading, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg4Copy []int
+	if arg4 != nil {
+		arg4Copy = make([]int, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.createImportRecordsMutex.Lock()
+	ret, specificReturn := fake.createImportRecordsReturnsOnCall[len(fake.createImportRecordsArgsForCall)]
+	fake.createImportRecordsArgsForCall = append(fake.createImportRecordsArgsForCall, struct {
+		arg1 []*domain.UserConsumption
+		arg2 []*domain.QuarantinedReading
+		arg3 *domain.Import
+		arg4 []int
+	}{arg1Copy, arg2Copy, arg3, arg4Copy})
+	stub := fake.CreateImportRecordsStub
+	fakeReturns := fake.createImportRecordsReturns
+	fake.recordInvocation("CreateImportRecords", []interface{}{arg1Copy, arg2Copy, arg3, arg4Copy})
+	fake.createImportRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecordsCallCount() int {
+	fake.createImportRecordsMutex.RLock()
+	defer fake.createImportRecordsMutex.RUnlock()
+	return len(fake.createImportRecordsArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecordsCalls(stub func([]*domain.UserConsumption, []*domain.QuarantinedReading, *domain.Import, []int) ([]*domain.UserConsumption, error)) {
+	fake.createImportRecordsMutex.Lock()
+	defer fake.createImportRecordsMutex.Unlock()
+	fake.CreateImportRecordsStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecordsArgsForCall(i int) ([]*domain.UserConsumption, []*domain.QuarantinedReading, *domain.Import, []int) {
+	fake.createImportRecordsMutex.RLock()
+	defer fake.createImportRecordsMutex.RUnlock()
+	argsForCall := fake.createImportRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecordsReturns(result1 []*domain.UserConsumption, result2 error) {
+	fake.createImportRecordsMutex.Lock()
+	defer fake.createImportRecordsMutex.Unlock()
+	fake.CreateImportRecordsStub = nil
+	fake.createImportRecordsReturns = struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateImportRecordsReturnsOnCall(i int, result1 []*domain.UserConsumption, result2 error) {
+	fake.createImportRecordsMutex.Lock()
+	defer fake.createImportRecordsMutex.Unlock()
+	fake.CreateImportRecordsStub = nil
+	if fake.createImportRecordsReturnsOnCall == nil {
+		fake.createImportRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.createImportRecordsReturnsOnCall[i] = struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecords(arg1 []*domain.UserConsumption, arg2 []*domain.QuarantinedReading) ([]*domain.UserConsumption, error) {
+	var arg1Copy []*domain.UserConsumption
+	if arg1 != nil {
+		arg1Copy = make([]*domain.UserConsumption, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*domain.QuarantinedReading
+	if arg2 != nil {
+		arg2Copy = make([]*domain.QuarantinedReading, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createIngestedRecordsMutex.Lock()
+	ret, specificReturn := fake.createIngestedRecordsReturnsOnCall[len(fake.createIngestedRecordsArgsForCall)]
+	fake.createIngestedRecordsArgsForCall = append(fake.createIngestedRecordsArgsForCall, struct {
+		arg1 []*domain.UserConsumption
+		arg2 []*domain.QuarantinedReading
+	}{arg1Copy, arg2Copy})
+	stub := fake.CreateIngestedRecordsStub
+	fakeReturns := fake.createIngestedRecordsReturns
+	fake.recordInvocation("CreateIngestedRecords", []interface{}{arg1Copy, arg2Copy})
+	fake.createIngestedRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecordsCallCount() int {
+	fake.createIngestedRecordsMutex.RLock()
+	defer fake.createIngestedRecordsMutex.RUnlock()
+	return len(fake.createIngestedRecordsArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecordsCalls(stub func([]*domain.UserConsumption, []*domain.QuarantinedReading) ([]*domain.UserConsumption, error)) {
+	fake.createIngestedRecordsMutex.Lock()
+	defer fake.createIngestedRecordsMutex.Unlock()
+	fake.CreateIngestedRecordsStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecordsArgsForCall(i int) ([]*domain.UserConsumption, []*domain.QuarantinedReading) {
+	fake.createIngestedRecordsMutex.RLock()
+	defer fake.createIngestedRecordsMutex.RUnlock()
+	argsForCall := fake.createIngestedRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecordsReturns(result1 []*domain.UserConsumption, result2 error) {
+	fake.createIngestedRecordsMutex.Lock()
+	defer fake.createIngestedRecordsMutex.Unlock()
+	fake.CreateIngestedRecordsStub = nil
+	fake.createIngestedRecordsReturns = struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreateIngestedRecordsReturnsOnCall(i int, result1 []*domain.UserConsumption, result2 error) {
+	fake.createIngestedRecordsMutex.Lock()
+	defer fake.createIngestedRecordsMutex.Unlock()
+	fake.CreateIngestedRecordsStub = nil
+	if fake.createIngestedRecordsReturnsOnCall == nil {
+		fake.createIngestedRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.createIngestedRecordsReturnsOnCall[i] = struct {
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecords(arg1 []*domain.UserConsumption) ([]*domain.UserConsumption, error) {
 	var arg1Copy []*domain.UserConsumption
 	if arg1 != nil {
 		arg1Copy = make([]*domain.UserConsumption, len(arg1))
@@ -68,9 +313,9 @@ func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecords(a
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsCallCount() int {
@@ -79,7 +324,7 @@ func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsCa
 	return len(fake.createPowerConsumptionRecordsArgsForCall)
 }
 
-func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsCalls(stub func([]*domain.UserConsumption) error) {
+func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsCalls(stub func([]*domain.UserConsumption) ([]*domain.UserConsumption, error)) {
 	fake.createPowerConsumptionRecordsMutex.Lock()
 	defer fake.createPowerConsumptionRecordsMutex.Unlock()
 	fake.CreatePowerConsumptionRecordsStub = stub
@@ -92,27 +337,164 @@ func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsAr
 	return argsForCall.arg1
 }
 
-func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsReturns(result1 error) {
+func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsReturns(result1 []*domain.UserConsumption, result2 error) {
 	fake.createPowerConsumptionRecordsMutex.Lock()
 	defer fake.createPowerConsumptionRecordsMutex.Unlock()
 	fake.CreatePowerConsumptionRecordsStub = nil
 	fake.createPowerConsumptionRecordsReturns = struct {
-		result1 error
-	}{result1}
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsReturnsOnCall(i int, result1 error) {
+func (fake *FakeMySQLPowerConsumptionRepository) CreatePowerConsumptionRecordsReturnsOnCall(i int, result1 []*domain.UserConsumption, result2 error) {
 	fake.createPowerConsumptionRecordsMutex.Lock()
 	defer fake.createPowerConsumptionRecordsMutex.Unlock()
 	fake.CreatePowerConsumptionRecordsStub = nil
 	if fake.createPowerConsumptionRecordsReturnsOnCall == nil {
 		fake.createPowerConsumptionRecordsReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 []*domain.UserConsumption
+			result2 error
 		})
 	}
 	fake.createPowerConsumptionRecordsReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 []*domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportID(arg1 uint) ([]domain.UserConsumption, error) {
+	fake.getConsumptionByImportIDMutex.Lock()
+	ret, specificReturn := fake.getConsumptionByImportIDReturnsOnCall[len(fake.getConsumptionByImportIDArgsForCall)]
+	fake.getConsumptionByImportIDArgsForCall = append(fake.getConsumptionByImportIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetConsumptionByImportIDStub
+	fakeReturns := fake.getConsumptionByImportIDReturns
+	fake.recordInvocation("GetConsumptionByImportID", []interface{}{arg1})
+	fake.getConsumptionByImportIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportIDCallCount() int {
+	fake.getConsumptionByImportIDMutex.RLock()
+	defer fake.getConsumptionByImportIDMutex.RUnlock()
+	return len(fake.getConsumptionByImportIDArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportIDCalls(stub func(uint) ([]domain.UserConsumption, error)) {
+	fake.getConsumptionByImportIDMutex.Lock()
+	defer fake.getConsumptionByImportIDMutex.Unlock()
+	fake.GetConsumptionByImportIDStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportIDArgsForCall(i int) uint {
+	fake.getConsumptionByImportIDMutex.RLock()
+	defer fake.getConsumptionByImportIDMutex.RUnlock()
+	argsForCall := fake.getConsumptionByImportIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportIDReturns(result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByImportIDMutex.Lock()
+	defer fake.getConsumptionByImportIDMutex.Unlock()
+	fake.GetConsumptionByImportIDStub = nil
+	fake.getConsumptionByImportIDReturns = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByImportIDReturnsOnCall(i int, result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByImportIDMutex.Lock()
+	defer fake.getConsumptionByImportIDMutex.Unlock()
+	fake.GetConsumptionByImportIDStub = nil
+	if fake.getConsumptionByImportIDReturnsOnCall == nil {
+		fake.getConsumptionByImportIDReturnsOnCall = make(map[int]struct {
+			result1 []domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.getConsumptionByImportIDReturnsOnCall[i] = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDates(arg1 int, arg2 []time.Time) ([]domain.UserConsumption, error) {
+	var arg2Copy []time.Time
+	if arg2 != nil {
+		arg2Copy = make([]time.Time, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getConsumptionByMeterIDAndDatesMutex.Lock()
+	ret, specificReturn := fake.getConsumptionByMeterIDAndDatesReturnsOnCall[len(fake.getConsumptionByMeterIDAndDatesArgsForCall)]
+	fake.getConsumptionByMeterIDAndDatesArgsForCall = append(fake.getConsumptionByMeterIDAndDatesArgsForCall, struct {
+		arg1 int
+		arg2 []time.Time
+	}{arg1, arg2Copy})
+	stub := fake.GetConsumptionByMeterIDAndDatesStub
+	fakeReturns := fake.getConsumptionByMeterIDAndDatesReturns
+	fake.recordInvocation("GetConsumptionByMeterIDAndDates", []interface{}{arg1, arg2Copy})
+	fake.getConsumptionByMeterIDAndDatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDatesCallCount() int {
+	fake.getConsumptionByMeterIDAndDatesMutex.RLock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.RUnlock()
+	return len(fake.getConsumptionByMeterIDAndDatesArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDatesCalls(stub func(int, []time.Time) ([]domain.UserConsumption, error)) {
+	fake.getConsumptionByMeterIDAndDatesMutex.Lock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.Unlock()
+	fake.GetConsumptionByMeterIDAndDatesStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDatesArgsForCall(i int) (int, []time.Time) {
+	fake.getConsumptionByMeterIDAndDatesMutex.RLock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.RUnlock()
+	argsForCall := fake.getConsumptionByMeterIDAndDatesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDatesReturns(result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByMeterIDAndDatesMutex.Lock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.Unlock()
+	fake.GetConsumptionByMeterIDAndDatesStub = nil
+	fake.getConsumptionByMeterIDAndDatesReturns = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndDatesReturnsOnCall(i int, result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByMeterIDAndDatesMutex.Lock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.Unlock()
+	fake.GetConsumptionByMeterIDAndDatesStub = nil
+	if fake.getConsumptionByMeterIDAndDatesReturnsOnCall == nil {
+		fake.getConsumptionByMeterIDAndDatesReturnsOnCall = make(map[int]struct {
+			result1 []domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.getConsumptionByMeterIDAndDatesReturnsOnCall[i] = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndWindowTime(arg1 time.Time, arg2 time.Time, arg3 int) ([]domain.UserConsumption, error) {
@@ -181,6 +563,142 @@ func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDAndWindo
 	}{result1, result2}
 }
 
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTime(arg1 time.Time, arg2 time.Time, arg3 []int) ([]domain.UserConsumption, error) {
+	var arg3Copy []int
+	if arg3 != nil {
+		arg3Copy = make([]int, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.Lock()
+	ret, specificReturn := fake.getConsumptionByMeterIDsAndWindowTimeReturnsOnCall[len(fake.getConsumptionByMeterIDsAndWindowTimeArgsForCall)]
+	fake.getConsumptionByMeterIDsAndWindowTimeArgsForCall = append(fake.getConsumptionByMeterIDsAndWindowTimeArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 []int
+	}{arg1, arg2, arg3Copy})
+	stub := fake.GetConsumptionByMeterIDsAndWindowTimeStub
+	fakeReturns := fake.getConsumptionByMeterIDsAndWindowTimeReturns
+	fake.recordInvocation("GetConsumptionByMeterIDsAndWindowTime", []interface{}{arg1, arg2, arg3Copy})
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTimeCallCount() int {
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.RUnlock()
+	return len(fake.getConsumptionByMeterIDsAndWindowTimeArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTimeCalls(stub func(time.Time, time.Time, []int) ([]domain.UserConsumption, error)) {
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByMeterIDsAndWindowTimeStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTimeArgsForCall(i int) (time.Time, time.Time, []int) {
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.RUnlock()
+	argsForCall := fake.getConsumptionByMeterIDsAndWindowTimeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTimeReturns(result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByMeterIDsAndWindowTimeStub = nil
+	fake.getConsumptionByMeterIDsAndWindowTimeReturns = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetConsumptionByMeterIDsAndWindowTimeReturnsOnCall(i int, result1 []domain.UserConsumption, result2 error) {
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.Lock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.Unlock()
+	fake.GetConsumptionByMeterIDsAndWindowTimeStub = nil
+	if fake.getConsumptionByMeterIDsAndWindowTimeReturnsOnCall == nil {
+		fake.getConsumptionByMeterIDsAndWindowTimeReturnsOnCall = make(map[int]struct {
+			result1 []domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.getConsumptionByMeterIDsAndWindowTimeReturnsOnCall[i] = struct {
+		result1 []domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDate(arg1 time.Time, arg2 int) (*domain.UserConsumption, error) {
+	fake.getLastConsumptionBeforeDateMutex.Lock()
+	ret, specificReturn := fake.getLastConsumptionBeforeDateReturnsOnCall[len(fake.getLastConsumptionBeforeDateArgsForCall)]
+	fake.getLastConsumptionBeforeDateArgsForCall = append(fake.getLastConsumptionBeforeDateArgsForCall, struct {
+		arg1 time.Time
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetLastConsumptionBeforeDateStub
+	fakeReturns := fake.getLastConsumptionBeforeDateReturns
+	fake.recordInvocation("GetLastConsumptionBeforeDate", []interface{}{arg1, arg2})
+	fake.getLastConsumptionBeforeDateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDateCallCount() int {
+	fake.getLastConsumptionBeforeDateMutex.RLock()
+	defer fake.getLastConsumptionBeforeDateMutex.RUnlock()
+	return len(fake.getLastConsumptionBeforeDateArgsForCall)
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDateCalls(stub func(time.Time, int) (*domain.UserConsumption, error)) {
+	fake.getLastConsumptionBeforeDateMutex.Lock()
+	defer fake.getLastConsumptionBeforeDateMutex.Unlock()
+	fake.GetLastConsumptionBeforeDateStub = stub
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDateArgsForCall(i int) (time.Time, int) {
+	fake.getLastConsumptionBeforeDateMutex.RLock()
+	defer fake.getLastConsumptionBeforeDateMutex.RUnlock()
+	argsForCall := fake.getLastConsumptionBeforeDateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDateReturns(result1 *domain.UserConsumption, result2 error) {
+	fake.getLastConsumptionBeforeDateMutex.Lock()
+	defer fake.getLastConsumptionBeforeDateMutex.Unlock()
+	fake.GetLastConsumptionBeforeDateStub = nil
+	fake.getLastConsumptionBeforeDateReturns = struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMySQLPowerConsumptionRepository) GetLastConsumptionBeforeDateReturnsOnCall(i int, result1 *domain.UserConsumption, result2 error) {
+	fake.getLastConsumptionBeforeDateMutex.Lock()
+	defer fake.getLastConsumptionBeforeDateMutex.Unlock()
+	fake.GetLastConsumptionBeforeDateStub = nil
+	if fake.getLastConsumptionBeforeDateReturnsOnCall == nil {
+		fake.getLastConsumptionBeforeDateReturnsOnCall = make(map[int]struct {
+			result1 *domain.UserConsumption
+			result2 error
+		})
+	}
+	fake.getLastConsumptionBeforeDateReturnsOnCall[i] = struct {
+		result1 *domain.UserConsumption
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMySQLPowerConsumptionRepository) ModelMigration() error {
 	fake.modelMigrationMutex.Lock()
 	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
@@ -237,10 +755,22 @@ func (fake *FakeMySQLPowerConsumptionRepository) ModelMigrationReturnsOnCall(i i
 func (fake *FakeMySQLPowerConsumptionRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createImportRecordsMutex.RLock()
+	defer fake.createImportRecordsMutex.RUnlock()
+	fake.createIngestedRecordsMutex.RLock()
+	defer fake.createIngestedRecordsMutex.RUnlock()
 	fake.createPowerConsumptionRecordsMutex.RLock()
 	defer fake.createPowerConsumptionRecordsMutex.RUnlock()
+	fake.getConsumptionByImportIDMutex.RLock()
+	defer fake.getConsumptionByImportIDMutex.RUnlock()
+	fake.getConsumptionByMeterIDAndDatesMutex.RLock()
+	defer fake.getConsumptionByMeterIDAndDatesMutex.RUnlock()
 	fake.getConsumptionByMeterIDAndWindowTimeMutex.RLock()
 	defer fake.getConsumptionByMeterIDAndWindowTimeMutex.RUnlock()
+	fake.getConsumptionByMeterIDsAndWindowTimeMutex.RLock()
+	defer fake.getConsumptionByMeterIDsAndWindowTimeMutex.RUnlock()
+	fake.getLastConsumptionBeforeDateMutex.RLock()
+	defer fake.getLastConsumptionBeforeDateMutex.RUnlock()
 	fake.modelMigrationMutex.RLock()
 	defer fake.modelMigrationMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,504 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeOutboxRepository struct {
+	CreateOutboxEventStub        func(*domain.OutboxEvent) error
+	createOutboxEventMutex       sync.RWMutex
+	createOutboxEventArgsForCall []struct {
+		arg1 *domain.OutboxEvent
+	}
+	createOutboxEventReturns struct {
+		result1 error
+	}
+	createOutboxEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPendingOutboxEventsStub        func(time.Time, int) ([]domain.OutboxEvent, error)
+	getPendingOutboxEventsMutex       sync.RWMutex
+	getPendingOutboxEventsArgsForCall []struct {
+		arg1 time.Time
+		arg2 int
+	}
+	getPendingOutboxEventsReturns struct {
+		result1 []domain.OutboxEvent
+		result2 error
+	}
+	getPendingOutboxEventsReturnsOnCall map[int]struct {
+		result1 []domain.OutboxEvent
+		result2 error
+	}
+	MarkOutboxEventDeadLetteredStub        func(uint, []string, string, time.Time) error
+	markOutboxEventDeadLetteredMutex       sync.RWMutex
+	markOutboxEventDeadLetteredArgsForCall []struct {
+		arg1 uint
+		arg2 []string
+		arg3 string
+		arg4 time.Time
+	}
+	markOutboxEventDeadLetteredReturns struct {
+		result1 error
+	}
+	markOutboxEventDeadLetteredReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkOutboxEventFailedStub        func(uint, []string, string, time.Time) error
+	markOutboxEventFailedMutex       sync.RWMutex
+	markOutboxEventFailedArgsForCall []struct {
+		arg1 uint
+		arg2 []string
+		arg3 string
+		arg4 time.Time
+	}
+	markOutboxEventFailedReturns struct {
+		result1 error
+	}
+	markOutboxEventFailedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkOutboxEventPublishedStub        func(uint, time.Time) error
+	markOutboxEventPublishedMutex       sync.RWMutex
+	markOutboxEventPublishedArgsForCall []struct {
+		arg1 uint
+		arg2 time.Time
+	}
+	markOutboxEventPublishedReturns struct {
+		result1 error
+	}
+	markOutboxEventPublishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEvent(arg1 *domain.OutboxEvent) error {
+	fake.createOutboxEventMutex.Lock()
+	ret, specificReturn := fake.createOutboxEventReturnsOnCall[len(fake.createOutboxEventArgsForCall)]
+	fake.createOutboxEventArgsForCall = append(fake.createOutboxEventArgsForCall, struct {
+		arg1 *domain.OutboxEvent
+	}{arg1})
+	stub := fake.CreateOutboxEventStub
+	fakeReturns := fake.createOutboxEventReturns
+	fake.recordInvocation("CreateOutboxEvent", []interface{}{arg1})
+	fake.createOutboxEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEventCallCount() int {
+	fake.createOutboxEventMutex.RLock()
+	defer fake.createOutboxEventMutex.RUnlock()
+	return len(fake.createOutboxEventArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEventCalls(stub func(*domain.OutboxEvent) error) {
+	fake.createOutboxEventMutex.Lock()
+	defer fake.createOutboxEventMutex.Unlock()
+	fake.CreateOutboxEventStub = stub
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEventArgsForCall(i int) *domain.OutboxEvent {
+	fake.createOutboxEventMutex.RLock()
+	defer fake.createOutboxEventMutex.RUnlock()
+	argsForCall := fake.createOutboxEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEventReturns(result1 error) {
+	fake.createOutboxEventMutex.Lock()
+	defer fake.createOutboxEventMutex.Unlock()
+	fake.CreateOutboxEventStub = nil
+	fake.createOutboxEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) CreateOutboxEventReturnsOnCall(i int, result1 error) {
+	fake.createOutboxEventMutex.Lock()
+	defer fake.createOutboxEventMutex.Unlock()
+	fake.CreateOutboxEventStub = nil
+	if fake.createOutboxEventReturnsOnCall == nil {
+		fake.createOutboxEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createOutboxEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEvents(arg1 time.Time, arg2 int) ([]domain.OutboxEvent, error) {
+	fake.getPendingOutboxEventsMutex.Lock()
+	ret, specificReturn := fake.getPendingOutboxEventsReturnsOnCall[len(fake.getPendingOutboxEventsArgsForCall)]
+	fake.getPendingOutboxEventsArgsForCall = append(fake.getPendingOutboxEventsArgsForCall, struct {
+		arg1 time.Time
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetPendingOutboxEventsStub
+	fakeReturns := fake.getPendingOutboxEventsReturns
+	fake.recordInvocation("GetPendingOutboxEvents", []interface{}{arg1, arg2})
+	fake.getPendingOutboxEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEventsCallCount() int {
+	fake.getPendingOutboxEventsMutex.RLock()
+	defer fake.getPendingOutboxEventsMutex.RUnlock()
+	return len(fake.getPendingOutboxEventsArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEventsCalls(stub func(time.Time, int) ([]domain.OutboxEvent, error)) {
+	fake.getPendingOutboxEventsMutex.Lock()
+	defer fake.getPendingOutboxEventsMutex.Unlock()
+	fake.GetPendingOutboxEventsStub = stub
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEventsArgsForCall(i int) (time.Time, int) {
+	fake.getPendingOutboxEventsMutex.RLock()
+	defer fake.getPendingOutboxEventsMutex.RUnlock()
+	argsForCall := fake.getPendingOutboxEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEventsReturns(result1 []domain.OutboxEvent, result2 error) {
+	fake.getPendingOutboxEventsMutex.Lock()
+	defer fake.getPendingOutboxEventsMutex.Unlock()
+	fake.GetPendingOutboxEventsStub = nil
+	fake.getPendingOutboxEventsReturns = struct {
+		result1 []domain.OutboxEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) GetPendingOutboxEventsReturnsOnCall(i int, result1 []domain.OutboxEvent, result2 error) {
+	fake.getPendingOutboxEventsMutex.Lock()
+	defer fake.getPendingOutboxEventsMutex.Unlock()
+	fake.GetPendingOutboxEventsStub = nil
+	if fake.getPendingOutboxEventsReturnsOnCall == nil {
+		fake.getPendingOutboxEventsReturnsOnCall = make(map[int]struct {
+			result1 []domain.OutboxEvent
+			result2 error
+		})
+	}
+	fake.getPendingOutboxEventsReturnsOnCall[i] = struct {
+		result1 []domain.OutboxEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLettered(arg1 uint, arg2 []string, arg3 string, arg4 time.Time) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.markOutboxEventDeadLetteredMutex.Lock()
+	ret, specificReturn := fake.markOutboxEventDeadLetteredReturnsOnCall[len(fake.markOutboxEventDeadLetteredArgsForCall)]
+	fake.markOutboxEventDeadLetteredArgsForCall = append(fake.markOutboxEventDeadLetteredArgsForCall, struct {
+		arg1 uint
+		arg2 []string
+		arg3 string
+		arg4 time.Time
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.MarkOutboxEventDeadLetteredStub
+	fakeReturns := fake.markOutboxEventDeadLetteredReturns
+	fake.recordInvocation("MarkOutboxEventDeadLettered", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.markOutboxEventDeadLetteredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLetteredCallCount() int {
+	fake.markOutboxEventDeadLetteredMutex.RLock()
+	defer fake.markOutboxEventDeadLetteredMutex.RUnlock()
+	return len(fake.markOutboxEventDeadLetteredArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLetteredCalls(stub func(uint, []string, string, time.Time) error) {
+	fake.markOutboxEventDeadLetteredMutex.Lock()
+	defer fake.markOutboxEventDeadLetteredMutex.Unlock()
+	fake.MarkOutboxEventDeadLetteredStub = stub
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLetteredArgsForCall(i int) (uint, []string, string, time.Time) {
+	fake.markOutboxEventDeadLetteredMutex.RLock()
+	defer fake.markOutboxEventDeadLetteredMutex.RUnlock()
+	argsForCall := fake.markOutboxEventDeadLetteredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLetteredReturns(result1 error) {
+	fake.markOutboxEventDeadLetteredMutex.Lock()
+	defer fake.markOutboxEventDeadLetteredMutex.Unlock()
+	fake.MarkOutboxEventDeadLetteredStub = nil
+	fake.markOutboxEventDeadLetteredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventDeadLetteredReturnsOnCall(i int, result1 error) {
+	fake.markOutboxEventDeadLetteredMutex.Lock()
+	defer fake.markOutboxEventDeadLetteredMutex.Unlock()
+	fake.MarkOutboxEventDeadLetteredStub = nil
+	if fake.markOutboxEventDeadLetteredReturnsOnCall == nil {
+		fake.markOutboxEventDeadLetteredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOutboxEventDeadLetteredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailed(arg1 uint, arg2 []string, arg3 string, arg4 time.Time) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.markOutboxEventFailedMutex.Lock()
+	ret, specificReturn := fake.markOutboxEventFailedReturnsOnCall[len(fake.markOutboxEventFailedArgsForCall)]
+	fake.markOutboxEventFailedArgsForCall = append(fake.markOutboxEventFailedArgsForCall, struct {
+		arg1 uint
+		arg2 []string
+		arg3 string
+		arg4 time.Time
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.MarkOutboxEventFailedStub
+	fakeReturns := fake.markOutboxEventFailedReturns
+	fake.recordInvocation("MarkOutboxEventFailed", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.markOutboxEventFailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailedCallCount() int {
+	fake.markOutboxEventFailedMutex.RLock()
+	defer fake.markOutboxEventFailedMutex.RUnlock()
+	return len(fake.markOutboxEventFailedArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailedCalls(stub func(uint, []string, string, time.Time) error) {
+	fake.markOutboxEventFailedMutex.Lock()
+	defer fake.markOutboxEventFailedMutex.Unlock()
+	fake.MarkOutboxEventFailedStub = stub
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailedArgsForCall(i int) (uint, []string, string, time.Time) {
+	fake.markOutboxEventFailedMutex.RLock()
+	defer fake.markOutboxEventFailedMutex.RUnlock()
+	argsForCall := fake.markOutboxEventFailedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailedReturns(result1 error) {
+	fake.markOutboxEventFailedMutex.Lock()
+	defer fake.markOutboxEventFailedMutex.Unlock()
+	fake.MarkOutboxEventFailedStub = nil
+	fake.markOutboxEventFailedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventFailedReturnsOnCall(i int, result1 error) {
+	fake.markOutboxEventFailedMutex.Lock()
+	defer fake.markOutboxEventFailedMutex.Unlock()
+	fake.MarkOutboxEventFailedStub = nil
+	if fake.markOutboxEventFailedReturnsOnCall == nil {
+		fake.markOutboxEventFailedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOutboxEventFailedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublished(arg1 uint, arg2 time.Time) error {
+	fake.markOutboxEventPublishedMutex.Lock()
+	ret, specificReturn := fake.markOutboxEventPublishedReturnsOnCall[len(fake.markOutboxEventPublishedArgsForCall)]
+	fake.markOutboxEventPublishedArgsForCall = append(fake.markOutboxEventPublishedArgsForCall, struct {
+		arg1 uint
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.MarkOutboxEventPublishedStub
+	fakeReturns := fake.markOutboxEventPublishedReturns
+	fake.recordInvocation("MarkOutboxEventPublished", []interface{}{arg1, arg2})
+	fake.markOutboxEventPublishedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublishedCallCount() int {
+	fake.markOutboxEventPublishedMutex.RLock()
+	defer fake.markOutboxEventPublishedMutex.RUnlock()
+	return len(fake.markOutboxEventPublishedArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublishedCalls(stub func(uint, time.Time) error) {
+	fake.markOutboxEventPublishedMutex.Lock()
+	defer fake.markOutboxEventPublishedMutex.Unlock()
+	fake.MarkOutboxEventPublishedStub = stub
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublishedArgsForCall(i int) (uint, time.Time) {
+	fake.markOutboxEventPublishedMutex.RLock()
+	defer fake.markOutboxEventPublishedMutex.RUnlock()
+	argsForCall := fake.markOutboxEventPublishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublishedReturns(result1 error) {
+	fake.markOutboxEventPublishedMutex.Lock()
+	defer fake.markOutboxEventPublishedMutex.Unlock()
+	fake.MarkOutboxEventPublishedStub = nil
+	fake.markOutboxEventPublishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) MarkOutboxEventPublishedReturnsOnCall(i int, result1 error) {
+	fake.markOutboxEventPublishedMutex.Lock()
+	defer fake.markOutboxEventPublishedMutex.Unlock()
+	fake.MarkOutboxEventPublishedStub = nil
+	if fake.markOutboxEventPublishedReturnsOnCall == nil {
+		fake.markOutboxEventPublishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOutboxEventPublishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeOutboxRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createOutboxEventMutex.RLock()
+	defer fake.createOutboxEventMutex.RUnlock()
+	fake.getPendingOutboxEventsMutex.RLock()
+	defer fake.getPendingOutboxEventsMutex.RUnlock()
+	fake.markOutboxEventDeadLetteredMutex.RLock()
+	defer fake.markOutboxEventDeadLetteredMutex.RUnlock()
+	fake.markOutboxEventFailedMutex.RLock()
+	defer fake.markOutboxEventFailedMutex.RUnlock()
+	fake.markOutboxEventPublishedMutex.RLock()
+	defer fake.markOutboxEventPublishedMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeOutboxRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.OutboxRepository = new(FakeOutboxRepository)
@@ -0,0 +1,320 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeQualityRuleRepository struct {
+	CreateQualityRuleStub        func(*domain.QualityRule) error
+	createQualityRuleMutex       sync.RWMutex
+	createQualityRuleArgsForCall []struct {
+		arg1 *domain.QualityRule
+	}
+	createQualityRuleReturns struct {
+		result1 error
+	}
+	createQualityRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteQualityRuleStub        func(uint) error
+	deleteQualityRuleMutex       sync.RWMutex
+	deleteQualityRuleArgsForCall []struct {
+		arg1 uint
+	}
+	deleteQualityRuleReturns struct {
+		result1 error
+	}
+	deleteQualityRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetQualityRulesStub        func() ([]domain.QualityRule, error)
+	getQualityRulesMutex       sync.RWMutex
+	getQualityRulesArgsForCall []struct {
+	}
+	getQualityRulesReturns struct {
+		result1 []domain.QualityRule
+		result2 error
+	}
+	getQualityRulesReturnsOnCall map[int]struct {
+		result1 []domain.QualityRule
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRule(arg1 *domain.QualityRule) error {
+	fake.createQualityRuleMutex.Lock()
+	ret, specificReturn := fake.createQualityRuleReturnsOnCall[len(fake.createQualityRuleArgsForCall)]
+	fake.createQualityRuleArgsForCall = append(fake.createQualityRuleArgsForCall, struct {
+		arg1 *domain.QualityRule
+	}{arg1})
+	stub := fake.CreateQualityRuleStub
+	fakeReturns := fake.createQualityRuleReturns
+	fake.recordInvocation("CreateQualityRule", []interface{}{arg1})
+	fake.createQualityRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRuleCallCount() int {
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	return len(fake.createQualityRuleArgsForCall)
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRuleCalls(stub func(*domain.QualityRule) error) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = stub
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRuleArgsForCall(i int) *domain.QualityRule {
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	argsForCall := fake.createQualityRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRuleReturns(result1 error) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = nil
+	fake.createQualityRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) CreateQualityRuleReturnsOnCall(i int, result1 error) {
+	fake.createQualityRuleMutex.Lock()
+	defer fake.createQualityRuleMutex.Unlock()
+	fake.CreateQualityRuleStub = nil
+	if fake.createQualityRuleReturnsOnCall == nil {
+		fake.createQualityRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createQualityRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRule(arg1 uint) error {
+	fake.deleteQualityRuleMutex.Lock()
+	ret, specificReturn := fake.deleteQualityRuleReturnsOnCall[len(fake.deleteQualityRuleArgsForCall)]
+	fake.deleteQualityRuleArgsForCall = append(fake.deleteQualityRuleArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.DeleteQualityRuleStub
+	fakeReturns := fake.deleteQualityRuleReturns
+	fake.recordInvocation("DeleteQualityRule", []interface{}{arg1})
+	fake.deleteQualityRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRuleCallCount() int {
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	return len(fake.deleteQualityRuleArgsForCall)
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRuleCalls(stub func(uint) error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = stub
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRuleArgsForCall(i int) uint {
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	argsForCall := fake.deleteQualityRuleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRuleReturns(result1 error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = nil
+	fake.deleteQualityRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) DeleteQualityRuleReturnsOnCall(i int, result1 error) {
+	fake.deleteQualityRuleMutex.Lock()
+	defer fake.deleteQualityRuleMutex.Unlock()
+	fake.DeleteQualityRuleStub = nil
+	if fake.deleteQualityRuleReturnsOnCall == nil {
+		fake.deleteQualityRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteQualityRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) GetQualityRules() ([]domain.QualityRule, error) {
+	fake.getQualityRulesMutex.Lock()
+	ret, specificReturn := fake.getQualityRulesReturnsOnCall[len(fake.getQualityRulesArgsForCall)]
+	fake.getQualityRulesArgsForCall = append(fake.getQualityRulesArgsForCall, struct {
+	}{})
+	stub := fake.GetQualityRulesStub
+	fakeReturns := fake.getQualityRulesReturns
+	fake.recordInvocation("GetQualityRules", []interface{}{})
+	fake.getQualityRulesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQualityRuleRepository) GetQualityRulesCallCount() int {
+	fake.getQualityRulesMutex.RLock()
+	defer fake.getQualityRulesMutex.RUnlock()
+	return len(fake.getQualityRulesArgsForCall)
+}
+
+func (fake *FakeQualityRuleRepository) GetQualityRulesCalls(stub func() ([]domain.QualityRule, error)) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = stub
+}
+
+func (fake *FakeQualityRuleRepository) GetQualityRulesReturns(result1 []domain.QualityRule, result2 error) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = nil
+	fake.getQualityRulesReturns = struct {
+		result1 []domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleRepository) GetQualityRulesReturnsOnCall(i int, result1 []domain.QualityRule, result2 error) {
+	fake.getQualityRulesMutex.Lock()
+	defer fake.getQualityRulesMutex.Unlock()
+	fake.GetQualityRulesStub = nil
+	if fake.getQualityRulesReturnsOnCall == nil {
+		fake.getQualityRulesReturnsOnCall = make(map[int]struct {
+			result1 []domain.QualityRule
+			result2 error
+		})
+	}
+	fake.getQualityRulesReturnsOnCall[i] = struct {
+		result1 []domain.QualityRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQualityRuleRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQualityRuleRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeQualityRuleRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeQualityRuleRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQualityRuleRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createQualityRuleMutex.RLock()
+	defer fake.createQualityRuleMutex.RUnlock()
+	fake.deleteQualityRuleMutex.RLock()
+	defer fake.deleteQualityRuleMutex.RUnlock()
+	fake.getQualityRulesMutex.RLock()
+	defer fake.getQualityRulesMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQualityRuleRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.QualityRuleRepository = new(FakeQualityRuleRepository)
@@ -0,0 +1,478 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeQuarantineRepository struct {
+	CreateQuarantinedReadingsStub        func([]*domain.QuarantinedReading) error
+	createQuarantinedReadingsMutex       sync.RWMutex
+	createQuarantinedReadingsArgsForCall []struct {
+		arg1 []*domain.QuarantinedReading
+	}
+	createQuarantinedReadingsReturns struct {
+		result1 error
+	}
+	createQuarantinedReadingsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteQuarantinedReadingStub        func(uint) error
+	deleteQuarantinedReadingMutex       sync.RWMutex
+	deleteQuarantinedReadingArgsForCall []struct {
+		arg1 uint
+	}
+	deleteQuarantinedReadingReturns struct {
+		result1 error
+	}
+	deleteQuarantinedReadingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetQuarantinedReadingByIDStub        func(uint) (*domain.QuarantinedReading, error)
+	getQuarantinedReadingByIDMutex       sync.RWMutex
+	getQuarantinedReadingByIDArgsForCall []struct {
+		arg1 uint
+	}
+	getQuarantinedReadingByIDReturns struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}
+	getQuarantinedReadingByIDReturnsOnCall map[int]struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}
+	GetQuarantinedReadingsStub        func() ([]domain.QuarantinedReading, error)
+	getQuarantinedReadingsMutex       sync.RWMutex
+	getQuarantinedReadingsArgsForCall []struct {
+	}
+	getQuarantinedReadingsReturns struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}
+	getQuarantinedReadingsReturnsOnCall map[int]struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateQuarantinedReadingStub        func(*domain.QuarantinedReading) error
+	updateQuarantinedReadingMutex       sync.RWMutex
+	updateQuarantinedReadingArgsForCall []struct {
+		arg1 *domain.QuarantinedReading
+	}
+	updateQuarantinedReadingReturns struct {
+		result1 error
+	}
+	updateQuarantinedReadingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadings(arg1 []*domain.QuarantinedReading) error {
+	var arg1Copy []*domain.QuarantinedReading
+	if arg1 != nil {
+		arg1Copy = make([]*domain.QuarantinedReading, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createQuarantinedReadingsMutex.Lock()
+	ret, specificReturn := fake.createQuarantinedReadingsReturnsOnCall[len(fake.createQuarantinedReadingsArgsForCall)]
+	fake.createQuarantinedReadingsArgsForCall = append(fake.createQuarantinedReadingsArgsForCall, struct {
+		arg1 []*domain.QuarantinedReading
+	}{arg1Copy})
+	stub := fake.CreateQuarantinedReadingsStub
+	fakeReturns := fake.createQuarantinedReadingsReturns
+	fake.recordInvocation("CreateQuarantinedReadings", []interface{}{arg1Copy})
+	fake.createQuarantinedReadingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadingsCallCount() int {
+	fake.createQuarantinedReadingsMutex.RLock()
+	defer fake.createQuarantinedReadingsMutex.RUnlock()
+	return len(fake.createQuarantinedReadingsArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadingsCalls(stub func([]*domain.QuarantinedReading) error) {
+	fake.createQuarantinedReadingsMutex.Lock()
+	defer fake.createQuarantinedReadingsMutex.Unlock()
+	fake.CreateQuarantinedReadingsStub = stub
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadingsArgsForCall(i int) []*domain.QuarantinedReading {
+	fake.createQuarantinedReadingsMutex.RLock()
+	defer fake.createQuarantinedReadingsMutex.RUnlock()
+	argsForCall := fake.createQuarantinedReadingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadingsReturns(result1 error) {
+	fake.createQuarantinedReadingsMutex.Lock()
+	defer fake.createQuarantinedReadingsMutex.Unlock()
+	fake.CreateQuarantinedReadingsStub = nil
+	fake.createQuarantinedReadingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) CreateQuarantinedReadingsReturnsOnCall(i int, result1 error) {
+	fake.createQuarantinedReadingsMutex.Lock()
+	defer fake.createQuarantinedReadingsMutex.Unlock()
+	fake.CreateQuarantinedReadingsStub = nil
+	if fake.createQuarantinedReadingsReturnsOnCall == nil {
+		fake.createQuarantinedReadingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createQuarantinedReadingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReading(arg1 uint) error {
+	fake.deleteQuarantinedReadingMutex.Lock()
+	ret, specificReturn := fake.deleteQuarantinedReadingReturnsOnCall[len(fake.deleteQuarantinedReadingArgsForCall)]
+	fake.deleteQuarantinedReadingArgsForCall = append(fake.deleteQuarantinedReadingArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.DeleteQuarantinedReadingStub
+	fakeReturns := fake.deleteQuarantinedReadingReturns
+	fake.recordInvocation("DeleteQuarantinedReading", []interface{}{arg1})
+	fake.deleteQuarantinedReadingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReadingCallCount() int {
+	fake.deleteQuarantinedReadingMutex.RLock()
+	defer fake.deleteQuarantinedReadingMutex.RUnlock()
+	return len(fake.deleteQuarantinedReadingArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReadingCalls(stub func(uint) error) {
+	fake.deleteQuarantinedReadingMutex.Lock()
+	defer fake.deleteQuarantinedReadingMutex.Unlock()
+	fake.DeleteQuarantinedReadingStub = stub
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReadingArgsForCall(i int) uint {
+	fake.deleteQuarantinedReadingMutex.RLock()
+	defer fake.deleteQuarantinedReadingMutex.RUnlock()
+	argsForCall := fake.deleteQuarantinedReadingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReadingReturns(result1 error) {
+	fake.deleteQuarantinedReadingMutex.Lock()
+	defer fake.deleteQuarantinedReadingMutex.Unlock()
+	fake.DeleteQuarantinedReadingStub = nil
+	fake.deleteQuarantinedReadingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) DeleteQuarantinedReadingReturnsOnCall(i int, result1 error) {
+	fake.deleteQuarantinedReadingMutex.Lock()
+	defer fake.deleteQuarantinedReadingMutex.Unlock()
+	fake.DeleteQuarantinedReadingStub = nil
+	if fake.deleteQuarantinedReadingReturnsOnCall == nil {
+		fake.deleteQuarantinedReadingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteQuarantinedReadingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByID(arg1 uint) (*domain.QuarantinedReading, error) {
+	fake.getQuarantinedReadingByIDMutex.Lock()
+	ret, specificReturn := fake.getQuarantinedReadingByIDReturnsOnCall[len(fake.getQuarantinedReadingByIDArgsForCall)]
+	fake.getQuarantinedReadingByIDArgsForCall = append(fake.getQuarantinedReadingByIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetQuarantinedReadingByIDStub
+	fakeReturns := fake.getQuarantinedReadingByIDReturns
+	fake.recordInvocation("GetQuarantinedReadingByID", []interface{}{arg1})
+	fake.getQuarantinedReadingByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByIDCallCount() int {
+	fake.getQuarantinedReadingByIDMutex.RLock()
+	defer fake.getQuarantinedReadingByIDMutex.RUnlock()
+	return len(fake.getQuarantinedReadingByIDArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByIDCalls(stub func(uint) (*domain.QuarantinedReading, error)) {
+	fake.getQuarantinedReadingByIDMutex.Lock()
+	defer fake.getQuarantinedReadingByIDMutex.Unlock()
+	fake.GetQuarantinedReadingByIDStub = stub
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByIDArgsForCall(i int) uint {
+	fake.getQuarantinedReadingByIDMutex.RLock()
+	defer fake.getQuarantinedReadingByIDMutex.RUnlock()
+	argsForCall := fake.getQuarantinedReadingByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByIDReturns(result1 *domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingByIDMutex.Lock()
+	defer fake.getQuarantinedReadingByIDMutex.Unlock()
+	fake.GetQuarantinedReadingByIDStub = nil
+	fake.getQuarantinedReadingByIDReturns = struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingByIDReturnsOnCall(i int, result1 *domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingByIDMutex.Lock()
+	defer fake.getQuarantinedReadingByIDMutex.Unlock()
+	fake.GetQuarantinedReadingByIDStub = nil
+	if fake.getQuarantinedReadingByIDReturnsOnCall == nil {
+		fake.getQuarantinedReadingByIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.QuarantinedReading
+			result2 error
+		})
+	}
+	fake.getQuarantinedReadingByIDReturnsOnCall[i] = struct {
+		result1 *domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadings() ([]domain.QuarantinedReading, error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	ret, specificReturn := fake.getQuarantinedReadingsReturnsOnCall[len(fake.getQuarantinedReadingsArgsForCall)]
+	fake.getQuarantinedReadingsArgsForCall = append(fake.getQuarantinedReadingsArgsForCall, struct {
+	}{})
+	stub := fake.GetQuarantinedReadingsStub
+	fakeReturns := fake.getQuarantinedReadingsReturns
+	fake.recordInvocation("GetQuarantinedReadings", []interface{}{})
+	fake.getQuarantinedReadingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingsCallCount() int {
+	fake.getQuarantinedReadingsMutex.RLock()
+	defer fake.getQuarantinedReadingsMutex.RUnlock()
+	return len(fake.getQuarantinedReadingsArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingsCalls(stub func() ([]domain.QuarantinedReading, error)) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = stub
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingsReturns(result1 []domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = nil
+	fake.getQuarantinedReadingsReturns = struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineRepository) GetQuarantinedReadingsReturnsOnCall(i int, result1 []domain.QuarantinedReading, result2 error) {
+	fake.getQuarantinedReadingsMutex.Lock()
+	defer fake.getQuarantinedReadingsMutex.Unlock()
+	fake.GetQuarantinedReadingsStub = nil
+	if fake.getQuarantinedReadingsReturnsOnCall == nil {
+		fake.getQuarantinedReadingsReturnsOnCall = make(map[int]struct {
+			result1 []domain.QuarantinedReading
+			result2 error
+		})
+	}
+	fake.getQuarantinedReadingsReturnsOnCall[i] = struct {
+		result1 []domain.QuarantinedReading
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuarantineRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeQuarantineRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReading(arg1 *domain.QuarantinedReading) error {
+	fake.updateQuarantinedReadingMutex.Lock()
+	ret, specificReturn := fake.updateQuarantinedReadingReturnsOnCall[len(fake.updateQuarantinedReadingArgsForCall)]
+	fake.updateQuarantinedReadingArgsForCall = append(fake.updateQuarantinedReadingArgsForCall, struct {
+		arg1 *domain.QuarantinedReading
+	}{arg1})
+	stub := fake.UpdateQuarantinedReadingStub
+	fakeReturns := fake.updateQuarantinedReadingReturns
+	fake.recordInvocation("UpdateQuarantinedReading", []interface{}{arg1})
+	fake.updateQuarantinedReadingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReadingCallCount() int {
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	return len(fake.updateQuarantinedReadingArgsForCall)
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReadingCalls(stub func(*domain.QuarantinedReading) error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = stub
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReadingArgsForCall(i int) *domain.QuarantinedReading {
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	argsForCall := fake.updateQuarantinedReadingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReadingReturns(result1 error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = nil
+	fake.updateQuarantinedReadingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) UpdateQuarantinedReadingReturnsOnCall(i int, result1 error) {
+	fake.updateQuarantinedReadingMutex.Lock()
+	defer fake.updateQuarantinedReadingMutex.Unlock()
+	fake.UpdateQuarantinedReadingStub = nil
+	if fake.updateQuarantinedReadingReturnsOnCall == nil {
+		fake.updateQuarantinedReadingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuarantinedReadingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuarantineRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createQuarantinedReadingsMutex.RLock()
+	defer fake.createQuarantinedReadingsMutex.RUnlock()
+	fake.deleteQuarantinedReadingMutex.RLock()
+	defer fake.deleteQuarantinedReadingMutex.RUnlock()
+	fake.getQuarantinedReadingByIDMutex.RLock()
+	defer fake.getQuarantinedReadingByIDMutex.RUnlock()
+	fake.getQuarantinedReadingsMutex.RLock()
+	defer fake.getQuarantinedReadingsMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	fake.updateQuarantinedReadingMutex.RLock()
+	defer fake.updateQuarantinedReadingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQuarantineRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.QuarantineRepository = new(FakeQuarantineRepository)
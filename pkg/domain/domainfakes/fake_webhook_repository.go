@@ -0,0 +1,787 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package domainfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type FakeWebhookRepository struct {
+	CreateWebhookDeliveriesStub        func([]*domain.WebhookDelivery) error
+	createWebhookDeliveriesMutex       sync.RWMutex
+	createWebhookDeliveriesArgsForCall []struct {
+		arg1 []*domain.WebhookDelivery
+	}
+	createWebhookDeliveriesReturns struct {
+		result1 error
+	}
+	createWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateWebhookSubscriptionStub        func(*domain.WebhookSubscription) error
+	createWebhookSubscriptionMutex       sync.RWMutex
+	createWebhookSubscriptionArgsForCall []struct {
+		arg1 *domain.WebhookSubscription
+	}
+	createWebhookSubscriptionReturns struct {
+		result1 error
+	}
+	createWebhookSubscriptionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteWebhookSubscriptionStub        func(uint) error
+	deleteWebhookSubscriptionMutex       sync.RWMutex
+	deleteWebhookSubscriptionArgsForCall []struct {
+		arg1 uint
+	}
+	deleteWebhookSubscriptionReturns struct {
+		result1 error
+	}
+	deleteWebhookSubscriptionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetDueWebhookDeliveriesStub        func(time.Time, int) ([]domain.WebhookDelivery, error)
+	getDueWebhookDeliveriesMutex       sync.RWMutex
+	getDueWebhookDeliveriesArgsForCall []struct {
+		arg1 time.Time
+		arg2 int
+	}
+	getDueWebhookDeliveriesReturns struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	getDueWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	GetWebhookDeliveriesBySubscriptionIDStub        func(uint) ([]domain.WebhookDelivery, error)
+	getWebhookDeliveriesBySubscriptionIDMutex       sync.RWMutex
+	getWebhookDeliveriesBySubscriptionIDArgsForCall []struct {
+		arg1 uint
+	}
+	getWebhookDeliveriesBySubscriptionIDReturns struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	getWebhookDeliveriesBySubscriptionIDReturnsOnCall map[int]struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}
+	GetWebhookSubscriptionByIDStub        func(uint) (*domain.WebhookSubscription, error)
+	getWebhookSubscriptionByIDMutex       sync.RWMutex
+	getWebhookSubscriptionByIDArgsForCall []struct {
+		arg1 uint
+	}
+	getWebhookSubscriptionByIDReturns struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	getWebhookSubscriptionByIDReturnsOnCall map[int]struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}
+	GetWebhookSubscriptionsStub        func() ([]domain.WebhookSubscription, error)
+	getWebhookSubscriptionsMutex       sync.RWMutex
+	getWebhookSubscriptionsArgsForCall []struct {
+	}
+	getWebhookSubscriptionsReturns struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}
+	getWebhookSubscriptionsReturnsOnCall map[int]struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}
+	ModelMigrationStub        func() error
+	modelMigrationMutex       sync.RWMutex
+	modelMigrationArgsForCall []struct {
+	}
+	modelMigrationReturns struct {
+		result1 error
+	}
+	modelMigrationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateWebhookDeliveryStub        func(*domain.WebhookDelivery) error
+	updateWebhookDeliveryMutex       sync.RWMutex
+	updateWebhookDeliveryArgsForCall []struct {
+		arg1 *domain.WebhookDelivery
+	}
+	updateWebhookDeliveryReturns struct {
+		result1 error
+	}
+	updateWebhookDeliveryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateWebhookSubscriptionStub        func(*domain.WebhookSubscription) error
+	updateWebhookSubscriptionMutex       sync.RWMutex
+	updateWebhookSubscriptionArgsForCall []struct {
+		arg1 *domain.WebhookSubscription
+	}
+	updateWebhookSubscriptionReturns struct {
+		result1 error
+	}
+	updateWebhookSubscriptionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveries(arg1 []*domain.WebhookDelivery) error {
+	var arg1Copy []*domain.WebhookDelivery
+	if arg1 != nil {
+		arg1Copy = make([]*domain.WebhookDelivery, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.createWebhookDeliveriesReturnsOnCall[len(fake.createWebhookDeliveriesArgsForCall)]
+	fake.createWebhookDeliveriesArgsForCall = append(fake.createWebhookDeliveriesArgsForCall, struct {
+		arg1 []*domain.WebhookDelivery
+	}{arg1Copy})
+	stub := fake.CreateWebhookDeliveriesStub
+	fakeReturns := fake.createWebhookDeliveriesReturns
+	fake.recordInvocation("CreateWebhookDeliveries", []interface{}{arg1Copy})
+	fake.createWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveriesCallCount() int {
+	fake.createWebhookDeliveriesMutex.RLock()
+	defer fake.createWebhookDeliveriesMutex.RUnlock()
+	return len(fake.createWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveriesCalls(stub func([]*domain.WebhookDelivery) error) {
+	fake.createWebhookDeliveriesMutex.Lock()
+	defer fake.createWebhookDeliveriesMutex.Unlock()
+	fake.CreateWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveriesArgsForCall(i int) []*domain.WebhookDelivery {
+	fake.createWebhookDeliveriesMutex.RLock()
+	defer fake.createWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.createWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveriesReturns(result1 error) {
+	fake.createWebhookDeliveriesMutex.Lock()
+	defer fake.createWebhookDeliveriesMutex.Unlock()
+	fake.CreateWebhookDeliveriesStub = nil
+	fake.createWebhookDeliveriesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookDeliveriesReturnsOnCall(i int, result1 error) {
+	fake.createWebhookDeliveriesMutex.Lock()
+	defer fake.createWebhookDeliveriesMutex.Unlock()
+	fake.CreateWebhookDeliveriesStub = nil
+	if fake.createWebhookDeliveriesReturnsOnCall == nil {
+		fake.createWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscription(arg1 *domain.WebhookSubscription) error {
+	fake.createWebhookSubscriptionMutex.Lock()
+	ret, specificReturn := fake.createWebhookSubscriptionReturnsOnCall[len(fake.createWebhookSubscriptionArgsForCall)]
+	fake.createWebhookSubscriptionArgsForCall = append(fake.createWebhookSubscriptionArgsForCall, struct {
+		arg1 *domain.WebhookSubscription
+	}{arg1})
+	stub := fake.CreateWebhookSubscriptionStub
+	fakeReturns := fake.createWebhookSubscriptionReturns
+	fake.recordInvocation("CreateWebhookSubscription", []interface{}{arg1})
+	fake.createWebhookSubscriptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscriptionCallCount() int {
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	return len(fake.createWebhookSubscriptionArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscriptionCalls(stub func(*domain.WebhookSubscription) error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = stub
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscriptionArgsForCall(i int) *domain.WebhookSubscription {
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	argsForCall := fake.createWebhookSubscriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscriptionReturns(result1 error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = nil
+	fake.createWebhookSubscriptionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) CreateWebhookSubscriptionReturnsOnCall(i int, result1 error) {
+	fake.createWebhookSubscriptionMutex.Lock()
+	defer fake.createWebhookSubscriptionMutex.Unlock()
+	fake.CreateWebhookSubscriptionStub = nil
+	if fake.createWebhookSubscriptionReturnsOnCall == nil {
+		fake.createWebhookSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createWebhookSubscriptionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscription(arg1 uint) error {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookSubscriptionReturnsOnCall[len(fake.deleteWebhookSubscriptionArgsForCall)]
+	fake.deleteWebhookSubscriptionArgsForCall = append(fake.deleteWebhookSubscriptionArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.DeleteWebhookSubscriptionStub
+	fakeReturns := fake.deleteWebhookSubscriptionReturns
+	fake.recordInvocation("DeleteWebhookSubscription", []interface{}{arg1})
+	fake.deleteWebhookSubscriptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscriptionCallCount() int {
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	return len(fake.deleteWebhookSubscriptionArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscriptionCalls(stub func(uint) error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = stub
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscriptionArgsForCall(i int) uint {
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	argsForCall := fake.deleteWebhookSubscriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscriptionReturns(result1 error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = nil
+	fake.deleteWebhookSubscriptionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) DeleteWebhookSubscriptionReturnsOnCall(i int, result1 error) {
+	fake.deleteWebhookSubscriptionMutex.Lock()
+	defer fake.deleteWebhookSubscriptionMutex.Unlock()
+	fake.DeleteWebhookSubscriptionStub = nil
+	if fake.deleteWebhookSubscriptionReturnsOnCall == nil {
+		fake.deleteWebhookSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteWebhookSubscriptionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveries(arg1 time.Time, arg2 int) ([]domain.WebhookDelivery, error) {
+	fake.getDueWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.getDueWebhookDeliveriesReturnsOnCall[len(fake.getDueWebhookDeliveriesArgsForCall)]
+	fake.getDueWebhookDeliveriesArgsForCall = append(fake.getDueWebhookDeliveriesArgsForCall, struct {
+		arg1 time.Time
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetDueWebhookDeliveriesStub
+	fakeReturns := fake.getDueWebhookDeliveriesReturns
+	fake.recordInvocation("GetDueWebhookDeliveries", []interface{}{arg1, arg2})
+	fake.getDueWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveriesCallCount() int {
+	fake.getDueWebhookDeliveriesMutex.RLock()
+	defer fake.getDueWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getDueWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveriesCalls(stub func(time.Time, int) ([]domain.WebhookDelivery, error)) {
+	fake.getDueWebhookDeliveriesMutex.Lock()
+	defer fake.getDueWebhookDeliveriesMutex.Unlock()
+	fake.GetDueWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveriesArgsForCall(i int) (time.Time, int) {
+	fake.getDueWebhookDeliveriesMutex.RLock()
+	defer fake.getDueWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getDueWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveriesReturns(result1 []domain.WebhookDelivery, result2 error) {
+	fake.getDueWebhookDeliveriesMutex.Lock()
+	defer fake.getDueWebhookDeliveriesMutex.Unlock()
+	fake.GetDueWebhookDeliveriesStub = nil
+	fake.getDueWebhookDeliveriesReturns = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetDueWebhookDeliveriesReturnsOnCall(i int, result1 []domain.WebhookDelivery, result2 error) {
+	fake.getDueWebhookDeliveriesMutex.Lock()
+	defer fake.getDueWebhookDeliveriesMutex.Unlock()
+	fake.GetDueWebhookDeliveriesStub = nil
+	if fake.getDueWebhookDeliveriesReturnsOnCall == nil {
+		fake.getDueWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []domain.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.getDueWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionID(arg1 uint) ([]domain.WebhookDelivery, error) {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.Lock()
+	ret, specificReturn := fake.getWebhookDeliveriesBySubscriptionIDReturnsOnCall[len(fake.getWebhookDeliveriesBySubscriptionIDArgsForCall)]
+	fake.getWebhookDeliveriesBySubscriptionIDArgsForCall = append(fake.getWebhookDeliveriesBySubscriptionIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetWebhookDeliveriesBySubscriptionIDStub
+	fakeReturns := fake.getWebhookDeliveriesBySubscriptionIDReturns
+	fake.recordInvocation("GetWebhookDeliveriesBySubscriptionID", []interface{}{arg1})
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionIDCallCount() int {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.RLock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.RUnlock()
+	return len(fake.getWebhookDeliveriesBySubscriptionIDArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionIDCalls(stub func(uint) ([]domain.WebhookDelivery, error)) {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.Lock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.Unlock()
+	fake.GetWebhookDeliveriesBySubscriptionIDStub = stub
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionIDArgsForCall(i int) uint {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.RLock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.RUnlock()
+	argsForCall := fake.getWebhookDeliveriesBySubscriptionIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionIDReturns(result1 []domain.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.Lock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.Unlock()
+	fake.GetWebhookDeliveriesBySubscriptionIDStub = nil
+	fake.getWebhookDeliveriesBySubscriptionIDReturns = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookDeliveriesBySubscriptionIDReturnsOnCall(i int, result1 []domain.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.Lock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.Unlock()
+	fake.GetWebhookDeliveriesBySubscriptionIDStub = nil
+	if fake.getWebhookDeliveriesBySubscriptionIDReturnsOnCall == nil {
+		fake.getWebhookDeliveriesBySubscriptionIDReturnsOnCall = make(map[int]struct {
+			result1 []domain.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.getWebhookDeliveriesBySubscriptionIDReturnsOnCall[i] = struct {
+		result1 []domain.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByID(arg1 uint) (*domain.WebhookSubscription, error) {
+	fake.getWebhookSubscriptionByIDMutex.Lock()
+	ret, specificReturn := fake.getWebhookSubscriptionByIDReturnsOnCall[len(fake.getWebhookSubscriptionByIDArgsForCall)]
+	fake.getWebhookSubscriptionByIDArgsForCall = append(fake.getWebhookSubscriptionByIDArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.GetWebhookSubscriptionByIDStub
+	fakeReturns := fake.getWebhookSubscriptionByIDReturns
+	fake.recordInvocation("GetWebhookSubscriptionByID", []interface{}{arg1})
+	fake.getWebhookSubscriptionByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByIDCallCount() int {
+	fake.getWebhookSubscriptionByIDMutex.RLock()
+	defer fake.getWebhookSubscriptionByIDMutex.RUnlock()
+	return len(fake.getWebhookSubscriptionByIDArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByIDCalls(stub func(uint) (*domain.WebhookSubscription, error)) {
+	fake.getWebhookSubscriptionByIDMutex.Lock()
+	defer fake.getWebhookSubscriptionByIDMutex.Unlock()
+	fake.GetWebhookSubscriptionByIDStub = stub
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByIDArgsForCall(i int) uint {
+	fake.getWebhookSubscriptionByIDMutex.RLock()
+	defer fake.getWebhookSubscriptionByIDMutex.RUnlock()
+	argsForCall := fake.getWebhookSubscriptionByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByIDReturns(result1 *domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionByIDMutex.Lock()
+	defer fake.getWebhookSubscriptionByIDMutex.Unlock()
+	fake.GetWebhookSubscriptionByIDStub = nil
+	fake.getWebhookSubscriptionByIDReturns = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionByIDReturnsOnCall(i int, result1 *domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionByIDMutex.Lock()
+	defer fake.getWebhookSubscriptionByIDMutex.Unlock()
+	fake.GetWebhookSubscriptionByIDStub = nil
+	if fake.getWebhookSubscriptionByIDReturnsOnCall == nil {
+		fake.getWebhookSubscriptionByIDReturnsOnCall = make(map[int]struct {
+			result1 *domain.WebhookSubscription
+			result2 error
+		})
+	}
+	fake.getWebhookSubscriptionByIDReturnsOnCall[i] = struct {
+		result1 *domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptions() ([]domain.WebhookSubscription, error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	ret, specificReturn := fake.getWebhookSubscriptionsReturnsOnCall[len(fake.getWebhookSubscriptionsArgsForCall)]
+	fake.getWebhookSubscriptionsArgsForCall = append(fake.getWebhookSubscriptionsArgsForCall, struct {
+	}{})
+	stub := fake.GetWebhookSubscriptionsStub
+	fakeReturns := fake.getWebhookSubscriptionsReturns
+	fake.recordInvocation("GetWebhookSubscriptions", []interface{}{})
+	fake.getWebhookSubscriptionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionsCallCount() int {
+	fake.getWebhookSubscriptionsMutex.RLock()
+	defer fake.getWebhookSubscriptionsMutex.RUnlock()
+	return len(fake.getWebhookSubscriptionsArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionsCalls(stub func() ([]domain.WebhookSubscription, error)) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = stub
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionsReturns(result1 []domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = nil
+	fake.getWebhookSubscriptionsReturns = struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) GetWebhookSubscriptionsReturnsOnCall(i int, result1 []domain.WebhookSubscription, result2 error) {
+	fake.getWebhookSubscriptionsMutex.Lock()
+	defer fake.getWebhookSubscriptionsMutex.Unlock()
+	fake.GetWebhookSubscriptionsStub = nil
+	if fake.getWebhookSubscriptionsReturnsOnCall == nil {
+		fake.getWebhookSubscriptionsReturnsOnCall = make(map[int]struct {
+			result1 []domain.WebhookSubscription
+			result2 error
+		})
+	}
+	fake.getWebhookSubscriptionsReturnsOnCall[i] = struct {
+		result1 []domain.WebhookSubscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookRepository) ModelMigration() error {
+	fake.modelMigrationMutex.Lock()
+	ret, specificReturn := fake.modelMigrationReturnsOnCall[len(fake.modelMigrationArgsForCall)]
+	fake.modelMigrationArgsForCall = append(fake.modelMigrationArgsForCall, struct {
+	}{})
+	stub := fake.ModelMigrationStub
+	fakeReturns := fake.modelMigrationReturns
+	fake.recordInvocation("ModelMigration", []interface{}{})
+	fake.modelMigrationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) ModelMigrationCallCount() int {
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	return len(fake.modelMigrationArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) ModelMigrationCalls(stub func() error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = stub
+}
+
+func (fake *FakeWebhookRepository) ModelMigrationReturns(result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	fake.modelMigrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) ModelMigrationReturnsOnCall(i int, result1 error) {
+	fake.modelMigrationMutex.Lock()
+	defer fake.modelMigrationMutex.Unlock()
+	fake.ModelMigrationStub = nil
+	if fake.modelMigrationReturnsOnCall == nil {
+		fake.modelMigrationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.modelMigrationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDelivery(arg1 *domain.WebhookDelivery) error {
+	fake.updateWebhookDeliveryMutex.Lock()
+	ret, specificReturn := fake.updateWebhookDeliveryReturnsOnCall[len(fake.updateWebhookDeliveryArgsForCall)]
+	fake.updateWebhookDeliveryArgsForCall = append(fake.updateWebhookDeliveryArgsForCall, struct {
+		arg1 *domain.WebhookDelivery
+	}{arg1})
+	stub := fake.UpdateWebhookDeliveryStub
+	fakeReturns := fake.updateWebhookDeliveryReturns
+	fake.recordInvocation("UpdateWebhookDelivery", []interface{}{arg1})
+	fake.updateWebhookDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDeliveryCallCount() int {
+	fake.updateWebhookDeliveryMutex.RLock()
+	defer fake.updateWebhookDeliveryMutex.RUnlock()
+	return len(fake.updateWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDeliveryCalls(stub func(*domain.WebhookDelivery) error) {
+	fake.updateWebhookDeliveryMutex.Lock()
+	defer fake.updateWebhookDeliveryMutex.Unlock()
+	fake.UpdateWebhookDeliveryStub = stub
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDeliveryArgsForCall(i int) *domain.WebhookDelivery {
+	fake.updateWebhookDeliveryMutex.RLock()
+	defer fake.updateWebhookDeliveryMutex.RUnlock()
+	argsForCall := fake.updateWebhookDeliveryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDeliveryReturns(result1 error) {
+	fake.updateWebhookDeliveryMutex.Lock()
+	defer fake.updateWebhookDeliveryMutex.Unlock()
+	fake.UpdateWebhookDeliveryStub = nil
+	fake.updateWebhookDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookDeliveryReturnsOnCall(i int, result1 error) {
+	fake.updateWebhookDeliveryMutex.Lock()
+	defer fake.updateWebhookDeliveryMutex.Unlock()
+	fake.UpdateWebhookDeliveryStub = nil
+	if fake.updateWebhookDeliveryReturnsOnCall == nil {
+		fake.updateWebhookDeliveryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateWebhookDeliveryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscription(arg1 *domain.WebhookSubscription) error {
+	fake.updateWebhookSubscriptionMutex.Lock()
+	ret, specificReturn := fake.updateWebhookSubscriptionReturnsOnCall[len(fake.updateWebhookSubscriptionArgsForCall)]
+	fake.updateWebhookSubscriptionArgsForCall = append(fake.updateWebhookSubscriptionArgsForCall, struct {
+		arg1 *domain.WebhookSubscription
+	}{arg1})
+	stub := fake.UpdateWebhookSubscriptionStub
+	fakeReturns := fake.updateWebhookSubscriptionReturns
+	fake.recordInvocation("UpdateWebhookSubscription", []interface{}{arg1})
+	fake.updateWebhookSubscriptionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscriptionCallCount() int {
+	fake.updateWebhookSubscriptionMutex.RLock()
+	defer fake.updateWebhookSubscriptionMutex.RUnlock()
+	return len(fake.updateWebhookSubscriptionArgsForCall)
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscriptionCalls(stub func(*domain.WebhookSubscription) error) {
+	fake.updateWebhookSubscriptionMutex.Lock()
+	defer fake.updateWebhookSubscriptionMutex.Unlock()
+	fake.UpdateWebhookSubscriptionStub = stub
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscriptionArgsForCall(i int) *domain.WebhookSubscription {
+	fake.updateWebhookSubscriptionMutex.RLock()
+	defer fake.updateWebhookSubscriptionMutex.RUnlock()
+	argsForCall := fake.updateWebhookSubscriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscriptionReturns(result1 error) {
+	fake.updateWebhookSubscriptionMutex.Lock()
+	defer fake.updateWebhookSubscriptionMutex.Unlock()
+	fake.UpdateWebhookSubscriptionStub = nil
+	fake.updateWebhookSubscriptionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) UpdateWebhookSubscriptionReturnsOnCall(i int, result1 error) {
+	fake.updateWebhookSubscriptionMutex.Lock()
+	defer fake.updateWebhookSubscriptionMutex.Unlock()
+	fake.UpdateWebhookSubscriptionStub = nil
+	if fake.updateWebhookSubscriptionReturnsOnCall == nil {
+		fake.updateWebhookSubscriptionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateWebhookSubscriptionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createWebhookDeliveriesMutex.RLock()
+	defer fake.createWebhookDeliveriesMutex.RUnlock()
+	fake.createWebhookSubscriptionMutex.RLock()
+	defer fake.createWebhookSubscriptionMutex.RUnlock()
+	fake.deleteWebhookSubscriptionMutex.RLock()
+	defer fake.deleteWebhookSubscriptionMutex.RUnlock()
+	fake.getDueWebhookDeliveriesMutex.RLock()
+	defer fake.getDueWebhookDeliveriesMutex.RUnlock()
+	fake.getWebhookDeliveriesBySubscriptionIDMutex.RLock()
+	defer fake.getWebhookDeliveriesBySubscriptionIDMutex.RUnlock()
+	fake.getWebhookSubscriptionByIDMutex.RLock()
+	defer fake.getWebhookSubscriptionByIDMutex.RUnlock()
+	fake.getWebhookSubscriptionsMutex.RLock()
+	defer fake.getWebhookSubscriptionsMutex.RUnlock()
+	fake.modelMigrationMutex.RLock()
+	defer fake.modelMigrationMutex.RUnlock()
+	fake.updateWebhookDeliveryMutex.RLock()
+	defer fake.updateWebhookDeliveryMutex.RUnlock()
+	fake.updateWebhookSubscriptionMutex.RLock()
+	defer fake.updateWebhookSubscriptionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ domain.WebhookRepository = new(FakeWebhookRepository)
@@ -0,0 +1,96 @@
+package domain
+
+import "errors"
+
+type ErrorKind string
+
+const (
+	ErrorKindValidation  ErrorKind = "validation"
+	ErrorKindNotFound    ErrorKind = "not_found"
+	ErrorKindConflict    ErrorKind = "conflict"
+	ErrorKindUnavailable ErrorKind = "unavailable"
+)
+
+// FieldError: the problem of one field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// DomainError: an error with the kind of failure and a machine readable code, the layers above the domain decide how
+// to show it to the clients by its kind, the cause is kept to be used with errors.Is and errors.As
+type DomainError struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError: the request has a field that is not valid
+func NewValidationError(code, field, message string) *DomainError {
+	return &DomainError{
+		Kind:    ErrorKindValidation,
+		Code:    code,
+		Message: message,
+		Fields:  []FieldError{{Field: field, Code: code, Message: message}},
+	}
+}
+
+// NewFieldsValidationError: the request has several fields that are not valid
+func NewFieldsValidationError(code, message string, fields []FieldError) *DomainError {
+	return &DomainError{
+		Kind:    ErrorKindValidation,
+		Code:    code,
+		Message: message,
+		Fields:  fields,
+	}
+}
+
+// NewNotFoundError: the resource of the request does not exist
+func NewNotFoundError(code, message string, err error) *DomainError {
+	return &DomainError{
+		Kind:    ErrorKindNotFound,
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewConflictError: the request collides with the current state of a resource
+func NewConflictError(code, message string, err error) *DomainError {
+	return &DomainError{
+		Kind:    ErrorKindConflict,
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewUnavailableError: a dependency like the database failed, the message does not show the cause to the clients
+func NewUnavailableError(code, message string, err error) *DomainError {
+	return &DomainError{
+		Kind:    ErrorKindUnavailable,
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// AsDomainError: find the domain error in the chain of an error
+func AsDomainError(err error) (*DomainError, bool) {
+	var domainError *DomainError
+	if errors.As(err, &domainError) {
+		return domainError, true
+	}
+	return nil, false
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+)
+
+type Import struct {
+	gorm.Model
+	FileName    string `gorm:"file_name" json:"file_name"`
+	Checksum    string `gorm:"checksum;index" json:"checksum"`
+	Uploader    string `gorm:"uploader" json:"uploader"`
+	Unit        string `gorm:"unit" json:"unit"`
+	Status      string `gorm:"status" json:"status"`
+	Total       int    `gorm:"total" json:"total"`
+	Imported    int    `gorm:"imported" json:"imported"`
+	Skipped     int    `gorm:"skipped" json:"skipped"`
+	Flagged     int    `gorm:"flagged" json:"flagged"`
+	Quarantined int    `gorm:"quarantined" json:"quarantined"`
+	Rejected    int    `gorm:"rejected" json:"rejected"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ImportRepository
+type ImportRepository interface {
+	CreateImport(importRecord *Import) error
+	UpdateImport(importRecord *Import) error
+	RollbackImport(importRecord *Import) (int64, error)
+	GetImports() ([]Import, error)
+	GetImportByID(importID uint) (*Import, error)
+	GetImportByChecksum(checksum string) (*Import, error)
+	ModelMigration() error
+}
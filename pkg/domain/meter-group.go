@@ -0,0 +1,25 @@
+package domain
+
+import "gorm.io/gorm"
+
+type MeterGroup struct {
+	gorm.Model
+	Name     string            `gorm:"name" json:"name"`
+	ParentID *uint             `gorm:"parent_id" json:"parent_id"`
+	Meters   []MeterGroupMeter `json:"meters"`
+}
+
+type MeterGroupMeter struct {
+	gorm.Model
+	MeterGroupID uint `gorm:"meter_group_id" json:"meter_group_id"`
+	MeterID      int  `gorm:"meter_id" json:"meter_id"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MeterGroupRepository
+type MeterGroupRepository interface {
+	CreateMeterGroup(group *MeterGroup) error
+	GetMeterGroupByID(groupID uint) (*MeterGroup, error)
+	GetMeterGroupsByParentID(parentID uint) ([]MeterGroup, error)
+	GetMeterGroups() ([]MeterGroup, error)
+	ModelMigration() error
+}
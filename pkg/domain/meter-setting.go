@@ -0,0 +1,20 @@
+package domain
+
+import "gorm.io/gorm"
+
+type MeterSetting struct {
+	gorm.Model
+	MeterID         int     `gorm:"meter_id;uniqueIndex" json:"meter_id"`
+	BillingCycleDay int     `gorm:"billing_cycle_day" json:"billing_cycle_day"`
+	Cumulative      bool    `gorm:"cumulative" json:"cumulative"`
+	RegisterMax     float64 `gorm:"register_max" json:"register_max"`
+	Unit            string  `gorm:"unit" json:"unit"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . MeterSettingRepository
+type MeterSettingRepository interface {
+	SaveMeterSetting(setting *MeterSetting) error
+	GetMeterSettingByMeterID(meterID int) (*MeterSetting, error)
+	GetMeterSettingsByMeterIDs(meterIDs []int) ([]MeterSetting, error)
+	ModelMigration() error
+}
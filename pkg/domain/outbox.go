@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"gorm.io/gorm"
+)
+
+type OutboxEvent struct {
+	gorm.Model
+	Kind        string     `gorm:"kind" json:"kind"`
+	Payload     string     `gorm:"payload;type:text" json:"payload"`
+	PublishedAt *time.Time `gorm:"published_at;index" json:"published_at"`
+	Attempts    int        `gorm:"attempts" json:"attempts"`
+	LastError   string     `gorm:"last_error" json:"last_error"`
+	// NextAttemptAt: the time when a failed event is sent again, blank while the event did not fail
+	NextAttemptAt *time.Time `gorm:"next_attempt_at" json:"next_attempt_at"`
+	// DeadLetteredAt: the time when the event reached the max attempts, it is not sent again
+	DeadLetteredAt *time.Time `gorm:"dead_lettered_at;index" json:"dead_lettered_at"`
+	// DeliveredSinks: the names of the sinks that already received the event separated by comma, a pending event is
+	// sent again only to the sinks that failed
+	DeliveredSinks string `gorm:"delivered_sinks" json:"delivered_sinks"`
+}
+
+// GetDeliveredSinks: get the names of the sinks that already received the event
+func (o OutboxEvent) GetDeliveredSinks() []string {
+	if o.DeliveredSinks == "" {
+		return nil
+	}
+	return strings.Split(o.DeliveredSinks, ",")
+}
+
+// DeliveredTo: tell if the sink already received the event
+//
+// Parameters:
+// sinkName: the name of the sink
+func (o OutboxEvent) DeliveredTo(sinkName string) bool {
+	for _, deliveredSink := range o.GetDeliveredSinks() {
+		if deliveredSink == sinkName {
+			return true
+		}
+	}
+	return false
+}
+
+type ReadingsImportedEvent struct {
+	MeterIDs  []int     `json:"meter_ids"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	ImportID  *uint     `json:"import_id"`
+	Count     int       `json:"count"`
+	// Days: the days with readings in date format, the days between the start and the end date without readings are
+	// not in the list
+	Days []string `json:"days"`
+}
+
+// NewReadingsImportedEvent: build the event of the readings written in the same transaction, the meters are in the
+// order they were found, the days are sorted and the import is the one of the first reading
+func NewReadingsImportedEvent(usersPowerConsumption []*UserConsumption) (*OutboxEvent, error) {
+	event := ReadingsImportedEvent{Count: len(usersPowerConsumption)}
+	seenMeterIDs := make(map[int]bool)
+	seenDays := make(map[string]bool)
+	for i, userPowerConsumption := range usersPowerConsumption {
+		if !seenMeterIDs[userPowerConsumption.MeterID] {
+			seenMeterIDs[userPowerConsumption.MeterID] = true
+			event.MeterIDs = append(event.MeterIDs, userPowerConsumption.MeterID)
+		}
+		day := userPowerConsumption.Date.UTC().Format(constants.DateFormatDate)
+		if !seenDays[day] {
+			seenDays[day] = true
+			event.Days = append(event.Days, day)
+		}
+		if i == 0 || userPowerConsumption.Date.Before(event.StartDate) {
+			event.StartDate = userPowerConsumption.Date
+		}
+		if i == 0 || userPowerConsumption.Date.After(event.EndDate) {
+			event.EndDate = userPowerConsumption.Date
+		}
+		if event.ImportID == nil {
+			event.ImportID = userPowerConsumption.ImportID
+		}
+	}
+	sort.Strings(event.Days)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Kind:    constants.EventReadingsImported,
+		Payload: string(payload),
+	}, nil
+}
+
+type ImportCompletedEvent struct {
+	ImportID    uint   `json:"import_id"`
+	FileName    string `json:"file_name"`
+	Uploader    string `json:"uploader"`
+	Imported    int    `json:"imported"`
+	Skipped     int    `json:"skipped"`
+	Flagged     int    `json:"flagged"`
+	Quarantined int    `json:"quarantined"`
+	Rejected    int    `json:"rejected"`
+	MeterIDs    []int  `json:"meter_ids"`
+}
+
+type AnomalyDetectedEvent struct {
+	MeterIDs []int              `json:"meter_ids"`
+	ImportID *uint              `json:"import_id"`
+	Readings []AnomalousReading `json:"readings"`
+}
+
+type ThresholdExceededEvent struct {
+	AlertID     uint      `json:"alert_id"`
+	RuleID      uint      `json:"rule_id"`
+	MeterID     *int      `json:"meter_id"`
+	GroupID     *uint     `json:"group_id"`
+	Metric      string    `json:"metric"`
+	PeriodKind  string    `json:"period_kind"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Value       float64   `json:"value"`
+	Projected   float64   `json:"projected"`
+	Threshold   float64   `json:"threshold"`
+	Comparison  string    `json:"comparison"`
+}
+
+type AnomalousReading struct {
+	MeterID int       `json:"meter_id"`
+	Date    time.Time `json:"date"`
+	Flags   string    `json:"flags"`
+}
+
+// NewImportCompletedEvent: build the event of an import that finished with the counts of its rows
+//
+// Parameters:
+// importRecord: the completed import
+// meterIDs: the meters of the imported readings
+//
+// Returns:
+// return the event to write in the outbox
+func NewImportCompletedEvent(importRecord *Import, meterIDs []int) (*OutboxEvent, error) {
+	payload, err := json.Marshal(ImportCompletedEvent{
+		ImportID:    importRecord.ID,
+		FileName:    importRecord.FileName,
+		Uploader:    importRecord.Uploader,
+		Imported:    importRecord.Imported,
+		Skipped:     importRecord.Skipped,
+		Flagged:     importRecord.Flagged,
+		Quarantined: importRecord.Quarantined,
+		Rejected:    importRecord.Rejected,
+		MeterIDs:    meterIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Kind:    constants.EventImportCompleted,
+		Payload: string(payload),
+	}, nil
+}
+
+// NewAnomalyDetectedEvent: build the event of the readings flagged by the data quality rules
+//
+// Parameters:
+// usersPowerConsumption: the readings to write
+//
+// Returns:
+// return the event to write in the outbox or nil if there are not flagged readings
+func NewAnomalyDetectedEvent(usersPowerConsumption []*UserConsumption) (*OutboxEvent, error) {
+	event := AnomalyDetectedEvent{}
+	seenMeterIDs := make(map[int]bool)
+	for _, userPowerConsumption := range usersPowerConsumption {
+		if userPowerConsumption.Flags == "" {
+			continue
+		}
+		if !seenMeterIDs[userPowerConsumption.MeterID] {
+			seenMeterIDs[userPowerConsumption.MeterID] = true
+			event.MeterIDs = append(event.MeterIDs, userPowerConsumption.MeterID)
+		}
+		if event.ImportID == nil {
+			event.ImportID = userPowerConsumption.ImportID
+		}
+		event.Readings = append(event.Readings, AnomalousReading{
+			MeterID: userPowerConsumption.MeterID,
+			Date:    userPowerConsumption.Date,
+			Flags:   userPowerConsumption.Flags,
+		})
+	}
+	if len(event.Readings) == 0 {
+		return nil, nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Kind:    constants.EventAnomalyDetected,
+		Payload: string(payload),
+	}, nil
+}
+
+// NewThresholdExceededEvent: build the event of a triggered alert
+//
+// Parameters:
+// alert: the triggered alert
+//
+// Returns:
+// return the event to write in the outbox
+func NewThresholdExceededEvent(alert Alert) (*OutboxEvent, error) {
+	payload, err := json.Marshal(ThresholdExceededEvent{
+		AlertID:     alert.ID,
+		RuleID:      alert.RuleID,
+		MeterID:     alert.MeterID,
+		GroupID:     alert.GroupID,
+		Metric:      alert.Metric,
+		PeriodKind:  alert.PeriodKind,
+		PeriodStart: alert.PeriodStart,
+		PeriodEnd:   alert.PeriodEnd,
+		Value:       alert.Value,
+		Projected:   alert.Projected,
+		Threshold:   alert.Threshold,
+		Comparison:  alert.Comparison,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Kind:    constants.EventThresholdExceeded,
+		Payload: string(payload),
+	}, nil
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . OutboxRepository
+type OutboxRepository interface {
+	CreateOutboxEvent(event *OutboxEvent) error
+	GetPendingOutboxEvents(now time.Time, limit int) ([]OutboxEvent, error)
+	MarkOutboxEventPublished(eventID uint, publishedAt time.Time) error
+	MarkOutboxEventFailed(eventID uint, deliveredSinks []string, reason string, nextAttemptAt time.Time) error
+	MarkOutboxEventDeadLettered(eventID uint, deliveredSinks []string, reason string, deadLetteredAt time.Time) error
+	ModelMigration() error
+}
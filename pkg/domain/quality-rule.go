@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QualityRule struct {
+	gorm.Model
+	Name      string     `gorm:"name" json:"name"`
+	MeterID   *int       `gorm:"meter_id" json:"meter_id"`
+	Kind      string     `gorm:"kind" json:"kind"`
+	Field     string     `gorm:"field" json:"field"`
+	Value     float64    `gorm:"value" json:"value"`
+	StartDate *time.Time `gorm:"start_date" json:"start_date"`
+	EndDate   *time.Time `gorm:"end_date" json:"end_date"`
+	Action    string     `gorm:"action" json:"action"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . QualityRuleRepository
+type QualityRuleRepository interface {
+	CreateQualityRule(rule *QualityRule) error
+	GetQualityRules() ([]QualityRule, error)
+	DeleteQualityRule(ruleID uint) error
+	ModelMigration() error
+}
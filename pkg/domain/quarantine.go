@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QuarantinedReading struct {
+	gorm.Model
+	MeterID            int       `gorm:"meter_id" json:"meter_id"`
+	ActiveEnergy       float64   `gorm:"active_energy" json:"active_energy"`
+	ReactiveEnergy     float64   `gorm:"reactive_energy" json:"reactive_energy"`
+	CapacitiveReactive float64   `gorm:"capacity_energy" json:"capacitive_reactive"`
+	Solar              float64   `gorm:"solar" json:"solar"`
+	Date               time.Time `gorm:"date" json:"date"`
+	Reason             string    `gorm:"reason" json:"reason"`
+	RawLine            string    `gorm:"raw_line" json:"raw_line"`
+	Source             string    `gorm:"source" json:"source"`
+	Unit               string    `gorm:"unit" json:"unit"`
+	ImportID           *uint     `gorm:"import_id;index" json:"import_id"`
+}
+
+func NewQuarantinedReading(reading UserConsumption, reason string) *QuarantinedReading {
+	return &QuarantinedReading{
+		MeterID:            reading.MeterID,
+		ActiveEnergy:       reading.ActiveEnergy,
+		ReactiveEnergy:     reading.ReactiveEnergy,
+		CapacitiveReactive: reading.CapacitiveReactive,
+		Solar:              reading.Solar,
+		Date:               reading.Date,
+		Reason:             reason,
+	}
+}
+
+func (q QuarantinedReading) ToUserConsumption() *UserConsumption {
+	return &UserConsumption{
+		MeterID:            q.MeterID,
+		ActiveEnergy:       q.ActiveEnergy,
+		ReactiveEnergy:     q.ReactiveEnergy,
+		CapacitiveReactive: q.CapacitiveReactive,
+		Solar:              q.Solar,
+		Date:               q.Date,
+		ImportID:           q.ImportID,
+	}
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . QuarantineRepository
+type QuarantineRepository interface {
+	CreateQuarantinedReadings(readings []*QuarantinedReading) error
+	GetQuarantinedReadings() ([]QuarantinedReading, error)
+	GetQuarantinedReadingByID(readingID uint) (*QuarantinedReading, error)
+	UpdateQuarantinedReading(reading *QuarantinedReading) error
+	DeleteQuarantinedReading(readingID uint) error
+	ModelMigration() error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookSubscription struct {
+	gorm.Model
+	URL      string `gorm:"url" json:"url"`
+	Secret   string `gorm:"secret" json:"secret"`
+	Events   string `gorm:"events" json:"events"`
+	MeterIDs string `gorm:"meter_ids" json:"meter_ids"`
+	// Active: an inactive subscription does not receive new events and its pending deliveries are not sent
+	Active bool `gorm:"active;default:true" json:"active"`
+}
+
+type WebhookDelivery struct {
+	gorm.Model
+	SubscriptionID uint       `gorm:"subscription_id;uniqueIndex:idx_webhook_delivery_event" json:"subscription_id"`
+	EventID        uint       `gorm:"event_id;uniqueIndex:idx_webhook_delivery_event" json:"event_id"`
+	Kind           string     `gorm:"kind" json:"kind"`
+	Payload        string     `gorm:"payload;type:text" json:"payload"`
+	Status         string     `gorm:"status;index" json:"status"`
+	Attempts       int        `gorm:"attempts" json:"attempts"`
+	StatusCode     int        `gorm:"status_code" json:"status_code"`
+	LastError      string     `gorm:"last_error" json:"last_error"`
+	NextAttemptAt  *time.Time `gorm:"next_attempt_at;index" json:"next_attempt_at"`
+	DeliveredAt    *time.Time `gorm:"delivered_at" json:"delivered_at"`
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . WebhookRepository
+type WebhookRepository interface {
+	CreateWebhookSubscription(subscription *WebhookSubscription) error
+	GetWebhookSubscriptions() ([]WebhookSubscription, error)
+	GetWebhookSubscriptionByID(subscriptionID uint) (*WebhookSubscription, error)
+	UpdateWebhookSubscription(subscription *WebhookSubscription) error
+	DeleteWebhookSubscription(subscriptionID uint) error
+	CreateWebhookDeliveries(deliveries []*WebhookDelivery) error
+	UpdateWebhookDelivery(delivery *WebhookDelivery) error
+	GetDueWebhookDeliveries(now time.Time, limit int) ([]WebhookDelivery, error)
+	GetWebhookDeliveriesBySubscriptionID(subscriptionID uint) ([]WebhookDelivery, error)
+	ModelMigration() error
+}
@@ -0,0 +1,207 @@
+package infraestructure
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type AlertRuleRequest struct {
+	Name       string  `json:"name"`
+	MeterID    *int    `json:"meter_id"`
+	GroupID    *uint   `json:"group_id"`
+	Metric     string  `json:"metric"`
+	PeriodKind string  `json:"period_kind" binding:"required"`
+	Threshold  float64 `json:"threshold"`
+	Comparison string  `json:"comparison" binding:"required"`
+}
+
+type AlertRuleSerializer struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	MeterID    *int    `json:"meter_id"`
+	GroupID    *uint   `json:"group_id"`
+	Metric     string  `json:"metric"`
+	PeriodKind string  `json:"period_kind"`
+	Threshold  float64 `json:"threshold"`
+	Comparison string  `json:"comparison"`
+}
+
+type AlertSerializer struct {
+	ID          uint    `json:"id"`
+	RuleID      uint    `json:"rule_id"`
+	MeterID     *int    `json:"meter_id"`
+	GroupID     *uint   `json:"group_id"`
+	Metric      string  `json:"metric"`
+	PeriodKind  string  `json:"period_kind"`
+	PeriodStart string  `json:"period_start"`
+	PeriodEnd   string  `json:"period_end"`
+	Value       float64 `json:"value"`
+	Projected   float64 `json:"projected"`
+	Threshold   float64 `json:"threshold"`
+	Comparison  string  `json:"comparison"`
+	TriggeredAt string  `json:"triggered_at"`
+}
+
+func (r AlertRuleRequest) ToAlertRule() domain.AlertRule {
+	return domain.AlertRule{
+		Name:       r.Name,
+		MeterID:    r.MeterID,
+		GroupID:    r.GroupID,
+		Metric:     r.Metric,
+		PeriodKind: r.PeriodKind,
+		Threshold:  r.Threshold,
+		Comparison: r.Comparison,
+	}
+}
+
+func ToAlertRuleSerializer(rule domain.AlertRule) AlertRuleSerializer {
+	return AlertRuleSerializer{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		MeterID:    rule.MeterID,
+		GroupID:    rule.GroupID,
+		Metric:     rule.Metric,
+		PeriodKind: rule.PeriodKind,
+		Threshold:  rule.Threshold,
+		Comparison: rule.Comparison,
+	}
+}
+
+func ToAlertSerializer(alert domain.Alert) AlertSerializer {
+	return AlertSerializer{
+		ID:          alert.ID,
+		RuleID:      alert.RuleID,
+		MeterID:     alert.MeterID,
+		GroupID:     alert.GroupID,
+		Metric:      alert.Metric,
+		PeriodKind:  alert.PeriodKind,
+		PeriodStart: alert.PeriodStart.Format(constants.DateFormatDate),
+		PeriodEnd:   alert.PeriodEnd.Format(constants.DateFormatDate),
+		Value:       alert.Value,
+		Projected:   alert.Projected,
+		Threshold:   alert.Threshold,
+		Comparison:  alert.Comparison,
+		TriggeredAt: alert.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type AlertHandlerImpl struct {
+	alertService application.AlertService
+}
+
+func NewAlertHandler(alertService application.AlertService) *AlertHandlerImpl {
+	return &AlertHandlerImpl{
+		alertService,
+	}
+}
+
+// Create an alert rule that is evaluated after every import and on a schedule
+// @Tags Alerts
+// @Summary Create an alert rule
+// @Description Create an alert rule of a meter or a group, the metric could be active_energy, reactive_energy, capacitive_reactive or solar, the period kind daily, calendar_weekly or monthly and the comparison greater_than, less_than or projected_greater_than to be told when the period is on track to exceed the threshold
+// @Accept  json
+// @Produce  json
+// @Param rule body AlertRuleRequest true "alert rule"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /alert-rules [post]
+func (a *AlertHandlerImpl) CreateAlertRule(c *gin.Context) {
+	var request AlertRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your alert rule", err))
+		return
+	}
+	rule, err := a.alertService.CreateAlertRule(request.ToAlertRule())
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your alert rule", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the alert rule was successfully created",
+		Status: "SUCCESS",
+		Data:   ToAlertRuleSerializer(*rule),
+		Err:    nil,
+	})
+}
+
+// Get all the alert rules
+// @Tags Alerts
+// @Summary Get all the alert rules
+// @Description Get all the alert rules
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /alert-rules [get]
+func (a *AlertHandlerImpl) GetAlertRules(c *gin.Context) {
+	rules, err := a.alertService.GetAlertRules()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []AlertRuleSerializer{}
+	for _, rule := range rules {
+		serializers = append(serializers, ToAlertRuleSerializer(rule))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
+
+// Delete an alert rule
+// @Tags Alerts
+// @Summary Delete an alert rule
+// @Description Delete an alert rule, its triggered alerts are kept
+// @Accept  json
+// @Produce  json
+// @Param id path string true "alert rule id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /alert-rules/{id} [delete]
+func (a *AlertHandlerImpl) DeleteAlertRule(c *gin.Context) {
+	if err := a.alertService.DeleteAlertRule(c.Param("id")); err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the alert rule was successfully deleted",
+		Status: "SUCCESS",
+		Data:   nil,
+		Err:    nil,
+	})
+}
+
+// Get the triggered alerts
+// @Tags Alerts
+// @Summary Get the triggered alerts
+// @Description Get the triggered alerts, a rule triggers at most one alert by period, the newest first
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /alerts [get]
+func (a *AlertHandlerImpl) GetAlerts(c *gin.Context) {
+	alerts, err := a.alertService.GetAlerts()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []AlertSerializer{}
+	for _, alert := range alerts {
+		serializers = append(serializers, ToAlertSerializer(alert))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
@@ -0,0 +1,59 @@
+package infraestructure
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const AlertRulesPath = "/alert-rules"
+
+var _ = Describe("CreateAlertRule", func() {
+	var (
+		router           *gin.Engine
+		server           *ghttp.Server
+		mockAlertService *applicationfakes.FakeAlertService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockAlertService = &applicationfakes.FakeAlertService{}
+		mockHandler := NewAlertHandler(mockAlertService)
+		router.POST(AlertRulesPath, mockHandler.CreateAlertRule)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", AlertRulesPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the comparison is missing", func() {
+		It("should return an error", func() {
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), AlertRulesPath), "application/json", bytes.NewBufferString(`{"meter_id":1,"period_kind":"daily","threshold":50}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockAlertService.CreateAlertRuleCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the rule is valid", func() {
+		It("should create the rule", func() {
+			meterID := 1
+			mockAlertService.CreateAlertRuleReturns(&domain.AlertRule{MeterID: &meterID, PeriodKind: "monthly", Threshold: 500, Comparison: "projected_greater_than"}, nil)
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), AlertRulesPath), "application/json", bytes.NewBufferString(`{"meter_id":1,"period_kind":"monthly","threshold":500,"comparison":"projected_greater_than"}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			rule := mockAlertService.CreateAlertRuleArgsForCall(0)
+			Expect(*rule.MeterID).To(Equal(1))
+			Expect(rule.Threshold).To(Equal(500.0))
+		})
+	})
+})
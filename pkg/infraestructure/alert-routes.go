@@ -0,0 +1,20 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type AlertRoutes struct {
+	alertHandler *AlertHandlerImpl
+}
+
+func (ro *AlertRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.POST("/alert-rules", ro.alertHandler.CreateAlertRule)
+	public.GET("/alert-rules", ro.alertHandler.GetAlertRules)
+	public.DELETE("/alert-rules/:id", ro.alertHandler.DeleteAlertRule)
+	public.GET("/alerts", ro.alertHandler.GetAlerts)
+}
+
+func NewAlertRoutes(alertHandler *AlertHandlerImpl) *AlertRoutes {
+	return &AlertRoutes{
+		alertHandler,
+	}
+}
@@ -0,0 +1,46 @@
+package infraestructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/sirupsen/logrus"
+)
+
+type AlertSchedulerImpl struct {
+	alertService       application.AlertService
+	evaluationInterval time.Duration
+}
+
+func NewAlertScheduler(alertService application.AlertService, evaluationInterval time.Duration) *AlertSchedulerImpl {
+	return &AlertSchedulerImpl{
+		alertService,
+		evaluationInterval,
+	}
+}
+
+// Run: evaluate the alert rules periodically until the context is done
+//
+// Parameters:
+// ctx: the context to stop the scheduler
+func (a *AlertSchedulerImpl) Run(ctx context.Context) {
+	logrus.Infof("the alert rules are evaluated every %s", a.evaluationInterval)
+	ticker := time.NewTicker(a.evaluationInterval)
+	defer ticker.Stop()
+	for {
+		triggered, err := a.alertService.EvaluateAlertRules()
+		if err != nil {
+			logrus.Errorf("Error: evaluating the alert rules %s", err.Error())
+		}
+		if triggered > 0 {
+			logrus.Infof("%d alerts were triggered", triggered)
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Info("the alert scheduler was stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -8,6 +8,8 @@ type PowerConsumptionRoutes struct {
 
 func (ro *PowerConsumptionRoutes) RegisterRoutes(public *gin.RouterGroup) {
 	public.GET("/consumption", ro.powerConsumptionHandler.GetConsumptionByMeterIDAndWindowTime)
+	public.GET("/consumption/demand", ro.powerConsumptionHandler.GetPeakDemandByMeterIDAndWindowTime)
+	public.GET("/consumption/analytics", ro.powerConsumptionHandler.GetAnalyticsByMeterIDAndWindowTime)
 	public.POST("/consumption/information", ro.powerConsumptionHandler.ImportCsvToDatabase)
 }
 
@@ -18,22 +18,31 @@ func (r *FilterConsumptionSerializer) Marshal() ([]byte, error) {
 }
 
 type FilterConsumptionSerializer struct {
-	Period    []string    `json:"period"`
-	DataGraph []DataGraph `json:"data_graph"`
+	Period       []string    `json:"period"`
+	Unit         string      `json:"unit,omitempty"`
+	ReactiveUnit string      `json:"reactive_unit,omitempty"`
+	GroupID      int         `json:"group_id,omitempty"`
+	GroupName    string      `json:"group_name,omitempty"`
+	DataGraph    []DataGraph `json:"data_graph"`
+	Total        *DataGraph  `json:"total,omitempty"`
 }
 
 type DataGraph struct {
-	MeterID            int       `json:"meter_id"`
-	Address            string    `json:"address"`
-	Active             []float64 `json:"active"`
-	ReactiveInductive  []float64 `json:"reactive_inductive"`
-	ReactiveCapacitive []float64 `json:"reactive_capacitive"`
-	Exported           []float64 `json:"exported"`
+	MeterID            int                                          `json:"meter_id"`
+	Address            string                                       `json:"address"`
+	Active             []float64                                    `json:"active"`
+	ReactiveInductive  []float64                                    `json:"reactive_inductive"`
+	ReactiveCapacitive []float64                                    `json:"reactive_capacitive"`
+	Exported           []float64                                    `json:"exported"`
+	Aggregations       map[string]application.AggregationSerializer `json:"aggregations,omitempty"`
+	Comparison         *application.ComparisonSerializer            `json:"comparison,omitempty"`
 }
 
 func (f *FilterConsumptionSerializer) ToFilterConsumptionSerializer(data []application.Serializer) {
 	for _, values := range data {
 		f.Period = values.Period
+		f.Unit = values.Unit
+		f.ReactiveUnit = values.ReactiveUnit
 		f.DataGraph = append(f.DataGraph, DataGraph{
 			MeterID:            values.MeterID,
 			Address:            fmt.Sprintf("Mock address %d", values.MeterID),
@@ -41,6 +50,60 @@ func (f *FilterConsumptionSerializer) ToFilterConsumptionSerializer(data []appli
 			ReactiveInductive:  values.ReactiveInductive,
 			ReactiveCapacitive: values.ReactiveCapacitive,
 			Exported:           values.Exported,
+			Aggregations:       values.Aggregations,
+			Comparison:         values.Comparison,
+		})
+	}
+}
+
+func (f *FilterConsumptionSerializer) ToGroupConsumptionSerializer(data *application.GroupSerializer) {
+	f.ToFilterConsumptionSerializer(data.Meters)
+	f.Period = data.Total.Period
+	f.Unit = data.Total.Unit
+	f.ReactiveUnit = data.Total.ReactiveUnit
+	f.GroupID = data.GroupID
+	f.GroupName = data.Name
+	f.Total = &DataGraph{
+		Address:            data.Name,
+		Active:             data.Total.Active,
+		ReactiveInductive:  data.Total.ReactiveInductive,
+		ReactiveCapacitive: data.Total.ReactiveCapacitive,
+		Exported:           data.Total.Exported,
+		Aggregations:       data.Total.Aggregations,
+		Comparison:         data.Total.Comparison,
+	}
+}
+
+type FilterAnalyticsSerializer struct {
+	LoadProfilePeriod []string             `json:"load_profile_period"`
+	LoadFactorPeriod  []string             `json:"load_factor_period"`
+	DataGraph         []AnalyticsDataGraph `json:"data_graph"`
+}
+
+type AnalyticsDataGraph struct {
+	MeterID        int       `json:"meter_id"`
+	Address        string    `json:"address"`
+	WeekdayProfile []float64 `json:"weekday_profile"`
+	WeekendProfile []float64 `json:"weekend_profile"`
+	Energy         []float64 `json:"energy"`
+	AverageDemand  []float64 `json:"average_demand"`
+	PeakDemand     []float64 `json:"peak_demand"`
+	LoadFactor     []float64 `json:"load_factor"`
+}
+
+func (f *FilterAnalyticsSerializer) ToFilterAnalyticsSerializer(data []application.AnalyticsSerializer) {
+	for _, values := range data {
+		f.LoadProfilePeriod = values.LoadProfile.Period
+		f.LoadFactorPeriod = values.LoadFactor.Period
+		f.DataGraph = append(f.DataGraph, AnalyticsDataGraph{
+			MeterID:        values.MeterID,
+			Address:        fmt.Sprintf("Mock address %d", values.MeterID),
+			WeekdayProfile: values.LoadProfile.Weekday,
+			WeekendProfile: values.LoadProfile.Weekend,
+			Energy:         values.LoadFactor.Energy,
+			AverageDemand:  values.LoadFactor.AverageDemand,
+			PeakDemand:     values.LoadFactor.PeakDemand,
+			LoadFactor:     values.LoadFactor.LoadFactor,
 		})
 	}
 }
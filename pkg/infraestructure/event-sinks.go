@@ -0,0 +1,125 @@
+package infraestructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/segmentio/kafka-go"
+)
+
+type WebhookEventSinkImpl struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookEventSink(url string) *WebhookEventSinkImpl {
+	return &WebhookEventSinkImpl{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *WebhookEventSinkImpl) Name() string {
+	return "webhook"
+}
+
+// Publish: post the event as json to the webhook, any status that is not 2xx is an error
+func (w *WebhookEventSinkImpl) Publish(envelope application.EventEnvelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Error: the webhook answered with the status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type KafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+type KafkaEventSinkImpl struct {
+	writer KafkaWriter
+}
+
+func NewKafkaEventSink(writer KafkaWriter) *KafkaEventSinkImpl {
+	return &KafkaEventSinkImpl{
+		writer,
+	}
+}
+
+// NewKafkaWriter: build the writer of a topic that waits for all the replicas
+//
+// Parameters:
+// brokers: the addresses of the brokers
+// topic: the topic of the events
+//
+// Returns:
+// return the writer of the topic
+func NewKafkaWriter(brokers []string, topic string) KafkaWriter {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		RequiredAcks: kafka.RequireAll,
+		Balancer:     &kafka.Hash{},
+	}
+}
+
+func (k *KafkaEventSinkImpl) Name() string {
+	return "kafka"
+}
+
+// Publish: write the event as json in the topic with the id of the event as key
+func (k *KafkaEventSinkImpl) Publish(envelope application.EventEnvelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(strconv.FormatUint(uint64(envelope.ID), 10)),
+		Value: body,
+	})
+}
+
+type ChannelEventSinkImpl struct {
+	events chan application.EventEnvelope
+}
+
+func NewChannelEventSink(size int) *ChannelEventSinkImpl {
+	return &ChannelEventSinkImpl{
+		events: make(chan application.EventEnvelope, size),
+	}
+}
+
+func (c *ChannelEventSinkImpl) Name() string {
+	return "channel"
+}
+
+// Publish: send the event to the channel, it is an error when the channel is full so the event is sent again later
+func (c *ChannelEventSinkImpl) Publish(envelope application.EventEnvelope) error {
+	select {
+	case c.events <- envelope:
+		return nil
+	default:
+		return fmt.Errorf("Error: the event channel is full")
+	}
+}
+
+// Events: the channel to receive the events in the same process
+func (c *ChannelEventSinkImpl) Events() <-chan application.EventEnvelope {
+	return c.events
+}
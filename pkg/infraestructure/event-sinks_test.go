@@ -0,0 +1,100 @@
+package infraestructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/segmentio/kafka-go"
+)
+
+type standInKafkaWriter struct {
+	messages []kafka.Message
+	err      error
+}
+
+func (w *standInKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+var _ = Describe("Event sinks", func() {
+	envelope := application.EventEnvelope{
+		ID:   5,
+		Kind: "readings_imported",
+		Data: json.RawMessage(`{"count":3}`),
+	}
+
+	Context("Webhook", func() {
+		It("should post the event as json", func() {
+			var received application.EventEnvelope
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				Expect(json.Unmarshal(body, &received)).To(Succeed())
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+			Expect(NewWebhookEventSink(server.URL).Publish(envelope)).To(Succeed())
+			Expect(received.ID).To(Equal(uint(5)))
+			Expect(string(received.Data)).To(Equal(`{"count":3}`))
+		})
+
+		It("should fail when the webhook does not answer with 2xx", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			defer server.Close()
+			Expect(NewWebhookEventSink(server.URL).Publish(envelope)).ToNot(Succeed())
+		})
+	})
+
+	Context("Kafka", func() {
+		It("should write the event with its id as key", func() {
+			writer := &standInKafkaWriter{}
+			Expect(NewKafkaEventSink(writer).Publish(envelope)).To(Succeed())
+			Expect(writer.messages).To(HaveLen(1))
+			Expect(string(writer.messages[0].Key)).To(Equal("5"))
+		})
+
+		It("should return the error of the writer", func() {
+			writer := &standInKafkaWriter{err: errors.New("no leader")}
+			Expect(NewKafkaEventSink(writer).Publish(envelope)).ToNot(Succeed())
+		})
+	})
+
+	Context("Channel", func() {
+		It("should deliver the event and fail when the channel is full", func() {
+			sink := NewChannelEventSink(1)
+			Expect(sink.Publish(envelope)).To(Succeed())
+			Expect(sink.Publish(envelope)).ToNot(Succeed())
+			Expect((<-sink.Events()).ID).To(Equal(uint(5)))
+		})
+	})
+})
+
+var _ = Describe("OutboxRelay", func() {
+	It("should publish the pending events until the context is done", func() {
+		mockOutboxService := &applicationfakes.FakeOutboxService{}
+		relay := NewOutboxRelay(mockOutboxService, 10*time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			relay.Run(ctx)
+			close(done)
+		}()
+		Eventually(mockOutboxService.PublishPendingEventsCallCount).Should(BeNumerically(">=", 2))
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,55 @@
+package infraestructure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+)
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type GraphQLHandlerImpl struct {
+	schema        graphql.Schema
+	loaderFactory application.ConsumptionLoaderFactory
+}
+
+func NewGraphQLHandler(schema graphql.Schema, loaderFactory application.ConsumptionLoaderFactory) *GraphQLHandlerImpl {
+	return &GraphQLHandlerImpl{
+		schema,
+		loaderFactory,
+	}
+}
+
+// Execute a graphql query over the meters, their readings and their aggregated series
+// @Tags GraphQL
+// @Summary Execute a graphql query
+// @Description Execute a graphql query, the clients select the meters, the fields, the period kind and the window of every series in only one request, e.g. { meters(ids: [1, 2]) { id settings { unit } consumption(startDate: "2023-06-01", endDate: "2023-06-30", periodKind: "weekly") { period active } } }. The response follows the graphql spec with data and errors
+// @Accept  json
+// @Produce  json
+// @Param query body GraphQLRequest true "graphql query"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} Response
+// @Router /graphql [post]
+func (g *GraphQLHandlerImpl) Query(c *gin.Context) {
+	var request GraphQLRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your graphql query", err))
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), consumptionLoaderKey{}, g.loaderFactory())
+	result := graphql.Do(graphql.Params{
+		Schema:         g.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        ctx,
+	})
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,140 @@
+package infraestructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const GraphQLPath = "/graphql"
+
+var _ = Describe("GraphQL Query", func() {
+	var (
+		router                      *gin.Engine
+		server                      *ghttp.Server
+		mockMySQLRepository         *domainfakes.FakeMySQLPowerConsumptionRepository
+		mockMeterSettingRepository  *domainfakes.FakeMeterSettingRepository
+		mockPowerConsumptionService *applicationfakes.FakePowerConsumptionService
+	)
+
+	postQuery := func(body string) (*http.Response, map[string]interface{}) {
+		resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), GraphQLPath), "application/json", bytes.NewBufferString(body))
+		Expect(err).To(BeNil())
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp, result
+	}
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockMySQLRepository = &domainfakes.FakeMySQLPowerConsumptionRepository{}
+		mockMeterSettingRepository = &domainfakes.FakeMeterSettingRepository{}
+		mockPowerConsumptionService = &applicationfakes.FakePowerConsumptionService{}
+		schema, err := NewConsumptionGraphQLSchema()
+		Expect(err).To(BeNil())
+		mockHandler := NewGraphQLHandler(schema, func() application.ConsumptionLoader {
+			return application.NewConsumptionLoader(mockMySQLRepository, mockMeterSettingRepository, mockPowerConsumptionService)
+		})
+		router.POST(GraphQLPath, mockHandler.Query)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", GraphQLPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the body does not have a query", func() {
+		It("should return an error", func() {
+			resp, _ := postQuery(`{}`)
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the query asks the settings and the readings of several meters", func() {
+		It("should load every level with only one query", func() {
+			mockMeterSettingRepository.GetMeterSettingsByMeterIDsReturns([]domain.MeterSetting{{MeterID: 2, Unit: "kWh"}}, nil)
+			mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeReturns([]domain.UserConsumption{
+				{ID: "1", MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: "2", MeterID: 2, ActiveEnergy: 20, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: "3", MeterID: 1, ActiveEnergy: 30, Date: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+			}, nil)
+			resp, result := postQuery(`{"query":"{ meters(ids: [1, 2, 3]) { id settings { unit } readings(startDate: \"2023-06-01\", endDate: \"2023-06-02\") { id activeEnergy } } }"}`)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(result["errors"]).To(BeNil())
+			Expect(mockMeterSettingRepository.GetMeterSettingsByMeterIDsCallCount()).To(Equal(1))
+			Expect(mockMeterSettingRepository.GetMeterSettingsByMeterIDsArgsForCall(0)).To(Equal([]int{1, 2, 3}))
+			Expect(mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeCallCount()).To(Equal(1))
+			startDate, endDate, meterIDs := mockMySQLRepository.GetConsumptionByMeterIDsAndWindowTimeArgsForCall(0)
+			Expect(startDate).To(Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(endDate).To(Equal(time.Date(2023, 6, 2, 23, 59, 59, 0, time.UTC)))
+			Expect(meterIDs).To(Equal([]int{1, 2, 3}))
+
+			meters := result["data"].(map[string]interface{})["meters"].([]interface{})
+			Expect(meters).To(HaveLen(3))
+			first := meters[0].(map[string]interface{})
+			Expect(first["settings"]).To(BeNil())
+			Expect(first["readings"]).To(HaveLen(2))
+			second := meters[1].(map[string]interface{})
+			Expect(second["settings"].(map[string]interface{})["unit"]).To(Equal("kWh"))
+			Expect(meters[2].(map[string]interface{})["readings"]).To(BeEmpty())
+		})
+	})
+
+	Context("when the query asks the series of several meters", func() {
+		It("should call the consumption service once with all the meters", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDsInBatchReturns([]application.Serializer{
+				{MeterID: 1, Period: []string{"JUN 2023"}, Active: []float64{100}, Aggregations: map[string]application.AggregationSerializer{"max": {Active: []float64{40}}}},
+				{MeterID: 2, Period: []string{"JUN 2023"}, Active: []float64{200}},
+			}, nil, nil)
+			resp, result := postQuery(`{"query":"query($kind: String!) { meters(ids: [1, 2]) { id consumption(startDate: \"2023-06-01\", endDate: \"2023-06-30\", periodKind: $kind, aggregations: \"max\") { periodKind period active aggregations { name active } } } }","variables":{"kind":"monthly"}}`)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(result["errors"]).To(BeNil())
+			Expect(mockPowerConsumptionService.GetConsumptionByMeterIDsInBatchCallCount()).To(Equal(1))
+			meterIDs, startDate, endDate, kindPeriod, options := mockPowerConsumptionService.GetConsumptionByMeterIDsInBatchArgsForCall(0)
+			Expect(meterIDs).To(Equal("1,2"))
+			Expect(startDate).To(Equal("2023-06-01"))
+			Expect(endDate).To(Equal("2023-06-30"))
+			Expect(kindPeriod).To(Equal("monthly"))
+			Expect(options.Aggregations).To(Equal("max"))
+
+			meters := result["data"].(map[string]interface{})["meters"].([]interface{})
+			series := meters[0].(map[string]interface{})["consumption"].(map[string]interface{})
+			Expect(series["periodKind"]).To(Equal("monthly"))
+			Expect(series["active"]).To(Equal([]interface{}{100.0}))
+			Expect(series["aggregations"]).To(Equal([]interface{}{map[string]interface{}{"name": "max", "active": []interface{}{40.0}}}))
+		})
+	})
+
+	Context("when the consumption service fails", func() {
+		It("should return the error of the field", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDsInBatchReturns(nil, nil, fmt.Errorf("Error: kind period not allowed"))
+			resp, result := postQuery(`{"query":"{ meters(ids: [1]) { id consumption(startDate: \"2023-06-01\", endDate: \"2023-06-30\", periodKind: \"hourly\") { active } } }"}`)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(result["errors"]).To(HaveLen(1))
+		})
+
+		It("should return the error only in the field of the meter that failed", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDsInBatchReturns([]application.Serializer{
+				{MeterID: 1, Period: []string{"JUN 2023"}, Active: []float64{100}},
+			}, map[int]error{2: fmt.Errorf("Error: the meter 2 does not have a billing cycle day")}, nil)
+			resp, result := postQuery(`{"query":"{ meters(ids: [1, 2]) { id consumption(startDate: \"2023-06-01\", endDate: \"2023-06-30\", periodKind: \"billing_cycle\") { active } } }"}`)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(result["errors"]).To(HaveLen(1))
+			meters := result["data"].(map[string]interface{})["meters"].([]interface{})
+			Expect(meters[0].(map[string]interface{})["consumption"].(map[string]interface{})["active"]).To(Equal([]interface{}{100.0}))
+			Expect(meters[1].(map[string]interface{})["consumption"]).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type GraphQLRoutes struct {
+	graphQLHandler *GraphQLHandlerImpl
+}
+
+func (ro *GraphQLRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.POST("/graphql", ro.graphQLHandler.Query)
+}
+
+func NewGraphQLRoutes(graphQLHandler *GraphQLHandlerImpl) *GraphQLRoutes {
+	return &GraphQLRoutes{
+		graphQLHandler,
+	}
+}
@@ -0,0 +1,308 @@
+package infraestructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type consumptionLoaderKey struct{}
+
+type MeterGraphQLSerializer struct {
+	ID int `graphql:"id"`
+}
+
+type MeterSettingGraphQLSerializer struct {
+	BillingCycleDay int     `graphql:"billingCycleDay"`
+	Cumulative      bool    `graphql:"cumulative"`
+	RegisterMax     float64 `graphql:"registerMax"`
+	Unit            string  `graphql:"unit"`
+}
+
+type ReadingGraphQLSerializer struct {
+	ID                 string  `graphql:"id"`
+	MeterID            int     `graphql:"meterId"`
+	Date               string  `graphql:"date"`
+	ActiveEnergy       float64 `graphql:"activeEnergy"`
+	ReactiveEnergy     float64 `graphql:"reactiveEnergy"`
+	CapacitiveReactive float64 `graphql:"capacitiveReactive"`
+	Solar              float64 `graphql:"solar"`
+	Flags              string  `graphql:"flags"`
+	ImportID           *uint   `graphql:"importId"`
+}
+
+type AggregationGraphQLSerializer struct {
+	Name               string    `graphql:"name"`
+	Active             []float64 `graphql:"active"`
+	ReactiveInductive  []float64 `graphql:"reactiveInductive"`
+	ReactiveCapacitive []float64 `graphql:"reactiveCapacitive"`
+	Exported           []float64 `graphql:"exported"`
+}
+
+type ConsumptionGraphQLSerializer struct {
+	MeterID            int                            `graphql:"meterId"`
+	PeriodKind         string                         `graphql:"periodKind"`
+	Period             []string                       `graphql:"period"`
+	Active             []float64                      `graphql:"active"`
+	ReactiveInductive  []float64                      `graphql:"reactiveInductive"`
+	ReactiveCapacitive []float64                      `graphql:"reactiveCapacitive"`
+	Exported           []float64                      `graphql:"exported"`
+	Unit               string                         `graphql:"unit"`
+	ReactiveUnit       string                         `graphql:"reactiveUnit"`
+	Aggregations       []AggregationGraphQLSerializer `graphql:"aggregations"`
+}
+
+func ToMeterSettingGraphQLSerializer(setting domain.MeterSetting) MeterSettingGraphQLSerializer {
+	return MeterSettingGraphQLSerializer{
+		BillingCycleDay: setting.BillingCycleDay,
+		Cumulative:      setting.Cumulative,
+		RegisterMax:     setting.RegisterMax,
+		Unit:            setting.Unit,
+	}
+}
+
+func ToReadingGraphQLSerializer(reading domain.UserConsumption) ReadingGraphQLSerializer {
+	return ReadingGraphQLSerializer{
+		ID:                 reading.ID,
+		MeterID:            reading.MeterID,
+		Date:               reading.Date.Format(time.RFC3339),
+		ActiveEnergy:       reading.ActiveEnergy,
+		ReactiveEnergy:     reading.ReactiveEnergy,
+		CapacitiveReactive: reading.CapacitiveReactive,
+		Solar:              reading.Solar,
+		Flags:              reading.Flags,
+		ImportID:           reading.ImportID,
+	}
+}
+
+func ToConsumptionGraphQLSerializer(serializer application.Serializer, periodKind string) ConsumptionGraphQLSerializer {
+	consumption := ConsumptionGraphQLSerializer{
+		MeterID:            serializer.MeterID,
+		PeriodKind:         periodKind,
+		Period:             serializer.Period,
+		Active:             serializer.Active,
+		ReactiveInductive:  serializer.ReactiveInductive,
+		ReactiveCapacitive: serializer.ReactiveCapacitive,
+		Exported:           serializer.Exported,
+		Unit:               serializer.Unit,
+		ReactiveUnit:       serializer.ReactiveUnit,
+	}
+	var names []string
+	for name := range serializer.Aggregations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		aggregation := serializer.Aggregations[name]
+		consumption.Aggregations = append(consumption.Aggregations, AggregationGraphQLSerializer{
+			Name:               name,
+			Active:             aggregation.Active,
+			ReactiveInductive:  aggregation.ReactiveInductive,
+			ReactiveCapacitive: aggregation.ReactiveCapacitive,
+			Exported:           aggregation.Exported,
+		})
+	}
+	return consumption
+}
+
+// NewConsumptionGraphQLSchema: build the graphql schema of the meters, their readings and their aggregated series,
+// the nested fields are resolved with the loader of the request so every level of the query is solved in batches
+//
+// Returns:
+// return the schema or an error if the schema is not valid
+func NewConsumptionGraphQLSchema() (graphql.Schema, error) {
+	meterSettingType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MeterSetting",
+		Fields: graphql.Fields{
+			"billingCycleDay": &graphql.Field{Type: graphql.Int},
+			"cumulative":      &graphql.Field{Type: graphql.Boolean},
+			"registerMax":     &graphql.Field{Type: graphql.Float},
+			"unit":            &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	readingType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Reading",
+		Fields: graphql.Fields{
+			"id":                 &graphql.Field{Type: graphql.String},
+			"meterId":            &graphql.Field{Type: graphql.Int},
+			"date":               &graphql.Field{Type: graphql.String},
+			"activeEnergy":       &graphql.Field{Type: graphql.Float},
+			"reactiveEnergy":     &graphql.Field{Type: graphql.Float},
+			"capacitiveReactive": &graphql.Field{Type: graphql.Float},
+			"solar":              &graphql.Field{Type: graphql.Float},
+			"flags":              &graphql.Field{Type: graphql.String},
+			"importId":           &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	aggregationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Aggregation",
+		Fields: graphql.Fields{
+			"name":               &graphql.Field{Type: graphql.String},
+			"active":             &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"reactiveInductive":  &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"reactiveCapacitive": &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"exported":           &graphql.Field{Type: graphql.NewList(graphql.Float)},
+		},
+	})
+
+	consumptionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ConsumptionSeries",
+		Fields: graphql.Fields{
+			"meterId":            &graphql.Field{Type: graphql.Int},
+			"periodKind":         &graphql.Field{Type: graphql.String},
+			"period":             &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"active":             &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"reactiveInductive":  &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"reactiveCapacitive": &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"exported":           &graphql.Field{Type: graphql.NewList(graphql.Float)},
+			"unit":               &graphql.Field{Type: graphql.String},
+			"reactiveUnit":       &graphql.Field{Type: graphql.String},
+			"aggregations":       &graphql.Field{Type: graphql.NewList(aggregationType)},
+		},
+	})
+
+	meterType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Meter",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"settings": &graphql.Field{
+				Type:    meterSettingType,
+				Resolve: resolveMeterSetting,
+			},
+			"readings": &graphql.Field{
+				Type: graphql.NewList(readingType),
+				Args: graphql.FieldConfigArgument{
+					"startDate": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"endDate":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveMeterReadings,
+			},
+			"consumption": &graphql.Field{
+				Type: consumptionType,
+				Args: graphql.FieldConfigArgument{
+					"startDate":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"endDate":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"periodKind":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"aggregations": &graphql.ArgumentConfig{Type: graphql.String},
+					"unit":         &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolveMeterConsumption,
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"meters": &graphql.Field{
+				Type: graphql.NewList(meterType),
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+				},
+				Resolve: resolveMeters,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+func loaderFromContext(ctx context.Context) (application.ConsumptionLoader, error) {
+	loader, ok := ctx.Value(consumptionLoaderKey{}).(application.ConsumptionLoader)
+	if !ok {
+		return nil, fmt.Errorf("Error: the request does not have a consumption loader")
+	}
+	return loader, nil
+}
+
+func resolveMeters(p graphql.ResolveParams) (interface{}, error) {
+	var meters []MeterGraphQLSerializer
+	seen := map[int]bool{}
+	ids, _ := p.Args["ids"].([]interface{})
+	for _, id := range ids {
+		meterID, ok := id.(int)
+		if !ok || seen[meterID] {
+			continue
+		}
+		seen[meterID] = true
+		meters = append(meters, MeterGraphQLSerializer{ID: meterID})
+	}
+	return meters, nil
+}
+
+func resolveMeterSetting(p graphql.ResolveParams) (interface{}, error) {
+	meter := p.Source.(MeterGraphQLSerializer)
+	loader, err := loaderFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loader.LoadMeterSetting(meter.ID)
+	return func() (interface{}, error) {
+		setting, err := thunk()
+		if err != nil || setting == nil {
+			return nil, err
+		}
+		return ToMeterSettingGraphQLSerializer(*setting), nil
+	}, nil
+}
+
+func resolveMeterReadings(p graphql.ResolveParams) (interface{}, error) {
+	meter := p.Source.(MeterGraphQLSerializer)
+	loader, err := loaderFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	startDate, _ := p.Args["startDate"].(string)
+	endDate, _ := p.Args["endDate"].(string)
+	thunk := loader.LoadReadings(application.ReadingsKey{
+		MeterID:   meter.ID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	return func() (interface{}, error) {
+		readings, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		serializers := []ReadingGraphQLSerializer{}
+		for _, reading := range readings {
+			serializers = append(serializers, ToReadingGraphQLSerializer(reading))
+		}
+		return serializers, nil
+	}, nil
+}
+
+func resolveMeterConsumption(p graphql.ResolveParams) (interface{}, error) {
+	meter := p.Source.(MeterGraphQLSerializer)
+	loader, err := loaderFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	startDate, _ := p.Args["startDate"].(string)
+	endDate, _ := p.Args["endDate"].(string)
+	periodKind, _ := p.Args["periodKind"].(string)
+	aggregations, _ := p.Args["aggregations"].(string)
+	unit, _ := p.Args["unit"].(string)
+	thunk := loader.LoadConsumption(application.ConsumptionKey{
+		MeterID:      meter.ID,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		KindPeriod:   periodKind,
+		Aggregations: aggregations,
+		Unit:         unit,
+	})
+	return func() (interface{}, error) {
+		serializer, err := thunk()
+		if err != nil || serializer == nil {
+			return nil, err
+		}
+		return ToConsumptionGraphQLSerializer(*serializer, periodKind), nil
+	}, nil
+}
@@ -0,0 +1,253 @@
+package infraestructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/consumptionpb"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+type ConsumptionGRPCServerImpl struct {
+	consumptionpb.UnimplementedConsumptionServiceServer
+	powerConsumptionService application.PowerConsumptionService
+}
+
+func NewConsumptionGRPCServer(powerConsumptionService application.PowerConsumptionService) *ConsumptionGRPCServerImpl {
+	return &ConsumptionGRPCServerImpl{
+		powerConsumptionService: powerConsumptionService,
+	}
+}
+
+// NewGRPCServer: build the grpc server with the consumption service and the reflection to explore it
+//
+// Parameters:
+// consumptionServer: the implementation of the consumption service
+//
+// Returns:
+// return the grpc server ready to serve
+func NewGRPCServer(consumptionServer consumptionpb.ConsumptionServiceServer) *grpc.Server {
+	server := grpc.NewServer()
+	consumptionpb.RegisterConsumptionServiceServer(server, consumptionServer)
+	reflection.Register(server)
+	return server
+}
+
+// GetConsumption: get the consumption of the meters organized by period like GET /consumption
+func (g *ConsumptionGRPCServerImpl) GetConsumption(ctx context.Context, req *consumptionpb.ConsumptionRequest) (*consumptionpb.ConsumptionResponse, error) {
+	meterIDs, err := grpcMeterIDs(req.GetMeterIds())
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(strings.Join(meterIDs, ","), req.GetStartDate(), req.GetEndDate(), req.GetKindPeriod(), grpcQueryOptions(req))
+	if err != nil {
+		return nil, ToGRPCError(err)
+	}
+	response := &consumptionpb.ConsumptionResponse{}
+	for _, serializer := range data {
+		response.Meters = append(response.Meters, ToMeterConsumptionMessage(serializer))
+	}
+	return response, nil
+}
+
+// StreamConsumption: get the consumption of the meters one by one and send every meter when it is ready, only the
+// information of one meter is kept in memory
+func (g *ConsumptionGRPCServerImpl) StreamConsumption(req *consumptionpb.ConsumptionRequest, stream consumptionpb.ConsumptionService_StreamConsumptionServer) error {
+	meterIDs, err := grpcMeterIDs(req.GetMeterIds())
+	if err != nil {
+		return err
+	}
+	for _, meterID := range meterIDs {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		data, err := g.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(meterID, req.GetStartDate(), req.GetEndDate(), req.GetKindPeriod(), grpcQueryOptions(req))
+		if err != nil {
+			return ToGRPCError(err)
+		}
+		for _, serializer := range data {
+			if err := stream.Send(ToMeterConsumptionMessage(serializer)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IngestReadings: receive the readings of the stream and import them in batches, every batch is recorded as an import
+// with the data quality rules and the events of the csv files, the source and the unit are taken from the first
+// message and the summary of all the batches is sent when the client closes the stream
+func (g *ConsumptionGRPCServerImpl) IngestReadings(stream consumptionpb.ConsumptionService_IngestReadingsServer) error {
+	summary := &consumptionpb.IngestReadingsResponse{}
+	var options domain.ImportOptions
+	var batch []*domain.CSVUserConsumption
+	first := true
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first {
+			first = false
+			options = domain.ImportOptions{FileName: req.GetSource(), Unit: req.GetUnit(), Uploader: constants.GRPCUploader}
+			if options.FileName == "" {
+				options.FileName = constants.GRPCUploader
+			}
+		}
+		for _, reading := range req.GetReadings() {
+			batch = append(batch, ToCSVUserConsumption(reading))
+		}
+		if len(batch) >= constants.GRPCIngestBatchSize {
+			if err := g.ingestBatch(batch, options, summary); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		if err := g.ingestBatch(batch, options, summary); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(summary)
+}
+
+// ingestBatch: import a batch of readings and add its counts to the summary of the stream
+func (g *ConsumptionGRPCServerImpl) ingestBatch(batch []*domain.CSVUserConsumption, options domain.ImportOptions, summary *consumptionpb.IngestReadingsResponse) error {
+	batchSummary, err := g.powerConsumptionService.ImportCSVRecords(batch, options)
+	if err != nil {
+		logrus.Errorf("Error: ingesting the readings of the grpc stream, %d readings were imported before the error %s", summary.Imported, err.Error())
+		return ToGRPCError(err)
+	}
+	logrus.Infof("the readings of the grpc stream were recorded in the import %d", batchSummary.ImportID)
+	summary.Imported += int32(batchSummary.Imported)
+	summary.Flagged += int32(batchSummary.Flagged)
+	summary.Quarantined += int32(batchSummary.Quarantined)
+	summary.Rejected += int32(batchSummary.Rejected)
+	for _, violation := range batchSummary.Violations {
+		summary.Violations = append(summary.Violations, &consumptionpb.QualityViolation{
+			Rule:   violation.Rule,
+			Action: violation.Action,
+			Reason: violation.Reason,
+		})
+	}
+	return nil
+}
+
+func grpcMeterIDs(meterIDs []int32) ([]string, error) {
+	if len(meterIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Error: the meter ids are empty")
+	}
+	var stringMeterIDs []string
+	for _, meterID := range meterIDs {
+		stringMeterIDs = append(stringMeterIDs, strconv.Itoa(int(meterID)))
+	}
+	return stringMeterIDs, nil
+}
+
+func grpcQueryOptions(req *consumptionpb.ConsumptionRequest) domain.ConsumptionQueryOptions {
+	options := domain.ConsumptionQueryOptions{
+		Aggregations: req.GetAggregations(),
+		WeekStart:    req.GetWeekStart(),
+		Interval:     req.GetInterval(),
+		Unit:         req.GetUnit(),
+	}
+	if req.GetBillingCycleDay() != 0 {
+		options.BillingCycleDay = strconv.Itoa(int(req.GetBillingCycleDay()))
+	}
+	return options
+}
+
+func ToMeterConsumptionMessage(serializer application.Serializer) *consumptionpb.MeterConsumption {
+	message := &consumptionpb.MeterConsumption{
+		MeterId: int32(serializer.MeterID),
+		Period:  serializer.Period,
+		Consumption: &consumptionpb.Series{
+			Active:             serializer.Active,
+			ReactiveInductive:  serializer.ReactiveInductive,
+			ReactiveCapacitive: serializer.ReactiveCapacitive,
+			Exported:           serializer.Exported,
+		},
+		Unit:         serializer.Unit,
+		ReactiveUnit: serializer.ReactiveUnit,
+	}
+	if len(serializer.Aggregations) > 0 {
+		message.Aggregations = make(map[string]*consumptionpb.Series)
+		for name, aggregation := range serializer.Aggregations {
+			message.Aggregations[name] = &consumptionpb.Series{
+				Active:             aggregation.Active,
+				ReactiveInductive:  aggregation.ReactiveInductive,
+				ReactiveCapacitive: aggregation.ReactiveCapacitive,
+				Exported:           aggregation.Exported,
+			}
+		}
+	}
+	return message
+}
+
+func ToCSVUserConsumption(reading *consumptionpb.Reading) *domain.CSVUserConsumption {
+	return &domain.CSVUserConsumption{
+		ID:                 reading.GetId(),
+		MeterID:            strconv.Itoa(int(reading.GetMeterId())),
+		ActiveEnergy:       reading.GetActiveEnergy(),
+		ReactiveEnergy:     reading.GetReactiveEnergy(),
+		CapacitiveReactive: reading.GetCapacitiveReactive(),
+		Solar:              reading.GetSolar(),
+		Date:               reading.GetDate(),
+	}
+}
+
+// ToGRPCError: convert an error of the services in a grpc status, the domain errors are converted by its kind with
+// its code as reason and its fields as violations, the other errors are unexpected and they are internal errors
+//
+// Parameters:
+// err: the error of the service
+//
+// Returns:
+// return the error with the grpc status
+func ToGRPCError(err error) error {
+	domainError, ok := domain.AsDomainError(err)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.InvalidArgument
+	switch domainError.Kind {
+	case domain.ErrorKindNotFound:
+		code = codes.NotFound
+	case domain.ErrorKindConflict:
+		code = codes.AlreadyExists
+	case domain.ErrorKindUnavailable:
+		code = codes.Unavailable
+	}
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{Reason: domainError.Code, Domain: "consumption-ms"}}
+	if len(domainError.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range domainError.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	grpcStatus, detailsErr := status.New(code, domainError.Error()).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, domainError.Error())
+	}
+	return grpcStatus.Err()
+}
@@ -0,0 +1,173 @@
+package infraestructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/consumptionpb"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/jeffleon1/consumption-ms/pkg/domain/domainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ = Describe("ConsumptionGRPCServer", func() {
+	var (
+		mockService *applicationfakes.FakePowerConsumptionService
+		server      *grpc.Server
+		conn        *grpc.ClientConn
+		client      consumptionpb.ConsumptionServiceClient
+	)
+
+	BeforeEach(func() {
+		mockService = &applicationfakes.FakePowerConsumptionService{}
+		listener := bufconn.Listen(1024 * 1024)
+		server = NewGRPCServer(NewConsumptionGRPCServer(mockService))
+		go server.Serve(listener)
+		var err error
+		conn, err = grpc.Dial("bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).To(BeNil())
+		client = consumptionpb.NewConsumptionServiceClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	Context("GetConsumption", func() {
+		It("should return the consumption of the meters", func() {
+			mockService.GetConsumptionByMeterIDAndWindowTimeReturns([]application.Serializer{
+				{MeterID: 1, Period: []string{"AUG 2023"}, Active: []float64{10}, Aggregations: map[string]application.AggregationSerializer{"max": {Active: []float64{4}}}},
+			}, nil)
+			response, err := client.GetConsumption(context.Background(), &consumptionpb.ConsumptionRequest{MeterIds: []int32{1, 2}, StartDate: "2023-08-01", EndDate: "2023-08-31", KindPeriod: "monthly", BillingCycleDay: 5})
+			Expect(err).To(BeNil())
+			meterIDs, _, _, kindPeriod, options := mockService.GetConsumptionByMeterIDAndWindowTimeArgsForCall(0)
+			Expect(meterIDs).To(Equal("1,2"))
+			Expect(kindPeriod).To(Equal("monthly"))
+			Expect(options.BillingCycleDay).To(Equal("5"))
+			Expect(response.Meters).To(HaveLen(1))
+			Expect(response.Meters[0].Consumption.Active).To(Equal([]float64{10}))
+			Expect(response.Meters[0].Aggregations["max"].Active).To(Equal([]float64{4}))
+		})
+
+		It("should return internal when the service fails unexpectedly", func() {
+			mockService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, errors.New("Error: the connection was closed"))
+			_, err := client.GetConsumption(context.Background(), &consumptionpb.ConsumptionRequest{MeterIds: []int32{1}})
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+		})
+
+		It("should return the field violations of a validation error", func() {
+			mockService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, domain.NewValidationError("invalid_kind_period", "kind_period", "Error: kind period not allowed hourly"))
+			_, err := client.GetConsumption(context.Background(), &consumptionpb.ConsumptionRequest{MeterIds: []int32{1}})
+			grpcStatus := status.Convert(err)
+			Expect(grpcStatus.Code()).To(Equal(codes.InvalidArgument))
+			Expect(grpcStatus.Details()).To(HaveLen(2))
+			Expect(grpcStatus.Details()[0].(*errdetails.ErrorInfo).Reason).To(Equal("invalid_kind_period"))
+			Expect(grpcStatus.Details()[1].(*errdetails.BadRequest).FieldViolations[0].Field).To(Equal("kind_period"))
+		})
+
+		It("should return unavailable when the database fails", func() {
+			mockService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, domain.NewUnavailableError("database_unavailable", "Error: the database is not available, try again later", errors.New("dial tcp: connection refused")))
+			_, err := client.GetConsumption(context.Background(), &consumptionpb.ConsumptionRequest{MeterIds: []int32{1}})
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+			Expect(status.Convert(err).Message()).ToNot(ContainSubstring("connection refused"))
+		})
+	})
+
+	Context("StreamConsumption", func() {
+		It("should send the consumption of every meter in its own message", func() {
+			mockService.GetConsumptionByMeterIDAndWindowTimeStub = func(meterIDs, startDate, endDate, kindPeriod string, options domain.ConsumptionQueryOptions) ([]application.Serializer, error) {
+				meterID, _ := domain.StrToInt(meterIDs)
+				return []application.Serializer{{MeterID: meterID}}, nil
+			}
+			stream, err := client.StreamConsumption(context.Background(), &consumptionpb.ConsumptionRequest{MeterIds: []int32{1, 2, 3}, KindPeriod: "daily"})
+			Expect(err).To(BeNil())
+			var meterIDs []int32
+			for {
+				message, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).To(BeNil())
+				meterIDs = append(meterIDs, message.MeterId)
+			}
+			Expect(meterIDs).To(Equal([]int32{1, 2, 3}))
+			Expect(mockService.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("IngestReadings", func() {
+		It("should import the readings of the stream and return the summary", func() {
+			mockService.ImportCSVRecordsReturns(&application.ImportSummary{ImportID: 7, Imported: 2, Flagged: 1}, nil)
+			stream, err := client.IngestReadings(context.Background())
+			Expect(err).To(BeNil())
+			Expect(stream.Send(&consumptionpb.IngestReadingsRequest{Source: "scada", Unit: "Wh", Readings: []*consumptionpb.Reading{{Id: "1", MeterId: 1, ActiveEnergy: 10, Date: "2023-08-01 00:00:00+00"}}})).To(Succeed())
+			Expect(stream.Send(&consumptionpb.IngestReadingsRequest{Readings: []*consumptionpb.Reading{{Id: "2", MeterId: 1, ActiveEnergy: 20, Date: "2023-08-01 01:00:00+00"}}})).To(Succeed())
+			summary, err := stream.CloseAndRecv()
+			Expect(err).To(BeNil())
+			Expect(summary.Imported).To(Equal(int32(2)))
+			Expect(summary.Flagged).To(Equal(int32(1)))
+			records, options := mockService.ImportCSVRecordsArgsForCall(0)
+			Expect(records).To(HaveLen(2))
+			Expect(records[1].MeterID).To(Equal("1"))
+			Expect(options.FileName).To(Equal("scada"))
+			Expect(options.Unit).To(Equal("Wh"))
+			Expect(options.Uploader).To(Equal(constants.GRPCUploader))
+			Expect(mockService.IngestCSVRecordsCallCount()).To(Equal(0))
+		})
+
+		It("should return the status of the domain error when the import fails", func() {
+			mockService.ImportCSVRecordsReturns(nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "unit", "Error: unit not allowed GWh"))
+			stream, err := client.IngestReadings(context.Background())
+			Expect(err).To(BeNil())
+			Expect(stream.Send(&consumptionpb.IngestReadingsRequest{Source: "scada", Unit: "GWh", Readings: []*consumptionpb.Reading{{Id: "1", MeterId: 1, ActiveEnergy: 10, Date: "2023-08-01 00:00:00+00"}}})).To(Succeed())
+			_, err = stream.CloseAndRecv()
+			grpcStatus := status.Convert(err)
+			Expect(grpcStatus.Code()).To(Equal(codes.InvalidArgument))
+			Expect(grpcStatus.Details()[0].(*errdetails.ErrorInfo).Reason).To(Equal(constants.ErrorCodeInvalidParam))
+		})
+
+		It("should store every energy of the readings in its own field", func() {
+			mockMySQLRepo := &domainfakes.FakeMySQLPowerConsumptionRepository{}
+			server.Stop()
+			listener := bufconn.Listen(1024 * 1024)
+			server = NewGRPCServer(NewConsumptionGRPCServer(application.NewPowerConsumptionService(mockMySQLRepo, &domainfakes.FakeCSVPowerConsumptionRepository{}, &domainfakes.FakeMeterGroupRepository{}, &domainfakes.FakeMeterSettingRepository{}, &domainfakes.FakeQualityRuleRepository{}, &domainfakes.FakeQuarantineRepository{}, &domainfakes.FakeImportRepository{})))
+			go server.Serve(listener)
+			conn.Close()
+			var err error
+			conn, err = grpc.Dial("bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			Expect(err).To(BeNil())
+			client = consumptionpb.NewConsumptionServiceClient(conn)
+
+			stream, err := client.IngestReadings(context.Background())
+			Expect(err).To(BeNil())
+			Expect(stream.Send(&consumptionpb.IngestReadingsRequest{Source: "scada", Unit: "kWh", Readings: []*consumptionpb.Reading{{Id: "1", MeterId: 1, ActiveEnergy: 10, ReactiveEnergy: 5, CapacitiveReactive: 3, Solar: 1, Date: "2023-08-01 00:00:00+00"}}})).To(Succeed())
+			_, err = stream.CloseAndRecv()
+			Expect(err).To(BeNil())
+
+			records, _, _, _ := mockMySQLRepo.CreateImportRecordsArgsForCall(0)
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].ActiveEnergy).To(Equal(10.0))
+			Expect(records[0].ReactiveEnergy).To(Equal(5.0))
+			Expect(records[0].CapacitiveReactive).To(Equal(3.0))
+			Expect(records[0].Solar).To(Equal(1.0))
+		})
+	})
+})
@@ -3,16 +3,101 @@ package infraestructure
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
 	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
 )
 
 type Response struct {
-	Msg    string      `json:"msg"`
-	Status string      `json:"status"`
-	Data   interface{} `json:"data"`
-	Err    interface{} `json:"error"`
+	Msg     string              `json:"msg"`
+	Status  string              `json:"status"`
+	Code    string              `json:"code,omitempty"`
+	Data    interface{}         `json:"data"`
+	Err     interface{}         `json:"error"`
+	Details []domain.FieldError `json:"details,omitempty"`
+}
+
+// NewErrorResponse: build the response of an error, the domain errors are answered with the status of its kind, its
+// code and the details of its fields, the other errors are unexpected and they are answered as an internal error
+//
+// Parameters:
+// msg: the message of the response
+// err: the error to answer
+//
+// Returns:
+// return the http status and the response
+func NewErrorResponse(msg string, err error) (int, Response) {
+	response := Response{
+		Msg:    msg,
+		Status: "ERROR",
+		Code:   constants.ErrorCodeInternal,
+		Data:   nil,
+		Err:    err.Error(),
+	}
+	domainError, ok := domain.AsDomainError(err)
+	if !ok {
+		return http.StatusInternalServerError, response
+	}
+	response.Code = domainError.Code
+	response.Details = domainError.Fields
+	switch domainError.Kind {
+	case domain.ErrorKindNotFound:
+		return http.StatusNotFound, response
+	case domain.ErrorKindConflict:
+		return http.StatusConflict, response
+	case domain.ErrorKindUnavailable:
+		return http.StatusServiceUnavailable, response
+	}
+	return http.StatusBadRequest, response
+}
+
+// NewBindingErrorResponse: build the response of a body that could not be read
+//
+// Parameters:
+// msg: the message of the response
+// err: the error of the binding
+//
+// Returns:
+// return the http status and the response
+func NewBindingErrorResponse(msg string, err error) (int, Response) {
+	return NewErrorResponse(msg, domain.NewValidationError(constants.ErrorCodeInvalidBody, "body", err.Error()))
+}
+
+// blankQueryParams: find the query params that are blank
+//
+// Parameters:
+// c: the context of the request
+// params: the names of the required query params
+//
+// Returns:
+// return the names of the params that are blank in the same order
+func blankQueryParams(c *gin.Context, params ...string) []string {
+	var blankParams []string
+	for _, param := range params {
+		if c.Query(param) == "" {
+			blankParams = append(blankParams, param)
+		}
+	}
+	return blankParams
+}
+
+// missingParamsError: the error of the required params that are blank
+//
+// Parameters:
+// message: the message of the error
+// params: the params that are blank
+//
+// Returns:
+// return the validation error with a field by param
+func missingParamsError(message string, params ...string) error {
+	var fields []domain.FieldError
+	for _, param := range params {
+		fields = append(fields, domain.FieldError{Field: param, Code: constants.ErrorCodeMissingParam, Message: fmt.Sprintf("Error: the param %s is required", param)})
+	}
+	return domain.NewFieldsValidationError(constants.ErrorCodeMissingParam, message, fields)
 }
 
 type PowerConsumptionHandlerImpl struct {
@@ -33,42 +118,81 @@ func NewPowerConsumptionHandler(powerConsumptionService application.PowerConsump
 // @Produce  json
 // @Param start_date query string  true  "start date"
 // @Param end_date query string  true  "end date"
-// @Param kind_period query string  true  "kind period"
-// @Param meter_ids query string  true "meter ids"
+// @Param kind_period query string  false "kind period monthly, weekly, daily, calendar_weekly, interval or billing_cycle, required if interval is blank"
+// @Param meter_ids query string  false "meter ids, required if group_id is blank"
+// @Param group_id query string  false "meter group id, required if meter_ids is blank"
+// @Param compare_to query string  false "compare with previous_period, previous_year or custom"
+// @Param compare_start_date query string  false "start date of the custom window to compare"
+// @Param compare_end_date query string  false "end date of the custom window to compare"
+// @Param aggregations query string  false "aggregations by period separated by comma max, min, mean, count or p95"
+// @Param week_start query string  false "day that begins the calendar_weekly periods, default monday"
+// @Param interval query string  false "length of the interval periods anchored at the start date like 2h or 3d, up to 3660 days"
+// @Param billing_cycle_day query string  false "day that starts the billing_cycle periods, default the billing cycle day of every meter"
+// @Param unit query string  false "unit of the values Wh, kWh or MWh, default kWh"
 // @Success 200 {object} Response
 // @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 503 {object} Response
 // @Router /consumption [get]
 func (s *PowerConsumptionHandlerImpl) GetConsumptionByMeterIDAndWindowTime(c *gin.Context) {
 	meterIDs := c.Query("meter_ids")
 	startDate := c.Query("start_date")
 	endDate := c.Query("end_date")
 	kindPeriod := c.Query("kind_period")
-	if meterIDs == "" || startDate == "" || endDate == "" || kindPeriod == "" {
-		c.AbortWithStatusJSON(http.StatusBadRequest, Response{
-			Msg:    "Something goes wrong with your query params",
-			Status: "ERROR",
-			Data:   nil,
-			Err:    fmt.Sprintf("Some params are blank meter_ids=%s start_date=%s end_date=%s kind_period=%s", meterIDs, startDate, endDate, kindPeriod),
-		})
+	options := domain.ConsumptionQueryOptions{
+		CompareTo:        c.Query("compare_to"),
+		CompareStartDate: c.Query("compare_start_date"),
+		CompareEndDate:   c.Query("compare_end_date"),
+		Aggregations:     c.Query("aggregations"),
+		WeekStart:        c.Query("week_start"),
+		Interval:         c.Query("interval"),
+		BillingCycleDay:  c.Query("billing_cycle_day"),
+		Unit:             c.Query("unit"),
+	}
+	groupID := c.Query("group_id")
+	var blankParams []string
+	if meterIDs == "" && groupID == "" {
+		blankParams = append(blankParams, "meter_ids")
+	}
+	if startDate == "" {
+		blankParams = append(blankParams, "start_date")
+	}
+	if endDate == "" {
+		blankParams = append(blankParams, "end_date")
+	}
+	if kindPeriod == "" && options.Interval == "" {
+		blankParams = append(blankParams, "kind_period")
+	}
+	if len(blankParams) > 0 {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your query params", missingParamsError(fmt.Sprintf("Some params are blank meter_ids=%s group_id=%s start_date=%s end_date=%s kind_period=%s", meterIDs, groupID, startDate, endDate, kindPeriod), blankParams...)))
+		return
+	}
+	if meterIDs != "" && groupID != "" {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your query params", domain.NewValidationError(constants.ErrorCodeInvalidParam, "group_id", fmt.Sprintf("meter_ids and group_id can not be used together meter_ids=%s group_id=%s", meterIDs, groupID))))
 		return
 	}
-
 	filterSerializer := &FilterConsumptionSerializer{}
-
-	data, err := s.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod)
-	if err != nil {
-		fmt.Println("Entro aca con todos los poderes")
-		c.AbortWithStatusJSON(http.StatusBadRequest, Response{
-			Msg:    "Something goes wrong",
-			Status: "ERROR",
-			Data:   nil,
-			Err:    err.Error(),
+	if groupID != "" {
+		data, err := s.powerConsumptionService.GetConsumptionByGroupAndWindowTime(groupID, startDate, endDate, kindPeriod, options)
+		if err != nil {
+			c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+			return
+		}
+		filterSerializer.ToGroupConsumptionSerializer(data)
+		c.JSON(http.StatusOK, Response{
+			Msg:    "information successfully brought",
+			Status: "SUCCESS",
+			Data:   filterSerializer,
+			Err:    nil,
 		})
 		return
 	}
-
+	data, err := s.powerConsumptionService.GetConsumptionByMeterIDAndWindowTime(meterIDs, startDate, endDate, kindPeriod, options)
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
 	filterSerializer.ToFilterConsumptionSerializer(data)
-
 	c.JSON(http.StatusOK, Response{
 		Msg:    "information successfully brought",
 		Status: "SUCCESS",
@@ -77,6 +201,77 @@ func (s *PowerConsumptionHandlerImpl) GetConsumptionByMeterIDAndWindowTime(c *gi
 	})
 }
 
+// Get the peak demand (kW) by demand window and by month of the meters in a window time
+// @Tags Consumption
+// @Summary Get the peak demand (kW) by demand window and by month of the meters in a window time
+// @Description Convert the active energy of the readings in average power by demand window and find the monthly peak
+// @Accept  json
+// @Produce  json
+// @Param start_date query string  true  "start date"
+// @Param end_date query string  true  "end date"
+// @Param meter_ids query string  true "meter ids"
+// @Param demand_window query string  false "length of the demand window, default 15m"
+// @Param window_type query string  false "fixed or rolling, default fixed"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /consumption/demand [get]
+func (s *PowerConsumptionHandlerImpl) GetPeakDemandByMeterIDAndWindowTime(c *gin.Context) {
+	meterIDs := c.Query("meter_ids")
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
+	if blankParams := blankQueryParams(c, "meter_ids", "start_date", "end_date"); len(blankParams) > 0 {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your query params", missingParamsError(fmt.Sprintf("Some params are blank meter_ids=%s start_date=%s end_date=%s", meterIDs, startDate, endDate), blankParams...)))
+		return
+	}
+	data, err := s.powerConsumptionService.GetPeakDemandByMeterIDAndWindowTime(meterIDs, startDate, endDate, c.Query("demand_window"), c.Query("window_type"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   data,
+		Err:    nil,
+	})
+}
+
+// Get the average daily load profile and the monthly load factor of the meters in a window time
+// @Tags Consumption
+// @Summary Get the average daily load profile and the monthly load factor of the meters in a window time
+// @Description Get the mean consumption by hour of the day split by weekday and weekend and the load factor by month
+// @Accept  json
+// @Produce  json
+// @Param start_date query string  true  "start date"
+// @Param end_date query string  true  "end date"
+// @Param meter_ids query string  true "meter ids"
+// @Param demand_window query string  false "length of the demand window used for the peak demand, default 15m"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /consumption/analytics [get]
+func (s *PowerConsumptionHandlerImpl) GetAnalyticsByMeterIDAndWindowTime(c *gin.Context) {
+	meterIDs := c.Query("meter_ids")
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
+	if blankParams := blankQueryParams(c, "meter_ids", "start_date", "end_date"); len(blankParams) > 0 {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your query params", missingParamsError(fmt.Sprintf("Some params are blank meter_ids=%s start_date=%s end_date=%s", meterIDs, startDate, endDate), blankParams...)))
+		return
+	}
+	analyticsSerializer := &FilterAnalyticsSerializer{}
+	data, err := s.powerConsumptionService.GetAnalyticsByMeterIDAndWindowTime(meterIDs, startDate, endDate, c.Query("demand_window"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	analyticsSerializer.ToFilterAnalyticsSerializer(data)
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   analyticsSerializer,
+		Err:    nil,
+	})
+}
+
 // Import a csv file to insert the information in the user_consumption database
 // @Tags Consumption
 // @Summary Import a csv file to insert the information in the user_consumption database
@@ -84,34 +279,41 @@ func (s *PowerConsumptionHandlerImpl) GetConsumptionByMeterIDAndWindowTime(c *gi
 // @Accept  json
 // @Produce  json
 // @Param file	formData file true "this is a csv test file"
+// @Param unit	formData string false "unit of the values in the file Wh, kWh or MWh, default the unit of every meter"
+// @Param uploader	formData string false "who uploads the file"
+// @Param force	formData bool false "import the file even if it was already imported"
 // @Success 200 {object} Response
 // @Failure 400 {object} Response
+// @Failure 409 {object} Response
 // @Router /consumption/information [post]
 func (s *PowerConsumptionHandlerImpl) ImportCsvToDatabase(c *gin.Context) {
-	csvPartFile, _, err := c.Request.FormFile("file")
+	csvPartFile, csvHeader, err := c.Request.FormFile("file")
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, Response{
-			Msg:    "Something goes wrong please check your csv file",
-			Status: "ERROR",
-			Data:   nil,
-			Err:    err.Error(),
-		})
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong please check your csv file", domain.NewValidationError(constants.ErrorCodeMissingParam, "file", err.Error())))
 		return
 	}
-	err = s.powerConsumptionService.ImportCsvToDatabase(&csvPartFile)
+	force := false
+	if forceValue := c.Request.FormValue("force"); forceValue != "" {
+		force, err = strconv.ParseBool(forceValue)
+		if err != nil {
+			c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong the force value is not valid", domain.NewValidationError(constants.ErrorCodeInvalidParam, "force", err.Error())))
+			return
+		}
+	}
+	summary, err := s.powerConsumptionService.ImportCsvToDatabase(&csvPartFile, domain.ImportOptions{
+		FileName: csvHeader.Filename,
+		Unit:     c.Request.FormValue("unit"),
+		Uploader: c.Request.FormValue("uploader"),
+		Force:    force,
+	})
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, Response{
-			Msg:    "Something goes wrong please check your csv file",
-			Status: "ERROR",
-			Data:   nil,
-			Err:    err.Error(),
-		})
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong please check your csv file", err))
 		return
 	}
 	c.JSON(http.StatusCreated, Response{
 		Msg:    "All records were successfully saved",
 		Status: "SUCCESS",
-		Data:   nil,
+		Data:   summary,
 		Err:    nil,
 	})
 }
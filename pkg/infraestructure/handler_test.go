@@ -9,8 +9,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
 	"github.com/jeffleon1/consumption-ms/pkg/application"
 	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
@@ -48,11 +50,12 @@ var _ = Describe("CreatePowerConsumptionRecords", func() {
 			mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, fmt.Errorf("Some error"))
 			resp, err := http.Get(fmt.Sprintf("%s%s", server.URL(), fmt.Sprintf("%s?meter_ids=1,2&start_date=2023-05-30&end_date=2023-07-01&kind_period=weekly", ConsumptionPath)))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
 
 			var responseBody Response
 			json.NewDecoder(resp.Body).Decode(&responseBody)
 			Expect(responseBody.Status).To(Equal("ERROR"))
+			Expect(responseBody.Code).To(Equal(constants.ErrorCodeInternal))
 			Expect(responseBody.Msg).To(ContainSubstring("Something goes wrong"))
 			Expect(mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeCallCount()).To(Equal(1))
 		})
@@ -74,6 +77,72 @@ var _ = Describe("CreatePowerConsumptionRecords", func() {
 		})
 	})
 
+	Context("when the params are blank", func() {
+		It("should return the blank params in the details", func() {
+			resp, err := http.Get(fmt.Sprintf("%s%s?meter_ids=1&kind_period=weekly", server.URL(), ConsumptionPath))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+			var responseBody Response
+			json.NewDecoder(resp.Body).Decode(&responseBody)
+			Expect(responseBody.Code).To(Equal("missing_param"))
+			Expect(responseBody.Details).To(HaveLen(2))
+			Expect(responseBody.Details[0].Field).To(Equal("start_date"))
+			Expect(responseBody.Details[1].Field).To(Equal("end_date"))
+		})
+	})
+
+	Context("when the service returns a domain error", func() {
+		It("should map every kind to its status and code", func() {
+			statusByError := []struct {
+				err    error
+				status int
+				code   string
+			}{
+				{domain.NewValidationError("invalid_kind_period", "kind_period", "Error: kind period not allowed hourly"), http.StatusBadRequest, "invalid_kind_period"},
+				{domain.NewNotFoundError("meter_group_not_found", "Error: the group 9 does not exist", nil), http.StatusNotFound, "meter_group_not_found"},
+				{domain.NewConflictError("duplicated", "Error: the record already exists", nil), http.StatusConflict, "duplicated"},
+				{domain.NewUnavailableError("database_unavailable", "Error: the database is not available, try again later", fmt.Errorf("dial tcp: connection refused")), http.StatusServiceUnavailable, "database_unavailable"},
+			}
+			for _, expected := range statusByError {
+				mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, expected.err)
+				resp, err := http.Get(fmt.Sprintf("%s%s?meter_ids=1&start_date=2023-06-01&end_date=2023-06-30&kind_period=weekly", server.URL(), ConsumptionPath))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(expected.status))
+
+				var responseBody Response
+				json.NewDecoder(resp.Body).Decode(&responseBody)
+				Expect(responseBody.Code).To(Equal(expected.code))
+				Expect(responseBody.Err).ToNot(ContainSubstring("connection refused"))
+			}
+		})
+
+		It("should return the fields of a validation error", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturns(nil, domain.NewValidationError("invalid_date", "start_date", "Invalid date time 2023-13-01"))
+			resp, err := http.Get(fmt.Sprintf("%s%s?meter_ids=1&start_date=2023-13-01&end_date=2023-06-30&kind_period=weekly", server.URL(), ConsumptionPath))
+			Expect(err).NotTo(HaveOccurred())
+
+			var responseBody Response
+			json.NewDecoder(resp.Body).Decode(&responseBody)
+			Expect(responseBody.Details).To(Equal([]domain.FieldError{{Field: "start_date", Code: "invalid_date", Message: "Invalid date time 2023-13-01"}}))
+		})
+	})
+
+	Context("when the request asks for a comparison", func() {
+		It("should pass the comparison params to the service", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturns([]application.Serializer{}, nil)
+			resp, err := http.Get(fmt.Sprintf("%s%s", server.URL(), fmt.Sprintf("%s?meter_ids=1&start_date=2023-06-01&end_date=2023-06-30&kind_period=monthly&compare_to=custom&compare_start_date=2022-06-01&compare_end_date=2022-06-30", ConsumptionPath)))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			_, _, _, _, options := mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeArgsForCall(0)
+			Expect(options).To(Equal(domain.ConsumptionQueryOptions{
+				CompareTo:        "custom",
+				CompareStartDate: "2022-06-01",
+				CompareEndDate:   "2022-06-30",
+			}))
+		})
+	})
+
 	Context("when data is OK in request", func() {
 		It("should return success response", func() {
 			serializers := []application.Serializer{
@@ -135,7 +204,7 @@ var _ = Describe("ImportCsvToDatabase", func() {
 			var buf bytes.Buffer
 			multipartWriter := multipart.NewWriter(&buf)
 			fileWriter, err := multipartWriter.CreateFormFile("file", "example.csv")
-			mockPowerConsumptionService.ImportCsvToDatabaseReturns(nil)
+			mockPowerConsumptionService.ImportCsvToDatabaseReturns(nil, nil)
 			Expect(err).To(BeNil())
 			_, err = io.Copy(fileWriter, bytes.NewBufferString("ID,MeterID,ActiveEnergy,ReactiveEnergy,CapacitiveReactive,Solar,Date\n1,2,100,50,30,20,2023-08-01 12:00:00\n"))
 			Expect(err).To(BeNil())
@@ -160,3 +229,88 @@ var _ = Describe("ImportCsvToDatabase", func() {
 	})
 
 })
+
+var _ = Describe("ImportCsvToDatabase duplicate file", func() {
+	var (
+		router                      *gin.Engine
+		server                      *ghttp.Server
+		mockPowerConsumptionService *applicationfakes.FakePowerConsumptionService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockPowerConsumptionService = &applicationfakes.FakePowerConsumptionService{}
+		mockHandler := NewPowerConsumptionHandler(mockPowerConsumptionService)
+		router.POST(ConsumptionInformationPath, mockHandler.ImportCsvToDatabase)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", ConsumptionInformationPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	postFile := func(force string) *http.Response {
+		var buf bytes.Buffer
+		multipartWriter := multipart.NewWriter(&buf)
+		fileWriter, err := multipartWriter.CreateFormFile("file", "example.csv")
+		Expect(err).To(BeNil())
+		_, err = io.Copy(fileWriter, bytes.NewBufferString("id,meter_id,active_energy,reactive_energy,capacitive_reactive,solar,date\n1,2,100,50,30,20,2023-08-01\n"))
+		Expect(err).To(BeNil())
+		if force != "" {
+			Expect(multipartWriter.WriteField("force", force)).To(Succeed())
+		}
+		multipartWriter.Close()
+		req, err := http.NewRequest("POST", server.URL()+ConsumptionInformationPath, &buf)
+		Expect(err).To(BeNil())
+		req.Header.Set("Content-Type", multipartWriter.FormDataContentType())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		return resp
+	}
+
+	Context("when the file was already imported", func() {
+		It("should return a conflict with the original import", func() {
+			duplicateImportError := domain.NewConflictError(constants.ErrorCodeDuplicateImport, "Error: the file was already imported in the import 7 at 2023-08-02T10:00:00Z, use force to import it again", nil)
+			duplicateImportError.Fields = []domain.FieldError{
+				{Field: "import_id", Code: constants.ErrorCodeDuplicateImport, Message: "7"},
+				{Field: "imported_at", Code: constants.ErrorCodeDuplicateImport, Message: "2023-08-02T10:00:00Z"},
+			}
+			mockPowerConsumptionService.ImportCsvToDatabaseReturns(nil, duplicateImportError)
+
+			resp := postFile("")
+
+			Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+			var responseBody Response
+			json.NewDecoder(resp.Body).Decode(&responseBody)
+			Expect(responseBody.Code).To(Equal(constants.ErrorCodeDuplicateImport))
+			Expect(responseBody.Details).To(HaveLen(2))
+			Expect(responseBody.Details[0].Field).To(Equal("import_id"))
+			Expect(responseBody.Details[0].Message).To(Equal("7"))
+			Expect(responseBody.Details[1].Field).To(Equal("imported_at"))
+			Expect(responseBody.Details[1].Message).To(Equal("2023-08-02T10:00:00Z"))
+			_, options := mockPowerConsumptionService.ImportCsvToDatabaseArgsForCall(0)
+			Expect(options.Force).To(BeFalse())
+			Expect(options.FileName).To(Equal("example.csv"))
+		})
+	})
+
+	Context("when the import is forced", func() {
+		It("should pass the force option to the service", func() {
+			mockPowerConsumptionService.ImportCsvToDatabaseReturns(&application.ImportSummary{ImportID: 8}, nil)
+
+			resp := postFile("true")
+
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			_, options := mockPowerConsumptionService.ImportCsvToDatabaseArgsForCall(0)
+			Expect(options.Force).To(BeTrue())
+		})
+
+		It("should return an error when the force value is not valid", func() {
+			resp := postFile("maybe")
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockPowerConsumptionService.ImportCsvToDatabaseCallCount()).To(Equal(0))
+		})
+	})
+})
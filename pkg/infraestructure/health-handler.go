@@ -0,0 +1,48 @@
+package infraestructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConsumerLag interface {
+	Lag() int64
+}
+
+type HealthSerializer struct {
+	Status   string `json:"status"`
+	KafkaLag *int64 `json:"kafka_lag,omitempty"`
+}
+
+type HealthHandlerImpl struct {
+	kafkaConsumer ConsumerLag
+}
+
+func NewHealthHandler(kafkaConsumer ConsumerLag) *HealthHandlerImpl {
+	return &HealthHandlerImpl{
+		kafkaConsumer,
+	}
+}
+
+// Get the health of the service
+// @Tags Health
+// @Summary Get the health of the service
+// @Description Get the status of the service and the lag of the kafka consumer when it is enabled
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Router /health [get]
+func (h *HealthHandlerImpl) GetHealth(c *gin.Context) {
+	health := HealthSerializer{Status: "UP"}
+	if h.kafkaConsumer != nil {
+		lag := h.kafkaConsumer.Lag()
+		health.KafkaLag = &lag
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the service is up",
+		Status: "SUCCESS",
+		Data:   health,
+		Err:    nil,
+	})
+}
@@ -0,0 +1,58 @@
+package infraestructure
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+type fixedConsumerLag int64
+
+func (l fixedConsumerLag) Lag() int64 {
+	return int64(l)
+}
+
+var _ = Describe("GetHealth", func() {
+	var server *ghttp.Server
+
+	serve := func(handler *HealthHandlerImpl) {
+		router := gin.Default()
+		router.GET("/health", handler.GetHealth)
+		server = ghttp.NewServer()
+		server.RouteToHandler("GET", "/health", router.ServeHTTP)
+	}
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	getHealth := func() HealthSerializer {
+		resp, err := http.Get(server.URL() + "/health")
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var responseBody struct {
+			Data HealthSerializer `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&responseBody)
+		return responseBody.Data
+	}
+
+	It("should return the lag of the kafka consumer", func() {
+		serve(NewHealthHandler(fixedConsumerLag(12)))
+
+		health := getHealth()
+
+		Expect(health.Status).To(Equal("UP"))
+		Expect(*health.KafkaLag).To(Equal(int64(12)))
+	})
+
+	It("should not return a lag when the consumer is disabled", func() {
+		serve(NewHealthHandler(nil))
+
+		Expect(getHealth().KafkaLag).To(BeNil())
+	})
+})
@@ -0,0 +1,17 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type HealthRoutes struct {
+	healthHandler *HealthHandlerImpl
+}
+
+func (ro *HealthRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.GET("/health", ro.healthHandler.GetHealth)
+}
+
+func NewHealthRoutes(healthHandler *HealthHandlerImpl) *HealthRoutes {
+	return &HealthRoutes{
+		healthHandler,
+	}
+}
@@ -0,0 +1,100 @@
+package infraestructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type ImportReadingsSerializer struct {
+	Import   *domain.Import           `json:"import"`
+	Readings []domain.UserConsumption `json:"readings"`
+}
+
+type ImportHandlerImpl struct {
+	importService application.ImportService
+}
+
+func NewImportHandler(importService application.ImportService) *ImportHandlerImpl {
+	return &ImportHandlerImpl{
+		importService,
+	}
+}
+
+// Get the history of the imported files
+// @Tags Imports
+// @Summary Get the imports
+// @Description Get all the imported files with their checksum, uploader, unit, status and row counts, the newest first
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /imports [get]
+func (i *ImportHandlerImpl) GetImports(c *gin.Context) {
+	imports, err := i.importService.GetImports()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   imports,
+		Err:    nil,
+	})
+}
+
+// Get an import and the readings that came from its file
+// @Tags Imports
+// @Summary Get the readings of an import
+// @Description Get an import and all the readings that came from its file
+// @Accept  json
+// @Produce  json
+// @Param id path string true "import id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /imports/{id}/readings [get]
+func (i *ImportHandlerImpl) GetImportReadings(c *gin.Context) {
+	importRecord, readings, err := i.importService.GetImportReadings(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data: ImportReadingsSerializer{
+			Import:   importRecord,
+			Readings: readings,
+		},
+		Err: nil,
+	})
+}
+
+// Roll back an import
+// @Tags Imports
+// @Summary Roll back an import
+// @Description Delete all the readings and the quarantined readings of an import, the import is kept as rolled back
+// @Accept  json
+// @Produce  json
+// @Param id path string true "import id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 409 {object} Response
+// @Router /imports/{id}/rollback [post]
+func (i *ImportHandlerImpl) RollbackImport(c *gin.Context) {
+	importRecord, err := i.importService.RollbackImport(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the import was successfully rolled back",
+		Status: "SUCCESS",
+		Data:   importRecord,
+		Err:    nil,
+	})
+}
@@ -0,0 +1,19 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type ImportRoutes struct {
+	importHandler *ImportHandlerImpl
+}
+
+func (ro *ImportRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.GET("/imports", ro.importHandler.GetImports)
+	public.GET("/imports/:id/readings", ro.importHandler.GetImportReadings)
+	public.POST("/imports/:id/rollback", ro.importHandler.RollbackImport)
+}
+
+func NewImportRoutes(importHandler *ImportHandlerImpl) *ImportRoutes {
+	return &ImportRoutes{
+		importHandler,
+	}
+}
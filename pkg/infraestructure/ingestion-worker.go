@@ -0,0 +1,145 @@
+package infraestructure
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type RemoteFileSource interface {
+	FetchFiles(localDir string) ([]string, error)
+}
+
+type IngestionWorkerImpl struct {
+	powerConsumptionService application.PowerConsumptionService
+	watchDir                string
+	pollInterval            time.Duration
+	unit                    string
+	minFileAge              time.Duration
+	sources                 []RemoteFileSource
+}
+
+func NewIngestionWorker(powerConsumptionService application.PowerConsumptionService, watchDir string, pollInterval time.Duration, unit string, sources ...RemoteFileSource) *IngestionWorkerImpl {
+	return &IngestionWorkerImpl{
+		powerConsumptionService,
+		watchDir,
+		pollInterval,
+		unit,
+		constants.IngestFileMinAge,
+		sources,
+	}
+}
+
+// Run: poll the watched directory until the context is done, the new files are imported and moved to the processed
+// or failed subfolders
+//
+// Parameters:
+// ctx: the context to stop the worker
+//
+// Returns:
+// return an error if the subfolders could not be created
+func (w *IngestionWorkerImpl) Run(ctx context.Context) error {
+	for _, subfolder := range []string{constants.IngestProcessedDir, constants.IngestFailedDir} {
+		if err := os.MkdirAll(filepath.Join(w.watchDir, subfolder), 0o755); err != nil {
+			logrus.Errorf("Error: creating the ingestion folder %s", err.Error())
+			return err
+		}
+	}
+	logrus.Infof("the ingestion worker is watching %s every %s", w.watchDir, w.pollInterval)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.RunOnce(); err != nil {
+			logrus.Errorf("Error: polling the ingestion folder %s", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Info("the ingestion worker was stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce: fetch the files of the remote sources and import the csv files of the watched directory
+//
+// Returns:
+// return an error if the watched directory could not be read
+func (w *IngestionWorkerImpl) RunOnce() error {
+	for _, source := range w.sources {
+		if _, err := source.FetchFiles(w.watchDir); err != nil {
+			logrus.Errorf("Error: fetching the remote files %s", err.Error())
+		}
+	}
+	entries, err := os.ReadDir(w.watchDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !isIngestionFile(entry) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			logrus.Errorf("Error: reading the file %s %s", entry.Name(), err.Error())
+			continue
+		}
+		if time.Since(info.ModTime()) < w.minFileAge {
+			continue
+		}
+		w.processFile(entry.Name())
+	}
+	return nil
+}
+
+func (w *IngestionWorkerImpl) processFile(fileName string) {
+	subfolder := constants.IngestProcessedDir
+	if err := w.importFile(fileName); err != nil {
+		logrus.Errorf("Error: importing the file %s %s", fileName, err.Error())
+		subfolder = constants.IngestFailedDir
+	}
+	if err := moveIngestionFile(w.watchDir, fileName, subfolder); err != nil {
+		logrus.Errorf("Error: moving the file %s to %s %s", fileName, subfolder, err.Error())
+	}
+}
+
+func (w *IngestionWorkerImpl) importFile(fileName string) error {
+	file, err := os.Open(filepath.Join(w.watchDir, fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var csvFile multipart.File = file
+	summary, err := w.powerConsumptionService.ImportCsvToDatabase(&csvFile, domain.ImportOptions{
+		FileName: fileName,
+		Unit:     w.unit,
+		Uploader: constants.IngestUploader,
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Infof("the file %s was imported in the import %d", fileName, summary.ImportID)
+	return nil
+}
+
+func isIngestionFile(entry os.DirEntry) bool {
+	name := entry.Name()
+	return entry.Type().IsRegular() && !strings.HasPrefix(name, ".") && strings.EqualFold(filepath.Ext(name), constants.IngestFileExtension)
+}
+
+func moveIngestionFile(watchDir, fileName, subfolder string) error {
+	target := filepath.Join(watchDir, subfolder, fileName)
+	if _, err := os.Stat(target); err == nil {
+		target = filepath.Join(watchDir, subfolder, fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405"), fileName))
+	}
+	return os.Rename(filepath.Join(watchDir, fileName), target)
+}
@@ -0,0 +1,145 @@
+package infraestructure
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net"
+	"os"
+	"path/filepath"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/sftp"
+)
+
+const IngestionCSV = "id,meter_id,active_energy,reactive_energy,capacitive_reactive,solar,date\n1,1,10,0,0,0,2023-08-01\n"
+
+var _ = Describe("IngestionWorker", func() {
+	var (
+		watchDir                    string
+		mockPowerConsumptionService *applicationfakes.FakePowerConsumptionService
+		worker                      *IngestionWorkerImpl
+	)
+
+	BeforeEach(func() {
+		var err error
+		watchDir, err = os.MkdirTemp("", "ingestion")
+		Expect(err).To(BeNil())
+		Expect(os.MkdirAll(filepath.Join(watchDir, constants.IngestProcessedDir), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(watchDir, constants.IngestFailedDir), 0o755)).To(Succeed())
+		mockPowerConsumptionService = &applicationfakes.FakePowerConsumptionService{}
+		worker = NewIngestionWorker(mockPowerConsumptionService, watchDir, 0, "Wh")
+		worker.minFileAge = 0
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(watchDir)
+	})
+
+	It("should import the csv files and move them to the processed folder", func() {
+		Expect(os.WriteFile(filepath.Join(watchDir, "august.csv"), []byte(IngestionCSV), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(watchDir, "notes.txt"), []byte("notes"), 0o644)).To(Succeed())
+		var content []byte
+		mockPowerConsumptionService.ImportCsvToDatabaseStub = func(file *multipart.File, options domain.ImportOptions) (*application.ImportSummary, error) {
+			content, _ = io.ReadAll(*file)
+			return &application.ImportSummary{ImportID: 1}, nil
+		}
+
+		Expect(worker.RunOnce()).To(Succeed())
+
+		Expect(mockPowerConsumptionService.ImportCsvToDatabaseCallCount()).To(Equal(1))
+		Expect(string(content)).To(Equal(IngestionCSV))
+		_, options := mockPowerConsumptionService.ImportCsvToDatabaseArgsForCall(0)
+		Expect(options).To(Equal(domain.ImportOptions{FileName: "august.csv", Unit: "Wh", Uploader: constants.IngestUploader}))
+		Expect(filepath.Join(watchDir, constants.IngestProcessedDir, "august.csv")).To(BeAnExistingFile())
+		Expect(filepath.Join(watchDir, "august.csv")).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(watchDir, "notes.txt")).To(BeAnExistingFile())
+	})
+
+	It("should move the files that could not be imported to the failed folder", func() {
+		Expect(os.WriteFile(filepath.Join(watchDir, "august.csv"), []byte(IngestionCSV), 0o644)).To(Succeed())
+		mockPowerConsumptionService.ImportCsvToDatabaseReturns(nil, errors.New("Error reading CSV"))
+
+		Expect(worker.RunOnce()).To(Succeed())
+
+		Expect(filepath.Join(watchDir, constants.IngestFailedDir, "august.csv")).To(BeAnExistingFile())
+	})
+
+	It("should wait until the files are old enough", func() {
+		worker.minFileAge = constants.IngestFileMinAge
+		Expect(os.WriteFile(filepath.Join(watchDir, "august.csv"), []byte(IngestionCSV), 0o644)).To(Succeed())
+
+		Expect(worker.RunOnce()).To(Succeed())
+
+		Expect(mockPowerConsumptionService.ImportCsvToDatabaseCallCount()).To(Equal(0))
+		Expect(filepath.Join(watchDir, "august.csv")).To(BeAnExistingFile())
+	})
+})
+
+var _ = Describe("SFTPFileSource", func() {
+	var (
+		localDir string
+		handlers sftp.Handlers
+		source   *SFTPFileSourceImpl
+	)
+
+	connectInMemory := func() (*sftp.Client, func() error, error) {
+		serverConn, clientConn := net.Pipe()
+		server := sftp.NewRequestServer(serverConn, handlers)
+		go server.Serve()
+		client, err := sftp.NewClientPipe(clientConn, clientConn)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() error {
+			client.Close()
+			return server.Close()
+		}, nil
+	}
+
+	BeforeEach(func() {
+		var err error
+		localDir, err = os.MkdirTemp("", "sftp")
+		Expect(err).To(BeNil())
+		handlers = sftp.InMemHandler()
+		source = NewSFTPFileSource(connectInMemory, "/drop")
+		client, closeConnection, err := connectInMemory()
+		Expect(err).To(BeNil())
+		defer closeConnection()
+		Expect(client.Mkdir("/drop")).To(Succeed())
+		for name, content := range map[string]string{"august.csv": IngestionCSV, "readme.txt": "readme"} {
+			file, err := client.Create("/drop/" + name)
+			Expect(err).To(BeNil())
+			_, err = file.Write([]byte(content))
+			Expect(err).To(BeNil())
+			file.Close()
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(localDir)
+	})
+
+	It("should download the csv files and remove them from the server", func() {
+		fileNames, err := source.FetchFiles(localDir)
+
+		Expect(err).To(BeNil())
+		Expect(fileNames).To(Equal([]string{"august.csv"}))
+		content, err := os.ReadFile(filepath.Join(localDir, "august.csv"))
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal(IngestionCSV))
+
+		client, closeConnection, err := connectInMemory()
+		Expect(err).To(BeNil())
+		defer closeConnection()
+		entries, err := client.ReadDir("/drop")
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Name()).To(Equal("readme.txt"))
+	})
+})
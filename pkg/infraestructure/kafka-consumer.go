@@ -0,0 +1,211 @@
+package infraestructure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+)
+
+type KafkaReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Stats() kafka.ReaderStats
+	Close() error
+}
+
+type KafkaConsumerImpl struct {
+	reader             KafkaReader
+	liveReadingService application.LiveReadingService
+	flushInterval      time.Duration
+	retryDelay         time.Duration
+	mutex              sync.Mutex
+	inflight           map[int][]kafka.Message
+	acked              map[int]map[int64]bool
+	lag                int64
+}
+
+func NewKafkaConsumer(reader KafkaReader, liveReadingService application.LiveReadingService, flushInterval time.Duration) *KafkaConsumerImpl {
+	return &KafkaConsumerImpl{
+		reader:             reader,
+		liveReadingService: liveReadingService,
+		flushInterval:      flushInterval,
+		retryDelay:         constants.KafkaRetryBaseDelay,
+		inflight:           make(map[int][]kafka.Message),
+		acked:              make(map[int]map[int64]bool),
+	}
+}
+
+// NewKafkaReader: build the reader of a topic in a consumer group, the offsets are committed by the consumer
+//
+// Parameters:
+// brokers: the addresses of the brokers
+// groupID: the consumer group
+// topic: the topic of the readings
+//
+// Returns:
+// return the reader of the topic
+func NewKafkaReader(brokers []string, groupID, topic string) KafkaReader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+	})
+}
+
+// Run: read the events of the topic until the context is done, the offsets are committed only after the readings
+// are stored and the buffer of readings is flushed when no event arrives in the flush interval, an event that could
+// not be stored is tried again before reading the next one so its offset is never skipped, a partition is only
+// committed up to the last event that has every previous event acknowledged
+//
+// Parameters:
+// ctx: the context to stop the consumer
+//
+// Returns:
+// return an error if the reader could not be closed
+func (k *KafkaConsumerImpl) Run(ctx context.Context) error {
+	logrus.Info("the kafka consumer was started")
+	lastFlush := time.Now()
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, k.flushInterval)
+		message, err := k.reader.FetchMessage(fetchCtx)
+		cancel()
+		k.updateLag()
+		switch {
+		case ctx.Err() != nil:
+			k.flush(context.Background())
+			logrus.Info("the kafka consumer was stopped")
+			return k.reader.Close()
+		case errors.Is(err, context.DeadlineExceeded):
+		case err != nil:
+			logrus.Errorf("Error: fetching the kafka message %s", err.Error())
+			time.Sleep(time.Second)
+		default:
+			k.track(message)
+			k.handleMessage(ctx, message)
+			k.commitAcked(ctx)
+		}
+		if time.Since(lastFlush) >= k.flushInterval {
+			k.flush(ctx)
+			lastFlush = time.Now()
+		}
+	}
+}
+
+// Lag: the number of messages of the topic that the consumer has not read yet
+//
+// Returns:
+// return the lag of the last fetch
+func (k *KafkaConsumerImpl) Lag() int64 {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.lag
+}
+
+// handleMessage: store a message and try it again with an exponential backoff until it's stored or the context is
+// done, the next messages are not read meanwhile so no offset after it is committed
+//
+// Parameters:
+// ctx: the context to stop the consumer
+// message: the message to store
+func (k *KafkaConsumerImpl) handleMessage(ctx context.Context, message kafka.Message) {
+	delay := k.retryDelay
+	for {
+		err := k.liveReadingService.HandleMessage(message.Topic, message.Value, k.ackFunc(message))
+		if err == nil {
+			return
+		}
+		logrus.Errorf("Error: storing the kafka message %d of the partition %d, trying again in %s %s", message.Offset, message.Partition, delay, err.Error())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > constants.KafkaRetryMaxDelay {
+			delay = constants.KafkaRetryMaxDelay
+		}
+	}
+}
+
+func (k *KafkaConsumerImpl) updateLag() {
+	stats := k.reader.Stats()
+	k.mutex.Lock()
+	k.lag = stats.Lag
+	k.mutex.Unlock()
+}
+
+func (k *KafkaConsumerImpl) flush(ctx context.Context) {
+	if err := k.liveReadingService.Flush(); err != nil {
+		logrus.Errorf("Error: flushing the kafka readings %s", err.Error())
+	}
+	k.commitAcked(ctx)
+}
+
+// track: keep a fetched message in the order of its partition until it's committed
+func (k *KafkaConsumerImpl) track(message kafka.Message) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.inflight[message.Partition] = append(k.inflight[message.Partition], message)
+}
+
+func (k *KafkaConsumerImpl) ackFunc(message kafka.Message) func() {
+	return func() {
+		k.mutex.Lock()
+		defer k.mutex.Unlock()
+		if k.acked[message.Partition] == nil {
+			k.acked[message.Partition] = make(map[int64]bool)
+		}
+		k.acked[message.Partition][message.Offset] = true
+	}
+}
+
+// commitAcked: commit every partition up to the last message that has all the previous messages acknowledged, kafka
+// commits the offset of a message as the position of the partition so a message acknowledged before the readings
+// buffered ahead of it must wait for them
+//
+// Parameters:
+// ctx: the context to stop the consumer
+func (k *KafkaConsumerImpl) commitAcked(ctx context.Context) {
+	k.mutex.Lock()
+	var toCommit []kafka.Message
+	committedByPartition := make(map[int][]kafka.Message)
+	for partition, messages := range k.inflight {
+		ackedMessages := 0
+		for ackedMessages < len(messages) && k.acked[partition][messages[ackedMessages].Offset] {
+			ackedMessages++
+		}
+		if ackedMessages == 0 {
+			continue
+		}
+		committedByPartition[partition] = messages[:ackedMessages]
+		k.inflight[partition] = messages[ackedMessages:]
+		toCommit = append(toCommit, messages[ackedMessages-1])
+	}
+	k.mutex.Unlock()
+	if len(toCommit) == 0 {
+		return
+	}
+	if err := k.reader.CommitMessages(ctx, toCommit...); err != nil {
+		logrus.Errorf("Error: committing the kafka offsets %s", err.Error())
+		k.mutex.Lock()
+		for partition, messages := range committedByPartition {
+			k.inflight[partition] = append(append([]kafka.Message{}, messages...), k.inflight[partition]...)
+		}
+		k.mutex.Unlock()
+		return
+	}
+	k.mutex.Lock()
+	for partition, messages := range committedByPartition {
+		for _, message := range messages {
+			delete(k.acked[partition], message.Offset)
+		}
+	}
+	k.mutex.Unlock()
+}
@@ -0,0 +1,197 @@
+package infraestructure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/segmentio/kafka-go"
+)
+
+// standInKafkaReader: an in-memory topic with a single partition to test the consumer without a broker
+type standInKafkaReader struct {
+	mutex     sync.Mutex
+	messages  chan kafka.Message
+	committed []int64
+	lag       int64
+	commitErr error
+}
+
+func (r *standInKafkaReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case message := <-r.messages:
+		return message, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *standInKafkaReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.commitErr != nil {
+		return r.commitErr
+	}
+	for _, message := range msgs {
+		r.committed = append(r.committed, message.Offset)
+	}
+	return nil
+}
+
+func (r *standInKafkaReader) Committed() []int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]int64{}, r.committed...)
+}
+
+func (r *standInKafkaReader) Stats() kafka.ReaderStats {
+	return kafka.ReaderStats{Lag: r.lag}
+}
+
+func (r *standInKafkaReader) Close() error {
+	return nil
+}
+
+var _ = Describe("KafkaConsumer", func() {
+	var (
+		reader                 *standInKafkaReader
+		mockLiveReadingService *applicationfakes.FakeLiveReadingService
+		consumer               *KafkaConsumerImpl
+		ctx                    context.Context
+		cancel                 context.CancelFunc
+		stopped                chan struct{}
+	)
+
+	BeforeEach(func() {
+		reader = &standInKafkaReader{messages: make(chan kafka.Message, 10), lag: 4}
+		mockLiveReadingService = &applicationfakes.FakeLiveReadingService{}
+		consumer = NewKafkaConsumer(reader, mockLiveReadingService, 50*time.Millisecond)
+		ctx, cancel = context.WithCancel(context.Background())
+		stopped = make(chan struct{})
+	})
+
+	run := func() {
+		go func() {
+			consumer.Run(ctx)
+			close(stopped)
+		}()
+	}
+
+	AfterEach(func() {
+		cancel()
+		Eventually(stopped).Should(BeClosed())
+	})
+
+	It("should commit the offsets only after the readings are stored", func() {
+		var mutex sync.Mutex
+		var pendingAcks []func()
+		mockLiveReadingService.HandleMessageStub = func(topic string, payload []byte, ack func()) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			pendingAcks = append(pendingAcks, ack)
+			return nil
+		}
+		flushErr := errors.New("Error creating records")
+		mockLiveReadingService.FlushStub = func() error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if flushErr != nil {
+				return flushErr
+			}
+			for _, ack := range pendingAcks {
+				ack()
+			}
+			pendingAcks = nil
+			return nil
+		}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 1, Value: []byte(`{}`)}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 2, Value: []byte(`{}`)}
+		run()
+
+		Eventually(mockLiveReadingService.FlushCallCount).Should(BeNumerically(">", 1))
+		Expect(reader.Committed()).To(BeEmpty())
+
+		mutex.Lock()
+		flushErr = nil
+		mutex.Unlock()
+		Eventually(reader.Committed).Should(Equal([]int64{2}))
+		topic, payload, _ := mockLiveReadingService.HandleMessageArgsForCall(0)
+		Expect(topic).To(Equal("readings"))
+		Expect(string(payload)).To(Equal(`{}`))
+	})
+
+	It("should try a message again instead of reading the next one when it could not be stored", func() {
+		consumer.retryDelay = 10 * time.Millisecond
+		var mutex sync.Mutex
+		failures := 2
+		mockLiveReadingService.HandleMessageStub = func(topic string, payload []byte, ack func()) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if string(payload) == "first" && failures > 0 {
+				failures--
+				return errors.New("Error creating the quarantined reading")
+			}
+			ack()
+			return nil
+		}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 1, Value: []byte("first")}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 2, Value: []byte("second")}
+		run()
+
+		Eventually(reader.Committed).Should(Equal([]int64{1, 2}))
+		Expect(mockLiveReadingService.HandleMessageCallCount()).To(Equal(4))
+		for call, payload := range []string{"first", "first", "first", "second"} {
+			_, handled, _ := mockLiveReadingService.HandleMessageArgsForCall(call)
+			Expect(string(handled)).To(Equal(payload))
+		}
+	})
+
+	It("should not commit a message acknowledged before the buffered readings ahead of it are stored", func() {
+		var mutex sync.Mutex
+		var pendingAcks []func()
+		mockLiveReadingService.HandleMessageStub = func(topic string, payload []byte, ack func()) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if string(payload) == "not valid" {
+				ack()
+				return nil
+			}
+			pendingAcks = append(pendingAcks, ack)
+			return nil
+		}
+		flushErr := errors.New("Error creating records")
+		mockLiveReadingService.FlushStub = func() error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if flushErr != nil {
+				return flushErr
+			}
+			for _, ack := range pendingAcks {
+				ack()
+			}
+			pendingAcks = nil
+			return nil
+		}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 1, Value: []byte("reading")}
+		reader.messages <- kafka.Message{Topic: "readings", Offset: 2, Value: []byte("not valid")}
+		run()
+
+		Eventually(mockLiveReadingService.FlushCallCount).Should(BeNumerically(">", 1))
+		Expect(reader.Committed()).To(BeEmpty())
+
+		mutex.Lock()
+		flushErr = nil
+		mutex.Unlock()
+		Eventually(reader.Committed).Should(Equal([]int64{2}))
+	})
+
+	It("should report the lag of the reader", func() {
+		run()
+
+		Eventually(consumer.Lag).Should(Equal(int64(4)))
+	})
+})
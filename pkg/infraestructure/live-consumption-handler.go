@@ -0,0 +1,116 @@
+package infraestructure
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type LiveReadingSerializer struct {
+	MeterID            int     `json:"meter_id"`
+	Date               string  `json:"date"`
+	ActiveEnergy       float64 `json:"active_energy"`
+	ReactiveEnergy     float64 `json:"reactive_energy"`
+	CapacitiveReactive float64 `json:"capacitive_reactive"`
+	Solar              float64 `json:"solar"`
+	Flags              string  `json:"flags,omitempty"`
+}
+
+type LiveAggregateSerializer struct {
+	KindPeriod string `json:"kind_period"`
+	application.Serializer
+}
+
+func ToLiveReadingSerializer(reading domain.UserConsumption) LiveReadingSerializer {
+	return LiveReadingSerializer{
+		MeterID:            reading.MeterID,
+		Date:               reading.Date.Format(time.RFC3339),
+		ActiveEnergy:       reading.ActiveEnergy,
+		ReactiveEnergy:     reading.ReactiveEnergy,
+		CapacitiveReactive: reading.CapacitiveReactive,
+		Solar:              reading.Solar,
+		Flags:              reading.Flags,
+	}
+}
+
+type LiveConsumptionHandlerImpl struct {
+	liveConsumptionService application.LiveConsumptionService
+	heartbeat              time.Duration
+}
+
+func NewLiveConsumptionHandler(liveConsumptionService application.LiveConsumptionService, heartbeat time.Duration) *LiveConsumptionHandlerImpl {
+	return &LiveConsumptionHandlerImpl{
+		liveConsumptionService,
+		heartbeat,
+	}
+}
+
+// Stream the readings of some meters as they are ingested and the aggregates of the current period
+// @Tags Power Consumption
+// @Summary Stream the live consumption of some meters
+// @Description Server-Sent Events stream, the aggregate event is sent when the client connects and after every reading event with the updated consumption of the current period of every meter, a heartbeat comment is sent when there are not new readings
+// @Produce  text/event-stream
+// @Param meter_ids query string  true  "meter ids separated by comma"
+// @Param kind_period query string  false "current period of the aggregates daily, calendar_weekly or monthly, default daily"
+// @Success 200 {object} LiveAggregateSerializer
+// @Failure 400 {object} Response
+// @Router /consumption/live [get]
+func (l *LiveConsumptionHandlerImpl) StreamConsumption(c *gin.Context) {
+	subscription, err := l.liveConsumptionService.Subscribe(c.Query("meter_ids"), c.DefaultQuery("kind_period", constants.PeriodKindDaily))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your query params", err))
+		return
+	}
+	defer l.liveConsumptionService.Unsubscribe(subscription)
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	l.sendAggregates(c, subscription)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(l.heartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case readings, ok := <-subscription.Readings:
+			if !ok {
+				return false
+			}
+			for _, reading := range readings {
+				c.SSEvent(constants.LiveEventReading, ToLiveReadingSerializer(reading))
+			}
+			l.sendAggregates(c, subscription)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
+
+func (l *LiveConsumptionHandlerImpl) sendAggregates(c *gin.Context, subscription *application.LiveConsumptionSubscription) {
+	serializers, err := l.liveConsumptionService.GetCurrentPeriodConsumption(subscription)
+	if err != nil {
+		logrus.Errorf("Error: getting the current period of the meters %v %s", subscription.MeterIDs, err.Error())
+		c.SSEvent(constants.LiveEventError, Response{
+			Msg:    "the aggregates of the current period could not be calculated",
+			Status: "ERROR",
+			Data:   nil,
+			Err:    err.Error(),
+		})
+		return
+	}
+	for _, serializer := range serializers {
+		c.SSEvent(constants.LiveEventAggregate, LiveAggregateSerializer{
+			KindPeriod: subscription.KindPeriod,
+			Serializer: serializer,
+		})
+	}
+}
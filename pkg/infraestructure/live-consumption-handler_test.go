@@ -0,0 +1,104 @@
+package infraestructure
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const LiveConsumptionPath = "/consumption/live"
+
+var _ = Describe("StreamConsumption", func() {
+	var (
+		router                      *gin.Engine
+		server                      *ghttp.Server
+		broker                      application.LiveConsumptionBroker
+		mockPowerConsumptionService *applicationfakes.FakePowerConsumptionService
+	)
+
+	readEvent := func(reader *bufio.Reader) (string, string) {
+		var event, data string
+		for {
+			line, err := reader.ReadString('\n')
+			Expect(err).To(BeNil())
+			line = strings.TrimRight(line, "\n")
+			if line == "" && event != "" {
+				return event, data
+			}
+			if strings.HasPrefix(line, "event:") {
+				event = line[len("event:"):]
+			}
+			if strings.HasPrefix(line, "data:") {
+				data = line[len("data:"):]
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		router = gin.Default()
+		broker = application.NewLiveConsumptionBroker()
+		mockPowerConsumptionService = &applicationfakes.FakePowerConsumptionService{}
+		mockHandler := NewLiveConsumptionHandler(application.NewLiveConsumptionService(broker, mockPowerConsumptionService), time.Minute)
+		router.GET(LiveConsumptionPath, mockHandler.StreamConsumption)
+		server = ghttp.NewServer()
+		server.RouteToHandler("GET", LiveConsumptionPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the period kind is not allowed", func() {
+		It("should return an error", func() {
+			resp, err := http.Get(fmt.Sprintf("%s%s?meter_ids=1&kind_period=weekly", server.URL(), LiveConsumptionPath))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when new readings of the meters are written", func() {
+		It("should stream the readings and the updated aggregates", func() {
+			mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturnsOnCall(0, []application.Serializer{{MeterID: 1, Period: []string{"Jun 15"}, Active: []float64{10}}}, nil)
+			mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeReturnsOnCall(1, []application.Serializer{{MeterID: 1, Period: []string{"Jun 15"}, Active: []float64{25}}}, nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			request, _ := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?meter_ids=1", server.URL(), LiveConsumptionPath), nil)
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Header.Get("Content-Type")).To(ContainSubstring("text/event-stream"))
+			reader := bufio.NewReader(resp.Body)
+
+			event, data := readEvent(reader)
+			Expect(event).To(Equal("aggregate"))
+			Expect(data).To(ContainSubstring(`"kind_period":"daily"`))
+			Expect(data).To(ContainSubstring(`"active":[10]`))
+
+			broker.PublishReadings([]*domain.UserConsumption{
+				{MeterID: 2, ActiveEnergy: 99},
+				{MeterID: 1, ActiveEnergy: 15, Date: time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC)},
+			})
+			event, data = readEvent(reader)
+			Expect(event).To(Equal("reading"))
+			Expect(data).To(ContainSubstring(`"meter_id":1`))
+			Expect(data).To(ContainSubstring(`"date":"2023-06-15T10:00:00Z"`))
+			event, data = readEvent(reader)
+			Expect(event).To(Equal("aggregate"))
+			Expect(data).To(ContainSubstring(`"active":[25]`))
+			meterIDs, _, _, kindPeriod, _ := mockPowerConsumptionService.GetConsumptionByMeterIDAndWindowTimeArgsForCall(1)
+			Expect(meterIDs).To(Equal("1"))
+			Expect(kindPeriod).To(Equal("daily"))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type LiveConsumptionRoutes struct {
+	liveConsumptionHandler *LiveConsumptionHandlerImpl
+}
+
+func (ro *LiveConsumptionRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.GET("/consumption/live", ro.liveConsumptionHandler.StreamConsumption)
+}
+
+func NewLiveConsumptionRoutes(liveConsumptionHandler *LiveConsumptionHandlerImpl) *LiveConsumptionRoutes {
+	return &LiveConsumptionRoutes{
+		liveConsumptionHandler,
+	}
+}
@@ -0,0 +1,128 @@
+package infraestructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type MeterGroupRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+	MeterIDs []int  `json:"meter_ids"`
+}
+
+type MeterGroupSerializer struct {
+	ID               uint   `json:"id"`
+	Name             string `json:"name"`
+	ParentID         *uint  `json:"parent_id"`
+	MeterIDs         []int  `json:"meter_ids"`
+	ResolvedMeterIDs []int  `json:"resolved_meter_ids,omitempty"`
+}
+
+func ToMeterGroupSerializer(group domain.MeterGroup) MeterGroupSerializer {
+	serializer := MeterGroupSerializer{
+		ID:       group.ID,
+		Name:     group.Name,
+		ParentID: group.ParentID,
+		MeterIDs: []int{},
+	}
+	for _, meter := range group.Meters {
+		serializer.MeterIDs = append(serializer.MeterIDs, meter.MeterID)
+	}
+	return serializer
+}
+
+type MeterGroupHandlerImpl struct {
+	meterGroupService application.MeterGroupService
+}
+
+func NewMeterGroupHandler(meterGroupService application.MeterGroupService) *MeterGroupHandlerImpl {
+	return &MeterGroupHandlerImpl{
+		meterGroupService,
+	}
+}
+
+// Create a meter group, a named set of meters that could be nested in another group
+// @Tags Meter Groups
+// @Summary Create a meter group
+// @Description Create a meter group, a named set of meters that could be nested in another group
+// @Accept  json
+// @Produce  json
+// @Param group body MeterGroupRequest true "meter group"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /groups [post]
+func (m *MeterGroupHandlerImpl) CreateMeterGroup(c *gin.Context) {
+	var request MeterGroupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your meter group", err))
+		return
+	}
+	group, err := m.meterGroupService.CreateMeterGroup(request.Name, request.ParentID, request.MeterIDs)
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your meter group", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the meter group was successfully created",
+		Status: "SUCCESS",
+		Data:   ToMeterGroupSerializer(*group),
+		Err:    nil,
+	})
+}
+
+// Get all the meter groups
+// @Tags Meter Groups
+// @Summary Get all the meter groups
+// @Description Get all the meter groups
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /groups [get]
+func (m *MeterGroupHandlerImpl) GetMeterGroups(c *gin.Context) {
+	groups, err := m.meterGroupService.GetMeterGroups()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []MeterGroupSerializer{}
+	for _, group := range groups {
+		serializers = append(serializers, ToMeterGroupSerializer(group))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
+
+// Get a meter group with all the meters in it and in its nested groups
+// @Tags Meter Groups
+// @Summary Get a meter group
+// @Description Get a meter group with all the meters in it and in its nested groups
+// @Accept  json
+// @Produce  json
+// @Param id path string true "meter group id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /groups/{id} [get]
+func (m *MeterGroupHandlerImpl) GetMeterGroupByID(c *gin.Context) {
+	group, meterIDs, err := m.meterGroupService.GetMeterGroupByID(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializer := ToMeterGroupSerializer(*group)
+	serializer.ResolvedMeterIDs = meterIDs
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializer,
+		Err:    nil,
+	})
+}
@@ -0,0 +1,73 @@
+package infraestructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"gorm.io/gorm"
+)
+
+const GroupsPath = "/groups"
+
+var _ = Describe("CreateMeterGroup", func() {
+	var (
+		router                *gin.Engine
+		server                *ghttp.Server
+		mockMeterGroupService *applicationfakes.FakeMeterGroupService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockMeterGroupService = &applicationfakes.FakeMeterGroupService{}
+		mockHandler := NewMeterGroupHandler(mockMeterGroupService)
+		router.POST(GroupsPath, mockHandler.CreateMeterGroup)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", GroupsPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the name is missing", func() {
+		It("should return an error", func() {
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), GroupsPath), "application/json", bytes.NewBufferString(`{"meter_ids":[1,2]}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockMeterGroupService.CreateMeterGroupCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the group is valid", func() {
+		It("should create the group", func() {
+			mockMeterGroupService.CreateMeterGroupReturns(&domain.MeterGroup{
+				Model:  gorm.Model{ID: 3},
+				Name:   "Building A",
+				Meters: []domain.MeterGroupMeter{{MeterID: 1}, {MeterID: 2}},
+			}, nil)
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), GroupsPath), "application/json", bytes.NewBufferString(`{"name":"Building A","meter_ids":[1,2]}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			var responseBody struct {
+				Status string               `json:"status"`
+				Data   MeterGroupSerializer `json:"data"`
+			}
+			json.NewDecoder(resp.Body).Decode(&responseBody)
+			Expect(responseBody.Status).To(Equal("SUCCESS"))
+			Expect(responseBody.Data.ID).To(Equal(uint(3)))
+			Expect(responseBody.Data.MeterIDs).To(Equal([]int{1, 2}))
+			name, parentID, meterIDs := mockMeterGroupService.CreateMeterGroupArgsForCall(0)
+			Expect(name).To(Equal("Building A"))
+			Expect(parentID).To(BeNil())
+			Expect(meterIDs).To(Equal([]int{1, 2}))
+		})
+	})
+})
@@ -0,0 +1,19 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type MeterGroupRoutes struct {
+	meterGroupHandler *MeterGroupHandlerImpl
+}
+
+func (ro *MeterGroupRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.POST("/groups", ro.meterGroupHandler.CreateMeterGroup)
+	public.GET("/groups", ro.meterGroupHandler.GetMeterGroups)
+	public.GET("/groups/:id", ro.meterGroupHandler.GetMeterGroupByID)
+}
+
+func NewMeterGroupRoutes(meterGroupHandler *MeterGroupHandlerImpl) *MeterGroupRoutes {
+	return &MeterGroupRoutes{
+		meterGroupHandler,
+	}
+}
@@ -0,0 +1,107 @@
+package infraestructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type MeterSettingRequest struct {
+	BillingCycleDay int     `json:"billing_cycle_day"`
+	Cumulative      bool    `json:"cumulative"`
+	RegisterMax     float64 `json:"register_max"`
+	Unit            string  `json:"unit"`
+}
+
+type MeterSettingSerializer struct {
+	MeterID         int     `json:"meter_id"`
+	BillingCycleDay int     `json:"billing_cycle_day"`
+	Cumulative      bool    `json:"cumulative"`
+	RegisterMax     float64 `json:"register_max"`
+	Unit            string  `json:"unit"`
+}
+
+func (r MeterSettingRequest) ToMeterSetting() domain.MeterSetting {
+	return domain.MeterSetting{
+		BillingCycleDay: r.BillingCycleDay,
+		Cumulative:      r.Cumulative,
+		RegisterMax:     r.RegisterMax,
+		Unit:            r.Unit,
+	}
+}
+
+func ToMeterSettingSerializer(setting domain.MeterSetting) MeterSettingSerializer {
+	return MeterSettingSerializer{
+		MeterID:         setting.MeterID,
+		BillingCycleDay: setting.BillingCycleDay,
+		Cumulative:      setting.Cumulative,
+		RegisterMax:     setting.RegisterMax,
+		Unit:            setting.Unit,
+	}
+}
+
+type MeterSettingHandlerImpl struct {
+	meterSettingService application.MeterSettingService
+}
+
+func NewMeterSettingHandler(meterSettingService application.MeterSettingService) *MeterSettingHandlerImpl {
+	return &MeterSettingHandlerImpl{
+		meterSettingService,
+	}
+}
+
+// Save the settings of a meter like the day that starts its billing cycles or if it reports register values
+// @Tags Meter Settings
+// @Summary Save the settings of a meter
+// @Description Save the settings of a meter like the day that starts its billing cycles or if it reports register values
+// @Accept  json
+// @Produce  json
+// @Param id path string true "meter id"
+// @Param setting body MeterSettingRequest true "meter settings"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /meters/{id}/settings [put]
+func (m *MeterSettingHandlerImpl) SaveMeterSetting(c *gin.Context) {
+	var request MeterSettingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your meter settings", err))
+		return
+	}
+	setting, err := m.meterSettingService.SaveMeterSetting(c.Param("id"), request.ToMeterSetting())
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your meter settings", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the meter settings were successfully saved",
+		Status: "SUCCESS",
+		Data:   ToMeterSettingSerializer(*setting),
+		Err:    nil,
+	})
+}
+
+// Get the settings of a meter
+// @Tags Meter Settings
+// @Summary Get the settings of a meter
+// @Description Get the settings of a meter
+// @Accept  json
+// @Produce  json
+// @Param id path string true "meter id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /meters/{id}/settings [get]
+func (m *MeterSettingHandlerImpl) GetMeterSettingByMeterID(c *gin.Context) {
+	setting, err := m.meterSettingService.GetMeterSettingByMeterID(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   ToMeterSettingSerializer(*setting),
+		Err:    nil,
+	})
+}
@@ -0,0 +1,79 @@
+package infraestructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const MeterSettingsPath = "/meters/:id/settings"
+
+var _ = Describe("SaveMeterSetting", func() {
+	var (
+		router                  *gin.Engine
+		server                  *ghttp.Server
+		mockMeterSettingService *applicationfakes.FakeMeterSettingService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockMeterSettingService = &applicationfakes.FakeMeterSettingService{}
+		mockHandler := NewMeterSettingHandler(mockMeterSettingService)
+		router.PUT(MeterSettingsPath, mockHandler.SaveMeterSetting)
+		server = ghttp.NewServer()
+		server.RouteToHandler("PUT", "/meters/7/settings", router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the body is not valid", func() {
+		It("should return an error", func() {
+			request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/meters/7/settings", server.URL()), bytes.NewBufferString(`{"billing_cycle_day":"17th"}`))
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockMeterSettingService.SaveMeterSettingCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the settings are valid", func() {
+		It("should save the settings of the meter", func() {
+			mockMeterSettingService.SaveMeterSettingReturns(&domain.MeterSetting{MeterID: 7, BillingCycleDay: 17}, nil)
+			request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/meters/7/settings", server.URL()), bytes.NewBufferString(`{"billing_cycle_day":17}`))
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var responseBody struct {
+				Status string                 `json:"status"`
+				Data   MeterSettingSerializer `json:"data"`
+			}
+			json.NewDecoder(resp.Body).Decode(&responseBody)
+			Expect(responseBody.Status).To(Equal("SUCCESS"))
+			Expect(responseBody.Data.BillingCycleDay).To(Equal(17))
+			meterID, setting := mockMeterSettingService.SaveMeterSettingArgsForCall(0)
+			Expect(meterID).To(Equal("7"))
+			Expect(setting.BillingCycleDay).To(Equal(17))
+		})
+	})
+
+	Context("when the service fails", func() {
+		It("should return an error", func() {
+			mockMeterSettingService.SaveMeterSettingReturns(nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "billing_cycle_day", "Error: billing cycle day not allowed 40"))
+			request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/meters/7/settings", server.URL()), bytes.NewBufferString(`{"billing_cycle_day":40}`))
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,18 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type MeterSettingRoutes struct {
+	meterSettingHandler *MeterSettingHandlerImpl
+}
+
+func (ro *MeterSettingRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.PUT("/meters/:id/settings", ro.meterSettingHandler.SaveMeterSetting)
+	public.GET("/meters/:id/settings", ro.meterSettingHandler.GetMeterSettingByMeterID)
+}
+
+func NewMeterSettingRoutes(meterSettingHandler *MeterSettingHandlerImpl) *MeterSettingRoutes {
+	return &MeterSettingRoutes{
+		meterSettingHandler,
+	}
+}
@@ -0,0 +1,110 @@
+package infraestructure
+
+import (
+	"context"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/sirupsen/logrus"
+)
+
+type MQTTSubscriberImpl struct {
+	options            *mqtt.ClientOptions
+	liveReadingService application.LiveReadingService
+	qos                byte
+	flushInterval      time.Duration
+	client             mqtt.Client
+}
+
+func NewMQTTSubscriber(options *mqtt.ClientOptions, liveReadingService application.LiveReadingService, qos byte, flushInterval time.Duration) *MQTTSubscriberImpl {
+	subscriber := &MQTTSubscriberImpl{
+		options:            options,
+		liveReadingService: liveReadingService,
+		qos:                qos,
+		flushInterval:      flushInterval,
+	}
+	options.SetCleanSession(false)
+	options.SetAutoAckDisabled(true)
+	options.SetAutoReconnect(true)
+	options.SetConnectRetry(true)
+	options.SetOnConnectHandler(subscriber.subscribe)
+	options.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		logrus.Errorf("Error: the mqtt connection was lost %s", err.Error())
+	})
+	options.SetReconnectingHandler(func(client mqtt.Client, options *mqtt.ClientOptions) {
+		logrus.Info("reconnecting to the mqtt broker")
+	})
+	subscriber.client = mqtt.NewClient(options)
+	return subscriber
+}
+
+// NewMQTTClientOptions: build the options of the mqtt client
+//
+// Parameters:
+// broker: the url of the broker like tcp://localhost:1883
+// clientID: the id of the client, it must be stable to keep the session between reconnections
+// user: the user of the broker, blank without authentication
+// password: the password of the user
+//
+// Returns:
+// return the options of the client
+func NewMQTTClientOptions(broker, clientID, user, password string) *mqtt.ClientOptions {
+	options := mqtt.NewClientOptions().AddBroker(broker).SetClientID(clientID)
+	if user != "" {
+		options.SetUsername(user)
+		options.SetPassword(password)
+	}
+	return options
+}
+
+// Run: connect to the broker and store the readings of the subscribed topic until the context is done, the buffer
+// of readings is flushed periodically and before disconnecting
+//
+// Parameters:
+// ctx: the context to stop the subscriber
+//
+// Returns:
+// return an error if the client could not connect
+func (m *MQTTSubscriberImpl) Run(ctx context.Context) error {
+	token := m.client.Connect()
+	token.Wait()
+	if err := token.Error(); err != nil {
+		logrus.Errorf("Error: connecting to the mqtt broker %s", err.Error())
+		return err
+	}
+	ticker := time.NewTicker(m.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := m.liveReadingService.Flush(); err != nil {
+				logrus.Errorf("Error: flushing the live readings %s", err.Error())
+			}
+			m.client.Disconnect(250)
+			logrus.Info("the mqtt subscriber was stopped")
+			return nil
+		case <-ticker.C:
+			if err := m.liveReadingService.Flush(); err != nil {
+				logrus.Errorf("Error: flushing the live readings %s", err.Error())
+			}
+		}
+	}
+}
+
+func (m *MQTTSubscriberImpl) subscribe(client mqtt.Client) {
+	topic := m.liveReadingService.SubscriptionTopic()
+	token := client.Subscribe(topic, m.qos, m.handleMessage)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		logrus.Errorf("Error: subscribing to the topic %s %s", topic, err.Error())
+		return
+	}
+	logrus.Infof("subscribed to the topic %s", topic)
+}
+
+func (m *MQTTSubscriberImpl) handleMessage(client mqtt.Client, message mqtt.Message) {
+	if err := m.liveReadingService.HandleMessage(message.Topic(), message.Payload(), message.Ack); err != nil {
+		logrus.Errorf("Error: storing the message %d of the topic %s %s", message.MessageID(), message.Topic(), err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package infraestructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// the test runs against a local broker like mosquitto when MQTT_TEST_BROKER is set, e.g. tcp://localhost:1883
+var _ = Describe("MQTTSubscriber", func() {
+	It("should pass the messages of the topic to the service", func() {
+		broker := os.Getenv("MQTT_TEST_BROKER")
+		if broker == "" {
+			Skip("MQTT_TEST_BROKER is not set")
+		}
+		topic := fmt.Sprintf("consumption-ms-test/%d/readings", time.Now().UnixNano())
+		mockLiveReadingService := &applicationfakes.FakeLiveReadingService{}
+		mockLiveReadingService.SubscriptionTopicReturns(topic)
+		received := make(chan string, 1)
+		mockLiveReadingService.HandleMessageStub = func(topic string, payload []byte, ack func()) error {
+			ack()
+			received <- string(payload)
+			return nil
+		}
+		subscriber := NewMQTTSubscriber(NewMQTTClientOptions(broker, fmt.Sprintf("subscriber-%d", time.Now().UnixNano()), "", ""), mockLiveReadingService, 1, time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go subscriber.Run(ctx)
+		Eventually(mockLiveReadingService.SubscriptionTopicCallCount, 5*time.Second).Should(BeNumerically(">", 0))
+		time.Sleep(200 * time.Millisecond)
+
+		publisher := mqtt.NewClient(NewMQTTClientOptions(broker, fmt.Sprintf("publisher-%d", time.Now().UnixNano()), "", ""))
+		Expect(publisher.Connect().WaitTimeout(5 * time.Second)).To(BeTrue())
+		defer publisher.Disconnect(250)
+		Expect(publisher.Publish(topic, 1, false, `{"date":"2023-08-01T10:00:00Z"}`).WaitTimeout(5 * time.Second)).To(BeTrue())
+
+		Eventually(received, 5*time.Second).Should(Receive(Equal(`{"date":"2023-08-01T10:00:00Z"}`)))
+	})
+})
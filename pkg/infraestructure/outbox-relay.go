@@ -0,0 +1,42 @@
+package infraestructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/sirupsen/logrus"
+)
+
+type OutboxRelayImpl struct {
+	outboxService application.OutboxService
+	pollInterval  time.Duration
+}
+
+func NewOutboxRelay(outboxService application.OutboxService, pollInterval time.Duration) *OutboxRelayImpl {
+	return &OutboxRelayImpl{
+		outboxService,
+		pollInterval,
+	}
+}
+
+// Run: publish the pending events of the outbox periodically until the context is done
+//
+// Parameters:
+// ctx: the context to stop the relay
+func (o *OutboxRelayImpl) Run(ctx context.Context) {
+	logrus.Infof("the outbox relay publishes the events every %s", o.pollInterval)
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.outboxService.PublishPendingEvents(); err != nil {
+			logrus.Errorf("Error: publishing the outbox events %s", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Info("the outbox relay was stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
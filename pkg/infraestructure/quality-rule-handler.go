@@ -0,0 +1,173 @@
+package infraestructure
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type QualityRuleRequest struct {
+	Name      string  `json:"name"`
+	MeterID   *int    `json:"meter_id"`
+	Kind      string  `json:"kind" binding:"required"`
+	Field     string  `json:"field"`
+	Value     float64 `json:"value"`
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	Action    string  `json:"action" binding:"required"`
+}
+
+type QualityRuleSerializer struct {
+	ID        uint    `json:"id"`
+	Name      string  `json:"name"`
+	MeterID   *int    `json:"meter_id"`
+	Kind      string  `json:"kind"`
+	Field     string  `json:"field,omitempty"`
+	Value     float64 `json:"value"`
+	StartDate string  `json:"start_date,omitempty"`
+	EndDate   string  `json:"end_date,omitempty"`
+	Action    string  `json:"action"`
+}
+
+func (r QualityRuleRequest) ToQualityRule() (domain.QualityRule, error) {
+	rule := domain.QualityRule{
+		Name:    r.Name,
+		MeterID: r.MeterID,
+		Kind:    r.Kind,
+		Field:   r.Field,
+		Value:   r.Value,
+		Action:  r.Action,
+	}
+	if r.StartDate != "" {
+		startDate, err := domain.StrToDate(r.StartDate)
+		if err != nil {
+			return rule, domain.NewValidationError(constants.ErrorCodeInvalidDate, "start_date", err.Error())
+		}
+		rule.StartDate = &startDate
+	}
+	if r.EndDate != "" {
+		endDate, err := domain.StrToDate(r.EndDate)
+		if err != nil {
+			return rule, domain.NewValidationError(constants.ErrorCodeInvalidDate, "end_date", err.Error())
+		}
+		rule.EndDate = &endDate
+	}
+	return rule, nil
+}
+
+func ToQualityRuleSerializer(rule domain.QualityRule) QualityRuleSerializer {
+	serializer := QualityRuleSerializer{
+		ID:      rule.ID,
+		Name:    rule.Name,
+		MeterID: rule.MeterID,
+		Kind:    rule.Kind,
+		Field:   rule.Field,
+		Value:   rule.Value,
+		Action:  rule.Action,
+	}
+	if rule.StartDate != nil {
+		serializer.StartDate = rule.StartDate.Format(time.RFC3339)
+	}
+	if rule.EndDate != nil {
+		serializer.EndDate = rule.EndDate.Format(time.RFC3339)
+	}
+	return serializer
+}
+
+type QualityRuleHandlerImpl struct {
+	qualityRuleService application.QualityRuleService
+}
+
+func NewQualityRuleHandler(qualityRuleService application.QualityRuleService) *QualityRuleHandlerImpl {
+	return &QualityRuleHandlerImpl{
+		qualityRuleService,
+	}
+}
+
+// Create a data quality rule that is checked in every import
+// @Tags Quality Rules
+// @Summary Create a data quality rule
+// @Description Create a data quality rule that is checked in every import, the kind could be min, max, max_delta, date_range or meter_exists and the action reject, quarantine or flag
+// @Accept  json
+// @Produce  json
+// @Param rule body QualityRuleRequest true "quality rule"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /quality-rules [post]
+func (q *QualityRuleHandlerImpl) CreateQualityRule(c *gin.Context) {
+	var request QualityRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your quality rule", err))
+		return
+	}
+	rule, err := request.ToQualityRule()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your quality rule", err))
+		return
+	}
+	createdRule, err := q.qualityRuleService.CreateQualityRule(rule)
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your quality rule", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the quality rule was successfully created",
+		Status: "SUCCESS",
+		Data:   ToQualityRuleSerializer(*createdRule),
+		Err:    nil,
+	})
+}
+
+// Get all the data quality rules
+// @Tags Quality Rules
+// @Summary Get all the data quality rules
+// @Description Get all the data quality rules
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /quality-rules [get]
+func (q *QualityRuleHandlerImpl) GetQualityRules(c *gin.Context) {
+	rules, err := q.qualityRuleService.GetQualityRules()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []QualityRuleSerializer{}
+	for _, rule := range rules {
+		serializers = append(serializers, ToQualityRuleSerializer(rule))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
+
+// Delete a data quality rule
+// @Tags Quality Rules
+// @Summary Delete a data quality rule
+// @Description Delete a data quality rule
+// @Accept  json
+// @Produce  json
+// @Param id path string true "quality rule id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /quality-rules/{id} [delete]
+func (q *QualityRuleHandlerImpl) DeleteQualityRule(c *gin.Context) {
+	if err := q.qualityRuleService.DeleteQualityRule(c.Param("id")); err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the quality rule was successfully deleted",
+		Status: "SUCCESS",
+		Data:   nil,
+		Err:    nil,
+	})
+}
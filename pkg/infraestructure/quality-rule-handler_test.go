@@ -0,0 +1,68 @@
+package infraestructure
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const QualityRulesPath = "/quality-rules"
+
+var _ = Describe("CreateQualityRule", func() {
+	var (
+		router                 *gin.Engine
+		server                 *ghttp.Server
+		mockQualityRuleService *applicationfakes.FakeQualityRuleService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockQualityRuleService = &applicationfakes.FakeQualityRuleService{}
+		mockHandler := NewQualityRuleHandler(mockQualityRuleService)
+		router.POST(QualityRulesPath, mockHandler.CreateQualityRule)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", QualityRulesPath, router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the action is missing", func() {
+		It("should return an error", func() {
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), QualityRulesPath), "application/json", bytes.NewBufferString(`{"kind":"min","field":"active_energy"}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockQualityRuleService.CreateQualityRuleCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the date is not valid", func() {
+		It("should return an error", func() {
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), QualityRulesPath), "application/json", bytes.NewBufferString(`{"kind":"date_range","start_date":"yesterday","action":"reject"}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockQualityRuleService.CreateQualityRuleCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the rule is valid", func() {
+		It("should create the rule", func() {
+			mockQualityRuleService.CreateQualityRuleReturns(&domain.QualityRule{Kind: "date_range", Action: "reject"}, nil)
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), QualityRulesPath), "application/json", bytes.NewBufferString(`{"kind":"date_range","start_date":"2020-01-01","action":"reject"}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			rule := mockQualityRuleService.CreateQualityRuleArgsForCall(0)
+			Expect(*rule.StartDate).To(Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(rule.EndDate).To(BeNil())
+		})
+	})
+})
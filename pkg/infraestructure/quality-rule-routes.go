@@ -0,0 +1,19 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type QualityRuleRoutes struct {
+	qualityRuleHandler *QualityRuleHandlerImpl
+}
+
+func (ro *QualityRuleRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.POST("/quality-rules", ro.qualityRuleHandler.CreateQualityRule)
+	public.GET("/quality-rules", ro.qualityRuleHandler.GetQualityRules)
+	public.DELETE("/quality-rules/:id", ro.qualityRuleHandler.DeleteQualityRule)
+}
+
+func NewQualityRuleRoutes(qualityRuleHandler *QualityRuleHandlerImpl) *QualityRuleRoutes {
+	return &QualityRuleRoutes{
+		qualityRuleHandler,
+	}
+}
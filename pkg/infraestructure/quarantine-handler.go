@@ -0,0 +1,123 @@
+package infraestructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+)
+
+type UpdateQuarantinedReadingRequest struct {
+	RawLine string `json:"raw_line" binding:"required"`
+}
+
+type QuarantineHandlerImpl struct {
+	quarantineService application.QuarantineService
+}
+
+func NewQuarantineHandler(quarantineService application.QuarantineService) *QuarantineHandlerImpl {
+	return &QuarantineHandlerImpl{
+		quarantineService,
+	}
+}
+
+// Get all the readings that did not pass the data quality rules
+// @Tags Quarantine
+// @Summary Get the quarantined readings
+// @Description Get all the readings that did not pass the data quality rules
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /quarantine [get]
+func (q *QuarantineHandlerImpl) GetQuarantinedReadings(c *gin.Context) {
+	readings, err := q.quarantineService.GetQuarantinedReadings()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   readings,
+		Err:    nil,
+	})
+}
+
+// Release a reviewed reading to the user_consumption database
+// @Tags Quarantine
+// @Summary Release a quarantined reading
+// @Description Save a reviewed reading in the user_consumption database without checking the rules and remove it from the quarantine
+// @Accept  json
+// @Produce  json
+// @Param id path string true "quarantined reading id"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /quarantine/{id}/release [post]
+func (q *QuarantineHandlerImpl) ReleaseQuarantinedReading(c *gin.Context) {
+	reading, err := q.quarantineService.ReleaseQuarantinedReading(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the reading was successfully released",
+		Status: "SUCCESS",
+		Data:   reading,
+		Err:    nil,
+	})
+}
+
+// Fix the csv line of a quarantined reading
+// @Tags Quarantine
+// @Summary Edit a quarantined reading
+// @Description Replace the csv line of a quarantined reading, the line has the same columns as the imported file
+// @Accept  json
+// @Produce  json
+// @Param id path string true "quarantined reading id"
+// @Param reading body UpdateQuarantinedReadingRequest true "fixed csv line"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /quarantine/{id} [put]
+func (q *QuarantineHandlerImpl) UpdateQuarantinedReading(c *gin.Context) {
+	var request UpdateQuarantinedReadingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong", err))
+		return
+	}
+	reading, err := q.quarantineService.UpdateQuarantinedReading(c.Param("id"), request.RawLine)
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the reading was successfully updated",
+		Status: "SUCCESS",
+		Data:   reading,
+		Err:    nil,
+	})
+}
+
+// Re-submit a quarantined reading through the import
+// @Tags Quarantine
+// @Summary Re-submit a quarantined reading
+// @Description Send the csv line of a quarantined reading through the import again checking the data quality rules
+// @Accept  json
+// @Produce  json
+// @Param id path string true "quarantined reading id"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /quarantine/{id}/resubmit [post]
+func (q *QuarantineHandlerImpl) ResubmitQuarantinedReading(c *gin.Context) {
+	summary, err := q.quarantineService.ResubmitQuarantinedReading(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the reading was successfully re-submitted",
+		Status: "SUCCESS",
+		Data:   summary,
+		Err:    nil,
+	})
+}
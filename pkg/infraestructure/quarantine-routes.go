@@ -0,0 +1,20 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type QuarantineRoutes struct {
+	quarantineHandler *QuarantineHandlerImpl
+}
+
+func (ro *QuarantineRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.GET("/quarantine", ro.quarantineHandler.GetQuarantinedReadings)
+	public.PUT("/quarantine/:id", ro.quarantineHandler.UpdateQuarantinedReading)
+	public.POST("/quarantine/:id/release", ro.quarantineHandler.ReleaseQuarantinedReading)
+	public.POST("/quarantine/:id/resubmit", ro.quarantineHandler.ResubmitQuarantinedReading)
+}
+
+func NewQuarantineRoutes(quarantineHandler *QuarantineHandlerImpl) *QuarantineRoutes {
+	return &QuarantineRoutes{
+		quarantineHandler,
+	}
+}
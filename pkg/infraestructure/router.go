@@ -13,10 +13,30 @@ func NewRouter(routes RoutesGroup) Router {
 	public := route.Group("/api/v1")
 	routes.Swagger.RegisterRoutes(public)
 	routes.PowerConsumption.RegisterRoutes(public)
+	routes.LiveConsumption.RegisterRoutes(public)
+	routes.MeterGroup.RegisterRoutes(public)
+	routes.MeterSetting.RegisterRoutes(public)
+	routes.QualityRule.RegisterRoutes(public)
+	routes.Quarantine.RegisterRoutes(public)
+	routes.Import.RegisterRoutes(public)
+	routes.Webhook.RegisterRoutes(public)
+	routes.Alert.RegisterRoutes(public)
+	routes.GraphQL.RegisterRoutes(public)
+	routes.Health.RegisterRoutes(public)
 	return route
 }
 
 type RoutesGroup struct {
 	PowerConsumption *PowerConsumptionRoutes
+	LiveConsumption  *LiveConsumptionRoutes
+	MeterGroup       *MeterGroupRoutes
+	MeterSetting     *MeterSettingRoutes
+	QualityRule      *QualityRuleRoutes
+	Quarantine       *QuarantineRoutes
+	Import           *ImportRoutes
+	Webhook          *WebhookRoutes
+	Alert            *AlertRoutes
+	GraphQL          *GraphQLRoutes
+	Health           *HealthRoutes
 	Swagger          *SwaggerRoutes
 }
@@ -0,0 +1,144 @@
+package infraestructure
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/pkg/sftp"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+type SFTPConnect func() (*sftp.Client, func() error, error)
+
+type SFTPFileSourceImpl struct {
+	connect   SFTPConnect
+	remoteDir string
+}
+
+func NewSFTPFileSource(connect SFTPConnect, remoteDir string) *SFTPFileSourceImpl {
+	return &SFTPFileSourceImpl{
+		connect,
+		remoteDir,
+	}
+}
+
+// NewSFTPConnect: build the connection to an sftp server with user and password
+//
+// Parameters:
+// addr: the host and port of the server
+// user: the user of the server
+// password: the password of the user
+// hostKey: the public key of the server in authorized keys format, blank to not check it
+//
+// Returns:
+// return the function to open a connection or an error if the host key is not valid
+func NewSFTPConnect(addr, user, password, hostKey string) (SFTPConnect, error) {
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if hostKey != "" {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			logrus.Errorf("Error: parsing the sftp host key %s", err.Error())
+			return nil, err
+		}
+		hostKeyCallback = ssh.FixedHostKey(publicKey)
+	} else {
+		logrus.Warnf("the host key of the sftp server %s is not checked", addr)
+	}
+	clientConfig := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.Password(password)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}
+	return func() (*sftp.Client, func() error, error) {
+		sshClient, err := ssh.Dial("tcp", addr, clientConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		client, err := sftp.NewClient(sshClient)
+		if err != nil {
+			sshClient.Close()
+			return nil, nil, err
+		}
+		return client, func() error {
+			client.Close()
+			return sshClient.Close()
+		}, nil
+	}, nil
+}
+
+// FetchFiles: download the csv files of the remote folder to the local folder and remove them from the server, the
+// files are written with a hidden name and renamed when they are complete
+//
+// Parameters:
+// localDir: the folder where the files are downloaded
+//
+// Returns:
+// return the names of the downloaded files or an error if the server could not be read
+func (s *SFTPFileSourceImpl) FetchFiles(localDir string) ([]string, error) {
+	client, closeConnection, err := s.connect()
+	if err != nil {
+		logrus.Errorf("Error: connecting to the sftp server %s", err.Error())
+		return nil, err
+	}
+	defer closeConnection()
+	entries, err := client.ReadDir(s.remoteDir)
+	if err != nil {
+		logrus.Errorf("Error: reading the sftp folder %s %s", s.remoteDir, err.Error())
+		return nil, err
+	}
+	var fileNames []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Mode().IsRegular() || strings.HasPrefix(name, ".") || !strings.EqualFold(filepath.Ext(name), constants.IngestFileExtension) {
+			continue
+		}
+		localName, err := downloadSFTPFile(client, path.Join(s.remoteDir, name), localDir, name)
+		if err != nil {
+			logrus.Errorf("Error: downloading the sftp file %s %s", name, err.Error())
+			continue
+		}
+		if err := client.Remove(path.Join(s.remoteDir, name)); err != nil {
+			logrus.Errorf("Error: removing the sftp file %s %s", name, err.Error())
+		}
+		fileNames = append(fileNames, localName)
+	}
+	if len(fileNames) > 0 {
+		logrus.Infof("%d files were downloaded from the sftp server", len(fileNames))
+	}
+	return fileNames, nil
+}
+
+func downloadSFTPFile(client *sftp.Client, remotePath, localDir, name string) (string, error) {
+	remoteFile, err := client.Open(remotePath)
+	if err != nil {
+		return "", err
+	}
+	defer remoteFile.Close()
+	localName := name
+	if _, err := os.Stat(filepath.Join(localDir, localName)); err == nil {
+		localName = fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405"), name)
+	}
+	partialPath := filepath.Join(localDir, "."+localName+".part")
+	localFile, err := os.Create(partialPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(localFile, remoteFile); err != nil {
+		localFile.Close()
+		os.Remove(partialPath)
+		return "", err
+	}
+	if err := localFile.Close(); err != nil {
+		os.Remove(partialPath)
+		return "", err
+	}
+	return localName, os.Rename(partialPath, filepath.Join(localDir, localName))
+}
@@ -0,0 +1,46 @@
+package infraestructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookDispatcherImpl struct {
+	webhookService application.WebhookService
+	pollInterval   time.Duration
+}
+
+func NewWebhookDispatcher(webhookService application.WebhookService, pollInterval time.Duration) *WebhookDispatcherImpl {
+	return &WebhookDispatcherImpl{
+		webhookService,
+		pollInterval,
+	}
+}
+
+// Run: send the due webhook deliveries periodically until the context is done
+//
+// Parameters:
+// ctx: the context to stop the dispatcher
+func (w *WebhookDispatcherImpl) Run(ctx context.Context) {
+	logrus.Infof("the webhook dispatcher sends the deliveries every %s", w.pollInterval)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		delivered, err := w.webhookService.DeliverDueWebhooks()
+		if err != nil {
+			logrus.Errorf("Error: sending the webhook deliveries %s", err.Error())
+		}
+		if delivered > 0 {
+			logrus.Infof("%d webhook deliveries were sent", delivered)
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Info("the webhook dispatcher was stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
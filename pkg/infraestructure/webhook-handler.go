@@ -0,0 +1,246 @@
+package infraestructure
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+)
+
+type WebhookSubscriptionRequest struct {
+	URL      string   `json:"url" binding:"required"`
+	Secret   string   `json:"secret"`
+	Events   []string `json:"events" binding:"required"`
+	MeterIDs []int    `json:"meter_ids"`
+}
+
+type WebhookSubscriptionStatusRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+type WebhookSubscriptionSerializer struct {
+	ID       uint     `json:"id"`
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret,omitempty"`
+	Events   []string `json:"events"`
+	MeterIDs []int    `json:"meter_ids"`
+	Active   bool     `json:"active"`
+}
+
+type WebhookDeliverySerializer struct {
+	ID            uint   `json:"id"`
+	EventID       uint   `json:"event_id"`
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	StatusCode    int    `json:"status_code,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	DeliveredAt   string `json:"delivered_at,omitempty"`
+}
+
+func (r WebhookSubscriptionRequest) ToWebhookSubscription() domain.WebhookSubscription {
+	var meterIDs []string
+	for _, meterID := range r.MeterIDs {
+		meterIDs = append(meterIDs, strconv.Itoa(meterID))
+	}
+	return domain.WebhookSubscription{
+		URL:      r.URL,
+		Secret:   r.Secret,
+		Events:   strings.Join(r.Events, ","),
+		MeterIDs: strings.Join(meterIDs, ","),
+	}
+}
+
+func ToWebhookSubscriptionSerializer(subscription domain.WebhookSubscription) WebhookSubscriptionSerializer {
+	serializer := WebhookSubscriptionSerializer{
+		ID:       subscription.ID,
+		URL:      subscription.URL,
+		Secret:   subscription.Secret,
+		Events:   strings.Split(subscription.Events, ","),
+		MeterIDs: []int{},
+		Active:   subscription.Active,
+	}
+	if subscription.MeterIDs != "" {
+		for _, meterID := range strings.Split(subscription.MeterIDs, ",") {
+			numberMeterID, err := strconv.Atoi(meterID)
+			if err == nil {
+				serializer.MeterIDs = append(serializer.MeterIDs, numberMeterID)
+			}
+		}
+	}
+	return serializer
+}
+
+func ToWebhookDeliverySerializer(delivery domain.WebhookDelivery) WebhookDeliverySerializer {
+	serializer := WebhookDeliverySerializer{
+		ID:         delivery.ID,
+		EventID:    delivery.EventID,
+		Kind:       delivery.Kind,
+		Status:     delivery.Status,
+		Attempts:   delivery.Attempts,
+		StatusCode: delivery.StatusCode,
+		LastError:  delivery.LastError,
+		CreatedAt:  delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.NextAttemptAt != nil {
+		serializer.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		serializer.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return serializer
+}
+
+type WebhookHandlerImpl struct {
+	webhookService application.WebhookService
+}
+
+func NewWebhookHandler(webhookService application.WebhookService) *WebhookHandlerImpl {
+	return &WebhookHandlerImpl{
+		webhookService,
+	}
+}
+
+// Subscribe a url to the events of the service
+// @Tags Webhooks
+// @Summary Subscribe a url to the events of the service
+// @Description Subscribe a url to the import_completed, anomaly_detected or threshold_exceeded events of some meters or of all the meters when the meters are blank, the payloads are signed with HMAC SHA256 in the X-Webhook-Signature header over the X-Webhook-Timestamp header, a dot and the body, the secret is only returned here
+// @Accept  json
+// @Produce  json
+// @Param subscription body WebhookSubscriptionRequest true "webhook subscription"
+// @Success 201 {object} Response
+// @Failure 400 {object} Response
+// @Router /webhooks [post]
+func (w *WebhookHandlerImpl) CreateWebhookSubscription(c *gin.Context) {
+	var request WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your webhook subscription", err))
+		return
+	}
+	subscription, err := w.webhookService.CreateWebhookSubscription(request.ToWebhookSubscription())
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your webhook subscription", err))
+		return
+	}
+	c.JSON(http.StatusCreated, Response{
+		Msg:    "the webhook subscription was successfully created",
+		Status: "SUCCESS",
+		Data:   ToWebhookSubscriptionSerializer(*subscription),
+		Err:    nil,
+	})
+}
+
+// Get all the webhook subscriptions
+// @Tags Webhooks
+// @Summary Get all the webhook subscriptions
+// @Description Get all the webhook subscriptions without their secrets
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /webhooks [get]
+func (w *WebhookHandlerImpl) GetWebhookSubscriptions(c *gin.Context) {
+	subscriptions, err := w.webhookService.GetWebhookSubscriptions()
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []WebhookSubscriptionSerializer{}
+	for _, subscription := range subscriptions {
+		serializers = append(serializers, ToWebhookSubscriptionSerializer(subscription))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
+
+// Activate or deactivate a webhook subscription
+// @Tags Webhooks
+// @Summary Activate or deactivate a webhook subscription
+// @Description Activate or deactivate a webhook subscription, an inactive subscription does not receive new events and its pending deliveries are not sent
+// @Accept  json
+// @Produce  json
+// @Param id path string true "webhook subscription id"
+// @Param status body WebhookSubscriptionStatusRequest true "webhook subscription status"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Router /webhooks/{id} [patch]
+func (w *WebhookHandlerImpl) UpdateWebhookSubscriptionActive(c *gin.Context) {
+	var request WebhookSubscriptionStatusRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(NewBindingErrorResponse("Something goes wrong with your webhook subscription", err))
+		return
+	}
+	subscription, err := w.webhookService.UpdateWebhookSubscriptionActive(c.Param("id"), *request.Active)
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong with your webhook subscription", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the webhook subscription was successfully updated",
+		Status: "SUCCESS",
+		Data:   ToWebhookSubscriptionSerializer(*subscription),
+		Err:    nil,
+	})
+}
+
+// Delete a webhook subscription
+// @Tags Webhooks
+// @Summary Delete a webhook subscription
+// @Description Delete a webhook subscription, its pending deliveries are not sent anymore
+// @Accept  json
+// @Produce  json
+// @Param id path string true "webhook subscription id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /webhooks/{id} [delete]
+func (w *WebhookHandlerImpl) DeleteWebhookSubscription(c *gin.Context) {
+	if err := w.webhookService.DeleteWebhookSubscription(c.Param("id")); err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "the webhook subscription was successfully deleted",
+		Status: "SUCCESS",
+		Data:   nil,
+		Err:    nil,
+	})
+}
+
+// Get the delivery log of a webhook subscription
+// @Tags Webhooks
+// @Summary Get the delivery log of a webhook subscription
+// @Description Get the deliveries of a webhook subscription with their status, attempts and last error, the newest first
+// @Accept  json
+// @Produce  json
+// @Param id path string true "webhook subscription id"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Router /webhooks/{id}/deliveries [get]
+func (w *WebhookHandlerImpl) GetWebhookDeliveries(c *gin.Context) {
+	deliveries, err := w.webhookService.GetWebhookDeliveries(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(NewErrorResponse("Something goes wrong", err))
+		return
+	}
+	serializers := []WebhookDeliverySerializer{}
+	for _, delivery := range deliveries {
+		serializers = append(serializers, ToWebhookDeliverySerializer(delivery))
+	}
+	c.JSON(http.StatusOK, Response{
+		Msg:    "information successfully brought",
+		Status: "SUCCESS",
+		Data:   serializers,
+		Err:    nil,
+	})
+}
@@ -0,0 +1,114 @@
+package infraestructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffleon1/consumption-ms/pkg/application/applicationfakes"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"gorm.io/gorm"
+)
+
+const WebhooksPath = "/webhooks"
+
+var _ = Describe("WebhookHandler", func() {
+	var (
+		router             *gin.Engine
+		server             *ghttp.Server
+		mockWebhookService *applicationfakes.FakeWebhookService
+	)
+
+	BeforeEach(func() {
+		router = gin.Default()
+		mockWebhookService = &applicationfakes.FakeWebhookService{}
+		mockHandler := NewWebhookHandler(mockWebhookService)
+		router.POST(WebhooksPath, mockHandler.CreateWebhookSubscription)
+		router.GET("/webhooks/:id/deliveries", mockHandler.GetWebhookDeliveries)
+		router.PATCH("/webhooks/:id", mockHandler.UpdateWebhookSubscriptionActive)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", WebhooksPath, router.ServeHTTP)
+		server.RouteToHandler("GET", "/webhooks/1/deliveries", router.ServeHTTP)
+		server.RouteToHandler("PATCH", "/webhooks/1", router.ServeHTTP)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the events are missing", func() {
+		It("should return an error", func() {
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), WebhooksPath), "application/json", bytes.NewBufferString(`{"url":"https://partner.test/hook"}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockWebhookService.CreateWebhookSubscriptionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the subscription is valid", func() {
+		It("should create the subscription and return its secret", func() {
+			mockWebhookService.CreateWebhookSubscriptionReturns(&domain.WebhookSubscription{Model: gorm.Model{ID: 1}, URL: "https://partner.test/hook", Secret: "s3cret", Events: "import_completed,anomaly_detected", MeterIDs: "1,2"}, nil)
+			resp, err := http.Post(fmt.Sprintf("%s%s", server.URL(), WebhooksPath), "application/json", bytes.NewBufferString(`{"url":"https://partner.test/hook","events":["import_completed","anomaly_detected"],"meter_ids":[1,2]}`))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			subscription := mockWebhookService.CreateWebhookSubscriptionArgsForCall(0)
+			Expect(subscription.Events).To(Equal("import_completed,anomaly_detected"))
+			Expect(subscription.MeterIDs).To(Equal("1,2"))
+			var body struct {
+				Data WebhookSubscriptionSerializer `json:"data"`
+			}
+			Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+			Expect(body.Data.Secret).To(Equal("s3cret"))
+			Expect(body.Data.MeterIDs).To(Equal([]int{1, 2}))
+		})
+	})
+
+	Context("when the delivery log is requested", func() {
+		It("should return the deliveries of the subscription", func() {
+			mockWebhookService.GetWebhookDeliveriesReturns([]domain.WebhookDelivery{{Model: gorm.Model{ID: 4}, EventID: 9, Status: "failed", Attempts: 8, LastError: "timeout"}}, nil)
+			resp, err := http.Get(fmt.Sprintf("%s/webhooks/1/deliveries", server.URL()))
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(mockWebhookService.GetWebhookDeliveriesArgsForCall(0)).To(Equal("1"))
+			var body struct {
+				Data []WebhookDeliverySerializer `json:"data"`
+			}
+			Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+			Expect(body.Data).To(HaveLen(1))
+			Expect(body.Data[0].Attempts).To(Equal(8))
+		})
+	})
+
+	Context("when the subscription is deactivated", func() {
+		It("should update the subscription", func() {
+			mockWebhookService.UpdateWebhookSubscriptionActiveReturns(&domain.WebhookSubscription{Model: gorm.Model{ID: 1}, URL: "https://partner.test/hook", Events: "import_completed"}, nil)
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/webhooks/1", server.URL()), bytes.NewBufferString(`{"active":false}`))
+			Expect(err).To(BeNil())
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			subscriptionID, active := mockWebhookService.UpdateWebhookSubscriptionActiveArgsForCall(0)
+			Expect(subscriptionID).To(Equal("1"))
+			Expect(active).To(BeFalse())
+			var body struct {
+				Data WebhookSubscriptionSerializer `json:"data"`
+			}
+			Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+			Expect(body.Data.Active).To(BeFalse())
+		})
+
+		It("should return an error when the status is missing", func() {
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/webhooks/1", server.URL()), bytes.NewBufferString(`{}`))
+			Expect(err).To(BeNil())
+			resp, err := http.DefaultClient.Do(request)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(mockWebhookService.UpdateWebhookSubscriptionActiveCallCount()).To(Equal(0))
+		})
+	})
+})
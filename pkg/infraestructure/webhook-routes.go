@@ -0,0 +1,21 @@
+package infraestructure
+
+import "github.com/gin-gonic/gin"
+
+type WebhookRoutes struct {
+	webhookHandler *WebhookHandlerImpl
+}
+
+func (ro *WebhookRoutes) RegisterRoutes(public *gin.RouterGroup) {
+	public.POST("/webhooks", ro.webhookHandler.CreateWebhookSubscription)
+	public.GET("/webhooks", ro.webhookHandler.GetWebhookSubscriptions)
+	public.PATCH("/webhooks/:id", ro.webhookHandler.UpdateWebhookSubscriptionActive)
+	public.DELETE("/webhooks/:id", ro.webhookHandler.DeleteWebhookSubscription)
+	public.GET("/webhooks/:id/deliveries", ro.webhookHandler.GetWebhookDeliveries)
+}
+
+func NewWebhookRoutes(webhookHandler *WebhookHandlerImpl) *WebhookRoutes {
+	return &WebhookRoutes{
+		webhookHandler,
+	}
+}
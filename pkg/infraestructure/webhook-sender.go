@@ -0,0 +1,45 @@
+package infraestructure
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+type HTTPWebhookSenderImpl struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(timeout time.Duration) *HTTPWebhookSenderImpl {
+	return &HTTPWebhookSenderImpl{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send: post a json payload to a webhook with the given headers
+//
+// Parameters:
+// url: the url of the webhook
+// headers: the headers of the request
+// body: the payload
+//
+// Returns:
+// return the status code of the webhook or an error if the webhook could not be reached
+func (h *HTTPWebhookSenderImpl) Send(url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
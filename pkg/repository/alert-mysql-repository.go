@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlertMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAlertMySQLRepository(db *gorm.DB) domain.AlertRepository {
+	return &AlertMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateAlertRule: create an alert rule
+//
+// Parámeters:
+// rule - the alert rule.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (a *AlertMySQLRepositoryImpl) CreateAlertRule(rule *domain.AlertRule) error {
+	err := a.db.Create(rule).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the alert rule %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetAlertRules: get all the alert rules
+//
+// Returns:
+// return all the alert rules
+func (a *AlertMySQLRepositoryImpl) GetAlertRules() ([]domain.AlertRule, error) {
+	var rules []domain.AlertRule
+	err := a.db.Order("id").Find(&rules).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the alert rules %s", err.Error())
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteAlertRule: delete an alert rule, its alerts are kept
+//
+// Parámeters:
+// ruleID - the id of the rule.
+//
+// Returns:
+// return an error if something goes wrong in the deletion of nil if it's not
+func (a *AlertMySQLRepositoryImpl) DeleteAlertRule(ruleID uint) error {
+	err := a.db.Delete(&domain.AlertRule{}, ruleID).Error
+	if err != nil {
+		logrus.Errorf("Error: deleting the alert rule %d %s", ruleID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// CreateAlert: create a triggered alert and its threshold exceeded event in the outbox inside a transaction, a rule
+// only triggers one alert by period
+//
+// Parámeters:
+// alert - the alert.
+//
+// Returns:
+// return true if the alert was created or false if the rule already triggered an alert in the period
+func (a *AlertMySQLRepositoryImpl) CreateAlert(alert *domain.Alert) (bool, error) {
+	created := false
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+		if result.Error != nil {
+			logrus.Errorf("Error: creating the alert of the rule %d %s", alert.RuleID, result.Error.Error())
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		event, err := domain.NewThresholdExceededEvent(*alert)
+		if err != nil {
+			logrus.Errorf("Error: building the threshold exceeded event of the alert %d %s", alert.ID, err.Error())
+			return err
+		}
+		if err := tx.Create(event).Error; err != nil {
+			logrus.Errorf("Error: inserting the event of the alert %d in the outbox %s", alert.ID, err.Error())
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// GetAlertPeriodStarts: get the first day of the periods where a rule already triggered an alert
+//
+// Parámeters:
+// ruleID - the id of the rule.
+// startDate - the first period start to look for.
+// endDate - the last period start to look for.
+//
+// Returns:
+// return the first day of the periods with an alert of the rule
+func (a *AlertMySQLRepositoryImpl) GetAlertPeriodStarts(ruleID uint, startDate, endDate time.Time) ([]time.Time, error) {
+	var periodStarts []time.Time
+	err := a.db.Model(&domain.Alert{}).Where("rule_id = ? AND period_start BETWEEN ? AND ?", ruleID, startDate, endDate).Pluck("period_start", &periodStarts).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the periods of the alerts of the rule %d %s", ruleID, err.Error())
+		return nil, err
+	}
+	return periodStarts, nil
+}
+
+// GetAlerts: get all the triggered alerts, the newest first
+//
+// Returns:
+// return all the alerts
+func (a *AlertMySQLRepositoryImpl) GetAlerts() ([]domain.Alert, error) {
+	var alerts []domain.Alert
+	err := a.db.Order("id desc").Find(&alerts).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the alerts %s", err.Error())
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (a *AlertMySQLRepositoryImpl) ModelMigration() error {
+	return a.db.AutoMigrate(&domain.AlertRule{}, &domain.Alert{})
+}
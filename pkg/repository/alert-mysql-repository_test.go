@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("CreateAlert", func() {
+	var (
+		mockDB         *gorm.DB
+		mock           sqlmock.Sqlmock
+		mockDb         *sql.DB
+		repositoryImpl *AlertMySQLRepositoryImpl
+		alert          *domain.Alert
+		meterID        = 1
+		err            error
+	)
+
+	BeforeEach(func() {
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err = gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		repositoryImpl = &AlertMySQLRepositoryImpl{
+			db: mockDB,
+		}
+		alert = &domain.Alert{RuleID: 3, MeterID: &meterID, Metric: "active_energy", PeriodKind: "daily", PeriodStart: time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC), Value: 70, Threshold: 50}
+	})
+
+	It("should write the alert and its event in the same transaction", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `alerts`").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "threshold_exceeded", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		created, err := repositoryImpl.CreateAlert(alert)
+
+		Expect(err).To(BeNil())
+		Expect(created).To(BeTrue())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not write the event of an alert already triggered in the period", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `alerts`").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		created, err := repositoryImpl.CreateAlert(alert)
+
+		Expect(err).To(BeNil())
+		Expect(created).To(BeFalse())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should roll back the alert when its event could not be written", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `alerts`").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnError(errors.New("Error inserting the event"))
+		mock.ExpectRollback()
+
+		created, err := repositoryImpl.CreateAlert(alert)
+
+		Expect(err).ToNot(BeNil())
+		Expect(created).To(BeFalse())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
+
+var _ = Describe("GetAlertPeriodStarts", func() {
+	var (
+		mock           sqlmock.Sqlmock
+		repositoryImpl *AlertMySQLRepositoryImpl
+	)
+
+	BeforeEach(func() {
+		var mockDb *sql.DB
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		repositoryImpl = &AlertMySQLRepositoryImpl{
+			db: mockDB,
+		}
+	})
+
+	It("should get the periods where the rule triggered an alert", func() {
+		startDate := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("SELECT `period_start` FROM `alerts` WHERE \\(rule_id = \\? AND period_start BETWEEN \\? AND \\?\\)").WithArgs(3, startDate, endDate).WillReturnRows(sqlmock.NewRows([]string{"period_start"}).AddRow(endDate))
+
+		periodStarts, err := repositoryImpl.GetAlertPeriodStarts(3, startDate, endDate)
+
+		Expect(err).To(BeNil())
+		Expect(periodStarts).To(Equal([]time.Time{endDate}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -1,9 +1,15 @@
 package repositories
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"mime/multipart"
+	"strings"
 
 	"github.com/gocarina/gocsv"
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
 	"github.com/jeffleon1/consumption-ms/pkg/domain"
 	"github.com/sirupsen/logrus"
 )
@@ -25,8 +31,67 @@ func (c *CSVConsumptionRepositoryImpl) ConvertCSVToStruct(file *multipart.File)
 	var userConsumption []*domain.CSVUserConsumption
 	if err := gocsv.UnmarshalMultipartFile(file, &userConsumption); err != nil {
 		logrus.Errorf("Error while converting from csv to structure %s", err.Error())
-		return nil, err
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "file", fmt.Sprintf("Error: the csv file could not be read %s", err.Error()))
 	}
 	logrus.Info("csv to struct conversion successfully performed")
 	return userConsumption, nil
 }
+
+// ConvertCSVLineToStruct: converts a line of a csv file without the header in a struct
+//
+// Parámeters:
+// line - the line to convert in struct.
+//
+// Returns:
+// The struct that repesents the line
+func (c *CSVConsumptionRepositoryImpl) ConvertCSVLineToStruct(line string) (*domain.CSVUserConsumption, error) {
+	header, err := gocsv.MarshalString([]*domain.CSVUserConsumption{})
+	if err != nil {
+		return nil, err
+	}
+	var userConsumption []*domain.CSVUserConsumption
+	if err := gocsv.UnmarshalString(header+strings.TrimSpace(line)+"\n", &userConsumption); err != nil {
+		logrus.Errorf("Error while converting from csv line to structure %s", err.Error())
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "raw_line", fmt.Sprintf("Error: the csv line could not be read %s", err.Error()))
+	}
+	if len(userConsumption) != 1 {
+		return nil, domain.NewValidationError(constants.ErrorCodeInvalidParam, "raw_line", fmt.Sprintf("Error: the csv line must have only one record %d", len(userConsumption)))
+	}
+	return userConsumption[0], nil
+}
+
+// ConvertStructToCSVLine: converts a struct in a line of a csv file without the header
+//
+// Parámeters:
+// record - the struct to convert in a line.
+//
+// Returns:
+// The csv line that repesents the struct
+func (c *CSVConsumptionRepositoryImpl) ConvertStructToCSVLine(record *domain.CSVUserConsumption) (string, error) {
+	line, err := gocsv.MarshalStringWithoutHeaders([]*domain.CSVUserConsumption{record})
+	if err != nil {
+		logrus.Errorf("Error while converting from structure to csv line %s", err.Error())
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// FileChecksum: compute the sha256 hash of the content of a file and go back to the start of the file
+//
+// Parámeters:
+// file - the file to hash.
+//
+// Returns:
+// The hash of the file in hexadecimal
+func (c *CSVConsumptionRepositoryImpl) FileChecksum(file *multipart.File) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, *file); err != nil {
+		logrus.Errorf("Error while reading the file to hash %s", err.Error())
+		return "", err
+	}
+	if _, err := (*file).Seek(0, io.SeekStart); err != nil {
+		logrus.Errorf("Error while going back to the start of the file %s", err.Error())
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
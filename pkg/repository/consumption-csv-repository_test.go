@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSVConsumptionRepository lines", func() {
+	var repository domain.CSVPowerConsumptionRepository
+
+	BeforeEach(func() {
+		repository = NewCSVConsumptionRepository()
+	})
+
+	It("should convert a record in a line and back", func() {
+		record := &domain.CSVUserConsumption{ID: "1", MeterID: "one", ActiveEnergy: 10.5, Date: "2023-08-01"}
+
+		line, err := repository.ConvertStructToCSVLine(record)
+		Expect(err).To(BeNil())
+		Expect(line).To(Equal("1,one,10.5,0,0,0,2023-08-01"))
+
+		parsed, err := repository.ConvertCSVLineToStruct(line)
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(record))
+	})
+
+	It("should return an error when the line has more than one record", func() {
+		_, err := repository.ConvertCSVLineToStruct("1,1,1,0,0,0,2023-08-01\n2,1,1,0,0,0,2023-08-02")
+		Expect(err).To(HaveOccurred())
+	})
+})
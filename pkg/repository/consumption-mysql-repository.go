@@ -1,12 +1,14 @@
 package repositories
 
 import (
+	"fmt"
 	"math"
 	"time"
 
 	"github.com/jeffleon1/consumption-ms/pkg/domain"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MySQLPowerConsumptionRepositoryImpl struct {
@@ -40,34 +42,283 @@ func (p *MySQLPowerConsumptionRepositoryImpl) GetConsumptionByMeterIDAndWindowTi
 
 }
 
-// CreatePowerConsumptionRecords: create a records for user power consumption
+// GetConsumptionByMeterIDsAndWindowTime: get the records of several meters in a window time with only one query
+//
+// Parámeters:
+// startDate - the date to start findings.
+// endDate - the date to end findings.
+// meterIDs - the meter ids to find the records.
+//
+// Returns:
+// return the records ordered by meter and date
+func (p *MySQLPowerConsumptionRepositoryImpl) GetConsumptionByMeterIDsAndWindowTime(startDate, endDate time.Time, meterIDs []int) ([]domain.UserConsumption, error) {
+	var userPowerConsumption []domain.UserConsumption
+	err := p.db.Where("date BETWEEN ? AND ? AND meter_id IN ?", startDate, endDate, meterIDs).Order("meter_id, date").Find(&userPowerConsumption).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the records of the meters %v %s", meterIDs, err.Error())
+		return nil, err
+	}
+	return userPowerConsumption, nil
+}
+
+// GetLastConsumptionBeforeDate: get the last record of a meter before a date
+//
+// Parámeters:
+// date - the date to find the record.
+// meterID - the meter id to find the record.
+//
+// Returns:
+// return the record or nil if the meter does not have records before the date
+func (p *MySQLPowerConsumptionRepositoryImpl) GetLastConsumptionBeforeDate(date time.Time, meterID int) (*domain.UserConsumption, error) {
+	var userPowerConsumption []domain.UserConsumption
+	err := p.db.Where("date < ? AND meter_id=?", date, meterID).Order("date desc").Limit(1).Find(&userPowerConsumption).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the last record of the meter %d %s", meterID, err.Error())
+		return nil, err
+	}
+	if len(userPowerConsumption) == 0 {
+		return nil, nil
+	}
+	return &userPowerConsumption[0], nil
+}
+
+// CreatePowerConsumptionRecords: create a records for user power consumption, the readings imported event and the
+// anomaly detected event of the flagged readings are written in the outbox in the same transaction to publish them
+// only when the records are saved, a record that already exists for the same meter and date is skipped
 //
 // Parámeters:
 // usersPowerConsumption - user power consumption domain.
 //
 // Returns:
-// return an error if something goes wrong in the insertion of nil if it's not
-func (p *MySQLPowerConsumptionRepositoryImpl) CreatePowerConsumptionRecords(usersPowerConsumption []*domain.UserConsumption) error {
+// return the records inserted or an error if something goes wrong in the insertion
+func (p *MySQLPowerConsumptionRepositoryImpl) CreatePowerConsumptionRecords(usersPowerConsumption []*domain.UserConsumption) ([]*domain.UserConsumption, error) {
+	var insertedRecords []*domain.UserConsumption
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		insertedRecords, err = createPowerConsumptionRecords(tx, usersPowerConsumption)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("the Insertion was succesfully in user_consumption database, %d records were inserted", len(insertedRecords))
+	return insertedRecords, nil
+}
+
+// CreateImportRecords: create the records and the quarantined readings of an import and complete the import in only
+// one transaction, so an import is never left in progress with its records saved and the import completed event is
+// written with them, the records skipped because they already exist are taken out of the imported count of the
+// import and its event
+//
+// Parámeters:
+// usersPowerConsumption - the records accepted by the data quality rules.
+// quarantinedReadings - the records quarantined by the data quality rules.
+// importRecord - the import with its status and its row counts.
+// meterIDs - the meters of the import for the import completed event.
+//
+// Returns:
+// return the records inserted or an error if something goes wrong in the insertion
+func (p *MySQLPowerConsumptionRepositoryImpl) CreateImportRecords(usersPowerConsumption []*domain.UserConsumption, quarantinedReadings []*domain.QuarantinedReading, importRecord *domain.Import, meterIDs []int) ([]*domain.UserConsumption, error) {
+	var insertedRecords []*domain.UserConsumption
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if len(quarantinedReadings) > 0 {
+			if err := tx.Create(&quarantinedReadings).Error; err != nil {
+				logrus.Errorf("Error inserting the quarantined readings: %s", err.Error())
+				return err
+			}
+		}
+		if len(usersPowerConsumption) > 0 {
+			var err error
+			insertedRecords, err = createPowerConsumptionRecords(tx, usersPowerConsumption)
+			if err != nil {
+				return err
+			}
+		}
+		importRecord.Skipped = len(usersPowerConsumption) - len(insertedRecords)
+		importRecord.Imported -= importRecord.Skipped
+		importEvent, err := domain.NewImportCompletedEvent(importRecord, meterIDs)
+		if err != nil {
+			logrus.Errorf("Error building the import completed event: %s", err.Error())
+			return err
+		}
+		if err := tx.Save(importRecord).Error; err != nil {
+			logrus.Errorf("Error completing the import: %s", err.Error())
+			return err
+		}
+		if err := tx.Create(importEvent).Error; err != nil {
+			logrus.Errorf("Error inserting the import event in the outbox: %s", err.Error())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.Errorf("Error: the records of the import %d were not saved %s", importRecord.ID, err.Error())
+		return nil, err
+	}
+	logrus.Infof("the import %d was saved with %d records, %d were skipped", importRecord.ID, len(insertedRecords), importRecord.Skipped)
+	return insertedRecords, nil
+}
+
+// CreateIngestedRecords: create the records and the quarantined readings of an ingestion without import in only one
+// transaction, so the quarantined readings are not written again when the ingestion is retried after a failure
+//
+// Parámeters:
+// usersPowerConsumption - the records accepted by the data quality rules.
+// quarantinedReadings - the records quarantined by the data quality rules.
+//
+// Returns:
+// return the records inserted or an error if something goes wrong in the insertion
+func (p *MySQLPowerConsumptionRepositoryImpl) CreateIngestedRecords(usersPowerConsumption []*domain.UserConsumption, quarantinedReadings []*domain.QuarantinedReading) ([]*domain.UserConsumption, error) {
+	var insertedRecords []*domain.UserConsumption
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if len(quarantinedReadings) > 0 {
+			if err := tx.Create(&quarantinedReadings).Error; err != nil {
+				logrus.Errorf("Error inserting the quarantined readings: %s", err.Error())
+				return err
+			}
+		}
+		if len(usersPowerConsumption) == 0 {
+			return nil
+		}
+		var err error
+		insertedRecords, err = createPowerConsumptionRecords(tx, usersPowerConsumption)
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("Error: the ingested records were not saved %s", err.Error())
+		return nil, err
+	}
+	logrus.Infof("the ingestion was saved with %d records and %d quarantined readings", len(insertedRecords), len(quarantinedReadings))
+	return insertedRecords, nil
+}
+
+// createPowerConsumptionRecords: insert the records by lots and their events in the outbox inside a transaction, the
+// records that already exist for the same meter and date are locked and skipped before the insertion, so only the
+// records inserted are returned and written in the events
+func createPowerConsumptionRecords(tx *gorm.DB, usersPowerConsumption []*domain.UserConsumption) ([]*domain.UserConsumption, error) {
 	recordSize := len(usersPowerConsumption)
 	recordLimit := 4000
 	lotsNumber := int(math.Ceil(float64(recordSize) / float64(recordLimit)))
+	seenRecords := make(map[string]bool)
+	var insertedRecords []*domain.UserConsumption
 
 	for i := 0; i < lotsNumber; i++ {
 		begin := i * recordLimit
 		end := int(math.Min(float64((i+1)*recordLimit), float64(recordSize)))
-		lot := usersPowerConsumption[begin:end]
 		logrus.Info("Lot ", begin, end)
-		errors := p.db.Create(&lot).Error
-		if errors != nil {
-			logrus.Errorf("Error inserting in the lot: %s", errors.Error())
-			return errors
+		lot, err := newRecords(tx, usersPowerConsumption[begin:end], seenRecords)
+		if err != nil {
+			return nil, err
 		}
+		if skipped := end - begin - len(lot); skipped > 0 {
+			logrus.Warnf("%d records of the lot already exist for the same meter and date", skipped)
+		}
+		if len(lot) == 0 {
+			continue
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lot)
+		if result.Error != nil {
+			logrus.Errorf("Error inserting in the lot: %s", result.Error.Error())
+			return nil, result.Error
+		}
+		insertedRecords = append(insertedRecords, lot...)
+	}
+	if len(insertedRecords) == 0 {
+		return nil, nil
+	}
+	event, err := domain.NewReadingsImportedEvent(insertedRecords)
+	if err != nil {
+		logrus.Errorf("Error building the readings imported event: %s", err.Error())
+		return nil, err
+	}
+	anomalyEvent, err := domain.NewAnomalyDetectedEvent(insertedRecords)
+	if err != nil {
+		logrus.Errorf("Error building the anomaly detected event: %s", err.Error())
+		return nil, err
+	}
+	if errors := tx.Create(event).Error; errors != nil {
+		logrus.Errorf("Error inserting the event in the outbox: %s", errors.Error())
+		return nil, errors
+	}
+	if anomalyEvent != nil {
+		if errors := tx.Create(anomalyEvent).Error; errors != nil {
+			logrus.Errorf("Error inserting the anomaly event in the outbox: %s", errors.Error())
+			return nil, errors
+		}
+	}
+	return insertedRecords, nil
+}
 
-		time.Sleep(500 * time.Millisecond)
+// newRecords: take out of a lot the records that already exist for the same meter and date or that are repeated in
+// the insertion, the existing records are read with a lock so another writer could not insert them meanwhile
+func newRecords(tx *gorm.DB, lot []*domain.UserConsumption, seenRecords map[string]bool) ([]*domain.UserConsumption, error) {
+	var meterIDs []int
+	var dates []time.Time
+	for _, record := range lot {
+		meterIDs = append(meterIDs, record.MeterID)
+		dates = append(dates, record.Date)
 	}
-	logrus.Info("the Insertion was succesfully in user_consumption database")
-	return nil
+	var existingRecords []domain.UserConsumption
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("meter_id", "date").Where("meter_id IN ? AND date IN ?", meterIDs, dates).Find(&existingRecords).Error
+	if err != nil {
+		logrus.Errorf("Error getting the existing records of the lot: %s", err.Error())
+		return nil, err
+	}
+	for _, record := range existingRecords {
+		seenRecords[recordKey(record.MeterID, record.Date)] = true
+	}
+	var records []*domain.UserConsumption
+	for _, record := range lot {
+		key := recordKey(record.MeterID, record.Date)
+		if seenRecords[key] {
+			continue
+		}
+		seenRecords[key] = true
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func recordKey(meterID int, date time.Time) string {
+	return fmt.Sprintf("%d-%d", meterID, date.UTC().UnixNano())
+}
+
+// GetConsumptionByMeterIDAndDates: get the records of a meter at the given dates
+//
+// Parámeters:
+// meterID - the id of the meter.
+// dates - the dates of the records.
+//
+// Returns:
+// return the records of the meter that exist at the dates
+func (p *MySQLPowerConsumptionRepositoryImpl) GetConsumptionByMeterIDAndDates(meterID int, dates []time.Time) ([]domain.UserConsumption, error) {
+	var userPowerConsumption []domain.UserConsumption
+	if len(dates) == 0 {
+		return userPowerConsumption, nil
+	}
+	err := p.db.Where("meter_id = ? AND date IN ?", meterID, dates).Find(&userPowerConsumption).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the records of the meter %d by dates %s", meterID, err.Error())
+		return nil, err
+	}
+	return userPowerConsumption, nil
+}
 
+// GetConsumptionByImportID: get all the records inserted by an import
+//
+// Parámeters:
+// importID - the id of the import.
+//
+// Returns:
+// return the records of the import ordered by date
+func (p *MySQLPowerConsumptionRepositoryImpl) GetConsumptionByImportID(importID uint) ([]domain.UserConsumption, error) {
+	var userConsumption []domain.UserConsumption
+	err := p.db.Where("import_id = ?", importID).Order("date").Find(&userConsumption).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the records of the import %d %s", importID, err.Error())
+		return nil, err
+	}
+	return userConsumption, nil
 }
 
 // ModelMigration: do the model migration to gorm
@@ -75,5 +326,27 @@ func (p *MySQLPowerConsumptionRepositoryImpl) CreatePowerConsumptionRecords(user
 // Returns:
 // return an error if something goes wrong in the migration of nil if it's not
 func (p *MySQLPowerConsumptionRepositoryImpl) ModelMigration() error {
+	if err := p.removeDuplicatedRecords(); err != nil {
+		return err
+	}
 	return p.db.AutoMigrate(&domain.UserConsumption{})
 }
+
+// removeDuplicatedRecords: keep only the first record of every meter and date before the unique index of the meter
+// and the date is created, the tables created before the index could have the same reading several times
+//
+// Returns:
+// return an error if the duplicated records could not be deleted
+func (p *MySQLPowerConsumptionRepositoryImpl) removeDuplicatedRecords() error {
+	migrator := p.db.Migrator()
+	if !migrator.HasTable(&domain.UserConsumption{}) || migrator.HasIndex(&domain.UserConsumption{}, "idx_user_consumption_meter_date") {
+		return nil
+	}
+	result := p.db.Exec("DELETE newer FROM user_consumptions newer JOIN user_consumptions older ON newer.meter_id = older.meter_id AND newer.date = older.date AND newer.id > older.id")
+	if result.Error != nil {
+		logrus.Errorf("Error deleting the duplicated records: %s", result.Error.Error())
+		return result.Error
+	}
+	logrus.Infof("%d duplicated records were deleted before creating the unique index of the meter and the date", result.RowsAffected)
+	return nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
 	"strconv"
 	"time"
 
@@ -94,6 +95,58 @@ var _ = Describe("GetConsumptionByMeterIDAndWindowTime", func() {
 	})
 })
 
+var _ = Describe("GetConsumptionByMeterIDsAndWindowTime", func() {
+	var (
+		mockDB         *gorm.DB
+		mock           sqlmock.Sqlmock
+		mockDb         *sql.DB
+		repositoryImpl *MySQLPowerConsumptionRepositoryImpl
+		err            error
+	)
+
+	BeforeEach(func() {
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err = gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+
+		repositoryImpl = &MySQLPowerConsumptionRepositoryImpl{
+			db: mockDB,
+		}
+	})
+
+	Context("when the meters have records in the window", func() {
+		It("should get the records of all the meters with one query", func() {
+			startDate := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+			endDate := startDate.AddDate(0, 0, 7)
+
+			rows := sqlmock.NewRows([]string{"id", "meter_id", "active_energy", "date"}).
+				AddRow("1", 1, 10.0, startDate).
+				AddRow("2", 2, 20.0, startDate)
+			mock.ExpectQuery("meter_id IN \\(\\?,\\?\\)").WithArgs(startDate, endDate, 1, 2).WillReturnRows(rows)
+
+			result, err := repositoryImpl.GetConsumptionByMeterIDsAndWindowTime(startDate, endDate, []int{1, 2})
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+			Expect(mock.ExpectationsWereMet()).To(BeNil())
+		})
+	})
+
+	Context("when the query fails", func() {
+		It("should return the error", func() {
+			mock.ExpectQuery(`SELECT`).WillReturnError(sqlmock.ErrCancelled)
+
+			result, err := repositoryImpl.GetConsumptionByMeterIDsAndWindowTime(time.Now(), time.Now(), []int{1})
+			Expect(err).To(Not(BeNil()))
+			Expect(result).To(BeNil())
+		})
+	})
+})
+
 var _ = Describe("CreatePowerConsumptionRecords", func() {
 	var (
 		mockDB           *gorm.DB
@@ -130,13 +183,79 @@ var _ = Describe("CreatePowerConsumptionRecords", func() {
 	})
 
 	Context("when data is create in the database", func() {
-		It("should return an empty error", func() {
+		It("should return the records inserted", func() {
 			num, _ := strconv.Atoi(userConsumptions[0].ID)
 			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT `meter_id`,`date` FROM `user_consumptions` .* FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
 			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(int64(num), 1))
+			mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+			inserted, err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
+			Expect(err).To(BeNil())
+			Expect(inserted).To(Equal(userConsumptions))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when some readings were flagged", func() {
+		It("should write the anomaly event in the outbox too", func() {
+			flagged := *userConsumptions[0]
+			flagged.Flags = "max"
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("INSERT INTO `outbox_events`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "readings_imported", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("INSERT INTO `outbox_events`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "anomaly_detected", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(2, 1))
+			mock.ExpectCommit()
+			_, err := repositoryImpl.CreatePowerConsumptionRecords([]*domain.UserConsumption{&flagged})
+			Expect(err).To(BeNil())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when a record already exists for the same meter and date", func() {
+		It("should skip it without writing it in the events", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}).AddRow(1, userConsumptions[0].Date))
+			mock.ExpectCommit()
+
+			inserted, err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
+
+			Expect(err).To(BeNil())
+			Expect(inserted).To(BeEmpty())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should insert only the records that are new", func() {
+			newRecord := *userConsumptions[0]
+			newRecord.ID = ""
+			newRecord.Date = newRecord.Date.Add(time.Hour)
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}).AddRow(1, userConsumptions[0].Date))
+			mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnResult(sqlmock.NewResult(2, 1))
+			mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
 			mock.ExpectCommit()
-			err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
+
+			inserted, err := repositoryImpl.CreatePowerConsumptionRecords([]*domain.UserConsumption{userConsumptions[0], &newRecord, &newRecord})
+
 			Expect(err).To(BeNil())
+			Expect(inserted).To(Equal([]*domain.UserConsumption{&newRecord}))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when the event could not be written in the outbox", func() {
+		It("should roll back the records", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnError(errors.New("Error inserting the event"))
+			mock.ExpectRollback()
+
+			_, err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
+
+			Expect(err).ToNot(BeNil())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
 		})
 	})
 
@@ -144,12 +263,241 @@ var _ = Describe("CreatePowerConsumptionRecords", func() {
 		It("should return an error", func() {
 			num, _ := strconv.Atoi(userConsumptions[0].ID)
 			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
 			mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(int64(num), 1))
 			mock.ExpectRollback()
 
-			err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
+			_, err := repositoryImpl.CreatePowerConsumptionRecords(userConsumptions)
 
 			Expect(err).ToNot(BeNil())
 		})
 	})
 })
+
+var _ = Describe("CreateImportRecords", func() {
+	var (
+		mock           sqlmock.Sqlmock
+		repositoryImpl *MySQLPowerConsumptionRepositoryImpl
+		importRecord   *domain.Import
+		readings       []*domain.UserConsumption
+	)
+
+	BeforeEach(func() {
+		var mockDb *sql.DB
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		repositoryImpl = &MySQLPowerConsumptionRepositoryImpl{
+			db: mockDB,
+		}
+		importRecord = &domain.Import{Model: gorm.Model{ID: 7}, Status: "completed", Imported: 2}
+		readings = []*domain.UserConsumption{
+			{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), ImportID: &importRecord.ID},
+			{MeterID: 1, ActiveEnergy: 20, Date: time.Date(2023, 8, 1, 1, 0, 0, 0, time.UTC), ImportID: &importRecord.ID},
+		}
+	})
+
+	It("should save the records and complete the import in the same transaction", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `quarantined_readings`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+		mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE `imports`").WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "import_completed", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		inserted, err := repositoryImpl.CreateImportRecords(readings, []*domain.QuarantinedReading{{MeterID: 1}}, importRecord, []int{1})
+
+		Expect(err).To(BeNil())
+		Expect(inserted).To(HaveLen(2))
+		Expect(importRecord.Imported).To(Equal(2))
+		Expect(importRecord.Skipped).To(Equal(0))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should take the records that already exist out of the imported count", func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}).AddRow(1, readings[0].Date))
+		mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE `imports`").WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "import_completed", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		inserted, err := repositoryImpl.CreateImportRecords(readings, nil, importRecord, []int{1})
+
+		Expect(err).To(BeNil())
+		Expect(inserted).To(Equal([]*domain.UserConsumption{readings[1]}))
+		Expect(importRecord.Imported).To(Equal(1))
+		Expect(importRecord.Skipped).To(Equal(1))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should roll back the records when the import could not be completed", func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+		mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE `imports`").WillReturnError(errors.New("Error updating the import"))
+		mock.ExpectRollback()
+
+		_, err := repositoryImpl.CreateImportRecords(readings, nil, importRecord, []int{1})
+
+		Expect(err).ToNot(BeNil())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
+
+var _ = Describe("CreateIngestedRecords", func() {
+	var (
+		mock           sqlmock.Sqlmock
+		repositoryImpl *MySQLPowerConsumptionRepositoryImpl
+		readings       []*domain.UserConsumption
+	)
+
+	BeforeEach(func() {
+		var mockDb *sql.DB
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		repositoryImpl = &MySQLPowerConsumptionRepositoryImpl{
+			db: mockDB,
+		}
+		readings = []*domain.UserConsumption{{MeterID: 1, ActiveEnergy: 10, Date: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)}}
+	})
+
+	It("should save the records and the quarantined readings in the same transaction", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `quarantined_readings`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+		mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO `outbox_events`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		inserted, err := repositoryImpl.CreateIngestedRecords(readings, []*domain.QuarantinedReading{{MeterID: 1}})
+
+		Expect(err).To(BeNil())
+		Expect(inserted).To(Equal(readings))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should roll back the quarantined readings when the records could not be saved", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `quarantined_readings`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"meter_id", "date"}))
+		mock.ExpectExec("INSERT INTO `user_consumptions`").WillReturnError(errors.New("Error inserting the records"))
+		mock.ExpectRollback()
+
+		_, err := repositoryImpl.CreateIngestedRecords(readings, []*domain.QuarantinedReading{{MeterID: 1}})
+
+		Expect(err).ToNot(BeNil())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
+
+var _ = Describe("GetLastConsumptionBeforeDate", func() {
+	var (
+		mockDB         *gorm.DB
+		mock           sqlmock.Sqlmock
+		mockDb         *sql.DB
+		repositoryImpl *MySQLPowerConsumptionRepositoryImpl
+		err            error
+	)
+
+	BeforeEach(func() {
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err = gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+
+		repositoryImpl = &MySQLPowerConsumptionRepositoryImpl{
+			db: mockDB,
+		}
+	})
+
+	Context("when the meter has records before the date", func() {
+		It("should return the last record", func() {
+			date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+			columns := []string{"id", "meter_id", "active_energy", "date"}
+			rows := sqlmock.NewRows(columns).AddRow("1", 1, 100.0, date.Add(-time.Hour))
+			mock.ExpectQuery(`SELECT .* ORDER BY date desc LIMIT 1`).WillReturnRows(rows)
+
+			result, err := repositoryImpl.GetLastConsumptionBeforeDate(date, 1)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			Expect(result.ActiveEnergy).To(Equal(100.0))
+		})
+	})
+
+	Context("when the meter does not have records before the date", func() {
+		It("should return nil", func() {
+			mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			result, err := repositoryImpl.GetLastConsumptionBeforeDate(time.Now(), 1)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("removeDuplicatedRecords", func() {
+	var (
+		mock           sqlmock.Sqlmock
+		repositoryImpl *MySQLPowerConsumptionRepositoryImpl
+	)
+
+	BeforeEach(func() {
+		var mockDb *sql.DB
+		mockDb, mock, _ = sqlmock.New()
+		mockDB, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		repositoryImpl = &MySQLPowerConsumptionRepositoryImpl{
+			db: mockDB,
+		}
+	})
+
+	It("should delete the newer duplicated records when the unique index does not exist yet", func() {
+		mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"database"}).AddRow("consumption"))
+		mock.ExpectQuery("information_schema.tables").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"database"}).AddRow("consumption"))
+		mock.ExpectQuery("information_schema.statistics").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec("DELETE newer FROM user_consumptions newer JOIN user_consumptions older .* newer.id > older.id").WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repositoryImpl.removeDuplicatedRecords()
+
+		Expect(err).To(BeNil())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not delete anything when the unique index already exists", func() {
+		mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"database"}).AddRow("consumption"))
+		mock.ExpectQuery("information_schema.tables").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"database"}).AddRow("consumption"))
+		mock.ExpectQuery("information_schema.statistics").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		err := repositoryImpl.removeDuplicatedRecords()
+
+		Expect(err).To(BeNil())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
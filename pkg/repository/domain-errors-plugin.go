@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"errors"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// DomainErrorsPlugin: gorm plugin that converts the errors of every statement in domain errors, a missing record is
+// not found, a duplicated unique key is a conflict and any other failure of the database is unavailable
+type DomainErrorsPlugin struct{}
+
+func NewDomainErrorsPlugin() *DomainErrorsPlugin {
+	return &DomainErrorsPlugin{}
+}
+
+func (d *DomainErrorsPlugin) Name() string {
+	return "domain_errors"
+}
+
+// Initialize: register the conversion after the statements of every kind, the writes are converted after the commit
+// so the errors of the commit are converted too
+//
+// Parámeters:
+// db - the connection where the plugin is used.
+//
+// Returns:
+// return an error if a callback could not be registered
+func (d *DomainErrorsPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:commit_or_rollback_transaction").Register("domain_errors:create", convertDomainError); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:after_query").Register("domain_errors:query", convertDomainError); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:commit_or_rollback_transaction").Register("domain_errors:update", convertDomainError); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:commit_or_rollback_transaction").Register("domain_errors:delete", convertDomainError); err != nil {
+		return err
+	}
+	if err := callback.Row().After("gorm:row").Register("domain_errors:row", convertDomainError); err != nil {
+		return err
+	}
+	return callback.Raw().After("gorm:raw").Register("domain_errors:raw", convertDomainError)
+}
+
+func convertDomainError(db *gorm.DB) {
+	if db.Error != nil {
+		db.Error = ToDomainError(db.Error)
+	}
+}
+
+// ToDomainError: convert an error of the database in a domain error
+//
+// Parámeters:
+// err - the error of the database.
+//
+// Returns:
+// return the domain error, the errors that already are domain errors are returned without changes
+func ToDomainError(err error) error {
+	if _, ok := domain.AsDomainError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.NewNotFoundError(constants.ErrorCodeNotFound, "Error: the record was not found", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return domain.NewConflictError(constants.ErrorCodeDuplicated, "Error: the record already exists", err)
+	}
+	return domain.NewUnavailableError(constants.ErrorCodeDatabaseUnavailable, "Error: the database is not available, try again later", err)
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DomainErrorsPlugin", func() {
+	var (
+		mock                   sqlmock.Sqlmock
+		meterSettingRepository *MeterSettingMySQLRepositoryImpl
+		meterGroupRepository   *MeterGroupMySQLRepositoryImpl
+	)
+
+	BeforeEach(func() {
+		mockDb, sqlMock, _ := sqlmock.New()
+		mock = sqlMock
+		mockDB, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      mockDb,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			panic(err)
+		}
+		Expect(mockDB.Use(NewDomainErrorsPlugin())).To(BeNil())
+		meterSettingRepository = &MeterSettingMySQLRepositoryImpl{db: mockDB}
+		meterGroupRepository = &MeterGroupMySQLRepositoryImpl{db: mockDB}
+	})
+
+	It("should convert the failures of the database in unavailable errors", func() {
+		mock.ExpectQuery(`SELECT`).WillReturnError(errors.New("dial tcp: connection refused"))
+
+		_, err := meterSettingRepository.GetMeterSettingsByMeterIDs([]int{1})
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Kind).To(Equal(domain.ErrorKindUnavailable))
+		Expect(domainError.Code).To(Equal("database_unavailable"))
+		Expect(err.Error()).ToNot(ContainSubstring("connection refused"))
+	})
+
+	It("should convert the missing records in not found errors", func() {
+		mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		_, err := meterGroupRepository.GetMeterGroupByID(9)
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Kind).To(Equal(domain.ErrorKindNotFound))
+		Expect(errors.Is(err, gorm.ErrRecordNotFound)).To(BeTrue())
+	})
+
+	It("should keep the settings of a meter without settings as nil", func() {
+		mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id", "meter_id"}))
+
+		setting, err := meterSettingRepository.GetMeterSettingByMeterID(1)
+		Expect(err).To(BeNil())
+		Expect(setting).To(BeNil())
+	})
+
+	It("should convert the duplicated keys in conflict errors", func() {
+		err := ToDomainError(gorm.ErrDuplicatedKey)
+		domainError, ok := domain.AsDomainError(err)
+		Expect(ok).To(BeTrue())
+		Expect(domainError.Kind).To(Equal(domain.ErrorKindConflict))
+	})
+})
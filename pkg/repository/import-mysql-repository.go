@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ImportMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewImportMySQLRepository(db *gorm.DB) domain.ImportRepository {
+	return &ImportMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateImport: create the record of an imported file
+//
+// Parámeters:
+// importRecord - the import.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (i *ImportMySQLRepositoryImpl) CreateImport(importRecord *domain.Import) error {
+	err := i.db.Create(importRecord).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the import %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// UpdateImport: update the status and the row counts of an import
+//
+// Parámeters:
+// importRecord - the import.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (i *ImportMySQLRepositoryImpl) UpdateImport(importRecord *domain.Import) error {
+	err := i.db.Save(importRecord).Error
+	if err != nil {
+		logrus.Errorf("Error: updating the import %d %s", importRecord.ID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// RollbackImport: delete the records and the quarantined readings of an import and keep the import as rolled back in
+// only one transaction, so an import is never marked as completed without its records, the records are deleted for
+// good so the unique index of the meter and the date lets the same readings be imported again
+//
+// Parámeters:
+// importRecord - the import.
+//
+// Returns:
+// return the number of deleted records or an error if something goes wrong in the deletion
+func (i *ImportMySQLRepositoryImpl) RollbackImport(importRecord *domain.Import) (int64, error) {
+	var deleted int64
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("import_id = ?", importRecord.ID).Delete(&domain.UserConsumption{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		if err := tx.Where("import_id = ?", importRecord.ID).Delete(&domain.QuarantinedReading{}).Error; err != nil {
+			return err
+		}
+		importRecord.Status = constants.ImportStatusRolledBack
+		return tx.Save(importRecord).Error
+	})
+	if err != nil {
+		logrus.Errorf("Error: rolling back the import %d %s", importRecord.ID, err.Error())
+		return 0, err
+	}
+	logrus.Infof("%d records of the import %d were deleted", deleted, importRecord.ID)
+	return deleted, nil
+}
+
+// GetImports: get all the imports, the newest first
+//
+// Returns:
+// return all the imports
+func (i *ImportMySQLRepositoryImpl) GetImports() ([]domain.Import, error) {
+	var imports []domain.Import
+	err := i.db.Order("id desc").Find(&imports).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the imports %s", err.Error())
+		return nil, err
+	}
+	return imports, nil
+}
+
+// GetImportByID: get an import
+//
+// Parámeters:
+// importID - the id of the import.
+//
+// Returns:
+// return the import or an error if it does not exist
+func (i *ImportMySQLRepositoryImpl) GetImportByID(importID uint) (*domain.Import, error) {
+	var importRecord domain.Import
+	err := i.db.First(&importRecord, importID).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the import %d %s", importID, err.Error())
+		return nil, err
+	}
+	return &importRecord, nil
+}
+
+// GetImportByChecksum: get the first import of a file by the hash of its content, the failed and rolled back
+// imports are not taken into account
+//
+// Parámeters:
+// checksum - the hash of the file.
+//
+// Returns:
+// return the import or nil if the file was not imported before
+func (i *ImportMySQLRepositoryImpl) GetImportByChecksum(checksum string) (*domain.Import, error) {
+	var imports []domain.Import
+	err := i.db.Where("checksum = ? AND status IN ?", checksum, []string{constants.ImportStatusInProgress, constants.ImportStatusCompleted}).Order("id").Limit(1).Find(&imports).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the import of the checksum %s %s", checksum, err.Error())
+		return nil, err
+	}
+	if len(imports) == 0 {
+		return nil, nil
+	}
+	return &imports[0], nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (i *ImportMySQLRepositoryImpl) ModelMigration() error {
+	return i.db.AutoMigrate(&domain.Import{})
+}
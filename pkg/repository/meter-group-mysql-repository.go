@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type MeterGroupMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMeterGroupMySQLRepository(db *gorm.DB) domain.MeterGroupRepository {
+	return &MeterGroupMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateMeterGroup: create a meter group with its meters
+//
+// Parámeters:
+// group - the meter group to create.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (m *MeterGroupMySQLRepositoryImpl) CreateMeterGroup(group *domain.MeterGroup) error {
+	err := m.db.Create(group).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the meter group %s", err.Error())
+		return err
+	}
+	logrus.Info("the meter group was succesfully created")
+	return nil
+}
+
+// GetMeterGroupByID: get a meter group with its meters
+//
+// Parámeters:
+// groupID - the id of the meter group.
+//
+// Returns:
+// return the meter group or an error if it does not exist
+func (m *MeterGroupMySQLRepositoryImpl) GetMeterGroupByID(groupID uint) (*domain.MeterGroup, error) {
+	var group domain.MeterGroup
+	err := m.db.Preload("Meters").First(&group, groupID).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the meter group %d %s", groupID, err.Error())
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetMeterGroupsByParentID: get the groups nested in a meter group
+//
+// Parámeters:
+// parentID - the id of the parent meter group.
+//
+// Returns:
+// return the nested meter groups with its meters
+func (m *MeterGroupMySQLRepositoryImpl) GetMeterGroupsByParentID(parentID uint) ([]domain.MeterGroup, error) {
+	var groups []domain.MeterGroup
+	err := m.db.Preload("Meters").Where("parent_id = ?", parentID).Find(&groups).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the nested meter groups %d %s", parentID, err.Error())
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetMeterGroups: get all the meter groups with its meters
+//
+// Returns:
+// return all the meter groups
+func (m *MeterGroupMySQLRepositoryImpl) GetMeterGroups() ([]domain.MeterGroup, error) {
+	var groups []domain.MeterGroup
+	err := m.db.Preload("Meters").Find(&groups).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the meter groups %s", err.Error())
+		return nil, err
+	}
+	return groups, nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (m *MeterGroupMySQLRepositoryImpl) ModelMigration() error {
+	return m.db.AutoMigrate(&domain.MeterGroup{}, &domain.MeterGroupMeter{})
+}
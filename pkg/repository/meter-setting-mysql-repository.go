@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type MeterSettingMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMeterSettingMySQLRepository(db *gorm.DB) domain.MeterSettingRepository {
+	return &MeterSettingMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// SaveMeterSetting: create the settings of a meter or replace them if the meter already has settings
+//
+// Parámeters:
+// setting - the settings of the meter.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (m *MeterSettingMySQLRepositoryImpl) SaveMeterSetting(setting *domain.MeterSetting) error {
+	currentSetting, err := m.GetMeterSettingByMeterID(setting.MeterID)
+	if err != nil {
+		return err
+	}
+	if currentSetting != nil {
+		setting.ID = currentSetting.ID
+		setting.CreatedAt = currentSetting.CreatedAt
+	}
+	err = m.db.Save(setting).Error
+	if err != nil {
+		logrus.Errorf("Error: saving the meter settings %d %s", setting.MeterID, err.Error())
+		return err
+	}
+	logrus.Infof("the settings of the meter %d were succesfully saved", setting.MeterID)
+	return nil
+}
+
+// GetMeterSettingsByMeterIDs: get the settings of several meters with only one query
+//
+// Parámeters:
+// meterIDs - the ids of the meters.
+//
+// Returns:
+// return the settings of the meters that have settings
+func (m *MeterSettingMySQLRepositoryImpl) GetMeterSettingsByMeterIDs(meterIDs []int) ([]domain.MeterSetting, error) {
+	var settings []domain.MeterSetting
+	err := m.db.Where("meter_id IN ?", meterIDs).Find(&settings).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the settings of the meters %v %s", meterIDs, err.Error())
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetMeterSettingByMeterID: get the settings of a meter
+//
+// Parámeters:
+// meterID - the id of the meter.
+//
+// Returns:
+// return the settings of the meter, nil if the meter does not have settings
+func (m *MeterSettingMySQLRepositoryImpl) GetMeterSettingByMeterID(meterID int) (*domain.MeterSetting, error) {
+	var setting domain.MeterSetting
+	err := m.db.Where("meter_id = ?", meterID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logrus.Errorf("Error: getting the meter settings %d %s", meterID, err.Error())
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (m *MeterSettingMySQLRepositoryImpl) ModelMigration() error {
+	return m.db.AutoMigrate(&domain.MeterSetting{})
+}
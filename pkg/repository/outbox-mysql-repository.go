@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type OutboxMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxMySQLRepository(db *gorm.DB) domain.OutboxRepository {
+	return &OutboxMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateOutboxEvent: write an event in the outbox to publish it
+//
+// Parámeters:
+// event - the outbox event.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (o *OutboxMySQLRepositoryImpl) CreateOutboxEvent(event *domain.OutboxEvent) error {
+	err := o.db.Create(event).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the outbox event %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetPendingOutboxEvents: get the events that were not published yet and whose next attempt is due, the oldest
+// first, the dead lettered events are left out
+//
+// Parámeters:
+// now - the current time.
+// limit - the max number of events.
+//
+// Returns:
+// return the pending events
+func (o *OutboxMySQLRepositoryImpl) GetPendingOutboxEvents(now time.Time, limit int) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := o.db.Where("published_at IS NULL AND dead_lettered_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now).Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the pending outbox events %s", err.Error())
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkOutboxEventPublished: mark an event as published
+//
+// Parámeters:
+// eventID - the id of the event.
+// publishedAt - the time when the event was published.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (o *OutboxMySQLRepositoryImpl) MarkOutboxEventPublished(eventID uint, publishedAt time.Time) error {
+	err := o.db.Model(&domain.OutboxEvent{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"published_at":    publishedAt,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      "",
+		"next_attempt_at": nil,
+	}).Error
+	if err != nil {
+		logrus.Errorf("Error: marking the outbox event %d as published %s", eventID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed: keep the reason why an event could not be published and the sinks that already received it,
+// the event stays pending until its next attempt
+//
+// Parámeters:
+// eventID - the id of the event.
+// deliveredSinks - the names of the sinks that already received the event.
+// reason - the error of the publication.
+// nextAttemptAt - the time when the event is sent again.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (o *OutboxMySQLRepositoryImpl) MarkOutboxEventFailed(eventID uint, deliveredSinks []string, reason string, nextAttemptAt time.Time) error {
+	err := o.db.Model(&domain.OutboxEvent{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      reason,
+		"delivered_sinks": strings.Join(deliveredSinks, ","),
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+	if err != nil {
+		logrus.Errorf("Error: marking the outbox event %d as failed %s", eventID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// MarkOutboxEventDeadLettered: keep the reason why an event could not be published after the max attempts, the event
+// is not sent again
+//
+// Parámeters:
+// eventID - the id of the event.
+// deliveredSinks - the names of the sinks that already received the event.
+// reason - the error of the last publication.
+// deadLetteredAt - the time of the last attempt.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (o *OutboxMySQLRepositoryImpl) MarkOutboxEventDeadLettered(eventID uint, deliveredSinks []string, reason string, deadLetteredAt time.Time) error {
+	err := o.db.Model(&domain.OutboxEvent{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"attempts":         gorm.Expr("attempts + 1"),
+		"last_error":       reason,
+		"delivered_sinks":  strings.Join(deliveredSinks, ","),
+		"next_attempt_at":  nil,
+		"dead_lettered_at": deadLetteredAt,
+	}).Error
+	if err != nil {
+		logrus.Errorf("Error: marking the outbox event %d as dead lettered %s", eventID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (o *OutboxMySQLRepositoryImpl) ModelMigration() error {
+	return o.db.AutoMigrate(&domain.OutboxEvent{})
+}
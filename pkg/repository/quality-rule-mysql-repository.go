@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type QualityRuleMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQualityRuleMySQLRepository(db *gorm.DB) domain.QualityRuleRepository {
+	return &QualityRuleMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateQualityRule: create a data quality rule
+//
+// Parámeters:
+// rule - the rule to create.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (q *QualityRuleMySQLRepositoryImpl) CreateQualityRule(rule *domain.QualityRule) error {
+	err := q.db.Create(rule).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the quality rule %s", err.Error())
+		return err
+	}
+	logrus.Info("the quality rule was succesfully created")
+	return nil
+}
+
+// GetQualityRules: get all the data quality rules
+//
+// Returns:
+// return all the rules
+func (q *QualityRuleMySQLRepositoryImpl) GetQualityRules() ([]domain.QualityRule, error) {
+	var rules []domain.QualityRule
+	err := q.db.Find(&rules).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the quality rules %s", err.Error())
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteQualityRule: delete a data quality rule
+//
+// Parámeters:
+// ruleID - the id of the rule.
+//
+// Returns:
+// return an error if something goes wrong in the deletion of nil if it's not
+func (q *QualityRuleMySQLRepositoryImpl) DeleteQualityRule(ruleID uint) error {
+	err := q.db.Delete(&domain.QualityRule{}, ruleID).Error
+	if err != nil {
+		logrus.Errorf("Error: deleting the quality rule %d %s", ruleID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (q *QualityRuleMySQLRepositoryImpl) ModelMigration() error {
+	return q.db.AutoMigrate(&domain.QualityRule{})
+}
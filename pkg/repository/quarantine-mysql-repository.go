@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type QuarantineMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQuarantineMySQLRepository(db *gorm.DB) domain.QuarantineRepository {
+	return &QuarantineMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateQuarantinedReadings: create the readings that did not pass the data quality rules
+//
+// Parámeters:
+// readings - the quarantined readings.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (q *QuarantineMySQLRepositoryImpl) CreateQuarantinedReadings(readings []*domain.QuarantinedReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+	err := q.db.Create(&readings).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the quarantined readings %s", err.Error())
+		return err
+	}
+	logrus.Infof("%d readings were quarantined", len(readings))
+	return nil
+}
+
+// GetQuarantinedReadings: get all the quarantined readings
+//
+// Returns:
+// return all the quarantined readings
+func (q *QuarantineMySQLRepositoryImpl) GetQuarantinedReadings() ([]domain.QuarantinedReading, error) {
+	var readings []domain.QuarantinedReading
+	err := q.db.Order("id").Find(&readings).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the quarantined readings %s", err.Error())
+		return nil, err
+	}
+	return readings, nil
+}
+
+// GetQuarantinedReadingByID: get a quarantined reading
+//
+// Parámeters:
+// readingID - the id of the quarantined reading.
+//
+// Returns:
+// return the quarantined reading or an error if it does not exist
+func (q *QuarantineMySQLRepositoryImpl) GetQuarantinedReadingByID(readingID uint) (*domain.QuarantinedReading, error) {
+	var reading domain.QuarantinedReading
+	err := q.db.First(&reading, readingID).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the quarantined reading %d %s", readingID, err.Error())
+		return nil, err
+	}
+	return &reading, nil
+}
+
+// UpdateQuarantinedReading: update a quarantined reading after it was edited
+//
+// Parámeters:
+// reading - the quarantined reading.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (q *QuarantineMySQLRepositoryImpl) UpdateQuarantinedReading(reading *domain.QuarantinedReading) error {
+	err := q.db.Save(reading).Error
+	if err != nil {
+		logrus.Errorf("Error: updating the quarantined reading %d %s", reading.ID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteQuarantinedReading: delete a quarantined reading
+//
+// Parámeters:
+// readingID - the id of the quarantined reading.
+//
+// Returns:
+// return an error if something goes wrong in the deletion of nil if it's not
+func (q *QuarantineMySQLRepositoryImpl) DeleteQuarantinedReading(readingID uint) error {
+	err := q.db.Delete(&domain.QuarantinedReading{}, readingID).Error
+	if err != nil {
+		logrus.Errorf("Error: deleting the quarantined reading %d %s", readingID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (q *QuarantineMySQLRepositoryImpl) ModelMigration() error {
+	return q.db.AutoMigrate(&domain.QuarantinedReading{})
+}
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"time"
+
+	constants "github.com/jeffleon1/consumption-ms/internal/constans"
+	"github.com/jeffleon1/consumption-ms/pkg/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookMySQLRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookMySQLRepository(db *gorm.DB) domain.WebhookRepository {
+	return &WebhookMySQLRepositoryImpl{
+		db,
+	}
+}
+
+// CreateWebhookSubscription: create a webhook subscription
+//
+// Parámeters:
+// subscription - the webhook subscription.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) CreateWebhookSubscription(subscription *domain.WebhookSubscription) error {
+	err := w.db.Create(subscription).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the webhook subscription %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetWebhookSubscriptions: get all the webhook subscriptions
+//
+// Returns:
+// return all the webhook subscriptions
+func (w *WebhookMySQLRepositoryImpl) GetWebhookSubscriptions() ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := w.db.Order("id").Find(&subscriptions).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the webhook subscriptions %s", err.Error())
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetWebhookSubscriptionByID: get a webhook subscription
+//
+// Parámeters:
+// subscriptionID - the id of the subscription.
+//
+// Returns:
+// return the webhook subscription or an error if it does not exist
+func (w *WebhookMySQLRepositoryImpl) GetWebhookSubscriptionByID(subscriptionID uint) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := w.db.First(&subscription, subscriptionID).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the webhook subscription %d %s", subscriptionID, err.Error())
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// UpdateWebhookSubscription: update a webhook subscription
+//
+// Parámeters:
+// subscription - the webhook subscription.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) UpdateWebhookSubscription(subscription *domain.WebhookSubscription) error {
+	err := w.db.Save(subscription).Error
+	if err != nil {
+		logrus.Errorf("Error: updating the webhook subscription %d %s", subscription.ID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteWebhookSubscription: delete a webhook subscription
+//
+// Parámeters:
+// subscriptionID - the id of the subscription.
+//
+// Returns:
+// return an error if something goes wrong in the deletion of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) DeleteWebhookSubscription(subscriptionID uint) error {
+	err := w.db.Delete(&domain.WebhookSubscription{}, subscriptionID).Error
+	if err != nil {
+		logrus.Errorf("Error: deleting the webhook subscription %d %s", subscriptionID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// CreateWebhookDeliveries: create the deliveries of an event, the deliveries of an event that already exist are
+// ignored so the same event can be received more than once
+//
+// Parámeters:
+// deliveries - the webhook deliveries.
+//
+// Returns:
+// return an error if something goes wrong in the insertion of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) CreateWebhookDeliveries(deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	err := w.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+	if err != nil {
+		logrus.Errorf("Error: creating the webhook deliveries %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// UpdateWebhookDelivery: update the status and the attempts of a delivery
+//
+// Parámeters:
+// delivery - the webhook delivery.
+//
+// Returns:
+// return an error if something goes wrong in the update of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) UpdateWebhookDelivery(delivery *domain.WebhookDelivery) error {
+	err := w.db.Save(delivery).Error
+	if err != nil {
+		logrus.Errorf("Error: updating the webhook delivery %d %s", delivery.ID, err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetDueWebhookDeliveries: get the pending deliveries whose next attempt is due, the oldest first
+//
+// Parámeters:
+// now - the current time.
+// limit - the max number of deliveries.
+//
+// Returns:
+// return the due deliveries
+func (w *WebhookMySQLRepositoryImpl) GetDueWebhookDeliveries(now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := w.db.Where("status = ? AND next_attempt_at <= ?", constants.WebhookStatusPending, now).Order("id").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the due webhook deliveries %s", err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDeliveriesBySubscriptionID: get the deliveries of a subscription, the newest first
+//
+// Parámeters:
+// subscriptionID - the id of the subscription.
+//
+// Returns:
+// return the deliveries of the subscription
+func (w *WebhookMySQLRepositoryImpl) GetWebhookDeliveriesBySubscriptionID(subscriptionID uint) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := w.db.Where("subscription_id = ?", subscriptionID).Order("id desc").Find(&deliveries).Error
+	if err != nil {
+		logrus.Errorf("Error: getting the deliveries of the webhook subscription %d %s", subscriptionID, err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ModelMigration: do the model migration to gorm
+//
+// Returns:
+// return an error if something goes wrong in the migration of nil if it's not
+func (w *WebhookMySQLRepositoryImpl) ModelMigration() error {
+	return w.db.AutoMigrate(&domain.WebhookSubscription{}, &domain.WebhookDelivery{})
+}